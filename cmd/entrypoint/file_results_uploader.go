@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+// realFileResultsUploader uploads the content of the results of type file with HTTP PUT requests.
+type realFileResultsUploader struct {
+	client *http.Client
+}
+
+var _ entrypoint.FileResultsUploader = (*realFileResultsUploader)(nil)
+
+// Upload puts the size bytes of content at the URL.
+func (u *realFileResultsUploader) Upload(ctx context.Context, url string, content io.Reader, size int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, content)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("PUT %s: unexpected status %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRealFileResultsUploader(t *testing.T) {
+	var gotMethod, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		if r.URL.Path == "/denied" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+	u := realFileResultsUploader{client: srv.Client()}
+
+	if err := u.Upload(t.Context(), srv.URL+"/ns/pod/sbom", strings.NewReader("content"), 7); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	if gotMethod != http.MethodPut || gotBody != "content" {
+		t.Errorf("expected a PUT of %q, got a %s of %q", "content", gotMethod, gotBody)
+	}
+
+	err := u.Upload(t.Context(), srv.URL+"/denied", strings.NewReader("content"), 7)
+	if err == nil || !strings.Contains(err.Error(), "403 Forbidden") {
+		t.Errorf("expected the upload to fail with status 403, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// newLogger returns the logger for the messages of the entrypoint itself in the given format.
+// It returns nil for the text format, which keeps the default logger. The JSON logger includes
+// the operational messages logged at debug level, along with the UID of the TaskRun and the
+// name of the step so that log pipelines can correlate them.
+func newLogger(w io.Writer, format, taskRunUID, stepName string) (*slog.Logger, error) {
+	switch format {
+	case "", logFormatText:
+		return nil, nil //nolint:nilnil // the default logger is kept
+	case logFormatJSON:
+		logger := slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		if taskRunUID != "" {
+			logger = logger.With(slog.String("taskRunUID", taskRunUID))
+		}
+		if stepName != "" {
+			logger = logger.With(slog.String("step", stepName))
+		}
+		return logger, nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q, it must be %q or %q", format, logFormatText, logFormatJSON)
+	}
+}
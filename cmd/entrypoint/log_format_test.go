@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewLoggerJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := newLogger(&buf, logFormatJSON, "uid-123", "build")
+	if err != nil {
+		t.Fatalf("newLogger: %v", err)
+	}
+	logger.Debug("Starting step")
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("the message %q is not JSON: %v", buf.String(), err)
+	}
+	delete(got, "time")
+	want := map[string]any{
+		"level":      "DEBUG",
+		"msg":        "Starting step",
+		"taskRunUID": "uid-123",
+		"step":       "build",
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("message %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestNewLoggerText(t *testing.T) {
+	for _, format := range []string{"", logFormatText} {
+		logger, err := newLogger(&bytes.Buffer{}, format, "uid-123", "build")
+		if err != nil {
+			t.Errorf("newLogger(%q): %v", format, err)
+		}
+		if logger != nil {
+			t.Errorf("newLogger(%q) = %v, want the default logger to be kept", format, logger)
+		}
+	}
+}
+
+func TestNewLoggerInvalidFormat(t *testing.T) {
+	if _, err := newLogger(&bytes.Buffer{}, "xml", "", ""); err == nil {
+		t.Error("expected an error for an invalid log format")
+	}
+}
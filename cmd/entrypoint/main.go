@@ -22,6 +22,8 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
 	"os/exec"
 	"strings"
@@ -56,6 +58,17 @@ var (
 		" Set to \"stopAndFail\" to declare a failure with a step error and stop executing the rest of the steps.")
 	stepMetadataDir        = flag.String("step_metadata_dir", "", "If specified, create directory to store the step metadata e.g. /tekton/steps/<step-name>/")
 	resultExtractionMethod = flag.String("result_from", entrypoint.ResultExtractionMethodTerminationMessage, "The method using which to extract results from tasks. Default is using the termination message.")
+	trace                  = flag.Bool("trace", false, "If specified, record the time spent in each phase of the step to the step metadata directory")
+	fileResults            = flag.String("file_results", "", "If specified, list of the task results of type file, whose content is uploaded to file_results_url")
+	fileResultsURL         = flag.String("file_results_url", "", "The URL under which the content of the results of type file is uploaded")
+	maxFileResultSize      = flag.Int64("max_file_result_size", 0, "If specified, the upper limit in bytes of the content of each result of type file")
+	whenFiles              = flag.String("when_files", "", "If specified, the files tested by when expressions, whose content is written to the termination message")
+	resultOverflow         = flag.Bool("result_overflow", false, "If specified, write the results which do not fit in the termination message to stdout")
+	maxLogSize             = flag.Int64("max_log_size", 0, "If specified, the upper limit in bytes of the output of the step, stdout and stderr combined")
+	maxLogLines            = flag.Int64("max_log_lines", 0, "If specified, the upper limit in lines of the output of the step, stdout and stderr combined")
+	logFormat              = flag.String("log_format", logFormatText, "The format of the messages of the entrypoint, \"text\" or \"json\"")
+	taskRunUID             = flag.String("taskrun_uid", "", "If specified, the UID of the TaskRun added to the messages of the entrypoint in the json format")
+	stepName               = flag.String("step_name", "", "If specified, the name of the step added to the messages of the entrypoint in the json format")
 )
 
 const (
@@ -78,6 +91,14 @@ func main() {
 	if err := flag.CommandLine.Parse(args); err != nil {
 		os.Exit(1)
 	}
+	logger, err := newLogger(os.Stderr, *logFormat, *taskRunUID, *stepName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if logger != nil {
+		// the messages of the standard logger are sent to this logger too
+		slog.SetDefault(logger)
+	}
 	if err := subcommands.Process(flag.CommandLine.Args()); err != nil {
 		log.Println(err.Error())
 		var ok subcommands.OK
@@ -125,7 +146,15 @@ func main() {
 		}
 	}
 
+	var files map[string]string
+	if len(*whenFiles) > 0 {
+		if err := json.Unmarshal([]byte(*whenFiles), &files); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	spireWorkloadAPI := initializeSpireAPI()
+	logLimiter := entrypoint.NewLogLimiter(*maxLogSize, *maxLogLines)
 
 	e := entrypoint.Entrypointer{
 		Command:         append(cmd, commandArgs...),
@@ -137,6 +166,7 @@ func main() {
 		Runner: &realRunner{
 			stdoutPath: *stdoutPath,
 			stderrPath: *stderrPath,
+			logLimiter: logLimiter,
 		},
 		PostWriter:             &realPostWriter{},
 		Results:                strings.Split(*results, ","),
@@ -149,6 +179,18 @@ func main() {
 		StepMetadataDir:        *stepMetadataDir,
 		SpireWorkloadAPI:       spireWorkloadAPI,
 		ResultExtractionMethod: *resultExtractionMethod,
+		Trace:                  *trace,
+		FileResultsURL:         *fileResultsURL,
+		MaxFileResultSize:      *maxFileResultSize,
+		WhenFiles:              files,
+		LogLimiter:             logLimiter,
+	}
+	if *resultOverflow {
+		e.ResultsOverflowWriter = os.Stdout
+	}
+	if *fileResults != "" {
+		e.FileResults = strings.Split(*fileResults, ",")
+		e.FileResultsUploader = &realFileResultsUploader{client: http.DefaultClient}
 	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
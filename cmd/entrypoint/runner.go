@@ -46,6 +46,7 @@ type realRunner struct {
 	signalsClosed bool
 	stdoutPath    string
 	stderrPath    string
+	logLimiter    *entrypoint.LogLimiter
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
@@ -108,6 +109,10 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	} else {
 		cmd.Stderr = os.Stderr
 	}
+	if rr.logLimiter != nil {
+		cmd.Stdout = rr.logLimiter.Writer(cmd.Stdout)
+		cmd.Stderr = rr.logLimiter.Writer(cmd.Stderr)
+	}
 
 	// dedicated PID group used to forward signals to
 	// main process and all children
@@ -30,6 +30,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/git"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/http"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/hub"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/s3"
 	hubresolution "github.com/tektoncd/pipeline/pkg/resolution/resolver/hub"
 	"k8s.io/client-go/rest"
 	filteredinformerfactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
@@ -71,7 +72,8 @@ func main() {
 		framework.NewController(ctx, &hub.Resolver{TektonHubURL: tektonHubURL, ArtifactHubURL: artifactHubURL}),
 		framework.NewController(ctx, &bundle.Resolver{}),
 		framework.NewController(ctx, &cluster.Resolver{}),
-		framework.NewController(ctx, &http.Resolver{}))
+		framework.NewController(ctx, &http.Resolver{}),
+		framework.NewController(ctx, &s3.Resolver{}))
 }
 
 func buildHubURL(configAPI, defaultURL string) string {
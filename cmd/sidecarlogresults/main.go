@@ -75,16 +75,12 @@ func main() {
 	if err := json.Unmarshal([]byte(stepResultsStr), &expectedStepResults); err != nil {
 		log.Fatal(err)
 	}
-	err := sidecarlogresults.LookForResults(os.Stdout, pod.RunDir, resultsDir, expectedResults, pipeline.StepsDir, expectedStepResults)
-	if err != nil {
-		log.Fatal(err)
-	}
-
 	var names []string
 	if len(stepNames) > 0 {
 		names = strings.Split(stepNames, ",")
 	}
-	err = sidecarlogresults.LookForArtifacts(os.Stdout, names, pod.RunDir)
+	// the artifacts are looked for with the results, so that they are signed together
+	err := sidecarlogresults.LookForResults(os.Stdout, pod.RunDir, resultsDir, expectedResults, pipeline.StepsDir, expectedStepResults, names, initializeSpireAPI())
 	if err != nil {
 		log.Fatal(err)
 	}
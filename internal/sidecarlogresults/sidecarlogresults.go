@@ -19,6 +19,8 @@ package sidecarlogresults
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -52,8 +54,20 @@ const (
 	stepArtifactType           SidecarLogResultType = "stepArtifact"
 	taskArtifactType           SidecarLogResultType = "taskArtifact"
 	sidecarResultNameSeparator string               = "."
+
+	// fileURIPrefix is the prefix of the uri of artifact values stored in files of the pod
+	fileURIPrefix = "file://"
+	// sha256Algorithm is the digest algorithm computed for artifact values stored in files of the pod
+	sha256Algorithm v1.Algorithm = "sha256"
 )
 
+// ResultSigner signs the task results extracted by the sidecar, e.g. with the SPIRE SVID of the TaskRun.
+// The signature material it returns is written out alongside the results so that the reconciler can
+// verify the results before storing them in the TaskRun status.
+type ResultSigner interface {
+	Sign(ctx context.Context, results []result.RunResult) ([]result.RunResult, error)
+}
+
 // SidecarLogResult holds fields for storing extracted results
 type SidecarLogResult struct {
 	Name  string               `json:"name"`
@@ -145,8 +159,11 @@ func readResults(resultsDir, resultFile, stepName string, resultType SidecarLogR
 
 // LookForResults waits for results to be written out by the steps
 // in their results path and prints them in a structured way to its
-// stdout so that the reconciler can parse those logs.
-func LookForResults(w io.Writer, runDir string, resultsDir string, resultNames []string, stepResultsDir string, stepResults map[string][]string) error {
+// stdout so that the reconciler can parse those logs, followed by the
+// artifacts of the given steps.
+// If signer is not nil, the signatures of the task results, step results
+// and artifacts are printed after them, see SignableResults.
+func LookForResults(w io.Writer, runDir string, resultsDir string, resultNames []string, stepResultsDir string, stepResults map[string][]string, stepNames []string, signer ResultSigner) error {
 	interval, err := getSidecarLogPollingInterval()
 	if err != nil {
 		return fmt.Errorf("error getting polling interval: %w", err)
@@ -197,14 +214,33 @@ func LookForResults(w io.Writer, runDir string, resultsDir string, resultNames [
 		return nil
 	})
 
-	for result := range results {
-		if err := encode(w, result); err != nil {
+	var written []result.RunResult
+	for r := range results {
+		if err := encode(w, r); err != nil {
 			return fmt.Errorf("error writing results: %w", err)
 		}
+		written = append(written, result.RunResult{Key: r.Name, Value: r.Value, ResultType: runResultType(r.Type)})
 	}
 	if err := channelGroup.Wait(); err != nil {
 		return err
 	}
+	artifacts, err := printArtifacts(w, stepNames)
+	if err != nil {
+		return err
+	}
+	written = append(written, artifacts...)
+	if signer == nil {
+		return nil
+	}
+	signed, err := signer.Sign(context.Background(), SignableResults(written))
+	if err != nil {
+		return fmt.Errorf("error signing results: %w", err)
+	}
+	for _, s := range signed {
+		if err := encode(w, SidecarLogResult{Name: s.Key, Value: s.Value, Type: taskResultType}); err != nil {
+			return fmt.Errorf("error writing results: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -221,26 +257,64 @@ func LookForArtifacts(w io.Writer, names []string, runDir string) error {
 		return err
 	}
 
+	_, err = printArtifacts(w, names)
+	return err
+}
+
+// printArtifacts prints the artifacts of the given steps and returns them.
+func printArtifacts(w io.Writer, names []string) ([]result.RunResult, error) {
+	var written []result.RunResult
 	for _, name := range names {
 		p := filepath.Join(stepDir, name, "artifacts", "provenance.json")
 		if exist, err := fileExists(p); err != nil {
-			return err
+			return nil, err
 		} else if !exist {
 			continue
 		}
 		subRes, err := extractArtifactsFromFile(p)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if err := computeArtifactDigests(&subRes); err != nil {
+			return nil, err
 		}
 		values, err := json.Marshal(&subRes)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if err := encode(w, SidecarLogResult{Name: name, Value: string(values), Type: stepArtifactType}); err != nil {
-			return err
+			return nil, err
 		}
+		written = append(written, result.RunResult{Key: name, Value: string(values), ResultType: result.StepArtifactsResultType})
 	}
-	return nil
+	return written, nil
+}
+
+// SignableResults returns the results extracted by the sidecar as the task results its signatures
+// cover. Step results and artifacts are not task results, they are covered as task results whose
+// key is prefixed with their type and whose value is their JSON quoted value, so that they cannot be
+// tampered with either. The signature material, which is made of task results, is kept as is.
+func SignableResults(results []result.RunResult) []result.RunResult {
+	signable := make([]result.RunResult, 0, len(results))
+	for _, r := range results {
+		var prefix string
+		switch r.ResultType {
+		case result.TaskRunResultType:
+			signable = append(signable, r)
+			continue
+		case result.StepResultType:
+			prefix = "step-result:"
+		case result.StepArtifactsResultType:
+			prefix = "step-artifacts:"
+		case result.TaskRunArtifactsResultType:
+			prefix = "task-artifacts:"
+		default:
+			continue
+		}
+		value, _ := json.Marshal(r.Value)
+		signable = append(signable, result.RunResult{Key: prefix + r.Key, Value: string(value), ResultType: result.TaskRunResultType})
+	}
+	return signable
 }
 
 // GetResultsFromSidecarLogs extracts results from the logs of the results sidecar
@@ -311,17 +385,8 @@ func parseResults(resultBytes []byte, maxResultLimit int) (result.RunResult, err
 	if len(resultBytes) > maxResultLimit {
 		return runResult, fmt.Errorf("invalid result \"%s\": %w of %d", res.Name, ErrSizeExceeded, maxResultLimit)
 	}
-	var resultType result.ResultType
-	switch res.Type {
-	case taskResultType:
-		resultType = result.TaskRunResultType
-	case stepResultType:
-		resultType = result.StepResultType
-	case stepArtifactType:
-		resultType = result.StepArtifactsResultType
-	case taskArtifactType:
-		resultType = result.TaskRunArtifactsResultType
-	default:
+	resultType := runResultType(res.Type)
+	if resultType == 0 {
 		return result.RunResult{}, fmt.Errorf("invalid sidecar result type %v. Must be %v or %v or %v", res.Type, taskResultType, stepResultType, stepArtifactType)
 	}
 	runResult = result.RunResult{
@@ -332,6 +397,21 @@ func parseResults(resultBytes []byte, maxResultLimit int) (result.RunResult, err
 	return runResult, nil
 }
 
+// runResultType returns the type of run result of the sidecar log result type, or 0 if it is invalid.
+func runResultType(t SidecarLogResultType) result.ResultType {
+	switch t {
+	case taskResultType:
+		return result.TaskRunResultType
+	case stepResultType:
+		return result.StepResultType
+	case stepArtifactType:
+		return result.StepArtifactsResultType
+	case taskArtifactType:
+		return result.TaskRunArtifactsResultType
+	}
+	return 0
+}
+
 func parseArtifacts(fileContent []byte) (v1.Artifacts, error) {
 	var as v1.Artifacts
 	if err := json.Unmarshal(fileContent, &as); err != nil {
@@ -348,6 +428,49 @@ func extractArtifactsFromFile(filename string) (v1.Artifacts, error) {
 	return parseArtifacts(b)
 }
 
+// computeArtifactDigests records the sha256 digest and the size of the output artifact values
+// whose uri is a file readable by the sidecar, e.g. "file:///tekton/steps/step-build/artifacts/app.tar".
+// Digests already reported by the step are kept, and files that do not exist are skipped.
+func computeArtifactDigests(as *v1.Artifacts) error {
+	for i := range as.Outputs {
+		for j := range as.Outputs[i].Values {
+			value := &as.Outputs[i].Values[j]
+			if !strings.HasPrefix(value.Uri, fileURIPrefix) {
+				continue
+			}
+			digest, size, err := digestFile(strings.TrimPrefix(value.Uri, fileURIPrefix))
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("error computing the digest of artifact %q: %w", as.Outputs[i].Name, err)
+			}
+			if _, ok := value.Digest[sha256Algorithm]; !ok {
+				if value.Digest == nil {
+					value.Digest = map[v1.Algorithm]string{}
+				}
+				value.Digest[sha256Algorithm] = digest
+			}
+			value.Size = size
+		}
+	}
+	return nil
+}
+
+// digestFile returns the hex encoded sha256 digest and the size in bytes of a file.
+func digestFile(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
 // getSidecarLogPollingInterval reads the SIDECAR_LOG_POLLING_INTERVAL environment variable,
 // parses it as a time.Duration, and returns the result. If the variable is not set or is invalid,
 // it defaults to 100ms.
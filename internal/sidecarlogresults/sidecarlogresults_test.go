@@ -31,7 +31,9 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -73,7 +75,7 @@ func TestLookForResults_FanOutAndWait(t *testing.T) {
 			dir2 := t.TempDir()
 			createRun(t, dir2, false)
 			got := new(bytes.Buffer)
-			err := LookForResults(got, dir2, dir, resultNames, "", map[string][]string{})
+			err := LookForResults(got, dir2, dir, resultNames, "", map[string][]string{}, nil, nil)
 			if err != nil {
 				t.Fatalf("Did not expect any error but got: %v", err)
 			}
@@ -139,7 +141,7 @@ func TestLookForResults(t *testing.T) {
 				want = encodedResult
 			}
 			got := new(bytes.Buffer)
-			err := LookForResults(got, dir2, dir, []string{c.resultName}, "", map[string][]string{})
+			err := LookForResults(got, dir2, dir, []string{c.resultName}, "", map[string][]string{}, nil, nil)
 			if err != nil {
 				t.Fatalf("Did not expect any error but got: %v", err)
 			}
@@ -150,6 +152,115 @@ func TestLookForResults(t *testing.T) {
 	}
 }
 
+func TestLookForResults_Signed(t *testing.T) {
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "foo"}}
+	signer := &spire.MockClient{}
+	if err := signer.CreateEntries(t.Context(), tr, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	signer.SignIdentities = []string{signer.GetIdentity(tr)}
+
+	dir := t.TempDir()
+	createResult(t, dir, "foo", "bar")
+	createResult(t, dir, "baz", "qux")
+	stepsDir := t.TempDir()
+	createStepResult(t, stepsDir, "step-foo", "res", "step-value")
+	dir2 := t.TempDir()
+	createRun(t, dir2, false)
+
+	got := new(bytes.Buffer)
+	if err := LookForResults(got, dir2, dir, []string{"foo", "baz"}, stepsDir, map[string][]string{"step-foo": {"res"}}, nil, signer); err != nil {
+		t.Fatalf("Did not expect any error but got: %v", err)
+	}
+	results, err := extractResultsFromLogs(got, []result.RunResult{}, 4096)
+	if err != nil {
+		t.Fatalf("Did not expect any error but got: %v", err)
+	}
+	keys := []string{}
+	for _, r := range results {
+		keys = append(keys, r.Key)
+	}
+	sort.Strings(keys)
+	wantKeys := []string{
+		spire.KeyResultManifest, spire.KeyResultManifest + spire.KeySignatureSuffix, spire.KeySVID,
+		"baz", "baz" + spire.KeySignatureSuffix, "foo", "foo" + spire.KeySignatureSuffix,
+		"step-foo.res", "step-result:step-foo.res" + spire.KeySignatureSuffix,
+	}
+	if d := cmp.Diff(wantKeys, keys); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+	if err := (&spire.MockClient{}).VerifyTaskRunResults(t.Context(), SignableResults(results), tr); err != nil {
+		t.Errorf("Expected the signed results to be verified but got: %v", err)
+	}
+
+	// the step results are signed too
+	for i := range results {
+		if results[i].Key == "step-foo.res" {
+			results[i].Value = "tampered"
+		}
+	}
+	if err := (&spire.MockClient{}).VerifyTaskRunResults(t.Context(), SignableResults(results), tr); err == nil {
+		t.Error("Expected the tampered step result to fail verification")
+	}
+}
+
+func TestSignableResults(t *testing.T) {
+	results := []result.RunResult{{
+		Key:        "foo",
+		Value:      "bar",
+		ResultType: result.TaskRunResultType,
+	}, {
+		Key:        "step-foo.res",
+		Value:      `["a","b"]`,
+		ResultType: result.StepResultType,
+	}, {
+		Key:        "step-foo",
+		Value:      `{"inputs":[]}`,
+		ResultType: result.StepArtifactsResultType,
+	}, {
+		Key:        "task",
+		Value:      `{"outputs":[]}`,
+		ResultType: result.TaskRunArtifactsResultType,
+	}, {
+		Key:        "StartedAt",
+		Value:      "now",
+		ResultType: result.InternalTektonResultType,
+	}}
+	want := []result.RunResult{{
+		Key:        "foo",
+		Value:      "bar",
+		ResultType: result.TaskRunResultType,
+	}, {
+		Key:        "step-result:step-foo.res",
+		Value:      `"[\"a\",\"b\"]"`,
+		ResultType: result.TaskRunResultType,
+	}, {
+		Key:        "step-artifacts:step-foo",
+		Value:      `"{\"inputs\":[]}"`,
+		ResultType: result.TaskRunResultType,
+	}, {
+		Key:        "task-artifacts:task",
+		Value:      `"{\"outputs\":[]}"`,
+		ResultType: result.TaskRunResultType,
+	}}
+	if d := cmp.Diff(want, SignableResults(results)); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
+func TestLookForResults_SignError(t *testing.T) {
+	dir := t.TempDir()
+	createResult(t, dir, "foo", "bar")
+	dir2 := t.TempDir()
+	createRun(t, dir2, false)
+
+	// the mock client can't sign without identities
+	err := LookForResults(new(bytes.Buffer), dir2, dir, []string{"foo"}, "", map[string][]string{}, nil, &spire.MockClient{})
+	if err == nil {
+		t.Fatal("Expected an error signing the results")
+	}
+}
+
 func TestLookForStepResults(t *testing.T) {
 	for _, c := range []struct {
 		desc         string
@@ -209,7 +320,7 @@ func TestLookForStepResults(t *testing.T) {
 			stepResults := map[string][]string{
 				c.stepName: {c.resultName},
 			}
-			err := LookForResults(got, dir2, "", []string{}, dir, stepResults)
+			err := LookForResults(got, dir2, "", []string{}, dir, stepResults, nil, nil)
 			if err != nil {
 				t.Fatalf("Did not expect any error but got: %v", err)
 			}
@@ -791,6 +902,51 @@ func writeArtifacts(path string, artifacts *v1.Artifacts) error {
 	return res
 }
 
+func TestComputeArtifactDigests(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.tar")
+	if err := os.WriteFile(file, []byte("hello world"), 0o644); err != nil {
+		t.Fatalf("failed to write artifact file: %v", err)
+	}
+	as := v1.Artifacts{
+		Inputs: []v1.Artifact{{
+			Name:   "source",
+			Values: []v1.ArtifactValue{{Uri: "file://" + file}},
+		}},
+		Outputs: []v1.Artifact{{
+			Name: "app",
+			Values: []v1.ArtifactValue{
+				{Uri: "file://" + file},
+				{Uri: "file://" + file, Digest: map[v1.Algorithm]string{"sha256": "reported"}},
+				{Uri: "file://" + filepath.Join(dir, "missing")},
+				{Uri: "pkg:github/package-url/purl-spec@244fd47e07d1004f0aed9c"},
+			},
+		}},
+	}
+	want := v1.Artifacts{
+		Inputs: []v1.Artifact{{
+			Name:   "source",
+			Values: []v1.ArtifactValue{{Uri: "file://" + file}},
+		}},
+		Outputs: []v1.Artifact{{
+			Name: "app",
+			Values: []v1.ArtifactValue{
+				{Uri: "file://" + file, Size: 11, Digest: map[v1.Algorithm]string{"sha256": "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"}},
+				{Uri: "file://" + file, Size: 11, Digest: map[v1.Algorithm]string{"sha256": "reported"}},
+				{Uri: "file://" + filepath.Join(dir, "missing")},
+				{Uri: "pkg:github/package-url/purl-spec@244fd47e07d1004f0aed9c"},
+			},
+		}},
+	}
+
+	if err := computeArtifactDigests(&as); err != nil {
+		t.Fatalf("computeArtifactDigests: %v", err)
+	}
+	if d := cmp.Diff(want, as); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
 func basicArtifacts() v1.Artifacts {
 	data := `{
             "inputs":[
@@ -59,6 +59,10 @@ const (
 	// DefaultStepRefConcurrencyLimit is the default concurrency limit for resolving step references.
 	DefaultStepRefConcurrencyLimit = 5
 
+	// DefaultMaxPodDisruptionRetries is the default number of times the pod of a TaskRun attempt is
+	// recreated after node-level disruptions when the "enable-pod-disruption-rescheduling" feature flag is set.
+	DefaultMaxPodDisruptionRetries = 3
+
 	defaultTimeoutMinutesKey                = "default-timeout-minutes"
 	defaultServiceAccountKey                = "default-service-account"
 	defaultManagedByLabelValueKey           = "default-managed-by-label-value"
@@ -68,12 +72,15 @@ const (
 	defaultTaskRunWorkspaceBinding          = "default-task-run-workspace-binding"
 	defaultMaxMatrixCombinationsCountKey    = "default-max-matrix-combinations-count"
 	defaultForbiddenEnv                     = "default-forbidden-env"
+	defaultMetadataEnvKey                   = "default-metadata-env"
 	defaultResolverTypeKey                  = "default-resolver-type"
 	defaultContainerResourceRequirementsKey = "default-container-resource-requirements"
 	defaultImagePullBackOffTimeout          = "default-imagepullbackoff-timeout"
 	defaultMaximumResolutionTimeout         = "default-maximum-resolution-timeout"
 	defaultSidecarLogPollingIntervalKey     = "default-sidecar-log-polling-interval"
 	DefaultStepRefConcurrencyLimitKey       = "default-step-ref-concurrency-limit"
+	defaultMaxPodDisruptionRetriesKey       = "default-max-pod-disruption-retries"
+	defaultStepResourcesConfigMapKey        = "default-step-resources-configmap"
 )
 
 // DefaultConfig holds all the default configurations for the config.
@@ -100,6 +107,15 @@ type Defaults struct {
 	// It is used to control the responsiveness and resource usage of the sidecar in both production and test environments.
 	DefaultSidecarLogPollingInterval time.Duration
 	DefaultStepRefConcurrencyLimit   int
+	// DefaultMetadataEnv restricts the environment variables injected into steps when the
+	// "enable-metadata-env" feature flag is set. All of them are injected if it is empty.
+	DefaultMetadataEnv []string
+	// DefaultMaxPodDisruptionRetries is the number of times the pod of a TaskRun attempt is recreated after
+	// node-level disruptions when the "enable-pod-disruption-rescheduling" feature flag is set.
+	DefaultMaxPodDisruptionRetries int
+	// DefaultStepResourcesConfigMap is the name of the ConfigMap looked up in the namespace of a TaskRun
+	// for the default resource requirements of its steps. Namespaces are not looked up if it is empty.
+	DefaultStepResourcesConfigMap string
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -134,7 +150,10 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultMaximumResolutionTimeout == cfg.DefaultMaximumResolutionTimeout &&
 		other.DefaultSidecarLogPollingInterval == cfg.DefaultSidecarLogPollingInterval &&
 		other.DefaultStepRefConcurrencyLimit == cfg.DefaultStepRefConcurrencyLimit &&
-		reflect.DeepEqual(other.DefaultForbiddenEnv, cfg.DefaultForbiddenEnv)
+		other.DefaultMaxPodDisruptionRetries == cfg.DefaultMaxPodDisruptionRetries &&
+		other.DefaultStepResourcesConfigMap == cfg.DefaultStepResourcesConfigMap &&
+		reflect.DeepEqual(other.DefaultForbiddenEnv, cfg.DefaultForbiddenEnv) &&
+		reflect.DeepEqual(other.DefaultMetadataEnv, cfg.DefaultMetadataEnv)
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -150,6 +169,7 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		DefaultMaximumResolutionTimeout:   DefaultMaximumResolutionTimeout,
 		DefaultSidecarLogPollingInterval:  DefaultSidecarLogPollingInterval,
 		DefaultStepRefConcurrencyLimit:    DefaultStepRefConcurrencyLimit,
+		DefaultMaxPodDisruptionRetries:    DefaultMaxPodDisruptionRetries,
 	}
 
 	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
@@ -207,6 +227,15 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		}
 		tc.DefaultForbiddenEnv = tmpString.List()
 	}
+	if defaultMetadataEnvString, ok := cfgMap[defaultMetadataEnvKey]; ok {
+		tmpString := sets.NewString()
+		for _, mEnv := range strings.Split(defaultMetadataEnvString, ",") {
+			if mEnv = strings.TrimSpace(mEnv); mEnv != "" {
+				tmpString.Insert(mEnv)
+			}
+		}
+		tc.DefaultMetadataEnv = tmpString.List()
+	}
 
 	if defaultResolverType, ok := cfgMap[defaultResolverTypeKey]; ok {
 		tc.DefaultResolverType = defaultResolverType
@@ -252,6 +281,18 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultStepRefConcurrencyLimit = int(stepRefConcurrencyLimit)
 	}
 
+	if maxPodDisruptionRetries, ok := cfgMap[defaultMaxPodDisruptionRetriesKey]; ok {
+		retries, err := strconv.ParseInt(maxPodDisruptionRetries, 10, 0)
+		if err != nil || retries < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", defaultMaxPodDisruptionRetriesKey)
+		}
+		tc.DefaultMaxPodDisruptionRetries = int(retries)
+	}
+
+	if stepResourcesConfigMap, ok := cfgMap[defaultStepResourcesConfigMapKey]; ok {
+		tc.DefaultStepResourcesConfigMap = strings.TrimSpace(stepResourcesConfigMap)
+	}
+
 	return &tc, nil
 }
 
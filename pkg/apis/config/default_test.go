@@ -48,6 +48,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
 				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
 				DefaultStepRefConcurrencyLimit:    5,
+				DefaultMaxPodDisruptionRetries:    3,
 			},
 			fileName: config.GetDefaultsConfigName(),
 		},
@@ -71,6 +72,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
 				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
 				DefaultStepRefConcurrencyLimit:    5,
+				DefaultMaxPodDisruptionRetries:    3,
 			},
 			fileName: "config-defaults-with-pod-template",
 		},
@@ -97,6 +99,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
 				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
 				DefaultStepRefConcurrencyLimit:    5,
+				DefaultMaxPodDisruptionRetries:    3,
 			},
 		},
 		{
@@ -112,6 +115,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
 				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
 				DefaultStepRefConcurrencyLimit:    5,
+				DefaultMaxPodDisruptionRetries:    3,
 			},
 		},
 		{
@@ -130,6 +134,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
 				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
 				DefaultStepRefConcurrencyLimit:    5,
+				DefaultMaxPodDisruptionRetries:    3,
 			},
 		},
 		{
@@ -145,6 +150,24 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
 				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
 				DefaultStepRefConcurrencyLimit:    5,
+				DefaultMaxPodDisruptionRetries:    3,
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-metadata-env",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultManagedByLabelValue:        "tekton-pipelines",
+				DefaultResolverType:               "",
+				DefaultMetadataEnv:                []string{"TEKTON_NAMESPACE", "TEKTON_TASK_RUN"},
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:    5,
+				DefaultMaxPodDisruptionRetries:    3,
 			},
 		},
 		{
@@ -160,6 +183,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaximumResolutionTimeout:      1 * time.Minute,
 				DefaultSidecarLogPollingInterval:     100 * time.Millisecond,
 				DefaultStepRefConcurrencyLimit:       5,
+				DefaultMaxPodDisruptionRetries:       3,
 			},
 		},
 		{
@@ -211,17 +235,54 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 					"test": {},
 				},
 				DefaultStepRefConcurrencyLimit: 5,
+				DefaultMaxPodDisruptionRetries: 3,
 			},
 		},
 		{
 			expectedError: true,
 			fileName:      "config-defaults-step-ref-concurrency-limit-err",
 		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-max-pod-disruption-retries-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-max-pod-disruption-retries",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:    5,
+				DefaultMaxPodDisruptionRetries:    1,
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-step-resources-configmap",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:    5,
+				DefaultMaxPodDisruptionRetries:    3,
+				DefaultStepResourcesConfigMap:     "tekton-step-defaults",
+			},
+		},
 		{
 			expectedError: false,
 			fileName:      "config-defaults-step-ref-concurrency-limit",
 			expectedConfig: &config.Defaults{
 				DefaultStepRefConcurrencyLimit:    10,
+				DefaultMaxPodDisruptionRetries:    3,
 				DefaultTimeoutMinutes:             60,
 				DefaultServiceAccount:             "default",
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
@@ -255,6 +316,7 @@ func TestNewDefaultsFromEmptyConfigMap(t *testing.T) {
 		DefaultMaximumResolutionTimeout:   1 * time.Minute,
 		DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
 		DefaultStepRefConcurrencyLimit:    5,
+		DefaultMaxPodDisruptionRetries:    3,
 	}
 	verifyConfigFileWithExpectedConfig(t, DefaultsConfigEmptyName, expectedConfig)
 }
@@ -445,18 +507,22 @@ func TestEquals(t *testing.T) {
 			name: "different default step ref concurrency limit",
 			left: &config.Defaults{
 				DefaultStepRefConcurrencyLimit: 5,
+				DefaultMaxPodDisruptionRetries: 3,
 			},
 			right: &config.Defaults{
 				DefaultStepRefConcurrencyLimit: 10,
+				DefaultMaxPodDisruptionRetries: 3,
 			},
 			expected: false,
 		}, {
 			name: "same default step ref concurrency limit",
 			left: &config.Defaults{
 				DefaultStepRefConcurrencyLimit: 5,
+				DefaultMaxPodDisruptionRetries: 3,
 			},
 			right: &config.Defaults{
 				DefaultStepRefConcurrencyLimit: 5,
+				DefaultMaxPodDisruptionRetries: 3,
 			},
 			expected: true,
 		},
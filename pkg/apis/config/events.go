@@ -18,7 +18,10 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 
@@ -32,8 +35,10 @@ const (
 	// DefaultSink is the default value for "sink"
 	DefaultSink = ""
 
-	formatsKey = "formats"
-	sinkKey    = "sink"
+	formatsKey        = "formats"
+	sinkKey           = "sink"
+	namespaceSinksKey = "namespace-sinks"
+	allowedSinksKey   = "allowed-sinks"
 )
 
 var (
@@ -53,6 +58,10 @@ var (
 type Events struct {
 	Sink    string
 	Formats EventFormats
+	// NamespaceSinks holds the sinks that replace Sink for the runs in a namespace
+	NamespaceSinks map[string]string
+	// AllowedSinks holds the sinks that runs may select through an annotation
+	AllowedSinks []string
 }
 
 // EventFormat is a single event format
@@ -149,9 +158,57 @@ func NewEventsFromMap(cfgMap map[string]string) (*Events, error) {
 		return nil, err
 	}
 	setField(sinkKey, DefaultSink, &events.Sink)
+	if err := setNamespaceSinks(cfgMap, &events.NamespaceSinks); err != nil {
+		return nil, err
+	}
+	if cfg, ok := cfgMap[allowedSinksKey]; ok {
+		for _, sink := range strings.Split(cfg, ",") {
+			if sink = strings.TrimSpace(sink); sink != "" {
+				events.AllowedSinks = append(events.AllowedSinks, sink)
+			}
+		}
+	}
 	return &events, nil
 }
 
+// setNamespaceSinks parses a comma separated list of namespace=sink pairs
+func setNamespaceSinks(cfgMap map[string]string, field *map[string]string) error {
+	cfg, ok := cfgMap[namespaceSinksKey]
+	if !ok {
+		return nil
+	}
+	sinks := map[string]string{}
+	for _, pair := range strings.Split(cfg, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		namespace, sink, found := strings.Cut(pair, "=")
+		namespace, sink = strings.TrimSpace(namespace), strings.TrimSpace(sink)
+		if !found || namespace == "" || sink == "" {
+			return fmt.Errorf("invalid %s entry %q, expected namespace=sink", namespaceSinksKey, pair)
+		}
+		if _, ok := sinks[namespace]; ok {
+			return errors.New("duplicate namespace in " + namespaceSinksKey + ": " + namespace)
+		}
+		sinks[namespace] = sink
+	}
+	*field = sinks
+	return nil
+}
+
+// SinkForNamespace returns the sink to be used for the runs in the namespace
+func (cfg *Events) SinkForNamespace(namespace string) string {
+	if sink, ok := cfg.NamespaceSinks[namespace]; ok {
+		return sink
+	}
+	return cfg.Sink
+}
+
+// IsAllowedSink returns true if runs may select the sink through an annotation
+func (cfg *Events) IsAllowedSink(sink string) bool {
+	return slices.Contains(cfg.AllowedSinks, sink)
+}
+
 func setFormats(cfgMap map[string]string, defaultValue EventFormats, field *EventFormats) error {
 	value := defaultValue
 	if cfg, ok := cfgMap[formatsKey]; ok {
@@ -181,5 +238,7 @@ func (cfg *Events) Equals(other *Events) bool {
 	}
 
 	return other.Sink == cfg.Sink &&
-		other.Formats.Equals(cfg.Formats)
+		other.Formats.Equals(cfg.Formats) &&
+		maps.Equal(other.NamespaceSinks, cfg.NamespaceSinks) &&
+		slices.Equal(other.AllowedSinks, cfg.AllowedSinks)
 }
@@ -218,6 +218,22 @@ func TestNewEventsFromConfigMap(t *testing.T) {
 			Sink:    config.DefaultSink,
 		},
 		fileName: "config-events-empty",
+	}, {
+		description: "namespace and allowed sinks",
+		expectedConfig: &config.Events{
+			Formats: config.DefaultFormats,
+			Sink:    "http://events.sink",
+			NamespaceSinks: map[string]string{
+				"team-a": "http://team-a.sink",
+				"team-b": "http://team-b.sink",
+			},
+			AllowedSinks: []string{"http://audit.sink", "http://team-a.sink"},
+		},
+		fileName: "config-events-sinks",
+	}, {
+		description:   "invalid namespace sinks",
+		expectedError: true,
+		fileName:      "config-events-invalid-namespace-sinks",
 	}, {
 		description:   "empty values in formats",
 		expectedError: true,
@@ -296,6 +312,27 @@ func TestEventsConfigEquals(t *testing.T) {
 			Sink: "http://event.sink/2",
 		},
 		expected: false,
+	}, {
+		name: "different namespace sinks",
+		left: &config.Events{
+			Sink:           "http://event.sink",
+			NamespaceSinks: map[string]string{"foo": "http://event.sink/1"},
+		},
+		right: &config.Events{
+			Sink:           "http://event.sink",
+			NamespaceSinks: map[string]string{"foo": "http://event.sink/2"},
+		},
+		expected: false,
+	}, {
+		name: "different allowed sinks",
+		left: &config.Events{
+			Sink:         "http://event.sink",
+			AllowedSinks: []string{"http://event.sink/1"},
+		},
+		right: &config.Events{
+			Sink: "http://event.sink",
+		},
+		expected: false,
 	}, {
 		name: "identical",
 		left: &config.Events{
@@ -320,3 +357,16 @@ func TestEventsConfigEquals(t *testing.T) {
 		})
 	}
 }
+
+func TestEventsSinkForNamespace(t *testing.T) {
+	events := &config.Events{
+		Sink:           "http://events.sink",
+		NamespaceSinks: map[string]string{"team-a": "http://team-a.sink"},
+	}
+	if d := cmp.Diff("http://team-a.sink", events.SinkForNamespace("team-a")); d != "" {
+		t.Errorf("Diff(-want,+got):\n%s", d)
+	}
+	if d := cmp.Diff("http://events.sink", events.SinkForNamespace("team-b")); d != "" {
+		t.Errorf("Diff(-want,+got):\n%s", d)
+	}
+}
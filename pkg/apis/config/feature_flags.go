@@ -18,6 +18,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -57,6 +58,11 @@ const (
 	ResultExtractionMethodTerminationMessage = "termination-message"
 	// ResultExtractionMethodSidecarLogs is the value used for "results-from" as a way to extract results from tasks using sidecar logs.
 	ResultExtractionMethodSidecarLogs = "sidecar-logs"
+	// EntrypointLogFormatText is the value used for "entrypoint-log-format" to log the messages of the entrypoint as text.
+	EntrypointLogFormatText = "text"
+	// EntrypointLogFormatJSON is the value used for "entrypoint-log-format" to log the messages of the entrypoint as JSON,
+	// along with the UID of the TaskRun and the name of the step.
+	EntrypointLogFormatJSON = "json"
 	// DefaultDisableCredsInit is the default value for "disable-creds-init".
 	DefaultDisableCredsInit = false
 	// DefaultRunningInEnvWithInjectedSidecars is the default value for "running-in-environment-with-injected-sidecars".
@@ -87,6 +93,14 @@ const (
 	DefaultResultExtractionMethod = ResultExtractionMethodTerminationMessage
 	// DefaultMaxResultSize is the default value in bytes for the size of a result
 	DefaultMaxResultSize = 4096
+	// DefaultMaxFileResultSize is the default value in bytes for the size of a result of type file
+	DefaultMaxFileResultSize = 100 * 1024 * 1024
+	// DefaultMaxStepLogSize is the default value in bytes for the volume of the output of a step, 0 means no limit
+	DefaultMaxStepLogSize = 0
+	// DefaultEntrypointLogFormat is the default value for "entrypoint-log-format"
+	DefaultEntrypointLogFormat = EntrypointLogFormatText
+	// DefaultMaxStepLogLines is the default value in lines for the volume of the output of a step, 0 means no limit
+	DefaultMaxStepLogLines = 0
 	// DefaultSetSecurityContext is the default value for "set-security-context"
 	DefaultSetSecurityContext = false
 	// DefaultSetSecurityContextReadOnlyRootFilesystem is the default value for "set-security-context-read-only-root-filesystem"
@@ -111,6 +125,46 @@ const (
 	EnableWaitExponentialBackoff = "enable-wait-exponential-backoff"
 	// DefaultEnableWaitExponentialBackoff is the default value for EnableWaitExponentialBackoff
 	DefaultEnableWaitExponentialBackoff = false
+	// EnableTopologyAwareScheduling is the flag to enable preferred pod affinity between the TaskRun pods of a PipelineRun
+	EnableTopologyAwareScheduling = "enable-topology-aware-scheduling"
+	// DefaultEnableTopologyAwareScheduling is the default value for EnableTopologyAwareScheduling
+	DefaultEnableTopologyAwareScheduling = false
+	// EnableImageDigestPinning is the flag to create TaskRun Pods with the images of all steps and sidecars referenced by digest
+	EnableImageDigestPinning = "enable-image-digest-pinning"
+	// DefaultEnableImageDigestPinning is the default value for EnableImageDigestPinning
+	DefaultEnableImageDigestPinning = false
+	// EnableWindowsHostProcess is the flag to allow TaskRuns to run as Windows HostProcess containers
+	EnableWindowsHostProcess = "enable-windows-host-process"
+	// DefaultEnableWindowsHostProcess is the default value for EnableWindowsHostProcess
+	DefaultEnableWindowsHostProcess = false
+	// EnableMetadataEnv is the flag to inject environment variables describing the TaskRun and PipelineRun into all steps
+	EnableMetadataEnv = "enable-metadata-env"
+	// DefaultEnableMetadataEnv is the default value for EnableMetadataEnv
+	DefaultEnableMetadataEnv = false
+	// EnablePodDisruptionRescheduling is the flag to recreate the pod of a TaskRun after node-level disruptions, e.g. evictions
+	EnablePodDisruptionRescheduling = "enable-pod-disruption-rescheduling"
+	// DefaultEnablePodDisruptionRescheduling is the default value for EnablePodDisruptionRescheduling
+	DefaultEnablePodDisruptionRescheduling = false
+	// EnableTaskRunRestart is the flag to restart completed TaskRuns in place when they have the experimental restart annotation
+	EnableTaskRunRestart = "enable-taskrun-restart"
+	// DefaultEnableTaskRunRestart is the default value for EnableTaskRunRestart
+	DefaultEnableTaskRunRestart = false
+	// EnableCompletedWithErrors is the flag to report the CompletedWithErrors reason for PipelineRuns with ignored task failures
+	EnableCompletedWithErrors = "enable-completed-with-errors"
+	// DefaultEnableCompletedWithErrors is the default value for EnableCompletedWithErrors
+	DefaultEnableCompletedWithErrors = false
+	// EnableResultOverflow is the flag to overflow the results which do not fit in the termination message to the step's log
+	EnableResultOverflow = "enable-result-overflow"
+	// DefaultEnableResultOverflow is the default value for EnableResultOverflow
+	DefaultEnableResultOverflow = false
+	// SendCloudEventsForSteps is the flag to send CloudEvents when the steps of a TaskRun start and complete
+	SendCloudEventsForSteps = "send-cloudevents-for-steps"
+	// DefaultSendCloudEventsForSteps is the default value for SendCloudEventsForSteps
+	DefaultSendCloudEventsForSteps = false
+	// EnforceHermeticNetworkPolicy is the flag to deny the egress traffic of hermetic TaskRun pods with a NetworkPolicy
+	EnforceHermeticNetworkPolicy = "enforce-hermetic-network-policy"
+	// DefaultEnforceHermeticNetworkPolicy is the default value for EnforceHermeticNetworkPolicy
+	DefaultEnforceHermeticNetworkPolicy = false
 
 	// EnableStepActions is the flag to enable step actions (no-op since it's stable)
 	EnableStepActions = "enable-step-actions"
@@ -132,6 +186,11 @@ const (
 	enableProvenanceInStatus                    = "enable-provenance-in-status"
 	resultExtractionMethod                      = "results-from"
 	maxResultSize                               = "max-result-size"
+	fileResultsStoreURL                         = "file-results-store-url"
+	maxFileResultSize                           = "max-file-result-size"
+	maxStepLogSize                              = "max-step-log-size"
+	maxStepLogLines                             = "max-step-log-lines"
+	entrypointLogFormat                         = "entrypoint-log-format"
 	setSecurityContextKey                       = "set-security-context"
 	setSecurityContextReadOnlyRootFilesystemKey = "set-security-context-read-only-root-filesystem"
 	coscheduleKey                               = "coschedule"
@@ -205,6 +264,11 @@ type FeatureFlags struct {
 	EnableProvenanceInStatus                 bool   `json:"enableProvenanceInStatus,omitempty"`
 	ResultExtractionMethod                   string `json:"resultExtractionMethod,omitempty"`
 	MaxResultSize                            int    `json:"maxResultSize,omitempty"`
+	FileResultsStoreURL                      string `json:"fileResultsStoreURL,omitempty"`
+	MaxFileResultSize                        int    `json:"maxFileResultSize,omitempty"`
+	MaxStepLogSize                           int    `json:"maxStepLogSize,omitempty"`
+	MaxStepLogLines                          int    `json:"maxStepLogLines,omitempty"`
+	EntrypointLogFormat                      string `json:"entrypointLogFormat,omitempty"`
 	SetSecurityContext                       bool   `json:"setSecurityContext,omitempty"`
 	SetSecurityContextReadOnlyRootFilesystem bool   `json:"setSecurityContextReadOnlyRootFilesystem,omitempty"`
 	Coschedule                               string `json:"coschedule,omitempty"`
@@ -217,6 +281,26 @@ type FeatureFlags struct {
 	EnableConciseResolverSyntax  bool   `json:"enableConciseResolverSyntax,omitempty"`
 	EnableKubernetesSidecar      bool   `json:"enableKubernetesSidecar,omitempty"`
 	EnableWaitExponentialBackoff bool   `json:"enableWaitExponentialBackoff,omitempty"`
+	// EnableTopologyAwareScheduling is the feature flag for "enable-topology-aware-scheduling"
+	EnableTopologyAwareScheduling bool `json:"enableTopologyAwareScheduling,omitempty"`
+	// EnableImageDigestPinning is the feature flag for "enable-image-digest-pinning"
+	EnableImageDigestPinning bool `json:"enableImageDigestPinning,omitempty"`
+	// EnableWindowsHostProcess is the feature flag for "enable-windows-host-process"
+	EnableWindowsHostProcess bool `json:"enableWindowsHostProcess,omitempty"`
+	// EnableMetadataEnv is the feature flag for "enable-metadata-env"
+	EnableMetadataEnv bool `json:"enableMetadataEnv,omitempty"`
+	// EnablePodDisruptionRescheduling is the feature flag for "enable-pod-disruption-rescheduling"
+	EnablePodDisruptionRescheduling bool `json:"enablePodDisruptionRescheduling,omitempty"`
+	// EnableTaskRunRestart is the feature flag for "enable-taskrun-restart"
+	EnableTaskRunRestart bool `json:"enableTaskRunRestart,omitempty"`
+	// EnableCompletedWithErrors is the feature flag for "enable-completed-with-errors"
+	EnableCompletedWithErrors bool `json:"enableCompletedWithErrors,omitempty"`
+	// EnableResultOverflow is the feature flag for "enable-result-overflow"
+	EnableResultOverflow bool `json:"enableResultOverflow,omitempty"`
+	// SendCloudEventsForSteps is the feature flag for "send-cloudevents-for-steps"
+	SendCloudEventsForSteps bool `json:"sendCloudEventsForSteps,omitempty"`
+	// EnforceHermeticNetworkPolicy is the feature flag for "enforce-hermetic-network-policy"
+	EnforceHermeticNetworkPolicy bool `json:"enforceHermeticNetworkPolicy,omitempty"`
 	// DeprecatedEnableTektonOCIBundles is maintained for backward compatibility
 	// to allow deletion of PipelineRuns created before v0.62.x.
 	// This field is not used and can be removed in a future release
@@ -293,6 +377,21 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setMaxResultSize(cfgMap, DefaultMaxResultSize, &tc.MaxResultSize); err != nil {
 		return nil, err
 	}
+	if err := setFileResultsStoreURL(cfgMap, &tc.FileResultsStoreURL); err != nil {
+		return nil, err
+	}
+	if err := setMaxFileResultSize(cfgMap, DefaultMaxFileResultSize, &tc.MaxFileResultSize); err != nil {
+		return nil, err
+	}
+	if err := setMaxStepLogLimit(cfgMap, maxStepLogSize, DefaultMaxStepLogSize, &tc.MaxStepLogSize); err != nil {
+		return nil, err
+	}
+	if err := setMaxStepLogLimit(cfgMap, maxStepLogLines, DefaultMaxStepLogLines, &tc.MaxStepLogLines); err != nil {
+		return nil, err
+	}
+	if err := setEntrypointLogFormat(cfgMap, DefaultEntrypointLogFormat, &tc.EntrypointLogFormat); err != nil {
+		return nil, err
+	}
 	if err := setPerFeatureFlag(KeepPodOnCancel, DefaultEnableKeepPodOnCancel, &tc.EnableKeepPodOnCancel); err != nil {
 		return nil, err
 	}
@@ -330,6 +429,36 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(EnableWaitExponentialBackoff, DefaultEnableWaitExponentialBackoff, &tc.EnableWaitExponentialBackoff); err != nil {
 		return nil, err
 	}
+	if err := setFeature(EnableTopologyAwareScheduling, DefaultEnableTopologyAwareScheduling, &tc.EnableTopologyAwareScheduling); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableImageDigestPinning, DefaultEnableImageDigestPinning, &tc.EnableImageDigestPinning); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableWindowsHostProcess, DefaultEnableWindowsHostProcess, &tc.EnableWindowsHostProcess); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableMetadataEnv, DefaultEnableMetadataEnv, &tc.EnableMetadataEnv); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnablePodDisruptionRescheduling, DefaultEnablePodDisruptionRescheduling, &tc.EnablePodDisruptionRescheduling); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableTaskRunRestart, DefaultEnableTaskRunRestart, &tc.EnableTaskRunRestart); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableCompletedWithErrors, DefaultEnableCompletedWithErrors, &tc.EnableCompletedWithErrors); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableResultOverflow, DefaultEnableResultOverflow, &tc.EnableResultOverflow); err != nil {
+		return nil, err
+	}
+	if err := setFeature(SendCloudEventsForSteps, DefaultSendCloudEventsForSteps, &tc.SendCloudEventsForSteps); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnforceHermeticNetworkPolicy, DefaultEnforceHermeticNetworkPolicy, &tc.EnforceHermeticNetworkPolicy); err != nil {
+		return nil, err
+	}
 
 	return &tc, nil
 }
@@ -410,6 +539,22 @@ func setResultExtractionMethod(cfgMap map[string]string, defaultValue string, fe
 	return nil
 }
 
+// setEntrypointLogFormat sets the "entrypoint-log-format" flag based on the content of a given map.
+// If the value is invalid then an error is returned.
+func setEntrypointLogFormat(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[entrypointLogFormat]; ok {
+		value = strings.ToLower(cfg)
+	}
+	switch value {
+	case EntrypointLogFormatText, EntrypointLogFormatJSON:
+		*feature = value
+	default:
+		return fmt.Errorf("invalid value for feature flag %q: %q", entrypointLogFormat, value)
+	}
+	return nil
+}
+
 // setMaxResultSize sets the "max-result-size" flag based on the content of a given map.
 // If the feature gate is invalid or missing then an error is returned.
 func setMaxResultSize(cfgMap map[string]string, defaultValue int, feature *int) error {
@@ -429,6 +574,57 @@ func setMaxResultSize(cfgMap map[string]string, defaultValue int, feature *int)
 	return nil
 }
 
+// setFileResultsStoreURL sets the "file-results-store-url" flag based on the content of a given map.
+// If the value is not an absolute http or https URL then an error is returned.
+func setFileResultsStoreURL(cfgMap map[string]string, feature *string) error {
+	cfg, ok := cfgMap[fileResultsStoreURL]
+	if !ok || cfg == "" {
+		return nil
+	}
+	u, err := url.Parse(cfg)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid value for feature flag %q: %q. It must be an http or https URL", fileResultsStoreURL, cfg)
+	}
+	*feature = strings.TrimSuffix(cfg, "/")
+	return nil
+}
+
+// setMaxFileResultSize sets the "max-file-result-size" flag based on the content of a given map.
+// If the value is invalid then an error is returned.
+func setMaxFileResultSize(cfgMap map[string]string, defaultValue int, feature *int) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[maxFileResultSize]; ok {
+		v, err := strconv.Atoi(cfg)
+		if err != nil {
+			return err
+		}
+		value = v
+	}
+	if value <= 0 {
+		return fmt.Errorf("invalid value for feature flag %q: %q. It must be a positive number of bytes", maxFileResultSize, strconv.Itoa(value))
+	}
+	*feature = value
+	return nil
+}
+
+// setMaxStepLogLimit sets the "max-step-log-size" or "max-step-log-lines" flag based on the content of a given map.
+// If the value is invalid then an error is returned.
+func setMaxStepLogLimit(cfgMap map[string]string, key string, defaultValue int, feature *int) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[key]; ok {
+		v, err := strconv.Atoi(cfg)
+		if err != nil {
+			return err
+		}
+		value = v
+	}
+	if value < 0 {
+		return fmt.Errorf("invalid value for feature flag %q: %q. It must not be negative", key, strconv.Itoa(value))
+	}
+	*feature = value
+	return nil
+}
+
 // setVerificationNoMatchPolicy sets the "trusted-resources-verification-no-match-policy" flag based on the content of a given map.
 // If the value is invalid or missing then an error is returned.
 func setVerificationNoMatchPolicy(cfgMap map[string]string, defaultValue string, feature *string) error {
@@ -46,7 +46,9 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
 				EnableProvenanceInStatus:         config.DefaultEnableProvenanceInStatus,
 				ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+				EntrypointLogFormat:              config.DefaultEntrypointLogFormat,
 				MaxResultSize:                    config.DefaultMaxResultSize,
+				MaxFileResultSize:                config.DefaultMaxFileResultSize,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
 				EnforceNonfalsifiability:         config.DefaultEnforceNonfalsifiability,
@@ -69,8 +71,13 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				VerificationNoMatchPolicy:                config.FailNoMatchPolicy,
 				EnableProvenanceInStatus:                 false,
 				ResultExtractionMethod:                   "termination-message",
+				EntrypointLogFormat:                      "json",
 				EnableKeepPodOnCancel:                    true,
 				MaxResultSize:                            4096,
+				FileResultsStoreURL:                      "https://results.example.com/tekton",
+				MaxFileResultSize:                        1048576,
+				MaxStepLogSize:                           10485760,
+				MaxStepLogLines:                          100000,
 				SetSecurityContext:                       true,
 				SetSecurityContextReadOnlyRootFilesystem: true,
 				Coschedule:                               config.CoscheduleDisabled,
@@ -80,6 +87,16 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				DisableInlineSpec:                        "pipeline,pipelinerun,taskrun",
 				EnableConciseResolverSyntax:              true,
 				EnableKubernetesSidecar:                  true,
+				EnableTopologyAwareScheduling:            true,
+				EnableImageDigestPinning:                 true,
+				EnableWindowsHostProcess:                 true,
+				EnableMetadataEnv:                        true,
+				EnablePodDisruptionRescheduling:          true,
+				EnableTaskRunRestart:                     true,
+				EnableCompletedWithErrors:                true,
+				EnableResultOverflow:                     true,
+				SendCloudEventsForSteps:                  true,
+				EnforceHermeticNetworkPolicy:             true,
 			},
 			fileName: "feature-flags-all-flags-set",
 		},
@@ -97,7 +114,9 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
 				EnableProvenanceInStatus:         config.DefaultEnableProvenanceInStatus,
 				ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+				EntrypointLogFormat:              config.DefaultEntrypointLogFormat,
 				MaxResultSize:                    config.DefaultMaxResultSize,
+				MaxFileResultSize:                config.DefaultMaxFileResultSize,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
 				EnableKeepPodOnCancel:            config.DefaultEnableKeepPodOnCancel.Enabled,
@@ -120,7 +139,9 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
 				EnableProvenanceInStatus:         config.DefaultEnableProvenanceInStatus,
 				ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+				EntrypointLogFormat:              config.DefaultEntrypointLogFormat,
 				MaxResultSize:                    config.DefaultMaxResultSize,
+				MaxFileResultSize:                config.DefaultMaxFileResultSize,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
 				EnableParamEnum:                  config.DefaultEnableParamEnum.Enabled,
@@ -140,7 +161,9 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
 				EnableProvenanceInStatus:         config.DefaultEnableProvenanceInStatus,
 				ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+				EntrypointLogFormat:              config.DefaultEntrypointLogFormat,
 				MaxResultSize:                    config.DefaultMaxResultSize,
+				MaxFileResultSize:                config.DefaultMaxFileResultSize,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
 				EnableParamEnum:                  config.DefaultEnableParamEnum.Enabled,
@@ -157,7 +180,9 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				AwaitSidecarReadiness:            config.DefaultAwaitSidecarReadiness,
 				EnableProvenanceInStatus:         config.DefaultEnableProvenanceInStatus,
 				ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+				EntrypointLogFormat:              config.DefaultEntrypointLogFormat,
 				MaxResultSize:                    config.DefaultMaxResultSize,
+				MaxFileResultSize:                config.DefaultMaxFileResultSize,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
 				EnableKeepPodOnCancel:            config.DefaultEnableKeepPodOnCancel.Enabled,
@@ -176,7 +201,9 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				AwaitSidecarReadiness:            config.DefaultAwaitSidecarReadiness,
 				EnableProvenanceInStatus:         config.DefaultEnableProvenanceInStatus,
 				ResultExtractionMethod:           config.ResultExtractionMethodSidecarLogs,
+				EntrypointLogFormat:              config.DefaultEntrypointLogFormat,
 				MaxResultSize:                    8192,
+				MaxFileResultSize:                config.DefaultMaxFileResultSize,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
 				EnableKeepPodOnCancel:            config.DefaultEnableKeepPodOnCancel.Enabled,
@@ -210,7 +237,9 @@ func TestNewFeatureFlagsFromEmptyConfigMap(t *testing.T) {
 		VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
 		EnableProvenanceInStatus:         config.DefaultEnableProvenanceInStatus,
 		ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+		EntrypointLogFormat:              config.DefaultEntrypointLogFormat,
 		MaxResultSize:                    config.DefaultMaxResultSize,
+		MaxFileResultSize:                config.DefaultMaxFileResultSize,
 		SetSecurityContext:               config.DefaultSetSecurityContext,
 		Coschedule:                       config.DefaultCoschedule,
 		EnableKeepPodOnCancel:            config.DefaultEnableKeepPodOnCancel.Enabled,
@@ -270,6 +299,18 @@ func TestNewFeatureFlagsConfigMapErrors(t *testing.T) {
 	}, {
 		fileName: "feature-flags-invalid-max-result-size-bad-value",
 		want:     `strconv.Atoi: parsing "foo": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-file-results-store-url",
+		want:     `invalid value for feature flag "file-results-store-url": "s3://bucket". It must be an http or https URL`,
+	}, {
+		fileName: "feature-flags-invalid-max-file-result-size",
+		want:     `invalid value for feature flag "max-file-result-size": "0". It must be a positive number of bytes`,
+	}, {
+		fileName: "feature-flags-invalid-max-step-log-size",
+		want:     `invalid value for feature flag "max-step-log-size": "-1". It must not be negative`,
+	}, {
+		fileName: "feature-flags-invalid-entrypoint-log-format",
+		want:     `invalid value for feature flag "entrypoint-log-format": "xml"`,
 	}, {
 		fileName: "feature-flags-enforce-nonfalsifiability-bad-flag",
 		want:     `invalid value for feature flag "enforce-nonfalsifiability": "bad-value"`,
@@ -297,6 +338,36 @@ func TestNewFeatureFlagsConfigMapErrors(t *testing.T) {
 	}, {
 		fileName: "feature-flags-invalid-enable-kubernetes-sidecar",
 		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-topology-aware-scheduling",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-image-digest-pinning",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-windows-host-process",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-metadata-env",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-pod-disruption-rescheduling",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-taskrun-restart",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-completed-with-errors",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-result-overflow",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-send-cloudevents-for-steps",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enforce-hermetic-network-policy",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
 	}, {
 		fileName: "feature-flags-invalid-set_security_context_read_only_root_filesystem",
 		want:     `failed parsing feature flags config "invalid read only root filesystem flag": strconv.ParseBool: parsing "invalid read only root filesystem flag": invalid syntax`,
@@ -35,6 +35,8 @@ const (
 	DefaultEnableClusterResolver = true
 	// DefaultEnableHttpResolver is the default value for "enable-http-resolver".
 	DefaultEnableHttpResolver = true
+	// DefaultEnableS3Resolver is the default value for "enable-s3-resolver".
+	DefaultEnableS3Resolver = true
 
 	// EnableGitResolver is the flag used to enable the git remote resolver
 	EnableGitResolver = "enable-git-resolver"
@@ -46,6 +48,8 @@ const (
 	EnableClusterResolver = "enable-cluster-resolver"
 	// EnableHttpResolver is the flag used to enable the http remote resolver
 	EnableHttpResolver = "enable-http-resolver"
+	// EnableS3Resolver is the flag used to enable the s3 remote resolver
+	EnableS3Resolver = "enable-s3-resolver"
 )
 
 // FeatureFlags holds the features configurations
@@ -56,6 +60,7 @@ type FeatureFlags struct {
 	EnableBundleResolver  bool
 	EnableClusterResolver bool
 	EnableHttpResolver    bool
+	EnableS3Resolver      bool
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -98,6 +103,9 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(EnableHttpResolver, DefaultEnableHttpResolver, &tc.EnableHttpResolver); err != nil {
 		return nil, err
 	}
+	if err := setFeature(EnableS3Resolver, DefaultEnableS3Resolver, &tc.EnableS3Resolver); err != nil {
+		return nil, err
+	}
 	return &tc, nil
 }
 
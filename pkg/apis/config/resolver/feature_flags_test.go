@@ -39,6 +39,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				EnableBundleResolver:  true,
 				EnableClusterResolver: true,
 				EnableHttpResolver:    true,
+				EnableS3Resolver:      true,
 			},
 			fileName: "feature-flags-empty",
 		},
@@ -49,6 +50,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				EnableBundleResolver:  false,
 				EnableClusterResolver: false,
 				EnableHttpResolver:    false,
+				EnableS3Resolver:      false,
 			},
 			fileName: "feature-flags-all-flags-set",
 		},
@@ -71,6 +73,7 @@ func TestNewFeatureFlagsFromEmptyConfigMap(t *testing.T) {
 		EnableBundleResolver:  resolver.DefaultEnableBundlesResolver,
 		EnableClusterResolver: resolver.DefaultEnableClusterResolver,
 		EnableHttpResolver:    resolver.DefaultEnableHttpResolver,
+		EnableS3Resolver:      resolver.DefaultEnableS3Resolver,
 	}
 	verifyConfigFileWithExpectedFeatureFlagsConfig(t, FeatureFlagsConfigEmptyName, expectedConfig)
 }
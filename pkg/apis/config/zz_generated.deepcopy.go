@@ -51,6 +51,11 @@ func (in *Defaults) DeepCopyInto(out *Defaults) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.DefaultMetadataEnv != nil {
+		in, out := &in.DefaultMetadataEnv, &out.DefaultMetadataEnv
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -74,6 +79,18 @@ func (in *Events) DeepCopyInto(out *Events) {
 			(*out)[key] = val
 		}
 	}
+	if in.NamespaceSinks != nil {
+		in, out := &in.NamespaceSinks, &out.NamespaceSinks
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AllowedSinks != nil {
+		in, out := &in.AllowedSinks, &out.AllowedSinks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -34,4 +34,10 @@ const (
 
 	// CustomRunControllerName holds the name of the CustomRun controller
 	CustomRunControllerName = "CustomRun"
+
+	// PipelineScheduleControllerName holds the name of the PipelineSchedule controller
+	PipelineScheduleControllerName = "PipelineSchedule"
+
+	// ApprovalControllerName holds the name of the Approval controller
+	ApprovalControllerName = "Approval"
 )
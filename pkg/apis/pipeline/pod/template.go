@@ -144,6 +144,12 @@ type Template struct {
 	// +optional
 	HostUsers *bool `json:"hostUsers,omitempty"`
 
+	// ShareProcessNamespace specifies whether all the containers of the pod share a single process
+	// namespace, so that e.g. a Step can signal the processes of a Sidecar.
+	// Optional: Default to false.
+	// +optional
+	ShareProcessNamespace *bool `json:"shareProcessNamespace,omitempty"`
+
 	// TopologySpreadConstraints controls how Pods are spread across your cluster among
 	// failure-domains such as regions, zones, nodes, and other user-defined topology domains.
 	// +optional
@@ -244,6 +250,9 @@ func MergePodTemplateWithDefault(tpl, defaultTpl *PodTemplate) *PodTemplate {
 		if tpl.HostUsers == nil {
 			tpl.HostUsers = defaultTpl.HostUsers
 		}
+		if tpl.ShareProcessNamespace == nil {
+			tpl.ShareProcessNamespace = defaultTpl.ShareProcessNamespace
+		}
 		if tpl.TopologySpreadConstraints == nil {
 			tpl.TopologySpreadConstraints = defaultTpl.TopologySpreadConstraints
 		}
@@ -90,6 +90,7 @@ func TestMergeByName(t *testing.T) {
 }
 
 func TestMergePodTemplateWithDefault(t *testing.T) {
+	shareProcessNamespace := true
 	type testCase struct {
 		name       string
 		tpl        *PodTemplate
@@ -157,6 +158,19 @@ func TestMergePodTemplateWithDefault(t *testing.T) {
 				HostNetwork: true,
 			},
 		},
+		{
+			name: "use default share process namespace",
+			tpl: &PodTemplate{
+				NodeSelector: map[string]string{"foo": "bar"},
+			},
+			defaultTpl: &PodTemplate{
+				ShareProcessNamespace: &shareProcessNamespace,
+			},
+			expected: &PodTemplate{
+				NodeSelector:          map[string]string{"foo": "bar"},
+				ShareProcessNamespace: &shareProcessNamespace,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
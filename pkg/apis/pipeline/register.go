@@ -43,6 +43,12 @@ const (
 	// PipelineRunLabelKey is used as the label identifier for a PipelineRun
 	PipelineRunUIDLabelKey = GroupName + "/pipelineRunUID"
 
+	// PipelineScheduleLabelKey is used as the label identifier for a PipelineSchedule
+	PipelineScheduleLabelKey = GroupName + "/pipelineSchedule"
+
+	// PipelineSidecarLabelKey is used as the label identifier for a sidecar of a PipelineRun
+	PipelineSidecarLabelKey = GroupName + "/pipelineSidecar"
+
 	// PipelineTaskLabelKey is used as the label identifier for a PipelineTask
 	PipelineTaskLabelKey = GroupName + "/pipelineTask"
 
@@ -92,6 +98,16 @@ var (
 		Group:    GroupName,
 		Resource: "pipelineruns",
 	}
+	// PipelineScheduleResource represents a Tekton PipelineSchedule
+	PipelineScheduleResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "pipelineschedules",
+	}
+	// ApprovalResource represents a Tekton Approval
+	ApprovalResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "approvals",
+	}
 
 	// CustomRunResource represents a Tekton CustomRun
 	CustomRunResource = schema.GroupResource{
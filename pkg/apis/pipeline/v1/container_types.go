@@ -157,6 +157,45 @@ type Step struct {
 	// When is a list of when expressions that need to be true for the task to run
 	// +optional
 	When StepWhenExpressions `json:"when,omitempty"`
+
+	// ServiceAccountTokens requests projected tokens of the TaskRun's ServiceAccount, e.g. with
+	// specific audiences, which are only mounted into this Step.
+	// +optional
+	// +listType=atomic
+	ServiceAccountTokens []ServiceAccountToken `json:"serviceAccountTokens,omitempty"`
+
+	// RuntimeClassName requests the RuntimeClass, e.g. a sandboxed runtime like gVisor or Kata Containers,
+	// the Step runs with. The RuntimeClass is set on the TaskRun's Pod, so all the Steps requesting a
+	// RuntimeClass must request the same one and the other Steps run with it too.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// PreStop is a handler called before the Step's container is terminated, e.g. when the TaskRun
+	// is cancelled or times out, so that the Step can shut down gracefully or signal the processes
+	// of the Sidecars to do so when the Pod shares its process namespace.
+	// More info: https://kubernetes.io/docs/concepts/containers/container-lifecycle-hooks/#container-hooks
+	// +optional
+	PreStop *corev1.LifecycleHandler `json:"preStop,omitempty"`
+
+	// ImagePullSecrets are additional secrets used to pull the image of the Step and to look up its
+	// entrypoint. They are added to the imagePullSecrets of the TaskRun's Pod, so the kubelet may use
+	// them to pull the images of the other containers of the Pod too.
+	// +optional
+	// +listType=atomic
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
+
+// ServiceAccountToken requests a projected token of the TaskRun's ServiceAccount for a Step.
+// The token is mounted at /var/run/secrets/tekton.dev/tokens/<name>.
+type ServiceAccountToken struct {
+	// Name of the token, unique within the Step.
+	Name string `json:"name"`
+	// Audience is the intended audience of the token.
+	Audience string `json:"audience"`
+	// ExpirationSeconds is the requested duration of validity of the token.
+	// It defaults to 1 hour and must be at least 10 minutes.
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
 }
 
 // Ref can be used to refer to a specific instance of a StepAction.
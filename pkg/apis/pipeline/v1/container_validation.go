@@ -29,6 +29,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/internal/resultref"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
@@ -233,6 +235,29 @@ func (s *Step) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if s.StderrConfig != nil {
 		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step stderr stream support", config.AlphaAPIFields).ViaField("stderrconfig"))
 	}
+	// ServiceAccountTokens is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if len(s.ServiceAccountTokens) > 0 {
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step service account tokens", config.AlphaAPIFields).ViaField("serviceAccountTokens"))
+		errs = errs.Also(ValidateServiceAccountTokens(ctx, s.ServiceAccountTokens).ViaField("serviceAccountTokens"))
+	}
+	// RuntimeClassName is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.RuntimeClassName != nil {
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step runtime class name", config.AlphaAPIFields).ViaField("runtimeClassName"))
+		errs = errs.Also(ValidateRuntimeClassName(*s.RuntimeClassName).ViaField("runtimeClassName"))
+	}
+	// PreStop is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.PreStop != nil {
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step preStop", config.AlphaAPIFields).ViaField("preStop"))
+	}
+	// ImagePullSecrets is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if len(s.ImagePullSecrets) > 0 {
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step image pull secrets", config.AlphaAPIFields).ViaField("imagePullSecrets"))
+		errs = errs.Also(ValidateImagePullSecrets(s.ImagePullSecrets).ViaField("imagePullSecrets"))
+	}
 
 	// Validate usage of step result reference.
 	// Referencing previous step's results are only allowed in `env`, `command` and `args`.
@@ -244,6 +269,59 @@ func (s *Step) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return errs
 }
 
+// minServiceAccountTokenExpirationSeconds is the minimum validity of a projected ServiceAccount token accepted by Kubernetes.
+const minServiceAccountTokenExpirationSeconds = 600
+
+// ValidateServiceAccountTokens validates that the ServiceAccountTokens requested by a Step have unique
+// names which can be used as file names, an audience and a supported expiration.
+func ValidateServiceAccountTokens(ctx context.Context, tokens []ServiceAccountToken) (errs *apis.FieldError) {
+	names := sets.NewString()
+	for i, t := range tokens {
+		if t.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(i))
+		} else if e := validation.IsDNS1123Label(t.Name); len(e) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(t.Name, "name", strings.Join(e, ", ")).ViaIndex(i))
+		} else if names.Has(t.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("multiple service account tokens with same name %q", t.Name), "name").ViaIndex(i))
+		}
+		names.Insert(t.Name)
+		if t.Audience == "" {
+			errs = errs.Also(apis.ErrMissingField("audience").ViaIndex(i))
+		}
+		if t.ExpirationSeconds != nil && *t.ExpirationSeconds < minServiceAccountTokenExpirationSeconds {
+			errs = errs.Also(apis.ErrInvalidValue(*t.ExpirationSeconds, "expirationSeconds", fmt.Sprintf("must be at least %d", minServiceAccountTokenExpirationSeconds)).ViaIndex(i))
+		}
+	}
+	return errs
+}
+
+// ValidateRuntimeClassName validates that the RuntimeClass requested by a Step is a valid RuntimeClass name.
+func ValidateRuntimeClassName(name string) *apis.FieldError {
+	if e := validation.IsDNS1123Subdomain(name); len(e) > 0 {
+		return apis.ErrInvalidValue(name, "", strings.Join(e, ", "))
+	}
+	return nil
+}
+
+// ValidateImagePullSecrets validates that the image pull secrets requested by a Step are named.
+func ValidateImagePullSecrets(secrets []corev1.LocalObjectReference) (errs *apis.FieldError) {
+	for i, secret := range secrets {
+		if secret.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(i))
+		}
+	}
+	return errs
+}
+
+// ValidateRuntimeClassNameConflict validates that the RuntimeClass requested by a Step is the same as the one
+// requested by the previous Steps, if any, since all the Steps run in the same Pod.
+func ValidateRuntimeClassNameConflict(name string, previous string) *apis.FieldError {
+	if previous != "" && name != previous {
+		return apis.ErrGeneric(fmt.Sprintf("runtimeClassName %q conflicts with runtimeClassName %q requested by another Step, all Steps must request the same runtimeClassName", name, previous), "runtimeClassName")
+	}
+	return nil
+}
+
 // isParamRefs attempts to check if a specified string looks like it contains any parameter reference
 // This is useful to make sure the specified value looks like a Parameter Reference before performing any strict validation
 func isParamRefs(s string) bool {
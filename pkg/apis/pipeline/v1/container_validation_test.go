@@ -31,6 +31,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 )
 
@@ -289,6 +290,44 @@ func TestStepValidateError(t *testing.T) {
 			Message: "invalid value: -10s",
 			Paths:   []string{"negative timeout"},
 		},
+	}, {
+		name: "invalid service account tokens",
+		Step: v1.Step{
+			Image: "myimage",
+			ServiceAccountTokens: []v1.ServiceAccountToken{{
+				Name:     "vault",
+				Audience: "vault.example.com",
+			}, {
+				Name:              "vault",
+				ExpirationSeconds: ptr.To[int64](60),
+			}},
+		},
+		expectedError: *(&apis.FieldError{
+			Message: `multiple service account tokens with same name "vault"`,
+			Paths:   []string{"serviceAccountTokens[1].name"},
+		}).Also(apis.ErrMissingField("serviceAccountTokens[1].audience")).Also(
+			apis.ErrInvalidValue(60, "serviceAccountTokens[1].expirationSeconds", "must be at least 600")),
+	}, {
+		name: "invalid runtime class name",
+		Step: v1.Step{
+			Image:            "myimage",
+			RuntimeClassName: ptr.To("gVisor"),
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: gVisor`,
+			Paths:   []string{"runtimeClassName"},
+			Details: "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+		},
+	}, {
+		name: "image pull secret without a name",
+		Step: v1.Step{
+			Image:            "myimage",
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-creds"}, {}},
+		},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"imagePullSecrets[1].name"},
+		},
 	}}
 	for _, st := range tests {
 		t.Run(st.name, func(t *testing.T) {
@@ -758,6 +797,39 @@ func TestStepIncompatibleAPIVersions(t *testing.T) {
 					Path: "/tmp/stderr.txt",
 				},
 			},
+		}, {
+			name:            "service account tokens requires alpha",
+			requiredVersion: "alpha",
+			step: v1.Step{
+				Image: "foo",
+				ServiceAccountTokens: []v1.ServiceAccountToken{{
+					Name:     "vault",
+					Audience: "vault.example.com",
+				}},
+			},
+		}, {
+			name:            "runtime class name requires alpha",
+			requiredVersion: "alpha",
+			step: v1.Step{
+				Image:            "foo",
+				RuntimeClassName: ptr.To("gvisor"),
+			},
+		}, {
+			name:            "preStop requires alpha",
+			requiredVersion: "alpha",
+			step: v1.Step{
+				Image: "foo",
+				PreStop: &corev1.LifecycleHandler{
+					Exec: &corev1.ExecAction{Command: []string{"pkill", "dockerd"}},
+				},
+			},
+		}, {
+			name:            "image pull secrets requires alpha",
+			requiredVersion: "alpha",
+			step: v1.Step{
+				Image:            "foo",
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-creds"}},
+			},
 		},
 	} {
 		for _, version := range versions {
@@ -19,7 +19,10 @@ import (
 	"maps"
 	"sort"
 
+	"github.com/google/cel-go/cel"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1/types"
+	"github.com/tektoncd/pipeline/pkg/substitution"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/strings/slices"
 	"knative.dev/pkg/apis"
@@ -36,6 +39,17 @@ type Matrix struct {
 	// Include is a list of IncludeParams which allows passing in specific combinations of Parameters into the Matrix.
 	// +optional
 	Include IncludeParamsList `json:"include,omitempty"`
+
+	// Exclude is a list of ExcludeParams which removes specific combinations of Parameters from the
+	// combinations generated from Matrix.Params.
+	// +optional
+	Exclude ExcludeParamsList `json:"exclude,omitempty"`
+
+	// MaxParallel is the maximum number of combinations of the Matrix which run at the same time.
+	// The remaining combinations are started as the earlier ones finish.
+	// Defaults to running all the combinations at the same time.
+	// +optional
+	MaxParallel int `json:"maxParallel,omitempty"`
 }
 
 // IncludeParamsList is a list of IncludeParams which allows passing in specific combinations of Parameters into the Matrix.
@@ -50,6 +64,27 @@ type IncludeParams struct {
 	// Params takes only `Parameters` of type `"string"`
 	// The names of the `params` must match the names of the `params` in the underlying `Task`
 	Params Params `json:"params,omitempty"`
+
+	// CEL is a Common Expression Language expression which must evaluate to true for the combination
+	// to be included. Variables such as $(params.name) are substituted before it is evaluated.
+	// +optional
+	CEL string `json:"cel,omitempty"`
+}
+
+// ExcludeParamsList is a list of ExcludeParams which removes specific combinations of Parameters from the Matrix.
+// +listType=atomic
+type ExcludeParamsList []ExcludeParams
+
+// ExcludeParams removes the combinations of Parameters matching specific values from the Matrix.
+type ExcludeParams struct {
+	// Params takes only `Parameters` of type `"string"`
+	// The combinations generated from Matrix.Params whose values match all of the `params` are removed.
+	Params Params `json:"params,omitempty"`
+
+	// CEL is a Common Expression Language expression which must evaluate to true for the combinations
+	// to be excluded. Variables such as $(params.name) are substituted before it is evaluated.
+	// +optional
+	CEL string `json:"cel,omitempty"`
 }
 
 // Combination is a map, mainly defined to hold a single combination from a Matrix with key as param.Name and value as param.Value
@@ -70,6 +105,7 @@ func (m *Matrix) FanOut() []Params {
 	for _, parameter := range m.Params {
 		combinations = combinations.fanOutMatrixParams(parameter)
 	}
+	combinations = combinations.removeCombinations(m.getExcludeCombinations())
 	combinations.overwriteCombinations(includeCombinations)
 	combinations = combinations.addNewCombinations(includeCombinations)
 	return combinations.toParams()
@@ -104,6 +140,40 @@ func (cs Combinations) addNewCombinations(ics Combinations) Combinations {
 	return cs
 }
 
+// removeCombinations returns the combinations which don't match any of the exclude combinations
+func (cs Combinations) removeCombinations(ecs Combinations) Combinations {
+	if len(ecs) == 0 {
+		return cs
+	}
+	var combinations Combinations
+	for _, paramCombination := range cs {
+		excluded := false
+		for _, excludeCombination := range ecs {
+			if paramCombination.matches(excludeCombination) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			combinations = append(combinations, paramCombination)
+		}
+	}
+	return combinations
+}
+
+// matches returns true if all the exclude parameter names and values exist in the combination
+func (c Combination) matches(excludeCombination Combination) bool {
+	if len(excludeCombination) == 0 {
+		return false
+	}
+	for name, val := range excludeCombination {
+		if v, exist := c[name]; !exist || v != val {
+			return false
+		}
+	}
+	return true
+}
+
 // contains returns true if the include parameter name and value exists in combinations
 func (c Combination) contains(includeCombination Combination) bool {
 	for name, val := range includeCombination {
@@ -175,6 +245,19 @@ func (m *Matrix) getIncludeCombinations() Combinations {
 	return combinations
 }
 
+// getExcludeCombinations generates combinations based on Matrix Exclude Parameters
+func (m *Matrix) getExcludeCombinations() Combinations {
+	var combinations Combinations
+	for i := range m.Exclude {
+		newCombination := make(Combination)
+		for _, param := range m.Exclude[i].Params {
+			newCombination[param.Name] = param.Value.StringVal
+		}
+		combinations = append(combinations, newCombination)
+	}
+	return combinations
+}
+
 // distribute generates a new Combination of Parameters by adding a new Parameter to an existing list of Combinations.
 func (cs Combinations) distribute(param Param) Combinations {
 	var expandedCombinations Combinations
@@ -217,6 +300,11 @@ func (c Combination) sortCombination() ([]string, Combination) {
 
 // CountCombinations returns the count of Combinations of Parameters generated from the Matrix in PipelineTask.
 func (m *Matrix) CountCombinations() int {
+	// The Combinations removed by Matrix Exclude Parameters depend on their values
+	if m.HasExclude() {
+		return len(m.FanOut())
+	}
+
 	// Iterate over Matrix Parameters and compute count of all generated Combinations
 	count := m.countGeneratedCombinationsFromParams()
 
@@ -267,6 +355,24 @@ func (m *Matrix) countNewCombinationsFromInclude() int {
 	return count
 }
 
+// HasExclude returns true if the Matrix has Exclude Parameters
+func (m *Matrix) HasExclude() bool {
+	return m != nil && len(m.Exclude) > 0
+}
+
+// ReplaceIncludeAndExcludeVariables applies the string replacements to the Params and CEL expressions
+// of Matrix.Include and Matrix.Exclude.
+func (m *Matrix) ReplaceIncludeAndExcludeVariables(replacements map[string]string) {
+	for i := range m.Include {
+		m.Include[i].Params = m.Include[i].Params.ReplaceVariables(replacements, nil, nil)
+		m.Include[i].CEL = substitution.ApplyReplacements(m.Include[i].CEL, replacements)
+	}
+	for i := range m.Exclude {
+		m.Exclude[i].Params = m.Exclude[i].Params.ReplaceVariables(replacements, nil, nil)
+		m.Exclude[i].CEL = substitution.ApplyReplacements(m.Exclude[i].CEL, replacements)
+	}
+}
+
 // HasInclude returns true if the Matrix has Include Parameters
 func (m *Matrix) HasInclude() bool {
 	return m != nil && m.Include != nil && len(m.Include) > 0
@@ -300,6 +406,75 @@ func (m *Matrix) validateCombinationsCount(ctx context.Context) (errs *apis.Fiel
 	return errs
 }
 
+// validateMaxParallel validates that Matrix.MaxParallel is positive when it is set
+func (m *Matrix) validateMaxParallel(ctx context.Context) (errs *apis.FieldError) {
+	if m == nil || m.MaxParallel == 0 {
+		return nil
+	}
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "matrix maxParallel", config.AlphaAPIFields))
+	if m.MaxParallel < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(m.MaxParallel, "matrix.maxParallel", "maxParallel must be a positive number"))
+	}
+	return errs
+}
+
+// validateIncludeAndExclude validates Matrix.Exclude and the CEL expressions of Matrix.Include
+func (m *Matrix) validateIncludeAndExclude(ctx context.Context) (errs *apis.FieldError) {
+	if m == nil {
+		return nil
+	}
+	for i, include := range m.Include {
+		if include.CEL == "" {
+			continue
+		}
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "matrix include cel", config.AlphaAPIFields))
+		// Without Matrix.Params, the Matrix would have no combinations left when its conditional Include are
+		// left out, so conditional Include can only add to or overwrite the generated combinations
+		if !m.HasParams() {
+			errs = errs.Also(apis.ErrGeneric("conditional include requires matrix params", fmt.Sprintf("matrix.include[%d].cel", i)))
+		}
+		errs = errs.Also(validateMatrixCEL(include.CEL).ViaField(fmt.Sprintf("matrix.include[%d].cel", i)))
+	}
+	if !m.HasExclude() {
+		return errs
+	}
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "matrix exclude", config.AlphaAPIFields))
+	matrixParamNames := m.Params.ExtractNames()
+	for i, exclude := range m.Exclude {
+		path := fmt.Sprintf("matrix.exclude[%d]", i)
+		if len(exclude.Params) == 0 {
+			errs = errs.Also(apis.ErrMissingField(path + ".params"))
+		}
+		for j, param := range exclude.Params {
+			if !matrixParamNames.Has(param.Name) {
+				errs = errs.Also(apis.ErrInvalidValue(param.Name, fmt.Sprintf("%s.params[%d].name", path, j), "exclude params must be matrix params"))
+			}
+			if param.Value.Type != ParamTypeString {
+				errs = errs.Also(apis.ErrInvalidValue(param.Value.Type, fmt.Sprintf("%s.params[%d].value", path, j), "exclude params must be of type string"))
+			}
+		}
+		errs = errs.Also(exclude.Params.validateDuplicateParameters().ViaField(path + ".params"))
+		if exclude.CEL != "" {
+			errs = errs.Also(validateMatrixCEL(exclude.CEL).ViaField(path + ".cel"))
+		}
+	}
+	return errs
+}
+
+// validateMatrixCEL validates that the CEL expression of a Matrix Include or Exclude compiles to a boolean.
+// Tekton's variables are not substituted at the validation webhook, so they need to be wrapped with single
+// quotes, e.g. '$(params.foo)' == 'foo'.
+func validateMatrixCEL(expression string) *apis.FieldError {
+	ast, err := types.CompileWhenExpressionCEL(expression)
+	if err != nil {
+		return apis.ErrInvalidValue(expression, "", fmt.Sprintf("invalid cel expression: %s", err.Error()))
+	}
+	if ast.OutputType() != cel.BoolType {
+		return apis.ErrInvalidValue(expression, "", "the cel expression must evaluate to a boolean")
+	}
+	return nil
+}
+
 // validateUniqueParams validates Matrix.Params for a unique list of params
 // and a unique list of params in each Matrix.Include.Params specification
 func (m *Matrix) validateUniqueParams() (errs *apis.FieldError) {
@@ -582,6 +582,48 @@ func TestMatrix_FanOut(t *testing.T) {
 					Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "I-do-not-exist"},
 				},
 			}},
+		}, {
+			name: "matrix params with exclude",
+			matrix: v1.Matrix{
+				Params: v1.Params{{
+					Name:  "platform",
+					Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{"linux", "windows"}},
+				}, {
+					Name:  "arch",
+					Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{"amd64", "arm64"}},
+				}},
+				Exclude: v1.ExcludeParamsList{{
+					Params: v1.Params{{
+						Name: "platform", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "windows"},
+					}, {
+						Name: "arch", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "arm64"},
+					}},
+				}},
+			},
+			want: []v1.Params{{
+				{Name: "arch", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "amd64"}},
+				{Name: "platform", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "linux"}},
+			}, {
+				{Name: "arch", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "amd64"}},
+				{Name: "platform", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "windows"}},
+			}, {
+				{Name: "arch", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "arm64"}},
+				{Name: "platform", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "linux"}},
+			}},
+		}, {
+			name: "matrix params with exclude removing all combinations",
+			matrix: v1.Matrix{
+				Params: v1.Params{{
+					Name:  "platform",
+					Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{"windows"}},
+				}},
+				Exclude: v1.ExcludeParamsList{{
+					Params: v1.Params{{
+						Name: "platform", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "windows"},
+					}},
+				}},
+			},
+			want: []v1.Params{},
 		}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -925,6 +967,27 @@ func TestPipelineTask_CountCombinations(t *testing.T) {
 				Name: "version", Value: v1.ParamValue{StringVal: "$(tasks.platforms.results.str[*])"}},
 			}},
 		want: 3,
+	}, {
+		name: "matrix params with exclude",
+		matrix: &v1.Matrix{
+			Params: v1.Params{{
+				Name: "platform", Value: v1.ParamValue{ArrayVal: []string{"linux", "mac", "windows"}},
+			}, {
+				Name: "arch", Value: v1.ParamValue{ArrayVal: []string{"amd64", "arm64"}},
+			}},
+			Exclude: v1.ExcludeParamsList{{
+				Params: v1.Params{{
+					Name: "platform", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "windows"},
+				}, {
+					Name: "arch", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "arm64"},
+				}},
+			}, {
+				Params: v1.Params{{
+					Name: "platform", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "mac"},
+				}},
+			}},
+		},
+		want: 3,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -538,6 +538,10 @@ func (paramValues *ParamValue) UnmarshalJSON(value []byte) error {
 
 // MarshalJSON implements the json.Marshaller interface.
 func (paramValues ParamValue) MarshalJSON() ([]byte, error) {
+	// The zero value, e.g. the value of a PipelineResult computed from an expression, is an empty string.
+	if paramValues.Type == "" && paramValues.StringVal == "" && len(paramValues.ArrayVal) == 0 && len(paramValues.ObjectVal) == 0 {
+		return json.Marshal("")
+	}
 	switch paramValues.Type {
 	case ParamTypeString:
 		return json.Marshal(paramValues.StringVal)
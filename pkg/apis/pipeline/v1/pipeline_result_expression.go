@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+)
+
+// resultExpressionEnv returns the CEL environment the expressions of PipelineResults are compiled in.
+// The results of the PipelineTasks are available as tasks.<pipelineTaskName>.results.<resultName>
+// (or finally.<pipelineTaskName>.results.<resultName>), along with the join, sum and max functions.
+var resultExpressionEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(ResultTaskPart, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(ResultFinallyPart, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Function("join",
+			cel.Overload("join_list_string", []*cel.Type{cel.ListType(cel.DynType), cel.StringType}, cel.StringType,
+				cel.BinaryBinding(joinResultValues))),
+		cel.Function("sum",
+			cel.Overload("sum_list", []*cel.Type{cel.ListType(cel.DynType)}, cel.DoubleType,
+				cel.UnaryBinding(sumResultValues))),
+		cel.Function("max",
+			cel.Overload("max_list", []*cel.Type{cel.ListType(cel.DynType)}, cel.DoubleType,
+				cel.UnaryBinding(maxResultValues))),
+	)
+})
+
+// CompileResultExpression compiles the CEL expression of a PipelineResult. It returns the program
+// evaluating the expression and the names of the PipelineTasks whose results it references.
+func CompileResultExpression(expression string) (cel.Program, []string, error) {
+	env, err := resultExpressionEnv()
+	if err != nil {
+		return nil, nil, err
+	}
+	checked, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return nil, nil, iss.Err()
+	}
+	prg, err := env.Program(checked)
+	if err != nil {
+		return nil, nil, err
+	}
+	var pipelineTasks []string
+	ast.PreOrderVisit(checked.NativeRep().Expr(), ast.NewExprVisitor(func(e ast.Expr) {
+		if name, ok := referencedPipelineTask(e); ok && !slices.Contains(pipelineTasks, name) {
+			pipelineTasks = append(pipelineTasks, name)
+		}
+	}))
+	return prg, pipelineTasks, nil
+}
+
+// referencedPipelineTask returns the name of the PipelineTask referenced by the expression, if it is
+// either tasks.<pipelineTaskName> or tasks["<pipelineTaskName>"].
+func referencedPipelineTask(e ast.Expr) (string, bool) {
+	isTasks := func(e ast.Expr) bool {
+		return e.Kind() == ast.IdentKind && (e.AsIdent() == ResultTaskPart || e.AsIdent() == ResultFinallyPart)
+	}
+	switch e.Kind() {
+	case ast.SelectKind:
+		if sel := e.AsSelect(); isTasks(sel.Operand()) {
+			return sel.FieldName(), true
+		}
+	case ast.CallKind:
+		call := e.AsCall()
+		if call.FunctionName() != operators.Index || len(call.Args()) != 2 || !isTasks(call.Args()[0]) {
+			return "", false
+		}
+		if key := call.Args()[1]; key.Kind() == ast.LiteralKind {
+			if name, ok := key.AsLiteral().Value().(string); ok {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// FormatResultExpressionValue formats a scalar value returned by the expression of a PipelineResult
+// as the string value of a result.
+func FormatResultExpressionValue(val ref.Val) (string, error) {
+	switch v := val.Value().(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %s", val.Type().TypeName())
+	}
+}
+
+func joinResultValues(list, sep ref.Val) ref.Val {
+	var values []string
+	err := iterateResultValues(list, func(val ref.Val) error {
+		s, err := FormatResultExpressionValue(val)
+		values = append(values, s)
+		return err
+	})
+	if err != nil {
+		return types.NewErr("join: %v", err)
+	}
+	return types.String(strings.Join(values, string(sep.(types.String))))
+}
+
+func sumResultValues(list ref.Val) ref.Val {
+	var sum float64
+	err := iterateResultValues(list, func(val ref.Val) error {
+		n, err := resultValueToNumber(val)
+		sum += n
+		return err
+	})
+	if err != nil {
+		return types.NewErr("sum: %v", err)
+	}
+	return types.Double(sum)
+}
+
+func maxResultValues(list ref.Val) ref.Val {
+	maxValue := math.Inf(-1)
+	err := iterateResultValues(list, func(val ref.Val) error {
+		n, err := resultValueToNumber(val)
+		maxValue = math.Max(maxValue, n)
+		return err
+	})
+	switch {
+	case err != nil:
+		return types.NewErr("max: %v", err)
+	case math.IsInf(maxValue, -1):
+		return types.NewErr("max: the list is empty")
+	}
+	return types.Double(maxValue)
+}
+
+func iterateResultValues(list ref.Val, f func(ref.Val) error) error {
+	lister, ok := list.(traits.Lister)
+	if !ok {
+		return fmt.Errorf("expected a list but got a value of type %s", list.Type().TypeName())
+	}
+	for it := lister.Iterator(); it.HasNext() == types.True; {
+		if err := f(it.Next()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resultValueToNumber converts a number, or a string holding a number since the values of results are
+// strings, to a float64.
+func resultValueToNumber(val ref.Val) (float64, error) {
+	switch v := val.Value().(type) {
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", v)
+		}
+		return n, nil
+	default:
+		return 0, errors.New("expected numbers or strings holding numbers")
+	}
+}
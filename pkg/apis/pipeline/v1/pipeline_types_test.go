@@ -1027,16 +1027,24 @@ func TestPipelineTaskList_Deps(t *testing.T) {
 		}, {
 			Name:     "task-9",
 			RunAfter: []string{"task-1", "task-1", "task-1", "task-1"},
+		}, {
+			Name: "task-10",
+			When: WhenExpressions{{
+				File:     &WhenFile{Task: "task-9", Workspace: "source", Path: "summary.txt"},
+				Operator: "notin",
+				Values:   []string{""},
+			}},
 		}},
 		expectedDeps: map[string][]string{
-			"task-2": {"task-1"},
-			"task-3": {"task-1"},
-			"task-4": {"task-1", "task-2", "task-3"},
-			"task-5": {"task-1", "task-2", "task-3", "task-4"},
-			"task-6": {"task-1", "task-2", "task-3", "task-4", "task-5"},
-			"task-7": {"task-3"},
-			"task-8": {"task-4"},
-			"task-9": {"task-1"},
+			"task-2":  {"task-1"},
+			"task-3":  {"task-1"},
+			"task-4":  {"task-1", "task-2", "task-3"},
+			"task-5":  {"task-1", "task-2", "task-3", "task-4"},
+			"task-6":  {"task-1", "task-2", "task-3", "task-4", "task-5"},
+			"task-7":  {"task-3"},
+			"task-8":  {"task-4"},
+			"task-9":  {"task-1"},
+			"task-10": {"task-9"},
 		},
 	}}
 	for _, tc := range pipelines {
@@ -1200,6 +1208,152 @@ func TestPipelineTask_ValidateMatrix(t *testing.T) {
 	}
 }
 
+func TestPipelineTask_ValidateMatrixMaxParallel(t *testing.T) {
+	matrixParams := Params{{
+		Name: "platform", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac", "windows"}},
+	}}
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		alpha    bool
+		wantErrs *apis.FieldError
+	}{{
+		name: "maxParallel limits the combinations running at the same time",
+		pt: &PipelineTask{
+			Name:   "task",
+			Matrix: &Matrix{Params: matrixParams, MaxParallel: 2},
+		},
+		alpha: true,
+	}, {
+		name: "maxParallel requires alpha",
+		pt: &PipelineTask{
+			Name:   "task",
+			Matrix: &Matrix{Params: matrixParams, MaxParallel: 2},
+		},
+		wantErrs: apis.ErrGeneric(`matrix maxParallel requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`),
+	}, {
+		name: "negative maxParallel",
+		pt: &PipelineTask{
+			Name:   "task",
+			Matrix: &Matrix{Params: matrixParams, MaxParallel: -1},
+		},
+		alpha:    true,
+		wantErrs: apis.ErrInvalidValue(-1, "matrix.maxParallel", "maxParallel must be a positive number"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enableAPIFields := "beta"
+			if tt.alpha {
+				enableAPIFields = "alpha"
+			}
+			featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+				"enable-api-fields": enableAPIFields,
+			})
+			cfg := &config.Config{
+				FeatureFlags: featureFlags,
+				Defaults:     &config.Defaults{DefaultMaxMatrixCombinationsCount: 4},
+			}
+			ctx := config.ToContext(t.Context(), cfg)
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.validateMatrix(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.validateMatrix() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_ValidateMatrixIncludeAndExclude(t *testing.T) {
+	matrixParams := Params{{
+		Name: "platform", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"linux", "windows"}},
+	}, {
+		Name: "arch", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"amd64", "arm64"}},
+	}}
+	windowsArm64 := Params{{
+		Name: "platform", Value: ParamValue{Type: ParamTypeString, StringVal: "windows"},
+	}, {
+		Name: "arch", Value: ParamValue{Type: ParamTypeString, StringVal: "arm64"},
+	}}
+	tests := []struct {
+		name     string
+		matrix   *Matrix
+		alpha    bool
+		wantErrs *apis.FieldError
+	}{{
+		name: "exclude and conditional include",
+		matrix: &Matrix{
+			Params:  matrixParams,
+			Exclude: ExcludeParamsList{{Params: windowsArm64, CEL: "'$(params.full)' != 'true'"}},
+			Include: IncludeParamsList{{
+				Name: "debug",
+				Params: Params{{
+					Name: "flags", Value: ParamValue{Type: ParamTypeString, StringVal: "-v"},
+				}},
+				CEL: "'$(params.debug)' == 'true'",
+			}},
+		},
+		alpha: true,
+	}, {
+		name:     "exclude requires alpha",
+		matrix:   &Matrix{Params: matrixParams, Exclude: ExcludeParamsList{{Params: windowsArm64}}},
+		wantErrs: apis.ErrGeneric(`matrix exclude requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`),
+	}, {
+		name: "conditional include requires alpha",
+		matrix: &Matrix{Params: matrixParams, Include: IncludeParamsList{{
+			Name: "debug",
+			Params: Params{{
+				Name: "flags", Value: ParamValue{Type: ParamTypeString, StringVal: "-v"},
+			}},
+			CEL: "true",
+		}}},
+		wantErrs: apis.ErrGeneric(`matrix include cel requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`),
+	}, {
+		name: "conditional include without matrix params",
+		matrix: &Matrix{Include: IncludeParamsList{{
+			Name: "debug",
+			Params: Params{{
+				Name: "flags", Value: ParamValue{Type: ParamTypeString, StringVal: "-v"},
+			}},
+			CEL: "true",
+		}}},
+		alpha:    true,
+		wantErrs: apis.ErrGeneric("conditional include requires matrix params", "matrix.include[0].cel"),
+	}, {
+		name: "invalid exclude",
+		matrix: &Matrix{Params: matrixParams, Exclude: ExcludeParamsList{{}, {
+			Params: Params{{
+				Name: "os", Value: ParamValue{Type: ParamTypeString, StringVal: "windows"},
+			}, {
+				Name: "arch", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"arm64"}},
+			}},
+			CEL: "'$(params.full)'",
+		}}},
+		alpha: true,
+		wantErrs: apis.ErrMissingField("matrix.exclude[0].params").
+			Also(apis.ErrInvalidValue("os", "matrix.exclude[1].params[0].name", "exclude params must be matrix params")).
+			Also(apis.ErrInvalidValue(ParamTypeArray, "matrix.exclude[1].params[1].value", "exclude params must be of type string")).
+			Also(apis.ErrInvalidValue("'$(params.full)'", "matrix.exclude[1].cel", "the cel expression must evaluate to a boolean")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enableAPIFields := "beta"
+			if tt.alpha {
+				enableAPIFields = "alpha"
+			}
+			featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+				"enable-api-fields": enableAPIFields,
+			})
+			cfg := &config.Config{
+				FeatureFlags: featureFlags,
+				Defaults:     &config.Defaults{DefaultMaxMatrixCombinationsCount: 4},
+			}
+			ctx := config.ToContext(t.Context(), cfg)
+			pt := &PipelineTask{Name: "task", Matrix: tt.matrix}
+			if d := cmp.Diff(tt.wantErrs.Error(), pt.validateMatrix(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.validateMatrix() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestPipelineTask_ValidateEmbeddedOrType(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -87,12 +87,20 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(validatePipelineWorkspacesDeclarations(ps.Workspaces))
 	// Validate the pipeline's results
 	errs = errs.Also(validatePipelineResults(ps.Results, ps.Tasks, ps.Finally))
+	errs = errs.Also(validatePipelineResultExpressions(ctx, ps.Results, ps.Tasks, ps.Finally))
 	errs = errs.Also(validateTasksAndFinallySection(ps))
 	errs = errs.Also(validateFinalTasks(ps.Tasks, ps.Finally))
 	errs = errs.Also(validateWhenExpressions(ctx, ps.Tasks, ps.Finally))
+	errs = errs.Also(validateWhenFiles(ps.Tasks, ps.Finally))
 	errs = errs.Also(validateArtifactReference(ctx, ps.Tasks, ps.Finally))
 	errs = errs.Also(validateMatrix(ctx, ps.Tasks).ViaField("tasks"))
 	errs = errs.Also(validateMatrix(ctx, ps.Finally).ViaField("finally"))
+	if len(ps.Sidecars) > 0 {
+		errs = errs.Also(ValidatePipelineSidecars(ctx, ps.Sidecars))
+	}
+	if ps.PriorityClassName != "" {
+		errs = errs.Also(ValidatePriorityClassName(ctx, ps.PriorityClassName).ViaField("priorityClassName"))
+	}
 	return errs
 }
 
@@ -192,6 +200,14 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	errs = errs.Also(pt.ValidateOnError(ctx))
 
+	if pt.RetryStrategy != nil {
+		errs = errs.Also(ValidateRetryStrategy(ctx, pt.RetryStrategy).ViaField("retryStrategy"))
+	}
+
+	if pt.PriorityClassName != "" {
+		errs = errs.Also(ValidatePriorityClassName(ctx, pt.PriorityClassName).ViaField("priorityClassName"))
+	}
+
 	// Pipeline task having taskRef/taskSpec with APIVersion is classified as custom task
 	switch {
 	case pt.TaskRef != nil && !taskKinds[pt.TaskRef.Kind]:
@@ -229,6 +245,8 @@ func (pt *PipelineTask) validateMatrix(ctx context.Context) (errs *apis.FieldErr
 		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "matrix", config.BetaAPIFields))
 		errs = errs.Also(pt.Matrix.validateCombinationsCount(ctx))
 		errs = errs.Also(pt.Matrix.validateUniqueParams())
+		errs = errs.Also(pt.Matrix.validateMaxParallel(ctx))
+		errs = errs.Also(pt.Matrix.validateIncludeAndExclude(ctx))
 	}
 	errs = errs.Also(pt.Matrix.validateParameterInOneOfMatrixOrParams(pt.Params))
 	return errs
@@ -351,6 +369,12 @@ func isValidAPIVersion(apiVersion string) bool {
 
 // validateCustomTask validates custom task specifications - checking kind and fail if not yet supported features specified
 func (pt PipelineTask) validateCustomTask() (errs *apis.FieldError) {
+	if pt.RetryStrategy != nil {
+		errs = errs.Also(apis.ErrGeneric("retryStrategy is not supported for custom tasks", "retryStrategy"))
+	}
+	if pt.PriorityClassName != "" {
+		errs = errs.Also(apis.ErrGeneric("priorityClassName is not supported for custom tasks", "priorityClassName"))
+	}
 	if pt.TaskRef != nil && pt.TaskRef.Kind == "" {
 		errs = errs.Also(apis.ErrInvalidValue("custom task ref must specify kind", "taskRef.kind"))
 	}
@@ -412,6 +436,26 @@ func validatePipelineWorkspacesDeclarations(wss []PipelineWorkspaceDeclaration)
 	return errs
 }
 
+// ValidatePipelineSidecars validates that the sidecars of a Pipeline have unique DNS label names and an image.
+func ValidatePipelineSidecars(ctx context.Context, sidecars []PipelineSidecar) (errs *apis.FieldError) {
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "sidecars", config.AlphaAPIFields))
+	names := sets.NewString()
+	for i, s := range sidecars {
+		if s.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("sidecars", i))
+		} else if e := validation.IsDNS1123Label(s.Name); len(e) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(s.Name, "name", strings.Join(e, ", ")).ViaFieldIndex("sidecars", i))
+		} else if names.Has(s.Name) {
+			errs = errs.Also(apis.ErrMultipleOneOf("name").ViaFieldIndex("sidecars", i))
+		}
+		names.Insert(s.Name)
+		if s.Image == "" {
+			errs = errs.Also(apis.ErrMissingField("image").ViaFieldIndex("sidecars", i))
+		}
+	}
+	return errs
+}
+
 // validatePipelineParameterUsage validates that parameters referenced in the Pipeline are declared by the Pipeline
 func (ps *PipelineSpec) validatePipelineParameterUsage(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(PipelineTaskList(ps.Tasks).validateUsageOfDeclaredPipelineTaskParameters(ctx, ps.Params, "tasks"))
@@ -496,6 +540,7 @@ func validatePipelineContextVariables(tasks []PipelineTask) *apis.FieldError {
 	)
 	pipelineTaskContextNames := sets.NewString().Insert(
 		"retries",
+		"index",
 	)
 	var paramValues []string
 	for _, task := range tasks {
@@ -540,12 +585,20 @@ func (pt *PipelineTask) GetVarSubstitutionExpressions() []string {
 	return allExpressions
 }
 
-// containsExecutionStatusRef checks if a specified param has a reference to execution status or reason
-// $(tasks.<task-name>.status), $(tasks.status), or $(tasks.<task-name>.reason)
+// executionStatusRefSuffixes are the suffixes of the references to the execution status of a pipeline task,
+// longest first: $(tasks.<task-name>.status.reason), $(tasks.<task-name>.status.message),
+// $(tasks.<task-name>.status) and $(tasks.<task-name>.reason)
+var executionStatusRefSuffixes = []string{".status.reason", ".status.message", ".status", ".reason"}
+
+// containsExecutionStatusRef checks if a specified param has a reference to execution status, reason or message
+// $(tasks.<task-name>.status), $(tasks.status), $(tasks.<task-name>.reason), $(tasks.<task-name>.status.reason)
+// or $(tasks.<task-name>.status.message)
 func containsExecutionStatusRef(p string) bool {
 	if strings.HasPrefix(p, "tasks.") {
-		if strings.HasSuffix(p, ".status") || strings.HasSuffix(p, ".reason") {
-			return true
+		for _, suffix := range executionStatusRefSuffixes {
+			if strings.HasSuffix(p, suffix) {
+				return true
+			}
 		}
 	}
 	return false
@@ -638,15 +691,15 @@ func validateExecutionStatusVariablesExpressions(expressions []string, ptNames s
 				continue
 			}
 			// check if it contains context variable accessing execution status - $(tasks.taskname.status) | $(tasks.taskname.reason)
+			// | $(tasks.taskname.status.reason) | $(tasks.taskname.status.message)
 			if containsExecutionStatusRef(expression) {
 				var pt string
-				if strings.HasSuffix(expression, ".status") {
-					// strip tasks. and .status from tasks.taskname.status to further verify task name
-					pt = strings.TrimSuffix(strings.TrimPrefix(expression, "tasks."), ".status")
-				}
-				if strings.HasSuffix(expression, ".reason") {
-					// strip tasks. and .reason from tasks.taskname.reason to further verify task name
-					pt = strings.TrimSuffix(strings.TrimPrefix(expression, "tasks."), ".reason")
+				for _, suffix := range executionStatusRefSuffixes {
+					if strings.HasSuffix(expression, suffix) {
+						// strip tasks. and the suffix, e.g. .status from tasks.taskname.status, to further verify task name
+						pt = strings.TrimSuffix(strings.TrimPrefix(expression, "tasks."), suffix)
+						break
+					}
 				}
 				// report an error if the task name does not exist in the list of dag tasks
 				if !ptNames.Has(pt) {
@@ -681,6 +734,9 @@ func validatePipelineResults(results []PipelineResult, tasks []PipelineTask, fin
 	pipelineTaskNames := getPipelineTasksNames(tasks)
 	pipelineFinallyTaskNames := getPipelineTasksNames(finally)
 	for idx, result := range results {
+		if result.Expression != "" {
+			continue
+		}
 		expressions, ok := result.GetVarSubstitutionExpressions()
 		if !ok {
 			errs = errs.Also(apis.ErrInvalidValue("expected pipeline results to be task result expressions but no expressions were found",
@@ -708,6 +764,33 @@ func validatePipelineResults(results []PipelineResult, tasks []PipelineTask, fin
 	return errs
 }
 
+// validatePipelineResultExpressions ensures that the pipeline results computed from a CEL expression
+// don't have a value, and that their expression compiles and references existing pipeline tasks.
+func validatePipelineResultExpressions(ctx context.Context, results []PipelineResult, tasks []PipelineTask, finally []PipelineTask) (errs *apis.FieldError) {
+	pipelineTaskNames := getPipelineTasksNames(tasks).Union(getPipelineTasksNames(finally))
+	for idx, result := range results {
+		if result.Expression == "" {
+			continue
+		}
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "pipeline result expressions", config.AlphaAPIFields).ViaFieldIndex("results", idx))
+		if result.Value.StringVal != "" || len(result.Value.ArrayVal) > 0 || len(result.Value.ObjectVal) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("value", "expression").ViaFieldIndex("results", idx))
+		}
+		_, referencedTasks, err := CompileResultExpression(result.Expression)
+		if err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(result.Expression, "expression", err.Error()).ViaFieldIndex("results", idx))
+			continue
+		}
+		for _, name := range referencedTasks {
+			if !pipelineTaskNames.Has(name) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("referencing a nonexistent task %q", name),
+					"expression").ViaFieldIndex("results", idx))
+			}
+		}
+	}
+	return errs
+}
+
 // put task names in a set
 func getPipelineTasksNames(pipelineTasks []PipelineTask) sets.String {
 	pipelineTaskNames := make(sets.String)
@@ -810,6 +893,40 @@ func validateWhenExpressions(ctx context.Context, tasks []PipelineTask, finalTas
 	return errs
 }
 
+// validateWhenFiles ensures that the files tested by the when expressions are written by a PipelineTask
+// of the tasks section, which isn't matrixed.
+func validateWhenFiles(tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
+	pipelineTasks := map[string]PipelineTask{}
+	for _, t := range tasks {
+		pipelineTasks[t.Name] = t
+	}
+	validate := func(t PipelineTask) (errs *apis.FieldError) {
+		for i, we := range t.When {
+			if we.File == nil {
+				continue
+			}
+			fieldPath := fmt.Sprintf("when[%d].file.task", i)
+			pt, ok := pipelineTasks[we.File.Task]
+			switch {
+			case !ok:
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q is not a pipeline task of the tasks section", we.File.Task), fieldPath))
+			case pt.Name == t.Name:
+				errs = errs.Also(apis.ErrInvalidValue("a pipeline task cannot test a file it writes", fieldPath))
+			case pt.IsMatrixed():
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("the files written by the matrixed pipeline task %q cannot be tested", we.File.Task), fieldPath))
+			}
+		}
+		return errs
+	}
+	for i, t := range tasks {
+		errs = errs.Also(validate(t).ViaFieldIndex("tasks", i))
+	}
+	for i, t := range finalTasks {
+		errs = errs.Also(validate(t).ViaFieldIndex("finally", i))
+	}
+	return errs
+}
+
 // validateGraph ensures the Pipeline's dependency Graph (DAG) make sense: that there is no dependency
 // cycle or that they rely on values from Tasks that ran previously.
 func validateGraph(tasks []PipelineTask) (errs *apis.FieldError) {
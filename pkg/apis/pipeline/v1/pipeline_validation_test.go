@@ -335,6 +335,23 @@ func TestPipeline_Validate_Success(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "valid priorityClassNames",
+		p: &Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
+			Spec: PipelineSpec{
+				Tasks: []PipelineTask{{
+					Name:              "release",
+					TaskRef:           &TaskRef{Name: "release-task"},
+					PriorityClassName: "urgent-release",
+				}, {
+					Name:    "test",
+					TaskRef: &TaskRef{Name: "test-task"},
+				}},
+				PriorityClassName: "low-priority",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1404,6 +1421,46 @@ func TestPipelineSpec_Validate_Failure(t *testing.T) {
 			Message: `feature flag enable-artifacts should be set to true to use artifacts feature.`,
 			Paths:   []string{"finally[0].params"},
 		},
+	}, {
+		name: "pipeline task priorityClassName without alpha api fields",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:              "foo",
+				TaskRef:           &TaskRef{Name: "foo-task"},
+				PriorityClassName: "urgent",
+			}},
+		},
+		wc: cfgtesting.EnableBetaAPIFields,
+		expectedError: apis.FieldError{
+			Message: `priorityClassName requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+		},
+	}, {
+		name: "pipeline priorityClassName without alpha api fields",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "foo",
+				TaskRef: &TaskRef{Name: "foo-task"},
+			}},
+			PriorityClassName: "low-priority",
+		},
+		wc: cfgtesting.EnableBetaAPIFields,
+		expectedError: apis.FieldError{
+			Message: `priorityClassName requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+		},
+	}, {
+		name: "custom task with priorityClassName",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:              "foo",
+				TaskRef:           &TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+				PriorityClassName: "urgent",
+			}},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+		expectedError: apis.FieldError{
+			Message: `priorityClassName is not supported for custom tasks`,
+			Paths:   []string{"tasks[0].priorityClassName"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1618,6 +1675,58 @@ func TestValidatePipelineResults_Failure(t *testing.T) {
 	}
 }
 
+func TestValidatePipelineResultExpressions(t *testing.T) {
+	tests := []struct {
+		desc          string
+		results       []PipelineResult
+		expectedError *apis.FieldError
+	}{{
+		desc: "valid pipeline result expressions",
+		results: []PipelineResult{{
+			Name:       "total",
+			Expression: "sum([tasks.build.results.count, tasks['unit-tests'].results.count])",
+		}, {
+			Name:       "reports",
+			Expression: `join(finally.report.results.reports, ",")`,
+		}, {
+			Name:  "not-an-expression",
+			Value: *NewStructuredValues("$(tasks.build.results.count)"),
+		}},
+	}, {
+		desc: "expression with a value",
+		results: []PipelineResult{{
+			Name:       "total",
+			Value:      *NewStructuredValues("$(tasks.build.results.count)"),
+			Expression: "max(tasks.build.results.count)",
+		}},
+		expectedError: apis.ErrMultipleOneOf("results[0].value", "results[0].expression"),
+	}, {
+		desc: "invalid expression",
+		results: []PipelineResult{{
+			Name:       "total",
+			Expression: "average(tasks.build.results.count)",
+		}},
+		expectedError: apis.ErrInvalidValue("average(tasks.build.results.count)", "results[0].expression",
+			"ERROR: <input>:1:8: undeclared reference to 'average' (in container '')\n | average(tasks.build.results.count)\n | .......^"),
+	}, {
+		desc: "expression referencing a nonexistent task",
+		results: []PipelineResult{{
+			Name:       "total",
+			Expression: "max(tasks.deploy.results.count)",
+		}},
+		expectedError: apis.ErrInvalidValue(`referencing a nonexistent task "deploy"`, "results[0].expression"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
+			err := validatePipelineResultExpressions(ctx, tt.results, []PipelineTask{{Name: "build"}, {Name: "unit-tests"}}, []PipelineTask{{Name: "report"}})
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("validatePipelineResultExpressions() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestFinallyTaskResultsToPipelineResults_Success(t *testing.T) {
 	tests := []struct {
 		name string
@@ -2834,6 +2943,40 @@ func TestValidatePipelineWorkspacesDeclarations_Failure(t *testing.T) {
 	}
 }
 
+func TestValidatePipelineSidecars(t *testing.T) {
+	tests := []struct {
+		name          string
+		sidecars      []PipelineSidecar
+		withContext   func(context.Context) context.Context
+		expectedError *apis.FieldError
+	}{{
+		name:        "valid sidecars",
+		sidecars:    []PipelineSidecar{{Name: "test-db", Image: "postgres"}, {Name: "cache", Image: "redis"}},
+		withContext: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name:          "sidecars without alpha api fields",
+		sidecars:      []PipelineSidecar{{Name: "test-db", Image: "postgres"}},
+		withContext:   cfgtesting.EnableBetaAPIFields,
+		expectedError: apis.ErrGeneric(`sidecars requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`),
+	}, {
+		name:        "invalid names and missing image",
+		sidecars:    []PipelineSidecar{{Image: "postgres"}, {Name: "Test_DB", Image: "postgres"}, {Name: "cache"}, {Name: "cache", Image: "redis"}},
+		withContext: cfgtesting.EnableAlphaAPIFields,
+		expectedError: apis.ErrMissingField("sidecars[0].name").Also(
+			apis.ErrInvalidValue("Test_DB", "sidecars[1].name", "a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')")).Also(
+			apis.ErrMissingField("sidecars[2].image")).Also(
+			apis.ErrMultipleOneOf("sidecars[3].name")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidatePipelineSidecars(tt.withContext(t.Context()), tt.sidecars)
+			if d := cmp.Diff(tt.expectedError.Error(), errs.Error()); d != "" {
+				t.Errorf("ValidatePipelineSidecars() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidatePipelineWorkspacesUsage_Failure(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -3515,6 +3658,66 @@ func TestValidateTasksAndFinallySection_Failure(t *testing.T) {
 	}
 }
 
+func TestValidateWhenFiles(t *testing.T) {
+	file := func(task string) WhenExpressions {
+		return WhenExpressions{{
+			File:     &WhenFile{Task: task, Workspace: "source", Path: "summary.txt"},
+			Operator: selection.NotIn,
+			Values:   []string{""},
+		}}
+	}
+	tasks := []PipelineTask{{
+		Name: "diff", TaskRef: &TaskRef{Name: "diff"},
+	}, {
+		Name: "build", TaskRef: &TaskRef{Name: "build"},
+		Matrix: &Matrix{Params: Params{{Name: "platform", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}}}}},
+	}}
+	for _, tc := range []struct {
+		name          string
+		tasks         []PipelineTask
+		finalTasks    []PipelineTask
+		expectedError string
+	}{{
+		name:       "files written by a pipeline task",
+		tasks:      append(tasks, PipelineTask{Name: "deploy", TaskRef: &TaskRef{Name: "deploy"}, When: file("diff")}),
+		finalTasks: []PipelineTask{{Name: "notify", TaskRef: &TaskRef{Name: "notify"}, When: file("diff")}},
+	}, {
+		name:          "file written by an undefined pipeline task",
+		tasks:         append(tasks, PipelineTask{Name: "deploy", TaskRef: &TaskRef{Name: "deploy"}, When: file("lint")}),
+		expectedError: `invalid value: "lint" is not a pipeline task of the tasks section: tasks[2].when[0].file.task`,
+	}, {
+		name:          "file written by the pipeline task testing it",
+		tasks:         append(tasks, PipelineTask{Name: "deploy", TaskRef: &TaskRef{Name: "deploy"}, When: file("deploy")}),
+		expectedError: `invalid value: a pipeline task cannot test a file it writes: tasks[2].when[0].file.task`,
+	}, {
+		name:          "file written by a matrixed pipeline task",
+		tasks:         tasks,
+		finalTasks:    []PipelineTask{{Name: "notify", TaskRef: &TaskRef{Name: "notify"}, When: file("build")}},
+		expectedError: `invalid value: the files written by the matrixed pipeline task "build" cannot be tested: finally[0].when[0].file.task`,
+	}, {
+		name:          "file written by a final task",
+		tasks:         tasks,
+		finalTasks:    []PipelineTask{{Name: "report", TaskRef: &TaskRef{Name: "report"}}, {Name: "notify", TaskRef: &TaskRef{Name: "notify"}, When: file("report")}},
+		expectedError: `invalid value: "report" is not a pipeline task of the tasks section: finally[1].when[0].file.task`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateWhenFiles(tc.tasks, tc.finalTasks)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("validateWhenFiles() returned an unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("validateWhenFiles() did not return the expected error %q", tc.expectedError)
+			}
+			if d := cmp.Diff(tc.expectedError, err.Error()); d != "" {
+				t.Errorf("validateWhenFiles() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidateFinalTasks_Failure(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -3681,6 +3884,15 @@ func TestContextValid(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		name: "valid string context variable for PipelineTask index",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "a-param", Value: ParamValue{StringVal: "$(context.pipelineTask.index)"},
+			}},
+		}},
 	}, {
 		name: "valid array context variable for PipelineTask retries",
 		tasks: []PipelineTask{{
@@ -3930,6 +4142,25 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 				Values:   []string{"Success"},
 			}},
 		}},
+	}, {
+		name: "valid string variable in finally accessing pipelineTask status reason and message",
+		tasks: []PipelineTask{{
+			Name: "foo",
+		}},
+		finalTasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "foo-reason", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.foo.status.reason)"},
+			}, {
+				Name: "foo-message", Value: ParamValue{Type: ParamTypeString, StringVal: "foo failed: $(tasks.foo.status.message)"},
+			}},
+			When: WhenExpressions{{
+				Input:    "$(tasks.foo.status.reason)",
+				Operator: selection.NotIn,
+				Values:   []string{"Succeeded"},
+			}},
+		}},
 	}, {
 		name: "valid task result reference with status as a variable must not cause validation failure",
 		tasks: []PipelineTask{{
@@ -3986,6 +4217,19 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 			Message: `invalid value: pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks`,
 			Paths:   []string{"tasks[0].params[bar-status].value", "tasks[0].when[0]"},
 		}),
+	}, {
+		name: "invalid string variable in dag task accessing pipelineTask status message",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params: Params{{
+				Name: "bar-message", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.bar.status.message)"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks`,
+			Paths:   []string{"tasks[0].params[bar-message].value"},
+		},
 	}, {
 		name: "invalid string variable in dag task accessing aggregate status of tasks",
 		tasks: []PipelineTask{{
@@ -4070,6 +4314,19 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 			Message: `invalid value: pipeline task notask is not defined in the pipeline`,
 			Paths:   []string{"finally[0].params[notask-status].value"},
 		},
+	}, {
+		name: "invalid string variable in finally accessing missing pipelineTask status reason",
+		finalTasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "notask-reason", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.notask.status.reason)"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline task notask is not defined in the pipeline`,
+			Paths:   []string{"finally[0].params[notask-reason].value"},
+		},
 	}, {
 		name: "invalid string variable in finally accessing missing pipelineTask status in when expression",
 		finalTasks: []PipelineTask{{
@@ -299,6 +299,39 @@ type PipelineRunSpec struct {
 	// This field is immutable.
 	// +optional
 	ManagedBy *string `json:"managedBy,omitempty"`
+
+	// Concurrency limits how many PipelineRuns sharing a concurrency key run at the same time.
+	// PipelineRuns over the limit are queued until earlier ones complete.
+	// +optional
+	Concurrency *PipelineRunConcurrency `json:"concurrency,omitempty"`
+
+	// RetryFrom re-executes part of a previous PipelineRun of the same Pipeline: the tasks
+	// which succeeded in that PipelineRun are not run again, their TaskRuns and results are
+	// reused instead.
+	// +optional
+	RetryFrom *PipelineRunRetryFrom `json:"retryFrom,omitempty"`
+}
+
+// PipelineRunRetryFrom identifies the previous PipelineRun to retry and the task to retry it from.
+type PipelineRunRetryFrom struct {
+	// PipelineRun is the name of the previous PipelineRun, in the same namespace.
+	PipelineRun string `json:"pipelineRun"`
+	// Task is the name of the pipeline task to retry from. The task and the tasks which depend
+	// on it are run again, even if they succeeded in the previous PipelineRun. Defaults to the
+	// tasks which did not succeed in the previous PipelineRun.
+	// +optional
+	Task string `json:"task,omitempty"`
+}
+
+// PipelineRunConcurrency identifies the PipelineRuns which share a concurrency limit, e.g.
+// the deployments of one branch, and how many of them can run at the same time.
+type PipelineRunConcurrency struct {
+	// Key is shared by the PipelineRuns in the same namespace which are subject to the limit.
+	Key string `json:"key"`
+	// MaxConcurrent is the number of PipelineRuns with the key which can run at the same time.
+	// Defaults to 1, i.e. the PipelineRuns run one after another in the order they were created.
+	// +optional
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
 }
 
 // TimeoutFields allows granular specification of pipeline, task, and finally timeouts
@@ -353,6 +386,9 @@ const (
 	PipelineRunReasonSuccessful PipelineRunReason = "Succeeded"
 	// PipelineRunReasonCompleted is the reason set when the PipelineRun completed successfully with one or more skipped Tasks
 	PipelineRunReasonCompleted PipelineRunReason = "Completed"
+	// PipelineRunReasonCompletedWithErrors is the reason set when the PipelineRun completed with one or more
+	// failed Tasks whose failure was ignored because of their onError: continue
+	PipelineRunReasonCompletedWithErrors PipelineRunReason = "CompletedWithErrors"
 	// PipelineRunReasonFailed is the reason set when the PipelineRun completed with a failure
 	PipelineRunReasonFailed PipelineRunReason = "Failed"
 	// PipelineRunReasonCancelled is the reason set when the PipelineRun cancelled by the user
@@ -361,6 +397,9 @@ const (
 	PipelineRunReasonCancelled PipelineRunReason = "Cancelled"
 	// PipelineRunReasonPending is the reason set when the PipelineRun is in the pending state
 	PipelineRunReasonPending PipelineRunReason = "PipelineRunPending"
+	// PipelineRunReasonQueued is the reason set when the PipelineRun waits for other PipelineRuns
+	// with the same concurrency key to complete before it starts
+	PipelineRunReasonQueued PipelineRunReason = "PipelineRunQueued"
 	// PipelineRunReasonTimedOut is the reason set when the PipelineRun has timed out
 	PipelineRunReasonTimedOut PipelineRunReason = "PipelineRunTimeout"
 	// PipelineRunReasonStopping indicates that no new Tasks will be scheduled by the controller, and the
@@ -443,6 +482,10 @@ const (
 // PipelineTaskOnErrorAnnotation is used to pass the failure strategy to TaskRun pods from PipelineTask OnError field
 const PipelineTaskOnErrorAnnotation = "pipeline.tekton.dev/pipeline-task-on-error"
 
+// WhenFilesAnnotation is used to pass to TaskRun pods the files written to their Workspaces, which
+// the WhenExpressions of the following PipelineTasks test
+const WhenFilesAnnotation = "pipeline.tekton.dev/when-files"
+
 func (t PipelineRunReason) String() string {
 	return string(t)
 }
@@ -598,6 +641,8 @@ const (
 	FinallyTimedOutSkip SkippingReason = "PipelineRun Finally timeout has been reached"
 	// EmptyArrayInMatrixParams means the task was skipped because Matrix parameters contain empty array.
 	EmptyArrayInMatrixParams SkippingReason = "Matrix Parameters have an empty array"
+	// AllMatrixCombinationsExcluded means the task was skipped because Matrix.Exclude removed all the combinations.
+	AllMatrixCombinationsExcluded SkippingReason = "All Matrix combinations were excluded"
 	// None means the task was not skipped
 	None SkippingReason = "None"
 )
@@ -126,6 +126,35 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		errs = errs.Also(validatePodTemplateEnv(ctx, *ps.TaskRunTemplate.PodTemplate).ViaField("taskRunTemplate"))
 	}
 
+	if ps.Concurrency != nil {
+		errs = errs.Also(ValidateConcurrency(ctx, ps.Concurrency).ViaField("concurrency"))
+	}
+
+	if ps.RetryFrom != nil {
+		errs = errs.Also(ValidateRetryFrom(ctx, ps.RetryFrom).ViaField("retryFrom"))
+	}
+
+	return errs
+}
+
+// ValidateConcurrency validates the concurrency key and limit of a PipelineRun.
+func ValidateConcurrency(ctx context.Context, c *PipelineRunConcurrency) (errs *apis.FieldError) {
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "concurrency", config.AlphaAPIFields))
+	if c.Key == "" {
+		errs = errs.Also(apis.ErrMissingField("key"))
+	}
+	if c.MaxConcurrent < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", c.MaxConcurrent), "maxConcurrent"))
+	}
+	return errs
+}
+
+// ValidateRetryFrom validates the previous PipelineRun and the task a PipelineRun is retried from.
+func ValidateRetryFrom(ctx context.Context, r *PipelineRunRetryFrom) (errs *apis.FieldError) {
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "retryFrom", config.AlphaAPIFields))
+	if r.PipelineRun == "" {
+		errs = errs.Also(apis.ErrMissingField("pipelineRun"))
+	}
 	return errs
 }
 
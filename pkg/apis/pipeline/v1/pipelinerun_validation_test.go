@@ -889,6 +889,39 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 		},
 		withContext: EnableForbiddenEnv,
 		wantErr:     apis.ErrInvalidValue("PodTemplate cannot update a forbidden env: TEST_ENV", "taskRunTemplate.PodTemplate.Env"),
+	}, {
+		name: "concurrency without key",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			Concurrency: &v1.PipelineRunConcurrency{MaxConcurrent: -1},
+		},
+		withContext: cfgtesting.EnableAlphaAPIFields,
+		wantErr: apis.ErrMissingField("concurrency.key").Also(
+			apis.ErrInvalidValue("-1 should be >= 0", "concurrency.maxConcurrent")),
+	}, {
+		name: "concurrency without alpha api fields",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			Concurrency: &v1.PipelineRunConcurrency{Key: "deploy-main"},
+		},
+		withContext: cfgtesting.EnableBetaAPIFields,
+		wantErr:     apis.ErrGeneric("concurrency requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\"").ViaField("concurrency"),
+	}, {
+		name: "retryFrom without pipelineRun",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			RetryFrom:   &v1.PipelineRunRetryFrom{Task: "build"},
+		},
+		withContext: cfgtesting.EnableAlphaAPIFields,
+		wantErr:     apis.ErrMissingField("retryFrom.pipelineRun"),
+	}, {
+		name: "retryFrom without alpha api fields",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			RetryFrom:   &v1.PipelineRunRetryFrom{PipelineRun: "foo-run"},
+		},
+		withContext: cfgtesting.EnableBetaAPIFields,
+		wantErr:     apis.ErrGeneric("retryFrom requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\"").ViaField("retryFrom"),
 	}, {
 		name: "pipelineRef and pipelineSpec together",
 		spec: v1.PipelineRunSpec{
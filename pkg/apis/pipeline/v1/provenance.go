@@ -25,6 +25,82 @@ type Provenance struct {
 
 	// FeatureFlags identifies the feature flags that were used during the task/pipeline run
 	FeatureFlags *config.FeatureFlags `json:"featureFlags,omitempty"`
+
+	// Verification records the trusted resources verification outcome of the
+	// remote resources referenced by the task/pipeline run.
+	// +optional
+	// +listType=atomic
+	Verification []ResourceVerification `json:"verification,omitempty"`
+}
+
+// VerificationOutcome is the outcome of verifying a resource against the
+// matching VerificationPolicies.
+type VerificationOutcome string
+
+const (
+	// VerificationOutcomePassed indicates the resource passed verification.
+	VerificationOutcomePassed VerificationOutcome = "Passed"
+	// VerificationOutcomeWarned indicates the resource failed a "warn" mode policy,
+	// or no policy matched and "trusted-resources-verification-no-match-policy" is "warn".
+	VerificationOutcomeWarned VerificationOutcome = "Warned"
+	// VerificationOutcomeFailed indicates the resource failed verification.
+	VerificationOutcomeFailed VerificationOutcome = "Failed"
+)
+
+// ResourceVerification records the trusted resources verification outcome of
+// a single resource referenced by a task/pipeline run.
+type ResourceVerification struct {
+	// Name is the name of the verified resource.
+	Name string `json:"name"`
+
+	// Kind is the kind of the verified resource, e.g. "Task" or "Pipeline".
+	Kind string `json:"kind,omitempty"`
+
+	// PipelineTask is the name of the PipelineTask referencing the resource, if any.
+	// +optional
+	PipelineTask string `json:"pipelineTask,omitempty"`
+
+	// Outcome is the overall verification outcome of the resource.
+	Outcome VerificationOutcome `json:"outcome"`
+
+	// Policies holds the outcome of each VerificationPolicy that was evaluated.
+	// +optional
+	// +listType=atomic
+	Policies []PolicyVerification `json:"policies,omitempty"`
+
+	// Message explains why the verification did not pass.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PolicyVerification records the outcome of verifying a resource against a
+// single VerificationPolicy.
+type PolicyVerification struct {
+	// Name is the namespace/name of the VerificationPolicy.
+	Name string `json:"name"`
+
+	// Mode is the mode of the VerificationPolicy, "enforce" or "warn".
+	Mode string `json:"mode,omitempty"`
+
+	// Authority is the name of the policy authority whose key verified the
+	// resource signature. It is empty when no key verified the signature.
+	// +optional
+	Authority string `json:"authority,omitempty"`
+
+	// Passed is true if the resource signature was verified by one of the
+	// policy authorities.
+	Passed bool `json:"passed"`
+}
+
+// SetVerification records rv, replacing a previous record for the same resource.
+func (p *Provenance) SetVerification(rv ResourceVerification) {
+	for i, existing := range p.Verification {
+		if existing.Name == rv.Name && existing.Kind == rv.Kind && existing.PipelineTask == rv.PipelineTask {
+			p.Verification[i] = rv
+			return
+		}
+	}
+	p.Verification = append(p.Verification, rv)
 }
 
 // RefSource contains the information that can uniquely identify where a remote
@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+// resultSchema is the subset of JSON Schema supported to validate the values of results.
+// The values of results are strings, arrays of strings or objects whose values are strings,
+// so the types "number", "integer" and "boolean" describe strings which can be parsed as such.
+// +k8s:openapi-gen=false
+// +k8s:deepcopy-gen=false
+type resultSchema struct {
+	Type                 string                   `json:"type,omitempty"`
+	Title                string                   `json:"title,omitempty"`
+	Description          string                   `json:"description,omitempty"`
+	Enum                 []string                 `json:"enum,omitempty"`
+	Pattern              string                   `json:"pattern,omitempty"`
+	MinLength            *int                     `json:"minLength,omitempty"`
+	MaxLength            *int                     `json:"maxLength,omitempty"`
+	Minimum              *float64                 `json:"minimum,omitempty"`
+	Maximum              *float64                 `json:"maximum,omitempty"`
+	Items                *resultSchema            `json:"items,omitempty"`
+	MinItems             *int                     `json:"minItems,omitempty"`
+	MaxItems             *int                     `json:"maxItems,omitempty"`
+	Properties           map[string]*resultSchema `json:"properties,omitempty"`
+	Required             []string                 `json:"required,omitempty"`
+	AdditionalProperties *bool                    `json:"additionalProperties,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+const (
+	schemaTypeString  = "string"
+	schemaTypeNumber  = "number"
+	schemaTypeInteger = "integer"
+	schemaTypeBoolean = "boolean"
+	schemaTypeArray   = "array"
+	schemaTypeObject  = "object"
+)
+
+// schemaStringTypes are the schema types which describe a string value.
+var schemaStringTypes = []string{"", schemaTypeString, schemaTypeNumber, schemaTypeInteger, schemaTypeBoolean}
+
+// parseResultSchema parses the JSON schema of a result, rejecting the keywords which aren't supported.
+func parseResultSchema(raw *runtime.RawExtension) (*resultSchema, error) {
+	if raw == nil || len(raw.Raw) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw.Raw))
+	dec.DisallowUnknownFields()
+	var s resultSchema
+	if err := dec.Decode(&s); err != nil {
+		return nil, err
+	}
+	if err := s.compile("", false); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// compile checks the keywords of the schema and compiles its pattern. Since the items of
+// arrays and the properties of objects are strings, their schemas can't be nested.
+func (s *resultSchema) compile(path string, nested bool) error {
+	at := func(format string, a ...interface{}) error {
+		if path == "" {
+			return fmt.Errorf(format, a...)
+		}
+		return fmt.Errorf("%s: %s", path, fmt.Sprintf(format, a...))
+	}
+	switch s.Type {
+	case "", schemaTypeString, schemaTypeNumber, schemaTypeInteger, schemaTypeBoolean:
+	case schemaTypeArray, schemaTypeObject:
+		if nested {
+			return at("nested arrays and objects are not supported")
+		}
+	default:
+		return at("unknown type %q", s.Type)
+	}
+	if s.Items != nil || s.MinItems != nil || s.MaxItems != nil {
+		if nested || (s.Type != "" && s.Type != schemaTypeArray) {
+			return at("items, minItems and maxItems only apply to arrays")
+		}
+	}
+	if s.Properties != nil || s.Required != nil || s.AdditionalProperties != nil {
+		if nested || (s.Type != "" && s.Type != schemaTypeObject) {
+			return at("properties, required and additionalProperties only apply to objects")
+		}
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return at("invalid pattern: %v", err)
+		}
+		s.pattern = re
+	}
+	if s.Items != nil {
+		if err := s.Items.compile(strings.TrimPrefix(path+".items", "."), true); err != nil {
+			return err
+		}
+	}
+	for name, property := range s.Properties {
+		if property == nil {
+			return at("the schema of property %q is empty", name)
+		}
+		if err := property.compile(strings.TrimPrefix(path+".properties."+name, "."), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateResultSchema validates the JSON schema of a result of the given type.
+func ValidateResultSchema(ctx context.Context, name string, resultType ResultsType, schema *runtime.RawExtension) (errs *apis.FieldError) {
+	if schema == nil {
+		return nil
+	}
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "result schema", config.AlphaAPIFields))
+	if resultType == ResultsTypeFile {
+		return errs.Also(apis.ErrDisallowedFields(name + ".schema"))
+	}
+	s, err := parseResultSchema(schema)
+	if err != nil {
+		return errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid schema: %v", err),
+			Paths:   []string{name + ".schema"},
+		})
+	}
+	if s == nil {
+		return errs
+	}
+	var valid bool
+	switch resultType {
+	case ResultsTypeArray:
+		valid = s.Type == "" || s.Type == schemaTypeArray
+	case ResultsTypeObject:
+		valid = s.Type == "" || s.Type == schemaTypeObject
+	default:
+		valid = slices.Contains(schemaStringTypes, s.Type)
+	}
+	if !valid {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("a schema of type %q can't describe a result of type %q", s.Type, resultType),
+			Paths:   []string{name + ".schema.type"},
+		})
+	}
+	return errs
+}
+
+// ValidateValueAgainstSchema returns an error describing where the given value of the result
+// doesn't match the schema of the result, if it has one.
+func (tr TaskResult) ValidateValueAgainstSchema(value ResultValue) error {
+	return validateValueAgainstSchema(tr.Name, tr.Schema, value)
+}
+
+// ValidateValueAgainstSchema returns an error describing where the given value of the result
+// doesn't match the schema of the result, if it has one.
+func (sr StepResult) ValidateValueAgainstSchema(value ResultValue) error {
+	return validateValueAgainstSchema(sr.Name, sr.Schema, value)
+}
+
+func validateValueAgainstSchema(name string, schema *runtime.RawExtension, value ResultValue) error {
+	s, err := parseResultSchema(schema)
+	if err != nil {
+		return fmt.Errorf("invalid schema of result %q: %w", name, err)
+	}
+	if s == nil {
+		return nil
+	}
+	var errs []string
+	switch value.Type {
+	case ParamTypeArray:
+		errs = s.validateArray(name, value.ArrayVal)
+	case ParamTypeObject:
+		errs = s.validateObject(name, value.ObjectVal)
+	default:
+		errs = s.validateString(name, value.StringVal)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(errs, "; "))
+}
+
+func (s *resultSchema) validateString(path, val string) []string {
+	if !slices.Contains(schemaStringTypes, s.Type) {
+		return []string{fmt.Sprintf("%s: expected type %q but got a string", path, s.Type)}
+	}
+	var errs []string
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, val) {
+		errs = append(errs, fmt.Sprintf("%s: %q is not one of %q", path, val, s.Enum))
+	}
+	if s.pattern != nil && !s.pattern.MatchString(val) {
+		errs = append(errs, fmt.Sprintf("%s: %q does not match the pattern %q", path, val, s.Pattern))
+	}
+	if length := utf8.RuneCountInString(val); s.MinLength != nil && length < *s.MinLength {
+		errs = append(errs, fmt.Sprintf("%s: %q is shorter than the minimum length of %d", path, val, *s.MinLength))
+	} else if s.MaxLength != nil && length > *s.MaxLength {
+		errs = append(errs, fmt.Sprintf("%s: %q is longer than the maximum length of %d", path, val, *s.MaxLength))
+	}
+
+	switch s.Type {
+	case schemaTypeBoolean:
+		if val != "true" && val != "false" {
+			errs = append(errs, fmt.Sprintf("%s: %q is not of type %q", path, val, s.Type))
+		}
+	case schemaTypeNumber, schemaTypeInteger:
+		var n float64
+		var err error
+		if s.Type == schemaTypeInteger {
+			var i int64
+			i, err = strconv.ParseInt(val, 10, 64)
+			n = float64(i)
+		} else {
+			n, err = strconv.ParseFloat(val, 64)
+		}
+		if err != nil {
+			return append(errs, fmt.Sprintf("%s: %q is not of type %q", path, val, s.Type))
+		}
+		if s.Minimum != nil && n < *s.Minimum {
+			errs = append(errs, fmt.Sprintf("%s: %s is less than the minimum of %v", path, val, *s.Minimum))
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			errs = append(errs, fmt.Sprintf("%s: %s is greater than the maximum of %v", path, val, *s.Maximum))
+		}
+	}
+	return errs
+}
+
+func (s *resultSchema) validateArray(path string, vals []string) []string {
+	if s.Type != "" && s.Type != schemaTypeArray {
+		return []string{fmt.Sprintf("%s: expected type %q but got an array", path, s.Type)}
+	}
+	var errs []string
+	if s.MinItems != nil && len(vals) < *s.MinItems {
+		errs = append(errs, fmt.Sprintf("%s: has %d items, fewer than the minimum of %d", path, len(vals), *s.MinItems))
+	}
+	if s.MaxItems != nil && len(vals) > *s.MaxItems {
+		errs = append(errs, fmt.Sprintf("%s: has %d items, more than the maximum of %d", path, len(vals), *s.MaxItems))
+	}
+	if s.Items != nil {
+		for i, val := range vals {
+			errs = append(errs, s.Items.validateString(fmt.Sprintf("%s[%d]", path, i), val)...)
+		}
+	}
+	return errs
+}
+
+func (s *resultSchema) validateObject(path string, vals map[string]string) []string {
+	if s.Type != "" && s.Type != schemaTypeObject {
+		return []string{fmt.Sprintf("%s: expected type %q but got an object", path, s.Type)}
+	}
+	var errs []string
+	for _, key := range s.Required {
+		if _, ok := vals[key]; !ok {
+			errs = append(errs, fmt.Sprintf("%s: missing the required property %q", path, key))
+		}
+	}
+	keys := make([]string, 0, len(vals))
+	for key := range vals {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if property, ok := s.Properties[key]; ok {
+			errs = append(errs, property.validateString(path+"."+key, vals[key])...)
+		} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+			errs = append(errs, fmt.Sprintf("%s: the property %q is not allowed", path, key))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+func TestResultSchemaValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		Result        v1.TaskResult
+		alpha         bool
+		expectedError *apis.FieldError
+	}{{
+		name: "valid string result schema",
+		Result: v1.TaskResult{
+			Name:   "digest",
+			Schema: &runtime.RawExtension{Raw: []byte(`{"type": "string", "pattern": "^sha256:[0-9a-f]{64}$"}`)},
+		},
+		alpha: true,
+	}, {
+		name: "valid object result schema",
+		Result: v1.TaskResult{
+			Name:       "image",
+			Type:       v1.ResultsTypeObject,
+			Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}, "size": {Type: v1.ParamTypeString}},
+			Schema:     &runtime.RawExtension{Raw: []byte(`{"type": "object", "required": ["url"], "properties": {"size": {"type": "integer", "minimum": 0}}}`)},
+		},
+		alpha: true,
+	}, {
+		name: "result schema requires alpha",
+		Result: v1.TaskResult{
+			Name:   "digest",
+			Schema: &runtime.RawExtension{Raw: []byte(`{"type": "string"}`)},
+		},
+		expectedError: apis.ErrGeneric("result schema requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\""),
+	}, {
+		name: "unsupported keyword",
+		Result: v1.TaskResult{
+			Name:   "digest",
+			Schema: &runtime.RawExtension{Raw: []byte(`{"format": "uri"}`)},
+		},
+		alpha: true,
+		expectedError: &apis.FieldError{
+			Message: `invalid schema: json: unknown field "format"`,
+			Paths:   []string{"digest.schema"},
+		},
+	}, {
+		name: "invalid pattern",
+		Result: v1.TaskResult{
+			Name:   "digest",
+			Schema: &runtime.RawExtension{Raw: []byte(`{"pattern": "("}`)},
+		},
+		alpha: true,
+		expectedError: &apis.FieldError{
+			Message: "invalid schema: invalid pattern: error parsing regexp: missing closing ): `(`",
+			Paths:   []string{"digest.schema"},
+		},
+	}, {
+		name: "nested array",
+		Result: v1.TaskResult{
+			Name:   "tags",
+			Type:   v1.ResultsTypeArray,
+			Schema: &runtime.RawExtension{Raw: []byte(`{"items": {"type": "array"}}`)},
+		},
+		alpha: true,
+		expectedError: &apis.FieldError{
+			Message: "invalid schema: items: nested arrays and objects are not supported",
+			Paths:   []string{"tags.schema"},
+		},
+	}, {
+		name: "schema type mismatching the result type",
+		Result: v1.TaskResult{
+			Name:   "tags",
+			Type:   v1.ResultsTypeArray,
+			Schema: &runtime.RawExtension{Raw: []byte(`{"type": "string"}`)},
+		},
+		alpha: true,
+		expectedError: &apis.FieldError{
+			Message: `a schema of type "string" can't describe a result of type "array"`,
+			Paths:   []string{"tags.schema.type"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tt.alpha {
+				ctx = cfgtesting.EnableAlphaAPIFields(ctx)
+			}
+			err := tt.Result.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskResult.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateValueAgainstSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		value   *v1.ResultValue
+		wantErr string
+	}{{
+		name:   "string matching the schema",
+		schema: `{"type": "string", "enum": ["debug", "release"]}`,
+		value:  v1.NewStructuredValues("release"),
+	}, {
+		name:    "string not in enum",
+		schema:  `{"enum": ["debug", "release"]}`,
+		value:   v1.NewStructuredValues("profile"),
+		wantErr: `res: "profile" is not one of ["debug" "release"]`,
+	}, {
+		name:    "string not matching the pattern and too long",
+		schema:  `{"pattern": "^[a-z]+$", "maxLength": 3}`,
+		value:   v1.NewStructuredValues("Abcd"),
+		wantErr: `res: "Abcd" does not match the pattern "^[a-z]+$"; res: "Abcd" is longer than the maximum length of 3`,
+	}, {
+		name:    "number out of range",
+		schema:  `{"type": "number", "maximum": 1}`,
+		value:   v1.NewStructuredValues("1.5"),
+		wantErr: `res: 1.5 is greater than the maximum of 1`,
+	}, {
+		name:    "not an integer",
+		schema:  `{"type": "integer"}`,
+		value:   v1.NewStructuredValues("1.5"),
+		wantErr: `res: "1.5" is not of type "integer"`,
+	}, {
+		name:    "not a boolean",
+		schema:  `{"type": "boolean"}`,
+		value:   v1.NewStructuredValues("yes"),
+		wantErr: `res: "yes" is not of type "boolean"`,
+	}, {
+		name:    "string instead of an array",
+		schema:  `{"type": "array"}`,
+		value:   v1.NewStructuredValues("a"),
+		wantErr: `res: expected type "array" but got a string`,
+	}, {
+		name:    "array items",
+		schema:  `{"type": "array", "minItems": 3, "items": {"type": "integer"}}`,
+		value:   v1.NewStructuredValues("1", "two"),
+		wantErr: `res: has 2 items, fewer than the minimum of 3; res[1]: "two" is not of type "integer"`,
+	}, {
+		name:   "object matching the schema",
+		schema: `{"required": ["url"], "properties": {"url": {"pattern": "^https://"}}}`,
+		value:  v1.NewObject(map[string]string{"url": "https://example.com", "size": "3"}),
+	}, {
+		name:    "object properties",
+		schema:  `{"required": ["url"], "properties": {"size": {"type": "integer"}}, "additionalProperties": false}`,
+		value:   v1.NewObject(map[string]string{"size": "big", "tag": "latest"}),
+		wantErr: `res: missing the required property "url"; res.size: "big" is not of type "integer"; res: the property "tag" is not allowed`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v1.TaskResult{Name: "res", Schema: &runtime.RawExtension{Raw: []byte(tt.schema)}}
+			err := result.ValidateValueAgainstSchema(*tt.value)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if d := cmp.Diff(tt.wantErr, got); d != "" {
+				t.Errorf("ValidateValueAgainstSchema() error diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
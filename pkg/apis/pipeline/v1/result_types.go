@@ -13,7 +13,11 @@ limitations under the License.
 
 package v1
 
-import "strings"
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
 
 // TaskResult used to describe the results of a task
 type TaskResult struct {
@@ -29,6 +33,12 @@ type TaskResult struct {
 	// +optional
 	Properties map[string]PropertySpec `json:"properties,omitempty"`
 
+	// Schema is a JSON schema the value of the result is validated against when it is emitted.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Schema *runtime.RawExtension `json:"schema,omitempty"`
+
 	// Description is a human-readable description of the result
 	// +optional
 	Description string `json:"description,omitempty"`
@@ -53,6 +63,12 @@ type StepResult struct {
 	// +optional
 	Properties map[string]PropertySpec `json:"properties,omitempty"`
 
+	// Schema is a JSON schema the value of the result is validated against when it is emitted.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Schema *runtime.RawExtension `json:"schema,omitempty"`
+
 	// Description is a human-readable description of the result
 	// +optional
 	Description string `json:"description,omitempty"`
@@ -92,6 +108,16 @@ const (
 	ResultsTypeString ResultsType = "string"
 	ResultsTypeArray  ResultsType = "array"
 	ResultsTypeObject ResultsType = "object"
+	// ResultsTypeFile is the type of the results whose content is uploaded to an object store,
+	// their value being an object holding the URI and the digest of the upload.
+	ResultsTypeFile ResultsType = "file"
+)
+
+const (
+	// FileResultURIKey is the key of the URI of the upload in the value of a result of type file.
+	FileResultURIKey = "uri"
+	// FileResultDigestKey is the key of the digest of the upload in the value of a result of type file.
+	FileResultDigestKey = "digest"
 )
 
 // AllResultsTypes can be used for ResultsTypes validation.
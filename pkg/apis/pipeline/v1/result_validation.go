@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
@@ -32,6 +33,8 @@ func (tr TaskResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 	case tr.Type == ResultsTypeObject:
 		errs = errs.Also(validateObjectResult(tr))
 	case tr.Type == ResultsTypeArray:
+	case tr.Type == ResultsTypeFile:
+		errs = errs.Also(validateFileResult(ctx, tr))
 	// Resources created before the result. Type was introduced may not have Type set
 	// and should be considered valid
 	case tr.Type == "":
@@ -39,9 +42,23 @@ func (tr TaskResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 	case tr.Type != ResultsTypeString:
 		errs = errs.Also(apis.ErrInvalidValue(tr.Type, "type", "type must be string"))
 	}
+	errs = errs.Also(ValidateResultSchema(ctx, tr.Name, tr.Type, tr.Schema))
 	return errs.Also(tr.validateValue(ctx))
 }
 
+// validateFileResult validates a result of type file, which is an alpha feature and whose content
+// can't be extracted from a StepResult.
+func validateFileResult(ctx context.Context, tr TaskResult) (errs *apis.FieldError) {
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "file type results", config.AlphaAPIFields))
+	if tr.Properties != nil {
+		errs = errs.Also(apis.ErrDisallowedFields(tr.Name + ".properties"))
+	}
+	if tr.Value != nil {
+		errs = errs.Also(apis.ErrDisallowedFields(tr.Name + ".value"))
+	}
+	return errs
+}
+
 // validateObjectResult validates the object result and check if the Properties is missing
 // for Properties values it will check if the type is string.
 func validateObjectResult(tr TaskResult) (errs *apis.FieldError) {
@@ -115,17 +132,15 @@ func (sr StepResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	switch {
 	case sr.Type == ResultsTypeObject:
-		return validateObjectStepResult(sr)
+		errs = validateObjectStepResult(sr)
 	case sr.Type == ResultsTypeArray:
-		return nil
 	// The Type is string by default if it is empty.
 	case sr.Type == "":
-		return nil
 	case sr.Type == ResultsTypeString:
-		return nil
 	default:
 		return apis.ErrInvalidValue(sr.Type, "type", fmt.Sprintf("invalid type %s", sr.Type))
 	}
+	return errs.Also(ValidateResultSchema(ctx, sr.Name, sr.Type, sr.Schema))
 }
 
 // validateObjectStepResult validates the object result and check if the Properties is missing
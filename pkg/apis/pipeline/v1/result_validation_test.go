@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
 	"knative.dev/pkg/apis"
@@ -125,6 +126,60 @@ func TestResultsValidateError(t *testing.T) {
 	}
 }
 
+func TestFileResultsValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		Result        v1.TaskResult
+		alpha         bool
+		expectedError *apis.FieldError
+	}{{
+		name: "valid result type file",
+		Result: v1.TaskResult{
+			Name:        "sbom",
+			Type:        v1.ResultsTypeFile,
+			Description: "my large result",
+		},
+		alpha: true,
+	}, {
+		name: "result type file requires alpha",
+		Result: v1.TaskResult{
+			Name: "sbom",
+			Type: v1.ResultsTypeFile,
+		},
+		expectedError: apis.ErrGeneric("file type results requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\""),
+	}, {
+		name: "result type file with properties",
+		Result: v1.TaskResult{
+			Name:       "sbom",
+			Type:       v1.ResultsTypeFile,
+			Properties: map[string]v1.PropertySpec{"hello": {Type: v1.ParamTypeString}},
+		},
+		alpha:         true,
+		expectedError: apis.ErrDisallowedFields("sbom.properties"),
+	}, {
+		name: "result type file extracted from a step result",
+		Result: v1.TaskResult{
+			Name:  "sbom",
+			Type:  v1.ResultsTypeFile,
+			Value: v1.NewStructuredValues("$(steps.step-name.results.sbom)"),
+		},
+		alpha:         true,
+		expectedError: apis.ErrDisallowedFields("sbom.value"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tt.alpha {
+				ctx = cfgtesting.EnableAlphaAPIFields(ctx)
+			}
+			err := tt.Result.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskResult.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestResultsValidateValue(t *testing.T) {
 	tests := []struct {
 		name   string
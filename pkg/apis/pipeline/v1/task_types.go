@@ -124,6 +124,19 @@ type TaskSpec struct {
 	// Results are values that this Task can output
 	// +listType=atomic
 	Results []TaskResult `json:"results,omitempty"`
+
+	// ParallelSteps are groups of consecutive Steps which run concurrently instead of
+	// sequentially. The Steps after a group start once all the Steps of the group are done.
+	// +optional
+	// +listType=atomic
+	ParallelSteps []ParallelStepGroup `json:"parallelSteps,omitempty"`
+}
+
+// ParallelStepGroup is a group of consecutive Steps of a Task which run concurrently.
+type ParallelStepGroup struct {
+	// Steps are the names of the Steps of the group.
+	// +listType=atomic
+	Steps []string `json:"steps"`
 }
 
 // TaskList contains a list of Task
@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"regexp"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
@@ -91,9 +93,53 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(SidecarList(ts.Sidecars).Validate(ctx).ViaField("sidecars"))
 	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
 	errs = errs.Also(ValidateParameterVariables(ctx, ts.Steps, ts.Params))
+	errs = errs.Also(ts.validateParamFallbacks())
 	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps))
 	errs = errs.Also(validateTaskResultsVariables(ctx, ts.Steps, ts.Results))
 	errs = errs.Also(validateResults(ctx, ts.Results).ViaField("results"))
+	errs = errs.Also(validateParallelSteps(ctx, ts.Steps, ts.ParallelSteps).ViaField("parallelSteps"))
+	return errs
+}
+
+// validateParallelSteps validates that each parallel step group is made of at least two
+// consecutive steps of the Task, and that a step belongs to at most one group.
+func validateParallelSteps(ctx context.Context, steps []Step, groups []ParallelStepGroup) (errs *apis.FieldError) {
+	if len(groups) == 0 {
+		return nil
+	}
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "parallelSteps", config.AlphaAPIFields))
+	stepIndices := map[string]int{}
+	for i, s := range steps {
+		if s.Name != "" {
+			stepIndices[s.Name] = i
+		}
+	}
+	grouped := sets.NewString()
+	for g, group := range groups {
+		if len(group.Steps) < 2 {
+			errs = errs.Also(apis.ErrInvalidValue("a parallel step group must have at least two steps", "steps").ViaIndex(g))
+			continue
+		}
+		minIndex, maxIndex, valid := len(steps), -1, true
+		for _, name := range group.Steps {
+			i, ok := stepIndices[name]
+			if !ok {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("step %q does not exist", name), "steps").ViaIndex(g))
+				valid = false
+				continue
+			}
+			if grouped.Has(name) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("step %q is listed more than once in the parallel step groups", name), "steps").ViaIndex(g))
+				valid = false
+				continue
+			}
+			grouped.Insert(name)
+			minIndex, maxIndex = min(minIndex, i), max(maxIndex, i)
+		}
+		if valid && maxIndex-minIndex+1 != len(group.Steps) {
+			errs = errs.Also(apis.ErrInvalidValue("the steps of a parallel step group must be consecutive", "steps").ViaIndex(g))
+		}
+	}
 	return errs
 }
 
@@ -217,6 +263,7 @@ func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 func (l StepList) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// Task must not have duplicate step names.
 	names := sets.NewString()
+	runtimeClassName := ""
 	for idx, s := range l {
 		// names cannot be duplicated - checking that Step names are unique
 		if s.Name != "" {
@@ -225,6 +272,12 @@ func (l StepList) Validate(ctx context.Context) (errs *apis.FieldError) {
 			}
 			names.Insert(s.Name)
 		}
+		if s.RuntimeClassName != nil {
+			errs = errs.Also(ValidateRuntimeClassNameConflict(*s.RuntimeClassName, runtimeClassName).ViaIndex(idx))
+			if runtimeClassName == "" {
+				runtimeClassName = *s.RuntimeClassName
+			}
+		}
 
 		errs = errs.Also(s.Validate(ctx).ViaIndex(idx))
 		if s.Results != nil {
@@ -233,6 +286,7 @@ func (l StepList) Validate(ctx context.Context) (errs *apis.FieldError) {
 		}
 		if len(s.When) > 0 {
 			errs = errs.Also(s.When.validate(ctx).ViaIndex(idx))
+			errs = errs.Also(s.When.validateNoFiles().ViaIndex(idx))
 		}
 	}
 	return errs
@@ -331,6 +385,7 @@ func ValidateParameterVariables(ctx context.Context, steps []Step, params ParamS
 	stringParameterNames := sets.NewString(stringParams.GetNames()...)
 	arrayParameterNames := sets.NewString(arrayParams.GetNames()...)
 	errs = errs.Also(ValidateNameFormat(stringParameterNames.Insert(arrayParameterNames.List()...), objectParams))
+	errs = errs.Also(validateParamFallbacks(steps, sets.NewString(arrayParams.GetNames()...).Insert(objectParams.GetNames()...)))
 	return errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
 }
 
@@ -340,6 +395,8 @@ func validateTaskContextVariables(ctx context.Context, steps []Step) *apis.Field
 		"name",
 		"namespace",
 		"uid",
+		"labels",
+		"annotations",
 	)
 	taskContextNames := sets.NewString().Insert(
 		"name",
@@ -402,16 +459,116 @@ func validateStepObjectUsageAsWhole(step Step, prefix string, vars sets.String)
 		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(arg, prefix, vars).ViaFieldIndex("args", i))
 	}
 	for _, env := range step.Env {
-		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(env.Value, prefix, vars).ViaFieldKey("env", env.Name))
+		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(substitution.StripArrayJoins(env.Value), prefix, vars).ViaFieldKey("env", env.Name))
 	}
 	for i, v := range step.VolumeMounts {
 		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(v.Name, prefix, vars).ViaField("name").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(v.MountPath, prefix, vars).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(v.SubPath, prefix, vars).ViaField("subPath").ViaFieldIndex("volumeMount", i))
 	}
+	errs = errs.Also(validateSecurityContextVariables(step.SecurityContext, func(value string) *apis.FieldError {
+		return substitution.ValidateNoReferencesToEntireProhibitedVariables(value, prefix, vars)
+	}).ViaField("securityContext"))
+	return errs
+}
+
+// validateParamFallbacks returns an error if the Steps contain references with a fallback value, e.g. `$(params.foo | default "bar")`,
+// to the entire array or object params in vars, or fallback values that are not valid quoted strings.
+func validateParamFallbacks(steps []Step, vars sets.String) (errs *apis.FieldError) {
+	for idx, step := range steps {
+		errs = errs.Also(validateStepParamFallbacks(step, vars)).ViaFieldIndex("steps", idx)
+	}
 	return errs
 }
 
+// validateStepParamFallbacks returns an error if the Step contains invalid references with a fallback value
+func validateStepParamFallbacks(step Step, vars sets.String) *apis.FieldError {
+	errs := substitution.ValidateParamFallbacks(step.Name, vars).ViaField("name")
+	errs = errs.Also(substitution.ValidateParamFallbacks(step.Image, vars).ViaField("image"))
+	errs = errs.Also(substitution.ValidateParamFallbacks(step.WorkingDir, vars).ViaField("workingDir"))
+	errs = errs.Also(substitution.ValidateParamFallbacks(step.Script, vars).ViaField("script"))
+	for i, cmd := range step.Command {
+		errs = errs.Also(substitution.ValidateParamFallbacks(cmd, vars).ViaFieldIndex("command", i))
+	}
+	for i, arg := range step.Args {
+		errs = errs.Also(substitution.ValidateParamFallbacks(arg, vars).ViaFieldIndex("args", i))
+	}
+	for _, env := range step.Env {
+		errs = errs.Also(substitution.ValidateParamFallbacks(env.Value, vars).ViaFieldKey("env", env.Name))
+	}
+	for i, v := range step.VolumeMounts {
+		errs = errs.Also(substitution.ValidateParamFallbacks(v.Name, vars).ViaField("name").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(substitution.ValidateParamFallbacks(v.MountPath, vars).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(substitution.ValidateParamFallbacks(v.SubPath, vars).ViaField("subPath").ViaFieldIndex("volumeMount", i))
+	}
+	errs = errs.Also(validateSecurityContextVariables(step.SecurityContext, func(value string) *apis.FieldError {
+		return substitution.ValidateParamFallbacks(value, vars)
+	}).ViaField("securityContext"))
+	return errs
+}
+
+// validateParamFallbacks returns an error if the fields of the TaskSpec other than its Steps, which are validated by
+// ValidateParameterVariables, contain invalid references with a fallback value. These are all the fields in which
+// the references are substituted, e.g. the Sidecars, the StepTemplate or the Volumes.
+func (ts *TaskSpec) validateParamFallbacks() (errs *apis.FieldError) {
+	_, arrayParams, objectParams := ts.Params.SortByType()
+	vars := sets.NewString(arrayParams.GetNames()...).Insert(objectParams.GetNames()...)
+	v := reflect.ValueOf(ts).Elem()
+	for i := range v.NumField() {
+		if name := jsonFieldName(v.Type().Field(i)); name != "steps" && name != "-" {
+			errs = errs.Also(validateParamFallbacksIn(v.Field(i), vars).ViaField(name))
+		}
+	}
+	return errs
+}
+
+// validateParamFallbacksIn returns an error if the strings found in v contain invalid references with a fallback
+// value. It walks the exported fields of v the way the references are collected when they are substituted.
+func validateParamFallbacksIn(v reflect.Value, vars sets.String) (errs *apis.FieldError) {
+	switch v.Kind() {
+	case reflect.String:
+		if s := v.String(); strings.Contains(s, "$(params") {
+			return substitution.ValidateParamFallbacks(s, vars)
+		}
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			return validateParamFallbacksIn(v.Elem(), vars)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+			name := jsonFieldName(f)
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name != "" {
+				errs = errs.Also(validateParamFallbacksIn(v.Field(i), vars).ViaField(name))
+			} else {
+				errs = errs.Also(validateParamFallbacksIn(v.Field(i), vars))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			errs = errs.Also(validateParamFallbacksIn(v.Index(i), vars).ViaIndex(i))
+		}
+	case reflect.Map:
+		for iter := v.MapRange(); iter.Next(); {
+			errs = errs.Also(validateParamFallbacksIn(iter.Value(), vars).ViaKey(fmt.Sprint(iter.Key().Interface())))
+		}
+	default:
+	}
+	return errs
+}
+
+// jsonFieldName returns the name of the field in JSON, "-" if it is not serialized, or an empty string if it is inlined.
+func jsonFieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" && !f.Anonymous {
+		return f.Name
+	}
+	return name
+}
+
 // validateArrayUsage returns an error if the Steps contain references to the input array params in fields where these references are prohibited
 func validateArrayUsage(steps []Step, prefix string, arrayParamNames sets.String) (errs *apis.FieldError) {
 	for idx, step := range steps {
@@ -433,13 +590,17 @@ func validateStepArrayUsage(step Step, prefix string, arrayParamNames sets.Strin
 		errs = errs.Also(substitution.ValidateVariableReferenceIsIsolated(arg, prefix, arrayParamNames).ViaFieldIndex("args", i))
 	}
 	for _, env := range step.Env {
-		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(env.Value, prefix, arrayParamNames).ViaFieldKey("env", env.Name))
+		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(substitution.StripArrayJoins(env.Value), prefix, arrayParamNames).ViaFieldKey("env", env.Name))
+		errs = errs.Also(substitution.ValidateArrayJoins(env.Value, arrayParamNames).ViaFieldKey("env", env.Name))
 	}
 	for i, v := range step.VolumeMounts {
 		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(v.Name, prefix, arrayParamNames).ViaField("name").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(v.MountPath, prefix, arrayParamNames).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(v.SubPath, prefix, arrayParamNames).ViaField("subPath").ViaFieldIndex("volumeMount", i))
 	}
+	errs = errs.Also(validateSecurityContextVariables(step.SecurityContext, func(value string) *apis.FieldError {
+		return substitution.ValidateNoReferencesToProhibitedVariables(value, prefix, arrayParamNames)
+	}).ViaField("securityContext"))
 	return errs
 }
 
@@ -513,7 +674,7 @@ func validateStepVariables(ctx context.Context, step Step, prefix string, vars s
 		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(arg, prefix, vars).ViaFieldIndex("args", i))
 	}
 	for _, env := range step.Env {
-		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(env.Value, prefix, vars).ViaFieldKey("env", env.Name))
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(substitution.StripArrayJoins(env.Value), prefix, vars).ViaFieldKey("env", env.Name))
 	}
 	for i, v := range step.VolumeMounts {
 		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(v.Name, prefix, vars).ViaField("name").ViaFieldIndex("volumeMount", i))
@@ -521,6 +682,58 @@ func validateStepVariables(ctx context.Context, step Step, prefix string, vars s
 		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(v.SubPath, prefix, vars).ViaField("SubPath").ViaFieldIndex("volumeMount", i))
 	}
 	errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(string(step.OnError), prefix, vars).ViaField("onError"))
+	errs = errs.Also(validateSecurityContextVariables(step.SecurityContext, func(value string) *apis.FieldError {
+		return substitution.ValidateNoReferencesToUnknownVariables(value, prefix, vars)
+	}).ViaField("securityContext"))
+	return errs
+}
+
+// validateSecurityContextVariables applies validate to the string fields of a Step's SecurityContext
+// which support variable substitution.
+func validateSecurityContextVariables(sc *corev1.SecurityContext, validate func(value string) *apis.FieldError) (errs *apis.FieldError) {
+	if sc == nil {
+		return nil
+	}
+	if sc.Capabilities != nil {
+		for i, c := range sc.Capabilities.Add {
+			errs = errs.Also(validate(string(c)).ViaFieldIndex("add", i).ViaField("capabilities"))
+		}
+		for i, c := range sc.Capabilities.Drop {
+			errs = errs.Also(validate(string(c)).ViaFieldIndex("drop", i).ViaField("capabilities"))
+		}
+	}
+	if sc.SELinuxOptions != nil {
+		errs = errs.Also(validate(sc.SELinuxOptions.User).ViaField("user").ViaField("seLinuxOptions"))
+		errs = errs.Also(validate(sc.SELinuxOptions.Role).ViaField("role").ViaField("seLinuxOptions"))
+		errs = errs.Also(validate(sc.SELinuxOptions.Type).ViaField("type").ViaField("seLinuxOptions"))
+		errs = errs.Also(validate(sc.SELinuxOptions.Level).ViaField("level").ViaField("seLinuxOptions"))
+	}
+	if sc.WindowsOptions != nil {
+		if sc.WindowsOptions.GMSACredentialSpecName != nil {
+			errs = errs.Also(validate(*sc.WindowsOptions.GMSACredentialSpecName).ViaField("gmsaCredentialSpecName").ViaField("windowsOptions"))
+		}
+		if sc.WindowsOptions.GMSACredentialSpec != nil {
+			errs = errs.Also(validate(*sc.WindowsOptions.GMSACredentialSpec).ViaField("gmsaCredentialSpec").ViaField("windowsOptions"))
+		}
+		if sc.WindowsOptions.RunAsUserName != nil {
+			errs = errs.Also(validate(*sc.WindowsOptions.RunAsUserName).ViaField("runAsUserName").ViaField("windowsOptions"))
+		}
+	}
+	if sc.ProcMount != nil {
+		errs = errs.Also(validate(string(*sc.ProcMount)).ViaField("procMount"))
+	}
+	if sc.SeccompProfile != nil {
+		errs = errs.Also(validate(string(sc.SeccompProfile.Type)).ViaField("type").ViaField("seccompProfile"))
+		if sc.SeccompProfile.LocalhostProfile != nil {
+			errs = errs.Also(validate(*sc.SeccompProfile.LocalhostProfile).ViaField("localhostProfile").ViaField("seccompProfile"))
+		}
+	}
+	if sc.AppArmorProfile != nil {
+		errs = errs.Also(validate(string(sc.AppArmorProfile.Type)).ViaField("type").ViaField("appArmorProfile"))
+		if sc.AppArmorProfile.LocalhostProfile != nil {
+			errs = errs.Also(validate(*sc.AppArmorProfile.LocalhostProfile).ViaField("localhostProfile").ViaField("appArmorProfile"))
+		}
+	}
 	return errs
 }
 
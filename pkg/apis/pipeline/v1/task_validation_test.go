@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 )
 
@@ -243,6 +244,53 @@ func TestTaskSpecValidate(t *testing.T) {
 				WorkingDir: "/foo/bar/src/",
 			}},
 		},
+	}, {
+		name: "valid template variable with fallback value",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+			}, {
+				Name: "tags",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: `$(params.baz | default "ubuntu")`,
+				Args:  []string{`--tag=$(params.tags[0] | default "latest")`},
+			}},
+		},
+	}, {
+		name: "valid template variable in securityContext",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "capability",
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				SecurityContext: &corev1.SecurityContext{
+					Capabilities: &corev1.Capabilities{
+						Add: []corev1.Capability{"$(params.capability)"},
+					},
+				},
+			}},
+		},
+	}, {
+		name: "valid joined array template variable in env",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "list",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Env: []corev1.EnvVar{{
+					Name:  "LIST",
+					Value: "$(params.list[*]:,)",
+				}},
+			}},
+		},
 	}, {
 		name: "valid array template variable",
 		fields: fields{
@@ -526,6 +574,17 @@ func TestTaskSpecValidate(t *testing.T) {
 				hello "$(context.taskRun.namespace)"`,
 			}},
 		},
+	}, {
+		name: "valid taskrun labels and annotations context",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Args:  []string{"$(context.taskRun.labels['tekton.dev/pipeline'])"},
+				Script: `
+				#!/usr/bin/env  bash
+				hello "$(context.taskRun.labels.app) $(context.taskRun.annotations.sha)"`,
+			}},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -796,6 +855,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 		Steps        []v1.Step
 		Volumes      []corev1.Volume
 		StepTemplate *v1.StepTemplate
+		Sidecars     []v1.Sidecar
 		Workspaces   []v1.WorkspaceDeclaration
 		Results      []v1.TaskResult
 	}
@@ -839,6 +899,128 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env bash\n\t\t\t\tdate | tee $(results.non-exist.path)"`,
 			Paths:   []string{"steps[0].script"},
 		},
+	}, {
+		name: "array param used in securityContext",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "caps",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				SecurityContext: &corev1.SecurityContext{
+					Capabilities: &corev1.Capabilities{
+						Add: []corev1.Capability{"$(params.caps)"},
+					},
+				},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.caps)"`,
+			Paths:   []string{"steps[0].securityContext.capabilities.add[0]"},
+		},
+	}, {
+		name: "joined array template variable with empty separator in env",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "list",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Env: []corev1.EnvVar{{
+					Name:  "LIST",
+					Value: "$(params.list[*]:)",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `empty separator in "$(params.list[*]:)"`,
+			Paths:   []string{"steps[0].env[LIST]"},
+		},
+	}, {
+		name: "fallback value for an array param",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "tags",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{`$(params.tags | default "latest")`},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `fallback values are only supported for references to strings in "$(params.tags | default \"latest\")"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "fallback value for an array param in a sidecar",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "tags",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: validSteps,
+			Sidecars: []v1.Sidecar{{
+				Name:  "mysidecar",
+				Image: "myimage",
+				Args:  []string{`$(params.tags | default "latest")`},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `fallback values are only supported for references to strings in "$(params.tags | default \"latest\")"`,
+			Paths:   []string{"sidecars[0].args[0]"},
+		},
+	}, {
+		name: "fallback value for an object param in the step template",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "conf",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"key": {Type: v1.ParamTypeString},
+				},
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+			}},
+			StepTemplate: &v1.StepTemplate{
+				Env: []corev1.EnvVar{{
+					Name:  "CONF",
+					Value: `$(params.conf | default "none")`,
+				}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `fallback values are only supported for references to strings in "$(params.conf | default \"none\")"`,
+			Paths:   []string{"stepTemplate.env[0].value", "steps[0].env[CONF]"},
+		},
+	}, {
+		name: "fallback value for an array param in a volume",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "tags",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: validSteps,
+			Volumes: []corev1.Volume{{
+				Name: "config",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: `$(params.tags | default "cm")`},
+					},
+				},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `fallback values are only supported for references to strings in "$(params.tags | default \"cm\")"`,
+			Paths:   []string{"volumes[0].configMap.name"},
+		},
 	}, {
 		name: "invalid param name format",
 		fields: fields{
@@ -1037,6 +1219,19 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: `expected exactly one, got both`,
 			Paths:   []string{"steps[1].name"},
 		},
+	}, {
+		name: "conflicting step runtime class names",
+		fields: fields{
+			Steps: []v1.Step{
+				{Name: "build", Image: "myimage", RuntimeClassName: ptr.To("gvisor")},
+				{Name: "test", Image: "myimage"},
+				{Name: "push", Image: "myimage", RuntimeClassName: ptr.To("kata")},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `runtimeClassName "kata" conflicts with runtimeClassName "gvisor" requested by another Step, all Steps must request the same runtimeClassName`,
+			Paths:   []string{"steps[2].runtimeClassName"},
+		},
 	}, {
 		name: "array used in a string field",
 		fields: fields{
@@ -1383,6 +1578,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 				Steps:        tt.fields.Steps,
 				Volumes:      tt.fields.Volumes,
 				StepTemplate: tt.fields.StepTemplate,
+				Sidecars:     tt.fields.Sidecars,
 				Workspaces:   tt.fields.Workspaces,
 				Results:      tt.fields.Results,
 			}
@@ -1558,6 +1754,21 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 					}},
 				}},
 			},
+		}, {
+			name:            "parallel steps require alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:  "lint",
+					Image: "foo",
+				}, {
+					Name:  "test",
+					Image: "foo",
+				}},
+				ParallelSteps: []v1.ParallelStepGroup{{
+					Steps: []string{"lint", "test"},
+				}},
+			},
 		},
 	} {
 		for _, version := range versions {
@@ -2278,3 +2489,63 @@ func TestTaskSpecValidate_StepWhen_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskSpecValidate_ParallelSteps_Error(t *testing.T) {
+	steps := []v1.Step{{
+		Name:  "build",
+		Image: "my-image",
+	}, {
+		Name:  "lint",
+		Image: "my-image",
+	}, {
+		Name:  "test",
+		Image: "my-image",
+	}}
+	tests := []struct {
+		name          string
+		groups        []v1.ParallelStepGroup
+		expectedError apis.FieldError
+	}{{
+		name:   "group with a single step",
+		groups: []v1.ParallelStepGroup{{Steps: []string{"lint"}}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: a parallel step group must have at least two steps`,
+			Paths:   []string{"parallelSteps[0].steps"},
+		},
+	}, {
+		name:   "nonexistent step",
+		groups: []v1.ParallelStepGroup{{Steps: []string{"lint", "format"}}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: step "format" does not exist`,
+			Paths:   []string{"parallelSteps[0].steps"},
+		},
+	}, {
+		name:   "steps not consecutive",
+		groups: []v1.ParallelStepGroup{{Steps: []string{"build", "test"}}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: the steps of a parallel step group must be consecutive`,
+			Paths:   []string{"parallelSteps[0].steps"},
+		},
+	}, {
+		name:   "step in two groups",
+		groups: []v1.ParallelStepGroup{{Steps: []string{"build", "lint"}}, {Steps: []string{"lint", "test"}}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: step "lint" is listed more than once in the parallel step groups`,
+			Paths:   []string{"parallelSteps[1].steps"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:         steps,
+				ParallelSteps: tt.groups,
+			}
+			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -63,8 +63,21 @@ type TaskRunSpec struct {
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Time after which a retry attempt fails if its pod has not been scheduled to a node,
+	// e.g. because no node has enough resources, instead of waiting for the Timeout.
+	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
+	// +optional
+	SchedulingTimeout *metav1.Duration `json:"schedulingTimeout,omitempty"`
+	// RetryStrategy specifies how long to wait before each retry attempt. Retry attempts start
+	// immediately if it is not set.
+	// +optional
+	RetryStrategy *RetryStrategy `json:"retryStrategy,omitempty"`
 	// PodTemplate holds pod specific configuration
 	PodTemplate *pod.PodTemplate `json:"podTemplate,omitempty"`
+	// PriorityClassName is the name of the PriorityClass of the pod of the TaskRun. It takes
+	// precedence over the priorityClassName of the PodTemplate.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 	// Workspaces is a list of WorkspaceBindings from volumes to workspaces.
 	// +optional
 	// +listType=atomic
@@ -122,6 +135,10 @@ const (
 type TaskRunDebug struct {
 	// +optional
 	Breakpoints *TaskBreakpoints `json:"breakpoints,omitempty"`
+	// Image is mounted read-only at /tekton/debug/tools in the debugged containers,
+	// to provide tools such as editors or network utilities to breakpoint sessions
+	// +optional
+	Image string `json:"image,omitempty"`
 }
 
 // TaskBreakpoints defines the breakpoint config for a particular Task
@@ -133,6 +150,11 @@ type TaskBreakpoints struct {
 	// +optional
 	// +listType=atomic
 	BeforeSteps []string `json:"beforeSteps,omitempty"`
+	// Sidecars lists the names of the sidecars to pause on failure,
+	// a failed sidecar will not exit until the breakpoint is released
+	// +optional
+	// +listType=atomic
+	Sidecars []string `json:"sidecars,omitempty"`
 }
 
 // NeedsDebugOnFailure return true if the TaskRun is configured to debug on failure
@@ -162,6 +184,14 @@ func (trd *TaskRunDebug) NeedsDebug() bool {
 	return trd.NeedsDebugOnFailure() || trd.HaveBeforeSteps()
 }
 
+// SidecarNeedsDebug return true if the sidecar is configured to debug on failure
+func (trd *TaskRunDebug) SidecarNeedsDebug(sidecarName string) bool {
+	if !trd.NeedsDebugOnFailure() {
+		return false
+	}
+	return sets.NewString(trd.Breakpoints.Sidecars...).Has(sidecarName)
+}
+
 // HaveBeforeSteps return true if have any before steps
 func (trd *TaskRunDebug) HaveBeforeSteps() bool {
 	return trd.Breakpoints != nil && len(trd.Breakpoints.BeforeSteps) > 0
@@ -184,6 +214,35 @@ type TaskRunStatus struct {
 	TaskRunStatusFields `json:",inline"`
 }
 
+const (
+	// TaskRunRestartAnnotation is an experimental annotation which, when set to "true" on a
+	// TaskRun, makes the reconciler run it again in place in a fresh pod once it has completed.
+	// The annotation is removed when the TaskRun is restarted.
+	TaskRunRestartAnnotation = "experimental.tekton.dev/restart"
+	// TaskRunRestartGenerationLabel is the label holding the number of times a TaskRun has
+	// been restarted. It is propagated to the pods of the TaskRun like its other labels.
+	TaskRunRestartGenerationLabel = "experimental.tekton.dev/restart-generation"
+)
+
+// TaskRunConditionType is an enum used to store TaskRun custom
+// conditions such as one used in spire results verification
+type TaskRunConditionType string
+
+const (
+	// TaskRunConditionResultsVerified is a Condition Type that indicates that the results were verified by spire
+	TaskRunConditionResultsVerified TaskRunConditionType = "SignedResultsVerified"
+	// TaskRunConditionAffinityAssistantReady is a Condition Type that indicates whether the Affinity Assistant
+	// the pod of the TaskRun waits for is ready
+	TaskRunConditionAffinityAssistantReady TaskRunConditionType = "AffinityAssistantReady"
+	// TaskRunConditionWorkspacePVCBound is a Condition Type that indicates whether the PersistentVolumeClaims
+	// of the workspaces the pod of the TaskRun waits for are bound
+	TaskRunConditionWorkspacePVCBound TaskRunConditionType = "WorkspacePVCBound"
+)
+
+func (t TaskRunConditionType) String() string {
+	return string(t)
+}
+
 // TaskRunReason is an enum used to store all TaskRun reason for
 // the Succeeded condition that are controlled by the TaskRun itself. Failure
 // reasons that emerge from underlying resources are not included here
@@ -212,10 +271,16 @@ const (
 	TaskRunReasonResolvingStepActionRef = "ResolvingStepActionRef"
 	// TaskRunReasonImagePullFailed is the reason set when the step of a task fails due to image not being pulled
 	TaskRunReasonImagePullFailed TaskRunReason = "TaskRunImagePullFailed"
+	// TaskRunReasonResultsVerified is the reason set when the TaskRun results are verified by spire
+	TaskRunReasonResultsVerified TaskRunReason = "TaskRunResultsVerified"
+	// TaskRunReasonsResultsVerificationFailed is the reason set when the TaskRun results are failed to verify by spire
+	TaskRunReasonsResultsVerificationFailed TaskRunReason = "TaskRunResultsVerificationFailed"
 	// TaskRunReasonCreateContainerConfigError is the reason set when the step of a task fails due to config error (e.g., missing ConfigMap or Secret)
 	TaskRunReasonCreateContainerConfigError TaskRunReason = "CreateContainerConfigError"
 	// TaskRunReasonPodCreationFailed is the reason set when the pod backing the TaskRun fails to be created (e.g., CreateContainerError)
 	TaskRunReasonPodCreationFailed TaskRunReason = "PodCreationFailed"
+	// TaskRunReasonPodUnschedulable is the reason set when the pod backing the TaskRun is not scheduled within the scheduling timeout
+	TaskRunReasonPodUnschedulable TaskRunReason = "TaskRunPodUnschedulable"
 	// TaskRunReasonResultLargerThanAllowedLimit is the reason set when one of the results exceeds its maximum allowed limit of 1 KB
 	TaskRunReasonResultLargerThanAllowedLimit TaskRunReason = "TaskRunResultLargerThanAllowedLimit"
 	// TaskRunReasonStopSidecarFailed indicates that the sidecar is not properly stopped.
@@ -234,9 +299,21 @@ const (
 	// TaskRunReasonResourceVerificationFailed indicates that the task fails the trusted resource verification,
 	// it could be the content has changed, signature is invalid or public key is invalid
 	TaskRunReasonResourceVerificationFailed TaskRunReason = "ResourceVerificationFailed"
+	// TaskRunReasonAffinityAssistantReady is the reason set when the Affinity Assistant of the TaskRun is ready
+	TaskRunReasonAffinityAssistantReady TaskRunReason = "AffinityAssistantReady"
+	// TaskRunReasonAffinityAssistantNotReady is the reason set when the pod of the TaskRun waits for its Affinity Assistant
+	TaskRunReasonAffinityAssistantNotReady TaskRunReason = "AffinityAssistantNotReady"
+	// TaskRunReasonWorkspacePVCBound is the reason set when the PersistentVolumeClaims of the workspaces of the TaskRun are bound
+	TaskRunReasonWorkspacePVCBound TaskRunReason = "WorkspacePVCBound"
+	// TaskRunReasonWorkspacePVCNotBound is the reason set when the pod of the TaskRun waits for the
+	// PersistentVolumeClaims of its workspaces to be bound
+	TaskRunReasonWorkspacePVCNotBound TaskRunReason = "WorkspacePVCNotBound"
 	// TaskRunReasonFailureIgnored is the reason set when the Taskrun has failed due to pod execution error and the failure is ignored for the owning PipelineRun.
 	// TaskRuns failed due to reconciler/validation error should not use this reason.
 	TaskRunReasonFailureIgnored TaskRunReason = "FailureIgnored"
+	// TaskRunReasonOOMKilled is the reason set when the TaskRun failed because one of its steps
+	// was killed for running out of memory.
+	TaskRunReasonOOMKilled TaskRunReason = "OOMKilled"
 )
 
 func (t TaskRunReason) String() string {
@@ -283,6 +360,37 @@ func (trs *TaskRunStatus) MarkResourceFailed(reason TaskRunReason, err error) {
 // +listType=atomic
 type RetriesStatus []TaskRunStatus
 
+// RetryStrategy specifies the exponential backoff between the retry attempts of a TaskRun.
+type RetryStrategy struct {
+	// Backoff is the delay before the first retry attempt. It doubles for each subsequent attempt.
+	Backoff metav1.Duration `json:"backoff"`
+	// MaxBackoff caps the delay before a retry attempt.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+	// Jitter randomizes each delay to between half of it and all of it, so that TaskRuns
+	// failing at the same time are not all retried at the same time.
+	// +optional
+	Jitter bool `json:"jitter,omitempty"`
+}
+
+// TaskRunPodDisruption records a node-level disruption of the pod of a TaskRun, e.g. its eviction
+// or the loss of its node, after which the pod was recreated.
+type TaskRunPodDisruption struct {
+	// PodName is the name of the disrupted pod.
+	PodName string `json:"podName"`
+	// NodeName is the name of the node the disrupted pod was scheduled to.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+	// Reason is a brief CamelCase reason for the disruption, e.g. Evicted.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message describing the disruption.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Time is the time at which the disruption was detected.
+	Time metav1.Time `json:"time"`
+}
+
 // TaskRunStatusFields holds the fields of TaskRun's status.  This is defined
 // separately and inlined so that other types can readily consume these fields
 // via duck typing.
@@ -317,11 +425,28 @@ type TaskRunStatusFields struct {
 	// +optional
 	Artifacts *Artifacts `json:"artifacts,omitempty"`
 
+	// WhenFiles are the contents of the files written to the Workspaces of the TaskRun, which the
+	// WhenExpressions of the PipelineTasks following it test.
+	// +optional
+	// +listType=atomic
+	WhenFiles []TaskRunWhenFile `json:"whenFiles,omitempty"`
+
 	// The list has one entry per sidecar in the manifest. Each entry is
 	// represents the imageid of the corresponding sidecar.
 	// +listType=atomic
 	Sidecars []SidecarState `json:"sidecars,omitempty"`
 
+	// RetryTime is the time at which this retry attempt was scheduled to start, after waiting
+	// for the backoff of the RetryStrategy of the TaskRun.
+	// +optional
+	RetryTime *metav1.Time `json:"retryTime,omitempty"`
+
+	// PodDisruptions are the node-level disruptions, e.g. evictions, of the pods of this attempt of
+	// the TaskRun, after which its pod was recreated.
+	// +optional
+	// +listType=atomic
+	PodDisruptions []TaskRunPodDisruption `json:"podDisruptions,omitempty"`
+
 	// TaskSpec contains the Spec from the dereferenced Task definition used to instantiate this TaskRun.
 	TaskSpec *TaskSpec `json:"taskSpec,omitempty"`
 
@@ -401,6 +526,20 @@ type StepState struct {
 	TerminationReason     string                `json:"terminationReason,omitempty"`
 	Inputs                []TaskRunStepArtifact `json:"inputs,omitempty"`
 	Outputs               []TaskRunStepArtifact `json:"outputs,omitempty"`
+	// Trace is the time the Step spent in each phase of its execution, reported when
+	// the TaskRun has the experimental.tekton.dev/debug-trace annotation.
+	// +optional
+	// +listType=atomic
+	Trace []StepTraceSpan `json:"trace,omitempty"`
+}
+
+// StepTraceSpan is the time a Step spent in one phase of its execution, e.g. waiting
+// for the previous Step, executing its command or capturing its results.
+type StepTraceSpan struct {
+	// Name is the name of the phase: wait, exec or results.
+	Name string `json:"name"`
+	// Duration is the time spent in the phase.
+	Duration metav1.Duration `json:"duration"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/strings/slices"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/webhook/resourcesemantics"
@@ -118,6 +119,42 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		errs = errs.Also(apis.ErrInvalidValue(ts.Timeout.Duration.String()+" should be >= 0", "timeout"))
 	}
 
+	if ts.SchedulingTimeout != nil {
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "schedulingTimeout", config.AlphaAPIFields).ViaField("schedulingTimeout"))
+		if ts.SchedulingTimeout.Duration <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(ts.SchedulingTimeout.Duration.String()+" should be > 0", "schedulingTimeout"))
+		}
+	}
+
+	if ts.RetryStrategy != nil {
+		errs = errs.Also(ValidateRetryStrategy(ctx, ts.RetryStrategy).ViaField("retryStrategy"))
+	}
+
+	if ts.PriorityClassName != "" {
+		errs = errs.Also(ValidatePriorityClassName(ctx, ts.PriorityClassName).ViaField("priorityClassName"))
+	}
+
+	return errs
+}
+
+// ValidateRetryStrategy validates the backoff of a RetryStrategy of a TaskRun or a PipelineTask.
+func ValidateRetryStrategy(ctx context.Context, rs *RetryStrategy) (errs *apis.FieldError) {
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "retryStrategy", config.AlphaAPIFields))
+	if rs.Backoff.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(rs.Backoff.Duration.String()+" should be > 0", "backoff"))
+	}
+	if rs.MaxBackoff != nil && rs.MaxBackoff.Duration < rs.Backoff.Duration {
+		errs = errs.Also(apis.ErrInvalidValue(rs.MaxBackoff.Duration.String()+" should be >= backoff", "maxBackoff"))
+	}
+	return errs
+}
+
+// ValidatePriorityClassName validates the PriorityClass requested by a TaskRun, a PipelineTask or a Pipeline.
+func ValidatePriorityClassName(ctx context.Context, name string) (errs *apis.FieldError) {
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "priorityClassName", config.AlphaAPIFields))
+	if e := validation.IsDNS1123Subdomain(name); len(e) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(name, "", strings.Join(e, ", ")))
+	}
 	return errs
 }
 
@@ -288,6 +325,13 @@ func validateDebug(db *TaskRunDebug) (errs *apis.FieldError) {
 		}
 		beforeSteps.Insert(step)
 	}
+	sidecars := sets.NewString()
+	for i, sidecar := range db.Breakpoints.Sidecars {
+		if sidecars.Has(sidecar) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("sidecar must be unique, the same sidecar: %s is defined multiple times at", sidecar), fmt.Sprintf("breakpoints.sidecars[%d]", i)))
+		}
+		sidecars.Insert(sidecar)
+	}
 	return errs
 }
 
@@ -32,6 +32,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	corev1resources "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/ptr"
@@ -597,6 +598,82 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			Timeout: &metav1.Duration{Duration: -48 * time.Hour},
 		},
 		wantErr: apis.ErrInvalidValue("-48h0m0s should be >= 0", "timeout"),
+	}, {
+		name: "non-positive scheduling timeout",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{
+				Name: "taskrefname",
+			},
+			SchedulingTimeout: &metav1.Duration{Duration: -5 * time.Minute},
+		},
+		wantErr: apis.ErrInvalidValue("-5m0s should be > 0", "schedulingTimeout"),
+		wc:      cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "scheduling timeout without alpha api fields",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{
+				Name: "taskrefname",
+			},
+			SchedulingTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+		},
+		wantErr: apis.ErrGeneric("schedulingTimeout requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\""),
+		wc:      cfgtesting.EnableBetaAPIFields,
+	}, {
+		name: "invalid retry strategy",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{
+				Name: "taskrefname",
+			},
+			Retries: 3,
+			RetryStrategy: &v1.RetryStrategy{
+				Backoff:    metav1.Duration{Duration: time.Minute},
+				MaxBackoff: &metav1.Duration{Duration: 10 * time.Second},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("10s should be >= backoff", "retryStrategy.maxBackoff"),
+		wc:      cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "non-positive retry backoff",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{
+				Name: "taskrefname",
+			},
+			Retries:       3,
+			RetryStrategy: &v1.RetryStrategy{},
+		},
+		wantErr: apis.ErrInvalidValue("0s should be > 0", "retryStrategy.backoff"),
+		wc:      cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "retry strategy without alpha api fields",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{
+				Name: "taskrefname",
+			},
+			Retries:       3,
+			RetryStrategy: &v1.RetryStrategy{Backoff: metav1.Duration{Duration: time.Minute}},
+		},
+		wantErr: apis.ErrGeneric("retryStrategy requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\"").ViaField("retryStrategy"),
+		wc:      cfgtesting.EnableBetaAPIFields,
+	}, {
+		name: "invalid priorityClassName",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{
+				Name: "taskrefname",
+			},
+			PriorityClassName: "Urgent",
+		},
+		wantErr: apis.ErrInvalidValue("Urgent", "priorityClassName", strings.Join(validation.IsDNS1123Subdomain("Urgent"), ", ")),
+		wc:      cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "priorityClassName without alpha api fields",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{
+				Name: "taskrefname",
+			},
+			PriorityClassName: "urgent",
+		},
+		wantErr: apis.ErrGeneric("priorityClassName requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\"").ViaField("priorityClassName"),
+		wc:      cfgtesting.EnableBetaAPIFields,
 	}, {
 		name: "negative pipeline retries",
 		spec: v1.TaskRunSpec{
@@ -722,6 +799,21 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 		},
 		wantErr: apis.ErrGeneric("before step must be unique, the same step: step-1 is defined multiple times at", "debug.breakpoints.beforeSteps[1]"),
 		wc:      cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "invalid breakpoint duplicate sidecars",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{
+				Name: "my-task",
+			},
+			Debug: &v1.TaskRunDebug{
+				Breakpoints: &v1.TaskBreakpoints{
+					Sidecars:  []string{"sidecar-1", "sidecar-1"},
+					OnFailure: "enabled",
+				},
+			},
+		},
+		wantErr: apis.ErrGeneric("sidecar must be unique, the same sidecar: sidecar-1 is defined multiple times at", "debug.breakpoints.sidecars[1]"),
+		wc:      cfgtesting.EnableAlphaAPIFields,
 	}, {
 		name: "empty onFailure breakpoint",
 		spec: v1.TaskRunSpec{
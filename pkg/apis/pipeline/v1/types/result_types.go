@@ -88,6 +88,16 @@ const (
 	ResultsTypeString ResultsType = "string"
 	ResultsTypeArray  ResultsType = "array"
 	ResultsTypeObject ResultsType = "object"
+	// ResultsTypeFile is the type of the results whose content is uploaded to an object store,
+	// their value being an object holding the URI and the digest of the upload.
+	ResultsTypeFile ResultsType = "file"
+)
+
+const (
+	// FileResultURIKey is the key of the URI of the upload in the value of a result of type file.
+	FileResultURIKey = "uri"
+	// FileResultDigestKey is the key of the digest of the upload in the value of a result of type file.
+	FileResultDigestKey = "digest"
 )
 
 // AllResultsTypes can be used for ResultsTypes validation.
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+)
+
+// whenExpressionCELEnv returns the CEL environment the CEL expressions of WhenExpressions and of
+// Matrix Include and Exclude entries are compiled in. On top of the standard functions and macros of
+// CEL, it provides the string, list and set extension libraries and the semver functions.
+var whenExpressionCELEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		ext.Strings(),
+		ext.Lists(),
+		ext.Sets(),
+		cel.Function("semver.isValid",
+			cel.Overload("semver_is_valid_string", []*cel.Type{cel.StringType}, cel.BoolType,
+				cel.UnaryBinding(semverIsValid))),
+		cel.Function("semver.compare",
+			cel.Overload("semver_compare_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.IntType,
+				cel.BinaryBinding(semverCompare))),
+		cel.Function("semver.major",
+			cel.Overload("semver_major_string", []*cel.Type{cel.StringType}, cel.IntType,
+				cel.UnaryBinding(semverPart(func(v semver.Version) uint64 { return v.Major })))),
+		cel.Function("semver.minor",
+			cel.Overload("semver_minor_string", []*cel.Type{cel.StringType}, cel.IntType,
+				cel.UnaryBinding(semverPart(func(v semver.Version) uint64 { return v.Minor })))),
+		cel.Function("semver.patch",
+			cel.Overload("semver_patch_string", []*cel.Type{cel.StringType}, cel.IntType,
+				cel.UnaryBinding(semverPart(func(v semver.Version) uint64 { return v.Patch })))),
+	)
+})
+
+// CompileWhenExpressionCEL parses and checks the CEL expression of a WhenExpression, or of a Matrix
+// Include or Exclude entry. The calls of functions that are not available in the environment are
+// reported by name, before the expression is type-checked.
+func CompileWhenExpressionCEL(expression string) (*cel.Ast, error) {
+	env, err := whenExpressionCELEnv()
+	if err != nil {
+		return nil, err
+	}
+	parsed, iss := env.Parse(expression)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	if unsupported := unsupportedFunctions(env, parsed); len(unsupported) != 0 {
+		return nil, fmt.Errorf("unsupported function(s): %s", strings.Join(unsupported, ", "))
+	}
+	checked, iss := env.Check(parsed)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	return checked, nil
+}
+
+// NewWhenExpressionCELProgram compiles the CEL expression of a WhenExpression, or of a Matrix Include
+// or Exclude entry, into a program that can be evaluated.
+func NewWhenExpressionCELProgram(expression string) (cel.Program, error) {
+	checked, err := CompileWhenExpressionCEL(expression)
+	if err != nil {
+		return nil, err
+	}
+	env, err := whenExpressionCELEnv()
+	if err != nil {
+		return nil, err
+	}
+	return env.Program(checked)
+}
+
+// unsupportedFunctions returns the names of the functions called by the parsed expression that are
+// not declared in the environment. A call on an identifier, e.g. semver.compare(...), is supported
+// if either the qualified name or the name of the member function is declared.
+func unsupportedFunctions(env *cel.Env, parsed *cel.Ast) []string {
+	declared := env.Functions()
+	var unsupported []string
+	ast.PreOrderVisit(parsed.NativeRep().Expr(), ast.NewExprVisitor(func(e ast.Expr) {
+		if e.Kind() != ast.CallKind {
+			return
+		}
+		call := e.AsCall()
+		name := call.FunctionName()
+		if _, ok := declared[name]; ok {
+			return
+		}
+		if call.IsMemberFunction() && call.Target().Kind() == ast.IdentKind {
+			name = call.Target().AsIdent() + "." + name
+			if _, ok := declared[name]; ok {
+				return
+			}
+		}
+		if !slices.Contains(unsupported, name) {
+			unsupported = append(unsupported, name)
+		}
+	}))
+	return unsupported
+}
+
+func parseSemver(val ref.Val) (semver.Version, ref.Val) {
+	s, ok := val.(types.String)
+	if !ok {
+		return semver.Version{}, types.MaybeNoSuchOverloadErr(val)
+	}
+	v, err := semver.ParseTolerant(string(s))
+	if err != nil {
+		return semver.Version{}, types.NewErr("invalid semantic version %q: %v", string(s), err)
+	}
+	return v, nil
+}
+
+func semverIsValid(val ref.Val) ref.Val {
+	if _, errVal := parseSemver(val); errVal != nil {
+		if _, ok := val.(types.String); !ok {
+			return errVal
+		}
+		return types.False
+	}
+	return types.True
+}
+
+func semverCompare(lhs, rhs ref.Val) ref.Val {
+	l, errVal := parseSemver(lhs)
+	if errVal != nil {
+		return errVal
+	}
+	r, errVal := parseSemver(rhs)
+	if errVal != nil {
+		return errVal
+	}
+	return types.Int(l.Compare(r))
+}
+
+func semverPart(part func(semver.Version) uint64) func(ref.Val) ref.Val {
+	return func(val ref.Val) ref.Val {
+		v, errVal := parseSemver(val)
+		if errVal != nil {
+			return errVal
+		}
+		return types.Int(part(v))
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1/types"
+)
+
+func TestNewWhenExpressionCELProgram(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		expression string
+		want       bool
+	}{{
+		name:       "standard macro",
+		expression: "['main', 'release'].exists(b, b == 'main')",
+		want:       true,
+	}, {
+		name:       "extension function in macro",
+		expression: "['Main', 'MAIN'].all(b, b.lowerAscii() == 'main')",
+		want:       true,
+	}, {
+		name:       "string extension",
+		expression: "'Release/v1.2'.lowerAscii().startsWith('release/')",
+		want:       true,
+	}, {
+		name:       "string extension with index",
+		expression: "'a,b,c'.split(',').size() == 3 && 'abc'.indexOf('c') == 2",
+		want:       true,
+	}, {
+		name:       "list extension",
+		expression: "[3, 1, 2, 1].distinct().sort() == [1, 2, 3]",
+		want:       true,
+	}, {
+		name:       "set extension",
+		expression: "sets.contains(['linux', 'darwin', 'windows'], ['linux'])",
+		want:       true,
+	}, {
+		name:       "semver compare",
+		expression: "semver.compare('v1.10.0', '1.9.3') > 0",
+		want:       true,
+	}, {
+		name:       "semver compare equal",
+		expression: "semver.compare('1.2', '1.2.0') == 0",
+		want:       true,
+	}, {
+		name:       "semver parts",
+		expression: "semver.major('2.3.4') == 2 && semver.minor('2.3.4') == 3 && semver.patch('2.3.4') == 4",
+		want:       true,
+	}, {
+		name:       "semver is valid",
+		expression: "semver.isValid('not-a-version')",
+		want:       false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prg, err := types.NewWhenExpressionCELProgram(tc.expression)
+			if err != nil {
+				t.Fatalf("NewWhenExpressionCELProgram() = %v", err)
+			}
+			out, _, err := prg.Eval(map[string]interface{}{})
+			if err != nil {
+				t.Fatalf("Eval() = %v", err)
+			}
+			if got := out.Value(); got != tc.want {
+				t.Errorf("Eval() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewWhenExpressionCELProgram_EvaluationError(t *testing.T) {
+	prg, err := types.NewWhenExpressionCELProgram("semver.compare('latest', '1.0.0') > 0")
+	if err != nil {
+		t.Fatalf("NewWhenExpressionCELProgram() = %v", err)
+	}
+	if _, _, err := prg.Eval(map[string]interface{}{}); err == nil || !strings.Contains(err.Error(), `invalid semantic version "latest"`) {
+		t.Errorf("Eval() = %v, want an invalid semantic version error", err)
+	}
+}
+
+func TestCompileWhenExpressionCEL_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		expression string
+		wantErr    string
+	}{{
+		name:       "unsupported function",
+		expression: "'foo'.shout() == 'FOO!'",
+		wantErr:    "unsupported function(s): shout",
+	}, {
+		name:       "unsupported namespaced function",
+		expression: "semver.newest('1.0.0', '2.0.0') == '2.0.0' && math.sqrt(4) == 2.0",
+		wantErr:    "unsupported function(s): semver.newest, math.sqrt",
+	}, {
+		name:       "wrong argument type",
+		expression: "semver.major(1) == 1",
+		wantErr:    "found no matching overload for 'semver.major'",
+	}, {
+		name:       "syntax error",
+		expression: "$(params.foo) == 'foo'",
+		wantErr:    "Syntax error",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := types.CompileWhenExpressionCEL(tc.expression)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("CompileWhenExpressionCEL() = %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -43,6 +43,35 @@ type WhenExpression struct {
 	// More info about CEL syntax: https://github.com/google/cel-spec/blob/master/doc/langdef.md
 	// +optional
 	CEL string `json:"cel,omitempty"`
+
+	// File is a small file written to a Workspace by a previous PipelineTask. When it is set, the
+	// content of the file is compared against the Values in place of the Input.
+	// +optional
+	File *WhenFile `json:"file,omitempty"`
+}
+
+// WhenFile is a file written to a Workspace by a PipelineTask, whose content a WhenExpression tests
+type WhenFile struct {
+	// Task is the name of the PipelineTask writing the file
+	Task string `json:"task"`
+
+	// Workspace is the name of the Workspace of the PipelineTask the file is written to
+	Workspace string `json:"workspace"`
+
+	// Path is the path of the file, relative to the root of the Workspace
+	Path string `json:"path"`
+}
+
+// TaskRunWhenFile is the content of a file written to a Workspace by a TaskRun, which a WhenExpression tests
+type TaskRunWhenFile struct {
+	// Workspace is the name of the Workspace the file is written to
+	Workspace string `json:"workspace"`
+
+	// Path is the path of the file, relative to the root of the Workspace
+	Path string `json:"path"`
+
+	// Content is the content of the file
+	Content string `json:"content"`
 }
 
 func (we *WhenExpression) isInputInValues() bool {
@@ -81,7 +110,7 @@ func (we *WhenExpression) applyReplacements(replacements map[string]string, arra
 		}
 	}
 
-	return WhenExpression{Input: replacedInput, Operator: we.Operator, Values: replacedValues, CEL: replacedCEL}
+	return WhenExpression{Input: replacedInput, Operator: we.Operator, Values: replacedValues, CEL: replacedCEL, File: we.File}
 }
 
 func applyReplacementsAsString(s string, replacements map[string]string, arrayReplacements map[string][]string) string {
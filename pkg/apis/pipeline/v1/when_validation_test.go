@@ -17,9 +17,11 @@ limitations under the License.
 package v1
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	"k8s.io/apimachinery/pkg/selection"
 )
 
@@ -56,7 +58,7 @@ func TestWhenExpressions_Valid(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if err := tt.wes.validate(t.Context()); err != nil {
-				t.Errorf("WhenExpressions.validate() returned an error for valid when expressions: %s", tt.wes)
+				t.Errorf("WhenExpressions.validate() returned an error for valid when expressions: %v", tt.wes)
 			}
 		})
 	}
@@ -99,7 +101,7 @@ func TestWhenExpressions_Invalid(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if err := tt.wes.validate(t.Context()); err == nil {
-				t.Errorf("WhenExpressions.validate() did not return error for invalid when expressions: %s, %s", tt.wes, err)
+				t.Errorf("WhenExpressions.validate() did not return error for invalid when expressions: %v, %s", tt.wes, err)
 			}
 		})
 	}
@@ -144,11 +146,26 @@ func TestCELinWhenExpressions_Valid(t *testing.T) {
 		wes: []WhenExpression{{
 			CEL: "'$(params.foo)' in ['foo', 'bar']",
 		}},
+	}, {
+		name: "valid string extension function",
+		wes: []WhenExpression{{
+			CEL: "'$(params.branch)'.lowerAscii().split('/')[0] == 'release'",
+		}},
+	}, {
+		name: "valid list extension function",
+		wes: []WhenExpression{{
+			CEL: "['b', 'a', 'b'].distinct().sort() == ['a', 'b']",
+		}},
+	}, {
+		name: "valid semver function",
+		wes: []WhenExpression{{
+			CEL: "semver.compare('$(params.version)', '1.2.0') >= 0",
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if err := tt.wes.validate(ctx); err != nil {
-				t.Errorf("WhenExpressions.validate() returned an error: %s for valid when expressions: %s", err, tt.wes)
+				t.Errorf("WhenExpressions.validate() returned an error: %s for valid when expressions: %v", err, tt.wes)
 			}
 		})
 	}
@@ -177,6 +194,12 @@ func TestCELWhenExpressions_Invalid(t *testing.T) {
 			CEL: " params.foo == 'foo' ",
 		}},
 		enableCELInWhenExpression: true,
+	}, {
+		name: "unsupported function",
+		wes: []WhenExpression{{
+			CEL: " 'foo'.shout() == 'FOO!' ",
+		}},
+		enableCELInWhenExpression: true,
 	}, {
 		name: "CEL should not coexist with input+operator+values",
 		wes: []WhenExpression{{
@@ -195,7 +218,86 @@ func TestCELWhenExpressions_Invalid(t *testing.T) {
 				},
 			})
 			if err := tt.wes.validate(ctx); err == nil {
-				t.Errorf("WhenExpressions.validate() did not return error for invalid when expressions: %s", tt.wes)
+				t.Errorf("WhenExpressions.validate() did not return error for invalid when expressions: %v", tt.wes)
+			}
+		})
+	}
+}
+
+func TestFileInWhenExpressions_Valid(t *testing.T) {
+	wes := WhenExpressions{{
+		File:     &WhenFile{Task: "diff", Workspace: "source", Path: "changes/summary.txt"},
+		Operator: selection.NotIn,
+		Values:   []string{""},
+	}}
+	if err := wes.validate(cfgtesting.EnableAlphaAPIFields(t.Context())); err != nil {
+		t.Errorf("WhenExpressions.validate() returned an error: %s for valid when expressions: %v", err, wes)
+	}
+}
+
+func TestFileInWhenExpressions_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		wes         WhenExpressions
+		alpha       bool
+		expectedErr string
+	}{{
+		name: "alpha api fields not enabled",
+		wes: []WhenExpression{{
+			File:     &WhenFile{Task: "diff", Workspace: "source", Path: "summary.txt"},
+			Operator: selection.In,
+			Values:   []string{"changed"},
+		}},
+		expectedErr: `when file requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+	}, {
+		name: "file should not coexist with input",
+		wes: []WhenExpression{{
+			File:     &WhenFile{Task: "diff", Workspace: "source", Path: "summary.txt"},
+			Input:    "foo",
+			Operator: selection.In,
+			Values:   []string{"changed"},
+		}},
+		alpha:       true,
+		expectedErr: "file and cel or input cannot be set in one WhenExpression",
+	}, {
+		name: "missing fields",
+		wes: []WhenExpression{{
+			File:     &WhenFile{},
+			Operator: selection.In,
+			Values:   []string{"changed"},
+		}},
+		alpha:       true,
+		expectedErr: "missing field(s): when[0].file.path, when[0].file.task, when[0].file.workspace",
+	}, {
+		name: "path outside of the workspace",
+		wes: []WhenExpression{{
+			File:     &WhenFile{Task: "diff", Workspace: "source", Path: "../summary.txt"},
+			Operator: selection.In,
+			Values:   []string{"changed"},
+		}},
+		alpha:       true,
+		expectedErr: `invalid value: "../summary.txt" must be a path relative to the root of the workspace: when[0].file.path`,
+	}, {
+		name: "missing values",
+		wes: []WhenExpression{{
+			File:     &WhenFile{Task: "diff", Workspace: "source", Path: "summary.txt"},
+			Operator: selection.In,
+		}},
+		alpha:       true,
+		expectedErr: "invalid value: expecting non-empty values field: when[0]",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tt.alpha {
+				ctx = cfgtesting.EnableAlphaAPIFields(ctx)
+			}
+			err := tt.wes.validate(ctx)
+			if err == nil {
+				t.Fatalf("WhenExpressions.validate() did not return error for invalid when expressions: %v", tt.wes)
+			}
+			if !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("WhenExpressions.validate() returned error %q, expected it to contain %q", err.Error(), tt.expectedErr)
 			}
 		})
 	}
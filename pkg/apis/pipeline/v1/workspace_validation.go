@@ -18,8 +18,11 @@ package v1
 
 import (
 	"context"
+	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"knative.dev/pkg/apis"
 )
 
@@ -80,6 +83,74 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 
+	// For an ObjectStorage to work, you must provide a supported URL and the image used to transfer its contents.
+	if b.ObjectStorage != nil {
+		if err := config.ValidateEnabledAPIFields(ctx, "objectStorage", config.AlphaAPIFields); err != nil {
+			return err
+		}
+		if b.ObjectStorage.URL == "" {
+			return apis.ErrMissingField("objectStorage.url")
+		}
+		if !strings.HasPrefix(b.ObjectStorage.URL, "s3://") && !strings.HasPrefix(b.ObjectStorage.URL, "gs://") {
+			return apis.ErrInvalidValue(b.ObjectStorage.URL, "objectStorage.url", `must start with "s3://" or "gs://"`)
+		}
+		if b.ObjectStorage.Image == "" {
+			return apis.ErrMissingField("objectStorage.image")
+		}
+	}
+
+	// For an Ephemeral volume to work, you must provide a valid size, unless it is set from a parameter.
+	if b.Ephemeral != nil {
+		if err := config.ValidateEnabledAPIFields(ctx, "ephemeral", config.AlphaAPIFields); err != nil {
+			return err
+		}
+		if b.Ephemeral.Size == "" {
+			return apis.ErrMissingField("ephemeral.size")
+		}
+		if !strings.Contains(b.Ephemeral.Size, "$(") {
+			if size, err := resource.ParseQuantity(b.Ephemeral.Size); err != nil || size.Sign() <= 0 {
+				return apis.ErrInvalidValue(b.Ephemeral.Size, "ephemeral.size", "must be a positive quantity")
+			}
+		}
+	}
+
+	// For a Cache to work, you must provide a key and a valid size, unless it is set from a parameter.
+	if b.Cache != nil {
+		if err := config.ValidateEnabledAPIFields(ctx, "cache", config.AlphaAPIFields); err != nil {
+			return err
+		}
+		if b.Cache.Key == "" {
+			return apis.ErrMissingField("cache.key")
+		}
+		if b.Cache.Size == "" {
+			return apis.ErrMissingField("cache.size")
+		}
+		if !strings.Contains(b.Cache.Size, "$(") {
+			if size, err := resource.ParseQuantity(b.Cache.Size); err != nil || size.Sign() <= 0 {
+				return apis.ErrInvalidValue(b.Cache.Size, "cache.size", "must be a positive quantity")
+			}
+		}
+		if b.Cache.TTL != nil && b.Cache.TTL.Duration <= 0 {
+			return apis.ErrInvalidValue(b.Cache.TTL.Duration.String(), "cache.ttl", "must be > 0")
+		}
+	}
+
+	// For a Snapshot to work, the workspace must be an EmptyDir and you must provide a valid size.
+	if b.Snapshot != nil {
+		if err := config.ValidateEnabledAPIFields(ctx, "snapshot", config.AlphaAPIFields); err != nil {
+			return err
+		}
+		if b.EmptyDir == nil {
+			return apis.ErrGeneric("snapshot can only be used with an emptyDir", "snapshot")
+		}
+		if b.Snapshot.Size == "" {
+			return apis.ErrMissingField("snapshot.size")
+		}
+		if size, err := resource.ParseQuantity(b.Snapshot.Size); err != nil || size.Sign() <= 0 {
+			return apis.ErrInvalidValue(b.Snapshot.Size, "snapshot.size", "must be a positive quantity")
+		}
+	}
+
 	return nil
 }
 
@@ -108,5 +179,14 @@ func (b *WorkspaceBinding) numSources() int {
 	if b.CSI != nil {
 		n++
 	}
+	if b.ObjectStorage != nil {
+		n++
+	}
+	if b.Ephemeral != nil {
+		n++
+	}
+	if b.Cache != nil {
+		n++
+	}
 	return n
 }
@@ -19,9 +19,12 @@ package v1_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -110,6 +113,59 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 				Driver: "my-csi",
 			},
 		},
+	}, {
+		name: "Valid objectStorage",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			ObjectStorage: &v1.ObjectStorageWorkspaceSource{
+				URL:        "gs://my-bucket/path",
+				Image:      "google/cloud-sdk",
+				SecretName: "gcs-credentials",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Valid ephemeral",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Ephemeral: &v1.EphemeralWorkspaceSource{
+				Size:             "1Gi",
+				StorageClassName: "fast",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Valid ephemeral with size from a param",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Ephemeral: &v1.EphemeralWorkspaceSource{
+				Size:             "$(params.size)",
+				StorageClassName: "$(params.storage-class)",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Valid cache",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Cache: &v1.CacheWorkspaceSource{
+				Key:  "go-$(params.go-version)",
+				Size: "1Gi",
+				TTL:  &metav1.Duration{Duration: 24 * time.Hour},
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Valid emptyDir with a snapshot",
+		binding: &v1.WorkspaceBinding{
+			Name:     "beth",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+			Snapshot: &v1.WorkspaceSnapshot{
+				Size:             "1Gi",
+				StorageClassName: "fast",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := t.Context()
@@ -179,6 +235,121 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 			},
 		},
 		wc: cfgtesting.EnableBetaAPIFields,
+	}, {
+		name: "Provide objectStorage without a url",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			ObjectStorage: &v1.ObjectStorageWorkspaceSource{
+				Image: "amazon/aws-cli",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide objectStorage with an unsupported url",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			ObjectStorage: &v1.ObjectStorageWorkspaceSource{
+				URL:   "https://my-bucket/path",
+				Image: "amazon/aws-cli",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide objectStorage without an image",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			ObjectStorage: &v1.ObjectStorageWorkspaceSource{
+				URL: "s3://my-bucket/path",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide ephemeral without a size",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Ephemeral: &v1.EphemeralWorkspaceSource{
+				StorageClassName: "fast",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide ephemeral with an invalid size",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Ephemeral: &v1.EphemeralWorkspaceSource{
+				Size: "lots",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide ephemeral with a negative size",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Ephemeral: &v1.EphemeralWorkspaceSource{
+				Size: "-1Gi",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide cache without a key",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Cache: &v1.CacheWorkspaceSource{
+				Size: "1Gi",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide cache with an invalid size",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Cache: &v1.CacheWorkspaceSource{
+				Key:  "deps",
+				Size: "lots",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide cache with a non-positive ttl",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Cache: &v1.CacheWorkspaceSource{
+				Key:  "deps",
+				Size: "1Gi",
+				TTL:  &metav1.Duration{},
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide snapshot without an emptyDir",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "pool-party",
+			},
+			Snapshot: &v1.WorkspaceSnapshot{
+				Size: "1Gi",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide snapshot without a size",
+		binding: &v1.WorkspaceBinding{
+			Name:     "beth",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+			Snapshot: &v1.WorkspaceSnapshot{},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide snapshot with an invalid size",
+		binding: &v1.WorkspaceBinding{
+			Name:     "beth",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+			Snapshot: &v1.WorkspaceSnapshot{
+				Size: "lots",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := t.Context()
@@ -191,3 +362,63 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaceBindingValidateAlphaAPIFields(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		binding *v1.WorkspaceBinding
+		wantErr string
+	}{{
+		name: "objectStorage requires alpha",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			ObjectStorage: &v1.ObjectStorageWorkspaceSource{
+				URL:   "s3://my-bucket/path",
+				Image: "amazon/aws-cli",
+			},
+		},
+		wantErr: `objectStorage requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+	}, {
+		name: "ephemeral requires alpha",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Ephemeral: &v1.EphemeralWorkspaceSource{
+				Size: "1Gi",
+			},
+		},
+		wantErr: `ephemeral requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+	}, {
+		name: "cache requires alpha",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Cache: &v1.CacheWorkspaceSource{
+				Key:  "deps",
+				Size: "1Gi",
+			},
+		},
+		wantErr: `cache requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+	}, {
+		name: "snapshot requires alpha",
+		binding: &v1.WorkspaceBinding{
+			Name:     "beth",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+			Snapshot: &v1.WorkspaceSnapshot{
+				Size: "1Gi",
+			},
+		},
+		wantErr: `snapshot requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.binding.Validate(cfgtesting.EnableBetaAPIFields(t.Context()))
+			if err == nil {
+				t.Fatalf("expected error %q but got none", tc.wantErr)
+			}
+			if d := cmp.Diff(tc.wantErr, err.Message); d != "" {
+				t.Errorf("WorkspaceBinding.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+			if err := tc.binding.Validate(cfgtesting.EnableAlphaAPIFields(t.Context())); err != nil {
+				t.Errorf("didnt expect error with alpha API fields but got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Defaultable = (*Approval)(nil)
+
+// SetDefaults implements apis.Defaultable
+func (a *Approval) SetDefaults(ctx context.Context) {
+	a.Spec.SetDefaults(apis.WithinSpec(ctx))
+}
+
+// SetDefaults sets the default values of the Approval's spec.
+func (as *ApprovalSpec) SetDefaults(ctx context.Context) {
+	if as.NumberOfApprovalsRequired == 0 {
+		as.NumberOfApprovalsRequired = 1
+	}
+	for i := range as.Approvers {
+		if as.Approvers[i].Input == "" {
+			as.Approvers[i].Input = ApprovalInputPending
+		}
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Approval holds the decisions of the approvers of an approval gate, i.e. a pipeline task
+// referencing the custom task kind Approval. The controller creates an Approval for each
+// CustomRun of an approval gate, and the approvers record their decision in its spec.
+//
+// +k8s:openapi-gen=true
+type Approval struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the approvers of the Approval and their decisions.
+	Spec ApprovalSpec `json:"spec"`
+
+	// Status holds the observed state of the Approval.
+	// +optional
+	Status ApprovalStatus `json:"status,omitempty"`
+}
+
+var _ kmeta.OwnerRefable = (*Approval)(nil)
+
+// ApprovalList contains a list of Approval
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Approval `json:"items"`
+}
+
+// ApprovalSpec defines who can approve an approval gate and what they decided.
+type ApprovalSpec struct {
+	// Description tells the approvers what they are asked to approve.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Approvers are the users, or the groups prefixed with "group:", who can approve the
+	// approval gate, along with their decision.
+	// +listType=atomic
+	Approvers []ApproverDecision `json:"approvers"`
+
+	// NumberOfApprovalsRequired is the number of approvers which must approve the approval
+	// gate for it to succeed. Defaults to 1.
+	// +optional
+	NumberOfApprovalsRequired int `json:"numberOfApprovalsRequired,omitempty"`
+}
+
+// ApproverDecision is the decision of an approver.
+type ApproverDecision struct {
+	// Name is the name of the user, or of the group prefixed with "group:", who can approve.
+	Name string `json:"name"`
+	// Input is the decision of the approver: pending (default), approve or reject.
+	// +optional
+	Input ApprovalInput `json:"input,omitempty"`
+	// Message is an optional comment of the approver on their decision.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ApprovalInput is the decision of an approver.
+type ApprovalInput string
+
+const (
+	// ApprovalInputPending means that the approver has not decided yet.
+	ApprovalInputPending ApprovalInput = "pending"
+	// ApprovalInputApprove means that the approver approves.
+	ApprovalInputApprove ApprovalInput = "approve"
+	// ApprovalInputReject means that the approver rejects.
+	ApprovalInputReject ApprovalInput = "reject"
+)
+
+// ApprovalState is the state of an Approval.
+type ApprovalState string
+
+const (
+	// ApprovalStatePending means that the Approval is waiting for decisions.
+	ApprovalStatePending ApprovalState = "pending"
+	// ApprovalStateApproved means that enough approvers approved.
+	ApprovalStateApproved ApprovalState = "approved"
+	// ApprovalStateRejected means that an approver rejected.
+	ApprovalStateRejected ApprovalState = "rejected"
+)
+
+const (
+	// ApprovalKind is the kind of custom task the approval gates of a Pipeline reference.
+	ApprovalKind = "Approval"
+	// ApprovalGroupPrefix prefixes the approvers which are groups rather than users.
+	ApprovalGroupPrefix = "group:"
+)
+
+// ApprovalStatus defines the observed state of an Approval.
+type ApprovalStatus struct {
+	// State is the state of the Approval: pending, approved or rejected.
+	// +optional
+	State ApprovalState `json:"state,omitempty"`
+
+	// ApprovedBy holds the names of the approvers who approved.
+	// +optional
+	// +listType=atomic
+	ApprovedBy []string `json:"approvedBy,omitempty"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*Approval) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind(pipeline.ApprovalControllerName)
+}
+
+// GetNumberOfApprovalsRequired returns the number of approvers which must approve, or 1 if none is set.
+func (as *ApprovalSpec) GetNumberOfApprovalsRequired() int {
+	if as.NumberOfApprovalsRequired == 0 {
+		return 1
+	}
+	return as.NumberOfApprovalsRequired
+}
+
+// GetState computes the state of the Approval from the decisions of its approvers: it is
+// rejected as soon as an approver rejects, and approved once enough approvers approve.
+func (as *ApprovalSpec) GetState() (ApprovalState, []string) {
+	var approvedBy []string
+	for _, a := range as.Approvers {
+		switch a.Input {
+		case ApprovalInputReject:
+			return ApprovalStateRejected, approvedBy
+		case ApprovalInputApprove:
+			approvedBy = append(approvedBy, a.Name)
+		}
+	}
+	if len(approvedBy) >= as.GetNumberOfApprovalsRequired() {
+		return ApprovalStateApproved, approvedBy
+	}
+	return ApprovalStatePending, approvedBy
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+var (
+	_ apis.Validatable              = (*Approval)(nil)
+	_ resourcesemantics.VerbLimited = (*Approval)(nil)
+)
+
+// SupportedVerbs returns the operations that validation should be called for
+func (a *Approval) SupportedVerbs() []admissionregistrationv1.OperationType {
+	return []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+}
+
+// Validate implements apis.Validatable
+func (a *Approval) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = config.ValidateEnabledAPIFields(ctx, "Approval", config.AlphaAPIFields)
+	errs = errs.Also(validate.ObjectMetadata(a.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(a.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+	errs = errs.Also(a.Spec.ValidateUpdate(ctx).ViaField("spec"))
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (as *ApprovalSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if len(as.Approvers) == 0 {
+		errs = errs.Also(apis.ErrMissingField("approvers"))
+	}
+	names := sets.Set[string]{}
+	for i, a := range as.Approvers {
+		switch {
+		case a.Name == "":
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("approvers", i))
+		case names.Has(a.Name):
+			errs = errs.Also(apis.ErrInvalidValue(a.Name, "name", "approver names must be unique").ViaFieldIndex("approvers", i))
+		}
+		names.Insert(a.Name)
+		switch a.Input {
+		case "", ApprovalInputPending, ApprovalInputApprove, ApprovalInputReject:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("available values are: %s, %s, %s, but got: %s", ApprovalInputPending, ApprovalInputApprove, ApprovalInputReject, a.Input), "input").ViaFieldIndex("approvers", i))
+		}
+	}
+	if as.NumberOfApprovalsRequired < 0 || as.NumberOfApprovalsRequired > len(as.Approvers) {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be between 1 and the number of approvers", as.NumberOfApprovalsRequired), "numberOfApprovalsRequired"))
+	}
+	return errs
+}
+
+// ValidateUpdate validates that an update of an Approval only changes the decisions of the
+// approvers, and that each decision is only changed by the approver it belongs to.
+func (as *ApprovalSpec) ValidateUpdate(ctx context.Context) (errs *apis.FieldError) {
+	if !apis.IsInUpdate(ctx) || apis.IsInStatusUpdate(ctx) {
+		return nil
+	}
+	old, ok := apis.GetBaseline(ctx).(*Approval)
+	if !ok || old == nil || equality.Semantic.DeepEqual(&old.Spec, as) {
+		return nil
+	}
+	if old.Status.State == ApprovalStateApproved || old.Status.State == ApprovalStateRejected {
+		return apis.ErrGeneric(fmt.Sprintf("the Approval is %s and can't be updated anymore", old.Status.State), "approvers")
+	}
+	if as.Description != old.Spec.Description || as.NumberOfApprovalsRequired != old.Spec.NumberOfApprovalsRequired || len(as.Approvers) != len(old.Spec.Approvers) {
+		return apis.ErrGeneric("only the decisions of the approvers can be updated", "approvers")
+	}
+	user := apis.GetUserInfo(ctx)
+	for i, a := range as.Approvers {
+		oldDecision := old.Spec.Approvers[i]
+		if a.Name != oldDecision.Name {
+			errs = errs.Also(apis.ErrInvalidValue(a.Name, "name", "approvers can't be changed").ViaFieldIndex("approvers", i))
+			continue
+		}
+		if a.Input == oldDecision.Input && a.Message == oldDecision.Message {
+			continue
+		}
+		if user == nil {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("the user deciding for approver %s is unknown", a.Name), "input").ViaFieldIndex("approvers", i))
+			continue
+		}
+		if !isApprover(user, a.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%s is not allowed to decide for approver %s", user.Username, a.Name), "input").ViaFieldIndex("approvers", i))
+		}
+	}
+	return errs
+}
+
+// isApprover returns whether the user is the named approver, or a member of the named group.
+func isApprover(user *authenticationv1.UserInfo, name string) bool {
+	if group, ok := strings.CutPrefix(name, ApprovalGroupPrefix); ok {
+		return slices.Contains(user.Groups, group)
+	}
+	return user.Username == name
+}
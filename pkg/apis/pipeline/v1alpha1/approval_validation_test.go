@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func newApproval(inputs ...v1alpha1.ApprovalInput) *v1alpha1.Approval {
+	a := &v1alpha1.Approval{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-approve"},
+		Spec: v1alpha1.ApprovalSpec{
+			Description:               "Deploy to production",
+			NumberOfApprovalsRequired: 1,
+		},
+	}
+	for i, name := range []string{"alice", "group:release-managers"} {
+		a.Spec.Approvers = append(a.Spec.Approvers, v1alpha1.ApproverDecision{Name: name, Input: inputs[i]})
+	}
+	return a
+}
+
+func TestApprovalValidate(t *testing.T) {
+	a := newApproval(v1alpha1.ApprovalInputPending, v1alpha1.ApprovalInputPending)
+	if err := a.Validate(cfgtesting.EnableAlphaAPIFields(t.Context())); err != nil {
+		t.Errorf("Approval.Validate() returned error for valid Approval: %v", err)
+	}
+	if err := a.Validate(t.Context()); err == nil {
+		t.Error("Approval.Validate() did not return an error without alpha features enabled")
+	}
+}
+
+func TestApprovalSpecValidate_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          v1alpha1.ApprovalSpec
+		expectedError apis.FieldError
+	}{{
+		name: "missing approvers",
+		spec: v1alpha1.ApprovalSpec{},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"approvers"},
+		},
+	}, {
+		name: "duplicate approvers",
+		spec: v1alpha1.ApprovalSpec{Approvers: []v1alpha1.ApproverDecision{{Name: "alice"}, {Name: "alice"}}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: alice",
+			Paths:   []string{"approvers[1].name"},
+			Details: "approver names must be unique",
+		},
+	}, {
+		name: "unknown input",
+		spec: v1alpha1.ApprovalSpec{Approvers: []v1alpha1.ApproverDecision{{Name: "alice", Input: "maybe"}}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: available values are: pending, approve, reject, but got: maybe",
+			Paths:   []string{"approvers[0].input"},
+		},
+	}, {
+		name: "more approvals required than approvers",
+		spec: v1alpha1.ApprovalSpec{Approvers: []v1alpha1.ApproverDecision{{Name: "alice"}}, NumberOfApprovalsRequired: 2},
+		expectedError: apis.FieldError{
+			Message: "invalid value: 2 should be between 1 and the number of approvers",
+			Paths:   []string{"numberOfApprovalsRequired"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", tt.spec)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ApprovalSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestApprovalSpecValidateUpdate(t *testing.T) {
+	pending := newApproval(v1alpha1.ApprovalInputPending, v1alpha1.ApprovalInputPending)
+	approved := newApproval(v1alpha1.ApprovalInputApprove, v1alpha1.ApprovalInputPending)
+	approved.Status.State = v1alpha1.ApprovalStateApproved
+	alice := &authenticationv1.UserInfo{Username: "alice"}
+	bob := &authenticationv1.UserInfo{Username: "bob", Groups: []string{"release-managers"}}
+
+	tests := []struct {
+		name    string
+		old     *v1alpha1.Approval
+		new     *v1alpha1.Approval
+		user    *authenticationv1.UserInfo
+		wantErr string
+	}{{
+		name: "approver approves",
+		old:  pending,
+		new:  newApproval(v1alpha1.ApprovalInputApprove, v1alpha1.ApprovalInputPending),
+		user: alice,
+	}, {
+		name: "group member rejects",
+		old:  pending,
+		new:  newApproval(v1alpha1.ApprovalInputPending, v1alpha1.ApprovalInputReject),
+		user: bob,
+	}, {
+		name:    "user decides for another approver",
+		old:     pending,
+		new:     newApproval(v1alpha1.ApprovalInputApprove, v1alpha1.ApprovalInputPending),
+		user:    bob,
+		wantErr: "bob is not allowed to decide for approver alice: approvers[0].input",
+	}, {
+		name:    "decision without user info",
+		old:     pending,
+		new:     newApproval(v1alpha1.ApprovalInputApprove, v1alpha1.ApprovalInputPending),
+		wantErr: "the user deciding for approver alice is unknown: approvers[0].input",
+	}, {
+		name: "approvers are changed",
+		old:  pending,
+		new: func() *v1alpha1.Approval {
+			a := newApproval(v1alpha1.ApprovalInputPending, v1alpha1.ApprovalInputPending)
+			a.Spec.NumberOfApprovalsRequired = 2
+			return a
+		}(),
+		user:    alice,
+		wantErr: "only the decisions of the approvers can be updated: approvers",
+	}, {
+		name:    "decided Approval is updated",
+		old:     approved,
+		new:     newApproval(v1alpha1.ApprovalInputApprove, v1alpha1.ApprovalInputReject),
+		user:    bob,
+		wantErr: "the Approval is approved and can't be updated anymore: approvers",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := apis.WithinUpdate(t.Context(), tt.old)
+			ctx = apis.WithUserInfo(ctx, tt.user)
+			err := tt.new.Spec.ValidateUpdate(ctx)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ApprovalSpec.ValidateUpdate() returned error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected error %q, got nothing", tt.wantErr)
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("ApprovalSpec.ValidateUpdate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Defaultable = (*PipelineSchedule)(nil)
+
+// SetDefaults implements apis.Defaultable
+func (ps *PipelineSchedule) SetDefaults(ctx context.Context) {
+	ps.Spec.SetDefaults(apis.WithinSpec(ctx))
+}
+
+// SetDefaults sets the default values of the PipelineSchedule's spec.
+func (ps *PipelineScheduleSpec) SetDefaults(ctx context.Context) {
+	if ps.ConcurrencyPolicy == "" {
+		ps.ConcurrencyPolicy = AllowConcurrent
+	}
+	if ps.SuccessfulRunsHistoryLimit == nil {
+		limit := DefaultSuccessfulRunsHistoryLimit
+		ps.SuccessfulRunsHistoryLimit = &limit
+	}
+	if ps.FailedRunsHistoryLimit == nil {
+		limit := DefaultFailedRunsHistoryLimit
+		ps.FailedRunsHistoryLimit = &limit
+	}
+}
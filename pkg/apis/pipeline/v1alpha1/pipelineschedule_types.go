@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PipelineSchedule creates PipelineRuns from a template on a cron schedule.
+//
+// +k8s:openapi-gen=true
+type PipelineSchedule struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the PipelineSchedule.
+	Spec PipelineScheduleSpec `json:"spec"`
+
+	// Status holds the observed state of the PipelineSchedule.
+	// +optional
+	Status PipelineScheduleStatus `json:"status,omitempty"`
+}
+
+var _ kmeta.OwnerRefable = (*PipelineSchedule)(nil)
+
+// PipelineScheduleList contains a list of PipelineSchedule
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PipelineScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PipelineSchedule `json:"items"`
+}
+
+// PipelineScheduleSpec defines when PipelineRuns are created and what they look like.
+type PipelineScheduleSpec struct {
+	// Schedule is the cron expression the PipelineRuns are created on, e.g. "0 2 * * *".
+	// Standard five field expressions and descriptors such as "@hourly" are supported.
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the name of the time zone the schedule is interpreted in, e.g. "Europe/Paris".
+	// Defaults to the time zone of the controller, which is UTC in the released images.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// Jitter is the maximum random delay added to each scheduled time, so that PipelineSchedules
+	// sharing the same schedule do not all create their PipelineRuns at once. The delay is stable
+	// for a given PipelineSchedule and scheduled time.
+	// +optional
+	Jitter *metav1.Duration `json:"jitter,omitempty"`
+
+	// ConcurrencyPolicy specifies what happens when a PipelineRun is due while PipelineRuns
+	// previously created by the PipelineSchedule are still running: Allow (default), Forbid or Replace.
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// Suspend stops the creation of new PipelineRuns. It does not affect the PipelineRuns
+	// which are already running.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// SuccessfulRunsHistoryLimit is the number of successful PipelineRuns to keep. Defaults to 3.
+	// +optional
+	SuccessfulRunsHistoryLimit *int32 `json:"successfulRunsHistoryLimit,omitempty"`
+
+	// FailedRunsHistoryLimit is the number of failed PipelineRuns to keep. Defaults to 1.
+	// +optional
+	FailedRunsHistoryLimit *int32 `json:"failedRunsHistoryLimit,omitempty"`
+
+	// PipelineRunTemplate describes the PipelineRuns created by the PipelineSchedule.
+	PipelineRunTemplate PipelineRunTemplate `json:"pipelineRunTemplate"`
+}
+
+// PipelineRunTemplate describes the PipelineRuns created by a PipelineSchedule.
+type PipelineRunTemplate struct {
+	// Metadata holds the labels and annotations added to the PipelineRuns.
+	// +optional
+	Metadata v1.PipelineTaskMetadata `json:"metadata,omitempty"`
+
+	// Spec is the spec of the PipelineRuns.
+	Spec v1.PipelineRunSpec `json:"spec"`
+}
+
+// ConcurrencyPolicy describes how a PipelineSchedule treats PipelineRuns which are due
+// while the PipelineRuns it previously created are still running.
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent lets the PipelineRuns run concurrently.
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// ForbidConcurrent skips the PipelineRun which is due.
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// ReplaceConcurrent cancels the running PipelineRuns and creates the PipelineRun which is due.
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+const (
+	// DefaultSuccessfulRunsHistoryLimit is the number of successful PipelineRuns kept by default.
+	DefaultSuccessfulRunsHistoryLimit int32 = 3
+	// DefaultFailedRunsHistoryLimit is the number of failed PipelineRuns kept by default.
+	DefaultFailedRunsHistoryLimit int32 = 1
+
+	// PipelineScheduleTimeAnnotation is the annotation holding the scheduled time, in RFC 3339
+	// format, of the PipelineRuns created by a PipelineSchedule.
+	PipelineScheduleTimeAnnotation = pipeline.GroupName + "/pipelineScheduleTime"
+)
+
+// PipelineScheduleStatus defines the observed state of a PipelineSchedule.
+type PipelineScheduleStatus struct {
+	duckv1.Status `json:",inline"`
+
+	// PipelineScheduleStatusFields inlines the status fields.
+	PipelineScheduleStatusFields `json:",inline"`
+}
+
+// PipelineScheduleStatusFields holds the fields of PipelineSchedule's status. This is defined
+// separately and inlined so that other types can readily consume these fields via duck typing.
+type PipelineScheduleStatusFields struct {
+	// Active holds the names of the PipelineRuns created by the PipelineSchedule which have not completed yet.
+	// +optional
+	// +listType=atomic
+	Active []string `json:"active,omitempty"`
+
+	// LastScheduleTime is the last time a PipelineRun was due.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is the last time a PipelineRun created by the PipelineSchedule completed successfully.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// NextScheduleTime is the next time a PipelineRun is due, jitter included.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+}
+
+var pipelineScheduleCondSet = apis.NewLivingConditionSet()
+
+// GetConditionSet retrieves the condition set for this resource. Implements
+// the KRShaped interface.
+func (*PipelineSchedule) GetConditionSet() apis.ConditionSet { return pipelineScheduleCondSet }
+
+// GetStatus retrieves the status of the PipelineSchedule. Implements the KRShaped
+// interface.
+func (ps *PipelineSchedule) GetStatus() *duckv1.Status { return &ps.Status.Status }
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*PipelineSchedule) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind(pipeline.PipelineScheduleControllerName)
+}
+
+// GetSchedule parses the cron schedule of the PipelineSchedule, in its time zone.
+func (ps *PipelineScheduleSpec) GetSchedule() (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(ps.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	if ps.TimeZone == nil {
+		return schedule, nil
+	}
+	loc, err := time.LoadLocation(*ps.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: %w", *ps.TimeZone, err)
+	}
+	return &inLocation{Schedule: schedule, loc: loc}, nil
+}
+
+// inLocation evaluates a cron schedule in the given time zone.
+// +k8s:openapi-gen=false
+type inLocation struct {
+	cron.Schedule
+	loc *time.Location
+}
+
+// Next implements cron.Schedule.
+func (s *inLocation) Next(t time.Time) time.Time {
+	return s.Schedule.Next(t.In(s.loc))
+}
+
+// GetConcurrencyPolicy returns the concurrency policy of the PipelineSchedule, or Allow if none is set.
+func (ps *PipelineScheduleSpec) GetConcurrencyPolicy() ConcurrencyPolicy {
+	if ps.ConcurrencyPolicy == "" {
+		return AllowConcurrent
+	}
+	return ps.ConcurrencyPolicy
+}
+
+// GetSuccessfulRunsHistoryLimit returns the number of successful PipelineRuns to keep.
+func (ps *PipelineScheduleSpec) GetSuccessfulRunsHistoryLimit() int32 {
+	if ps.SuccessfulRunsHistoryLimit == nil {
+		return DefaultSuccessfulRunsHistoryLimit
+	}
+	return *ps.SuccessfulRunsHistoryLimit
+}
+
+// GetFailedRunsHistoryLimit returns the number of failed PipelineRuns to keep.
+func (ps *PipelineScheduleSpec) GetFailedRunsHistoryLimit() int32 {
+	if ps.FailedRunsHistoryLimit == nil {
+		return DefaultFailedRunsHistoryLimit
+	}
+	return *ps.FailedRunsHistoryLimit
+}
+
+// InitializeConditions will set all conditions in pipelineScheduleCondSet to unknown
+// for the PipelineSchedule.
+func (pss *PipelineScheduleStatus) InitializeConditions() {
+	pipelineScheduleCondSet.Manage(pss).InitializeConditions()
+}
+
+// MarkReady marks the PipelineSchedule as ready to create PipelineRuns.
+func (pss *PipelineScheduleStatus) MarkReady(reason, messageFormat string, messageA ...interface{}) {
+	pipelineScheduleCondSet.Manage(pss).MarkTrueWithReason(apis.ConditionReady, reason, messageFormat, messageA...)
+}
+
+// MarkFailed marks the PipelineSchedule as unable to create PipelineRuns.
+func (pss *PipelineScheduleStatus) MarkFailed(reason, messageFormat string, messageA ...interface{}) {
+	pipelineScheduleCondSet.Manage(pss).MarkFalse(apis.ConditionReady, reason, messageFormat, messageA...)
+}
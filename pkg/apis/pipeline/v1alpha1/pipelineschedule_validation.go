@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+var (
+	_ apis.Validatable              = (*PipelineSchedule)(nil)
+	_ resourcesemantics.VerbLimited = (*PipelineSchedule)(nil)
+)
+
+// SupportedVerbs returns the operations that validation should be called for
+func (ps *PipelineSchedule) SupportedVerbs() []admissionregistrationv1.OperationType {
+	return []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+}
+
+// Validate implements apis.Validatable
+func (ps *PipelineSchedule) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = config.ValidateEnabledAPIFields(ctx, "PipelineSchedule", config.AlphaAPIFields)
+	errs = errs.Also(validate.ObjectMetadata(ps.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(ps.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (ps *PipelineScheduleSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch {
+	case ps.Schedule == "":
+		errs = errs.Also(apis.ErrMissingField("schedule"))
+	case strings.Contains(ps.Schedule, "TZ="):
+		errs = errs.Also(apis.ErrInvalidValue(ps.Schedule, "schedule", "use timeZone to set the time zone of the schedule"))
+	default:
+		if _, err := ps.GetSchedule(); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(ps.Schedule, "schedule", err.Error()))
+		}
+	}
+	if ps.TimeZone != nil && *ps.TimeZone == "" {
+		errs = errs.Also(apis.ErrInvalidValue(*ps.TimeZone, "timeZone", "time zone must not be empty"))
+	}
+	if ps.Jitter != nil && ps.Jitter.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(ps.Jitter.Duration.String(), "jitter", "jitter must not be negative"))
+	}
+	switch ps.ConcurrencyPolicy {
+	case "", AllowConcurrent, ForbidConcurrent, ReplaceConcurrent:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("available values are: %s, %s, %s, but got: %s", AllowConcurrent, ForbidConcurrent, ReplaceConcurrent, ps.ConcurrencyPolicy), "concurrencyPolicy"))
+	}
+	if ps.SuccessfulRunsHistoryLimit != nil && *ps.SuccessfulRunsHistoryLimit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*ps.SuccessfulRunsHistoryLimit, "successfulRunsHistoryLimit", "history limit must not be negative"))
+	}
+	if ps.FailedRunsHistoryLimit != nil && *ps.FailedRunsHistoryLimit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*ps.FailedRunsHistoryLimit, "failedRunsHistoryLimit", "history limit must not be negative"))
+	}
+	errs = errs.Also(ps.PipelineRunTemplate.Spec.Validate(ctx).ViaField("pipelineRunTemplate.spec"))
+	return errs
+}
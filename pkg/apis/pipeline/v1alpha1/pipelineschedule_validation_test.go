@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestPipelineScheduleValidate(t *testing.T) {
+	tz := "Europe/Paris"
+	ps := &v1alpha1.PipelineSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly"},
+		Spec: v1alpha1.PipelineScheduleSpec{
+			Schedule:          "0 2 * * *",
+			TimeZone:          &tz,
+			Jitter:            &metav1.Duration{Duration: 5 * time.Minute},
+			ConcurrencyPolicy: v1alpha1.ForbidConcurrent,
+			PipelineRunTemplate: v1alpha1.PipelineRunTemplate{
+				Spec: v1.PipelineRunSpec{PipelineRef: &v1.PipelineRef{Name: "release"}},
+			},
+		},
+	}
+	if err := ps.Validate(cfgtesting.EnableAlphaAPIFields(t.Context())); err != nil {
+		t.Errorf("PipelineSchedule.Validate() returned error for valid PipelineSchedule: %v", err)
+	}
+	if err := ps.Validate(t.Context()); err == nil {
+		t.Error("PipelineSchedule.Validate() did not return an error without alpha features enabled")
+	}
+}
+
+func TestPipelineScheduleSpecValidate_Invalid(t *testing.T) {
+	emptyTZ, unknownTZ := "", "Mars/Olympus_Mons"
+	negative := int32(-1)
+	template := v1alpha1.PipelineRunTemplate{
+		Spec: v1.PipelineRunSpec{PipelineRef: &v1.PipelineRef{Name: "release"}},
+	}
+	tests := []struct {
+		name          string
+		spec          v1alpha1.PipelineScheduleSpec
+		expectedError apis.FieldError
+	}{{
+		name: "missing schedule",
+		spec: v1alpha1.PipelineScheduleSpec{PipelineRunTemplate: template},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"schedule"},
+		},
+	}, {
+		name: "invalid schedule",
+		spec: v1alpha1.PipelineScheduleSpec{Schedule: "every day", PipelineRunTemplate: template},
+		expectedError: apis.FieldError{
+			Message: `invalid value: every day`,
+			Paths:   []string{"schedule"},
+			Details: "expected exactly 5 fields, found 2: [every day]",
+		},
+	}, {
+		name: "time zone in schedule",
+		spec: v1alpha1.PipelineScheduleSpec{Schedule: "CRON_TZ=UTC 0 2 * * *", PipelineRunTemplate: template},
+		expectedError: apis.FieldError{
+			Message: `invalid value: CRON_TZ=UTC 0 2 * * *`,
+			Paths:   []string{"schedule"},
+			Details: "use timeZone to set the time zone of the schedule",
+		},
+	}, {
+		name: "empty time zone",
+		spec: v1alpha1.PipelineScheduleSpec{Schedule: "0 2 * * *", TimeZone: &emptyTZ, PipelineRunTemplate: template},
+		expectedError: apis.FieldError{
+			Message: `invalid value: `,
+			Paths:   []string{"timeZone"},
+			Details: "time zone must not be empty",
+		},
+	}, {
+		name: "unknown time zone",
+		spec: v1alpha1.PipelineScheduleSpec{Schedule: "0 2 * * *", TimeZone: &unknownTZ, PipelineRunTemplate: template},
+		expectedError: apis.FieldError{
+			Message: `invalid value: 0 2 * * *`,
+			Paths:   []string{"schedule"},
+			Details: `unknown time zone "Mars/Olympus_Mons": unknown time zone Mars/Olympus_Mons`,
+		},
+	}, {
+		name: "negative jitter",
+		spec: v1alpha1.PipelineScheduleSpec{Schedule: "0 2 * * *", Jitter: &metav1.Duration{Duration: -time.Minute}, PipelineRunTemplate: template},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1m0s`,
+			Paths:   []string{"jitter"},
+			Details: "jitter must not be negative",
+		},
+	}, {
+		name: "unknown concurrency policy",
+		spec: v1alpha1.PipelineScheduleSpec{Schedule: "0 2 * * *", ConcurrencyPolicy: "Queue", PipelineRunTemplate: template},
+		expectedError: apis.FieldError{
+			Message: `invalid value: available values are: Allow, Forbid, Replace, but got: Queue`,
+			Paths:   []string{"concurrencyPolicy"},
+		},
+	}, {
+		name: "negative history limit",
+		spec: v1alpha1.PipelineScheduleSpec{Schedule: "0 2 * * *", FailedRunsHistoryLimit: &negative, PipelineRunTemplate: template},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1`,
+			Paths:   []string{"failedRunsHistoryLimit"},
+			Details: "history limit must not be negative",
+		},
+	}, {
+		name: "invalid PipelineRun template",
+		spec: v1alpha1.PipelineScheduleSpec{Schedule: "0 2 * * *"},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got neither`,
+			Paths:   []string{"pipelineRunTemplate.spec.pipelineRef", "pipelineRunTemplate.spec.pipelineSpec"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", tt.spec)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("PipelineScheduleSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineScheduleSetDefaults(t *testing.T) {
+	ps := &v1alpha1.PipelineSchedule{}
+	ps.SetDefaults(t.Context())
+	successful, failed := v1alpha1.DefaultSuccessfulRunsHistoryLimit, v1alpha1.DefaultFailedRunsHistoryLimit
+	want := v1alpha1.PipelineScheduleSpec{
+		ConcurrencyPolicy:          v1alpha1.AllowConcurrent,
+		SuccessfulRunsHistoryLimit: &successful,
+		FailedRunsHistoryLimit:     &failed,
+	}
+	if d := cmp.Diff(want, ps.Spec); d != "" {
+		t.Errorf("PipelineSchedule.SetDefaults() %s", diff.PrintWantGot(d))
+	}
+}
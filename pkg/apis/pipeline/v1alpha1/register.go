@@ -52,6 +52,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VerificationPolicyList{},
 		&StepAction{},
 		&StepActionList{},
+		&PipelineSchedule{},
+		&PipelineScheduleList{},
+		&Approval{},
+		&ApprovalList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
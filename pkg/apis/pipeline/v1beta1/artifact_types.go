@@ -18,6 +18,7 @@ type Artifact struct {
 type ArtifactValue struct {
 	Digest map[Algorithm]string `json:"digest,omitempty"` // Algorithm-specific digests for verifying the content (e.g., SHA256)
 	Uri    string               `json:"uri,omitempty"`    // Location where the artifact value can be retrieved
+	Size   int64                `json:"size,omitempty"`   // Size of the artifact value in bytes, if known
 }
 
 // TaskRunStepArtifact represents an artifact produced or used by a step within a task run.
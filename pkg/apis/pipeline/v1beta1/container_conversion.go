@@ -78,6 +78,10 @@ func (s Step) convertTo(ctx context.Context, sink *v1.Step) {
 		w.convertTo(ctx, &new)
 		sink.When = append(sink.When, new)
 	}
+	sink.ServiceAccountTokens = s.ServiceAccountTokens
+	sink.RuntimeClassName = s.RuntimeClassName
+	sink.PreStop = s.PreStop
+	sink.ImagePullSecrets = s.ImagePullSecrets
 }
 
 func (s *Step) convertFrom(ctx context.Context, source v1.Step) {
@@ -123,6 +127,10 @@ func (s *Step) convertFrom(ctx context.Context, source v1.Step) {
 		new.convertFrom(ctx, w)
 		s.When = append(s.When, new)
 	}
+	s.ServiceAccountTokens = source.ServiceAccountTokens
+	s.RuntimeClassName = source.RuntimeClassName
+	s.PreStop = source.PreStop
+	s.ImagePullSecrets = source.ImagePullSecrets
 }
 
 func (s StepTemplate) convertTo(ctx context.Context, sink *v1.StepTemplate) {
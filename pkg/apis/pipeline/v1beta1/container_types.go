@@ -250,6 +250,32 @@ type Step struct {
 	Results []v1.StepResult `json:"results,omitempty"`
 
 	When StepWhenExpressions `json:"when,omitempty"`
+
+	// ServiceAccountTokens requests projected tokens of the TaskRun's ServiceAccount, e.g. with
+	// specific audiences, which are only mounted into this Step.
+	// +optional
+	// +listType=atomic
+	ServiceAccountTokens []v1.ServiceAccountToken `json:"serviceAccountTokens,omitempty"`
+
+	// RuntimeClassName requests the RuntimeClass, e.g. a sandboxed runtime like gVisor or Kata Containers,
+	// the Step runs with. The RuntimeClass is set on the TaskRun's Pod, so all the Steps requesting a
+	// RuntimeClass must request the same one and the other Steps run with it too.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// PreStop is a handler called before the Step's container is terminated, e.g. when the TaskRun
+	// is cancelled or times out, so that the Step can shut down gracefully or signal the processes
+	// of the Sidecars to do so when the Pod shares its process namespace.
+	// More info: https://kubernetes.io/docs/concepts/containers/container-lifecycle-hooks/#container-hooks
+	// +optional
+	PreStop *corev1.LifecycleHandler `json:"preStop,omitempty"`
+
+	// ImagePullSecrets are additional secrets used to pull the image of the Step and to look up its
+	// entrypoint. They are added to the imagePullSecrets of the TaskRun's Pod, so the kubelet may use
+	// them to pull the images of the other containers of the Pod too.
+	// +optional
+	// +listType=atomic
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 }
 
 // Ref can be used to refer to a specific instance of a StepAction.
@@ -86,6 +86,13 @@ func (ps *PipelineSpec) ConvertTo(ctx context.Context, sink *v1.PipelineSpec, me
 		}
 		sink.Finally = append(sink.Finally, new)
 	}
+	sink.Sidecars = nil
+	for _, sc := range ps.Sidecars {
+		new := v1.PipelineSidecar{}
+		sc.convertTo(ctx, &new)
+		sink.Sidecars = append(sink.Sidecars, new)
+	}
+	sink.PriorityClassName = ps.PriorityClassName
 	return nil
 }
 
@@ -143,6 +150,13 @@ func (ps *PipelineSpec) ConvertFrom(ctx context.Context, source *v1.PipelineSpec
 		}
 		ps.Finally = append(ps.Finally, new)
 	}
+	ps.Sidecars = nil
+	for _, sc := range source.Sidecars {
+		new := PipelineSidecar{}
+		new.convertFrom(ctx, sc)
+		ps.Sidecars = append(ps.Sidecars, new)
+	}
+	ps.PriorityClassName = source.PriorityClassName
 	return nil
 }
 
@@ -169,6 +183,7 @@ func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask, met
 	}
 	sink.OnError = (v1.PipelineTaskOnErrorType)(pt.OnError)
 	sink.Retries = pt.Retries
+	sink.RetryStrategy = pt.RetryStrategy
 	sink.RunAfter = pt.RunAfter
 	sink.Params = nil
 	for _, p := range pt.Params {
@@ -190,6 +205,7 @@ func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask, met
 	}
 
 	sink.Timeout = pt.Timeout
+	sink.PriorityClassName = pt.PriorityClassName
 	return nil
 }
 
@@ -218,6 +234,7 @@ func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask,
 	}
 	pt.OnError = (PipelineTaskOnErrorType)(source.OnError)
 	pt.Retries = source.Retries
+	pt.RetryStrategy = source.RetryStrategy
 	pt.RunAfter = source.RunAfter
 	pt.Params = nil
 	for _, p := range source.Params {
@@ -239,6 +256,7 @@ func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask,
 	}
 
 	pt.Timeout = source.Timeout
+	pt.PriorityClassName = source.PriorityClassName
 	return nil
 }
 
@@ -263,6 +281,9 @@ func (we WhenExpression) convertTo(ctx context.Context, sink *v1.WhenExpression)
 	sink.Operator = we.Operator
 	sink.Values = we.Values
 	sink.CEL = we.CEL
+	if we.File != nil {
+		sink.File = &v1.WhenFile{Task: we.File.Task, Workspace: we.File.Workspace, Path: we.File.Path}
+	}
 }
 
 func (we *WhenExpression) convertFrom(ctx context.Context, source v1.WhenExpression) {
@@ -270,6 +291,9 @@ func (we *WhenExpression) convertFrom(ctx context.Context, source v1.WhenExpress
 	we.Operator = source.Operator
 	we.Values = source.Values
 	we.CEL = source.CEL
+	if source.File != nil {
+		we.File = &WhenFile{Task: source.File.Task, Workspace: source.File.Workspace, Path: source.File.Path}
+	}
 }
 
 func (m *Matrix) convertTo(ctx context.Context, sink *v1.Matrix) {
@@ -279,13 +303,22 @@ func (m *Matrix) convertTo(ctx context.Context, sink *v1.Matrix) {
 		sink.Params = append(sink.Params, new)
 	}
 	for i, include := range m.Include {
-		sink.Include = append(sink.Include, v1.IncludeParams{Name: include.Name})
+		sink.Include = append(sink.Include, v1.IncludeParams{Name: include.Name, CEL: include.CEL})
 		for _, param := range include.Params {
 			newIncludeParam := v1.Param{}
 			param.convertTo(ctx, &newIncludeParam)
 			sink.Include[i].Params = append(sink.Include[i].Params, newIncludeParam)
 		}
 	}
+	for i, exclude := range m.Exclude {
+		sink.Exclude = append(sink.Exclude, v1.ExcludeParams{CEL: exclude.CEL})
+		for _, param := range exclude.Params {
+			newExcludeParam := v1.Param{}
+			param.convertTo(ctx, &newExcludeParam)
+			sink.Exclude[i].Params = append(sink.Exclude[i].Params, newExcludeParam)
+		}
+	}
+	sink.MaxParallel = m.MaxParallel
 }
 
 func (m *Matrix) convertFrom(ctx context.Context, source v1.Matrix) {
@@ -296,13 +329,22 @@ func (m *Matrix) convertFrom(ctx context.Context, source v1.Matrix) {
 	}
 
 	for i, include := range source.Include {
-		m.Include = append(m.Include, IncludeParams{Name: include.Name})
+		m.Include = append(m.Include, IncludeParams{Name: include.Name, CEL: include.CEL})
 		for _, p := range include.Params {
 			new := Param{}
 			new.ConvertFrom(ctx, p)
 			m.Include[i].Params = append(m.Include[i].Params, new)
 		}
 	}
+	for i, exclude := range source.Exclude {
+		m.Exclude = append(m.Exclude, ExcludeParams{CEL: exclude.CEL})
+		for _, p := range exclude.Params {
+			new := Param{}
+			new.ConvertFrom(ctx, p)
+			m.Exclude[i].Params = append(m.Exclude[i].Params, new)
+		}
+	}
+	m.MaxParallel = source.MaxParallel
 }
 
 func (pr PipelineResult) convertTo(ctx context.Context, sink *v1.PipelineResult) {
@@ -312,6 +354,7 @@ func (pr PipelineResult) convertTo(ctx context.Context, sink *v1.PipelineResult)
 	newValue := v1.ParamValue{}
 	pr.Value.convertTo(ctx, &newValue)
 	sink.Value = newValue
+	sink.Expression = pr.Expression
 }
 
 func (pr *PipelineResult) convertFrom(ctx context.Context, source v1.PipelineResult) {
@@ -321,6 +364,29 @@ func (pr *PipelineResult) convertFrom(ctx context.Context, source v1.PipelineRes
 	newValue := ParamValue{}
 	newValue.convertFrom(ctx, source.Value)
 	pr.Value = newValue
+	pr.Expression = source.Expression
+}
+
+func (s PipelineSidecar) convertTo(ctx context.Context, sink *v1.PipelineSidecar) {
+	sink.Name = s.Name
+	sink.Image = s.Image
+	sink.Command = s.Command
+	sink.Args = s.Args
+	sink.Env = s.Env
+	sink.Ports = s.Ports
+	sink.ReadinessProbe = s.ReadinessProbe
+	sink.ComputeResources = s.ComputeResources
+}
+
+func (s *PipelineSidecar) convertFrom(ctx context.Context, source v1.PipelineSidecar) {
+	s.Name = source.Name
+	s.Image = source.Image
+	s.Command = source.Command
+	s.Args = source.Args
+	s.Env = source.Env
+	s.Ports = source.Ports
+	s.ReadinessProbe = source.ReadinessProbe
+	s.ComputeResources = source.ComputeResources
 }
 
 func (ptm PipelineTaskMetadata) convertTo(ctx context.Context, sink *v1.PipelineTaskMetadata) {
@@ -26,8 +26,10 @@ import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/apis"
 )
 
@@ -167,6 +169,10 @@ func TestPipelineConversion(t *testing.T) {
 						Input:    "foo",
 						Operator: selection.In,
 						Values:   []string{"foo", "bar"},
+					}, {
+						File:     &v1beta1.WhenFile{Task: "task-1", Workspace: "source", Path: "summary.txt"},
+						Operator: selection.NotIn,
+						Values:   []string{""},
 					}},
 					Retries:  1,
 					RunAfter: []string{"task-1"},
@@ -191,15 +197,25 @@ func TestPipelineConversion(t *testing.T) {
 								Name: "a-param", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString, StringVal: "$(params.baz)"},
 							}, {
 								Name: "flags", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString, StringVal: "-cover -v"}}},
+							CEL: "'$(params.baz)' == 'baz'",
 						}},
+						Exclude: v1beta1.ExcludeParamsList{{
+							Params: v1beta1.Params{{
+								Name: "a-param", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString, StringVal: "and"},
+							}},
+							CEL: "true",
+						}},
+						MaxParallel: 2,
 					},
 					Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
 						Name:      "my-task-workspace",
 						Workspace: "source",
 					}},
-					Timeout: &metav1.Duration{Duration: 5 * time.Minute},
+					Timeout:           &metav1.Duration{Duration: 5 * time.Minute},
+					PriorityClassName: "urgent-release",
 				},
 				},
+				PriorityClassName: "low-priority",
 				Params: []v1beta1.ParamSpec{{
 					Name:        "param-1",
 					Type:        v1beta1.ParamTypeString,
@@ -226,6 +242,34 @@ func TestPipelineConversion(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "pipeline with sidecars",
+		in: &v1beta1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name:    "task-1",
+					TaskRef: &v1beta1.TaskRef{Name: "foo-task"},
+				}},
+				Sidecars: []v1beta1.PipelineSidecar{{
+					Name:    "db",
+					Image:   "postgres",
+					Command: []string{"docker-entrypoint.sh"},
+					Args:    []string{"postgres"},
+					Env:     []corev1.EnvVar{{Name: "POSTGRES_PASSWORD", Value: "secret"}},
+					Ports:   []corev1.ContainerPort{{ContainerPort: 5432}},
+					ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+						TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(5432)},
+					}},
+					ComputeResources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					}},
+				}},
+			},
+		},
 	}} {
 		t.Run(test.name, func(t *testing.T) {
 			versions := []apis.Convertible{&v1.Pipeline{}}
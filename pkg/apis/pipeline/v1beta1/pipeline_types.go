@@ -19,7 +19,9 @@ package v1beta1
 import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/internal/checksum"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -139,6 +141,50 @@ type PipelineSpec struct {
 	// or after a failure which would result in ending the Pipeline
 	// +listType=atomic
 	Finally []PipelineTask `json:"finally,omitempty"`
+	// Sidecars declares long-lived services, e.g. a test database, which run for the
+	// duration of the PipelineRun and are reachable from all of its TaskRuns.
+	// +optional
+	// +listType=atomic
+	Sidecars []PipelineSidecar `json:"sidecars,omitempty"`
+	// PriorityClassName is the default name of the PriorityClass of the pods of the TaskRuns
+	// created for the tasks of the Pipeline, which is used when a task does not specify its own.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// PipelineSidecar is a service started in its own Pod before the first Task of a
+// PipelineRun and deleted once the PipelineRun is done. Its address is passed to the
+// Steps of the TaskRuns in the TEKTON_SIDECAR_<NAME>_HOST and TEKTON_SIDECAR_<NAME>_PORT
+// environment variables, where <NAME> is the upper-cased name of the sidecar.
+type PipelineSidecar struct {
+	// Name of the sidecar, unique within the Pipeline. It must be a DNS label.
+	Name string `json:"name"`
+	// Image of the sidecar's container.
+	Image string `json:"image"`
+	// Command is the entrypoint array of the sidecar's container.
+	// +optional
+	// +listType=atomic
+	Command []string `json:"command,omitempty"`
+	// Args are the arguments to the entrypoint.
+	// +optional
+	// +listType=atomic
+	Args []string `json:"args,omitempty"`
+	// Env is the list of environment variables to set in the sidecar's container.
+	// +optional
+	// +listType=atomic
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Ports exposed by the sidecar's container. The first one is passed to the TaskRuns
+	// in TEKTON_SIDECAR_<NAME>_PORT.
+	// +optional
+	// +listType=atomic
+	Ports []corev1.ContainerPort `json:"ports,omitempty"`
+	// ReadinessProbe of the sidecar's container. The Tasks of the PipelineRun only start
+	// once all of its sidecars are ready.
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+	// ComputeResources required by the sidecar's container.
+	// +optional
+	ComputeResources corev1.ResourceRequirements `json:"computeResources,omitempty"`
 }
 
 // PipelineResult used to describe the results of a pipeline
@@ -156,9 +202,16 @@ type PipelineResult struct {
 	Description string `json:"description"`
 
 	// Value the expression used to retrieve the value
+	// +optional
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Value ResultValue `json:"value"`
+
+	// Expression is a CEL expression computing the value from the results of several PipelineTasks,
+	// e.g. "sum(tasks.shard.results.failures)", used instead of Value. The results are available as
+	// tasks.<pipelineTaskName>.results.<resultName>, along with the join, sum and max functions.
+	// +optional
+	Expression string `json:"expression,omitempty"`
 }
 
 // PipelineTaskMetadata contains the labels or annotations for an EmbeddedTask
@@ -226,6 +279,10 @@ type PipelineTask struct {
 	// +optional
 	Retries int `json:"retries,omitempty"`
 
+	// RetryStrategy specifies how long to wait before each retry attempt of the task.
+	// +optional
+	RetryStrategy *v1.RetryStrategy `json:"retryStrategy,omitempty"`
+
 	// RunAfter is the list of PipelineTask names that should be executed before
 	// this Task executes. (Used to force a specific ordering in graph execution.)
 	// +optional
@@ -255,6 +312,11 @@ type PipelineTask struct {
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 
+	// PriorityClassName is the name of the PriorityClass of the pod of the TaskRun created for
+	// this task, e.g. to let it preempt pods of lower priority when the cluster is full.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
 	// PipelineRef is a reference to a pipeline definition
 	// Note: PipelineRef is in preview mode and not yet supported
 	// +optional
@@ -308,6 +370,13 @@ func (pt PipelineTask) Deps() []string {
 		deps.Insert(ref.PipelineTask)
 	}
 
+	// add any new dependents from the files tested by when expressions - resource dependency
+	for _, we := range pt.WhenExpressions {
+		if we.File != nil {
+			deps.Insert(we.File.Task)
+		}
+	}
+
 	// add any new dependents from runAfter - order dependency
 	for _, runAfter := range pt.RunAfter {
 		deps.Insert(runAfter)
@@ -3650,6 +3650,15 @@ func TestContextValid(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		name: "valid string context variable for PipelineTask index",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "a-param", Value: ParamValue{StringVal: "$(context.pipelineTask.index)"},
+			}},
+		}},
 	}, {
 		name: "valid array context variable for PipelineTask retries",
 		tasks: []PipelineTask{{
@@ -3899,6 +3908,25 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 				Values:   []string{"Success"},
 			}},
 		}},
+	}, {
+		name: "valid string variable in finally accessing pipelineTask status reason and message",
+		tasks: []PipelineTask{{
+			Name: "foo",
+		}},
+		finalTasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "foo-reason", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.foo.status.reason)"},
+			}, {
+				Name: "foo-message", Value: ParamValue{Type: ParamTypeString, StringVal: "foo failed: $(tasks.foo.status.message)"},
+			}},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.foo.status.reason)",
+				Operator: selection.NotIn,
+				Values:   []string{"Succeeded"},
+			}},
+		}},
 	}, {
 		name: "valid task result reference with status as a variable must not cause validation failure",
 		tasks: []PipelineTask{{
@@ -3955,6 +3983,19 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 			Message: `invalid value: pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks`,
 			Paths:   []string{"tasks[0].params[bar-status].value", "tasks[0].when[0]"},
 		}),
+	}, {
+		name: "invalid string variable in dag task accessing pipelineTask status message",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params: Params{{
+				Name: "bar-message", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.bar.status.message)"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks`,
+			Paths:   []string{"tasks[0].params[bar-message].value"},
+		},
 	}, {
 		name: "invalid string variable in dag task accessing aggregate status of tasks",
 		tasks: []PipelineTask{{
@@ -4039,6 +4080,19 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 			Message: `invalid value: pipeline task notask is not defined in the pipeline`,
 			Paths:   []string{"finally[0].params[notask-status].value"},
 		},
+	}, {
+		name: "invalid string variable in finally accessing missing pipelineTask status reason",
+		finalTasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "notask-reason", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.notask.status.reason)"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline task notask is not defined in the pipeline`,
+			Paths:   []string{"finally[0].params[notask-reason].value"},
+		},
 	}, {
 		name: "invalid string variable in finally accessing missing pipelineTask status in when expression",
 		finalTasks: []PipelineTask{{
@@ -80,6 +80,8 @@ func (prs PipelineRunSpec) ConvertTo(ctx context.Context, sink *v1.PipelineRunSp
 	sink.TaskRunTemplate = v1.PipelineTaskRunTemplate{}
 	sink.TaskRunTemplate.PodTemplate = prs.PodTemplate
 	sink.TaskRunTemplate.ServiceAccountName = prs.ServiceAccountName
+	sink.Concurrency = prs.Concurrency
+	sink.RetryFrom = prs.RetryFrom
 	sink.Workspaces = nil
 	for _, w := range prs.Workspaces {
 		new := v1.WorkspaceBinding{}
@@ -141,6 +143,8 @@ func (prs *PipelineRunSpec) ConvertFrom(ctx context.Context, source *v1.Pipeline
 		prs.Timeouts = newTimeouts
 	}
 	prs.PodTemplate = source.TaskRunTemplate.PodTemplate
+	prs.Concurrency = source.Concurrency
+	prs.RetryFrom = source.RetryFrom
 	prs.Workspaces = nil
 	for _, w := range source.Workspaces {
 		new := WorkspaceBinding{}
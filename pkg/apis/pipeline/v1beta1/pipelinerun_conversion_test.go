@@ -373,6 +373,18 @@ func TestPipelineRunConversion(t *testing.T) {
 							Digest: map[string]string{"sha256": "digest"},
 						},
 						FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+						Verification: []v1beta1.ResourceVerification{{
+							Name:         "task",
+							Kind:         "Task",
+							PipelineTask: "task-1",
+							Outcome:      "Passed",
+							Policies: []v1beta1.PolicyVerification{{
+								Name:      "ns/policy",
+								Mode:      "enforce",
+								Authority: "key",
+								Passed:    true,
+							}},
+						}},
 					},
 				},
 			},
@@ -25,6 +25,7 @@ import (
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -306,6 +307,17 @@ type PipelineRunSpec struct {
 	// This field is immutable.
 	// +optional
 	ManagedBy *string `json:"managedBy,omitempty"`
+
+	// Concurrency limits how many PipelineRuns sharing a concurrency key run at the same time.
+	// PipelineRuns over the limit are queued until earlier ones complete.
+	// +optional
+	Concurrency *v1.PipelineRunConcurrency `json:"concurrency,omitempty"`
+
+	// RetryFrom re-executes part of a previous PipelineRun of the same Pipeline: the tasks
+	// which succeeded in that PipelineRun are not run again, their TaskRuns and results are
+	// reused instead.
+	// +optional
+	RetryFrom *v1.PipelineRunRetryFrom `json:"retryFrom,omitempty"`
 }
 
 // TimeoutFields allows granular specification of pipeline, task, and finally timeouts
@@ -360,6 +372,9 @@ const (
 	PipelineRunReasonSuccessful PipelineRunReason = "Succeeded"
 	// PipelineRunReasonCompleted is the reason set when the PipelineRun completed successfully with one or more skipped Tasks
 	PipelineRunReasonCompleted PipelineRunReason = "Completed"
+	// PipelineRunReasonCompletedWithErrors is the reason set when the PipelineRun completed with one or more
+	// failed Tasks whose failure was ignored because of their onError: continue
+	PipelineRunReasonCompletedWithErrors PipelineRunReason = "CompletedWithErrors"
 	// PipelineRunReasonFailed is the reason set when the PipelineRun completed with a failure
 	PipelineRunReasonFailed PipelineRunReason = "Failed"
 	// PipelineRunReasonCancelled is the reason set when the PipelineRun cancelled by the user
@@ -551,6 +566,8 @@ const (
 	FinallyTimedOutSkip SkippingReason = "PipelineRun Finally timeout has been reached"
 	// EmptyArrayInMatrixParams means the task was skipped because Matrix parameters contain empty array.
 	EmptyArrayInMatrixParams SkippingReason = "Matrix Parameters have an empty array"
+	// AllMatrixCombinationsExcluded means the task was skipped because Matrix.Exclude removed all the combinations.
+	AllMatrixCombinationsExcluded SkippingReason = "All Matrix combinations were excluded"
 	// None means the task was not skipped
 	None SkippingReason = "None"
 )
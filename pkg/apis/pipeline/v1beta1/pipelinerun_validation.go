@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/internal/resultref"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -145,6 +146,12 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	if ps.Resources != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("resources"))
 	}
+	if ps.Concurrency != nil {
+		errs = errs.Also(v1.ValidateConcurrency(ctx, ps.Concurrency).ViaField("concurrency"))
+	}
+	if ps.RetryFrom != nil {
+		errs = errs.Also(v1.ValidateRetryFrom(ctx, ps.RetryFrom).ViaField("retryFrom"))
+	}
 
 	return errs
 }
@@ -28,6 +28,61 @@ type Provenance struct {
 
 	// FeatureFlags identifies the feature flags that were used during the task/pipeline run
 	FeatureFlags *config.FeatureFlags `json:"featureFlags,omitempty"`
+
+	// Verification records the trusted resources verification outcome of the
+	// remote resources referenced by the task/pipeline run.
+	// +optional
+	// +listType=atomic
+	Verification []ResourceVerification `json:"verification,omitempty"`
+}
+
+// VerificationOutcome is the outcome of verifying a resource against the
+// matching VerificationPolicies.
+type VerificationOutcome string
+
+// ResourceVerification records the trusted resources verification outcome of
+// a single resource referenced by a task/pipeline run.
+type ResourceVerification struct {
+	// Name is the name of the verified resource.
+	Name string `json:"name"`
+
+	// Kind is the kind of the verified resource, e.g. "Task" or "Pipeline".
+	Kind string `json:"kind,omitempty"`
+
+	// PipelineTask is the name of the PipelineTask referencing the resource, if any.
+	// +optional
+	PipelineTask string `json:"pipelineTask,omitempty"`
+
+	// Outcome is the overall verification outcome of the resource.
+	Outcome VerificationOutcome `json:"outcome"`
+
+	// Policies holds the outcome of each VerificationPolicy that was evaluated.
+	// +optional
+	// +listType=atomic
+	Policies []PolicyVerification `json:"policies,omitempty"`
+
+	// Message explains why the verification did not pass.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PolicyVerification records the outcome of verifying a resource against a
+// single VerificationPolicy.
+type PolicyVerification struct {
+	// Name is the namespace/name of the VerificationPolicy.
+	Name string `json:"name"`
+
+	// Mode is the mode of the VerificationPolicy, "enforce" or "warn".
+	Mode string `json:"mode,omitempty"`
+
+	// Authority is the name of the policy authority whose key verified the
+	// resource signature. It is empty when no key verified the signature.
+	// +optional
+	Authority string `json:"authority,omitempty"`
+
+	// Passed is true if the resource signature was verified by one of the
+	// policy authorities.
+	Passed bool `json:"passed"`
 }
 
 // RefSource contains the information that can uniquely identify where a remote
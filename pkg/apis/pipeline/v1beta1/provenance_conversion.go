@@ -28,6 +28,11 @@ func (p Provenance) convertTo(ctx context.Context, sink *v1.Provenance) {
 	if p.FeatureFlags != nil {
 		sink.FeatureFlags = p.FeatureFlags
 	}
+	for _, rv := range p.Verification {
+		new := v1.ResourceVerification{}
+		rv.convertTo(ctx, &new)
+		sink.Verification = append(sink.Verification, new)
+	}
 }
 
 func (p *Provenance) convertFrom(ctx context.Context, source v1.Provenance) {
@@ -39,6 +44,11 @@ func (p *Provenance) convertFrom(ctx context.Context, source v1.Provenance) {
 	if source.FeatureFlags != nil {
 		p.FeatureFlags = source.FeatureFlags
 	}
+	for _, rv := range source.Verification {
+		new := ResourceVerification{}
+		new.convertFrom(ctx, rv)
+		p.Verification = append(p.Verification, new)
+	}
 }
 
 func (cs RefSource) convertTo(ctx context.Context, sink *v1.RefSource) {
@@ -52,3 +62,25 @@ func (cs *RefSource) convertFrom(ctx context.Context, source v1.RefSource) {
 	cs.Digest = source.Digest
 	cs.EntryPoint = source.EntryPoint
 }
+
+func (rv ResourceVerification) convertTo(ctx context.Context, sink *v1.ResourceVerification) {
+	sink.Name = rv.Name
+	sink.Kind = rv.Kind
+	sink.PipelineTask = rv.PipelineTask
+	sink.Outcome = v1.VerificationOutcome(rv.Outcome)
+	for _, pv := range rv.Policies {
+		sink.Policies = append(sink.Policies, v1.PolicyVerification(pv))
+	}
+	sink.Message = rv.Message
+}
+
+func (rv *ResourceVerification) convertFrom(ctx context.Context, source v1.ResourceVerification) {
+	rv.Name = source.Name
+	rv.Kind = source.Kind
+	rv.PipelineTask = source.PipelineTask
+	rv.Outcome = VerificationOutcome(source.Outcome)
+	for _, pv := range source.Policies {
+		rv.Policies = append(rv.Policies, PolicyVerification(pv))
+	}
+	rv.Message = source.Message
+}
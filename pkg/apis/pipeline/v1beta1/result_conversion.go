@@ -33,6 +33,7 @@ func (r TaskResult) convertTo(ctx context.Context, sink *v1.TaskResult) {
 		}
 		sink.Properties = properties
 	}
+	sink.Schema = r.Schema
 	if r.Value != nil {
 		sink.Value = &v1.ParamValue{}
 		r.Value.convertTo(ctx, sink.Value)
@@ -50,6 +51,7 @@ func (r *TaskResult) convertFrom(ctx context.Context, source v1.TaskResult) {
 		}
 		r.Properties = properties
 	}
+	r.Schema = source.Schema
 	if source.Value != nil {
 		r.Value = &ParamValue{}
 		r.Value.convertFrom(ctx, *source.Value)
@@ -13,7 +13,11 @@ limitations under the License.
 
 package v1beta1
 
-import "strings"
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
 
 // TaskResult used to describe the results of a task
 type TaskResult struct {
@@ -29,6 +33,12 @@ type TaskResult struct {
 	// +optional
 	Properties map[string]PropertySpec `json:"properties,omitempty"`
 
+	// Schema is a JSON schema the value of the result is validated against when it is emitted.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Schema *runtime.RawExtension `json:"schema,omitempty"`
+
 	// Description is a human-readable description of the result
 	// +optional
 	Description string `json:"description,omitempty"`
@@ -74,6 +84,9 @@ const (
 	ResultsTypeString ResultsType = "string"
 	ResultsTypeArray  ResultsType = "array"
 	ResultsTypeObject ResultsType = "object"
+	// ResultsTypeFile is the type of the results whose content is uploaded to an object store,
+	// their value being an object holding the URI and the digest of the upload.
+	ResultsTypeFile ResultsType = "file"
 )
 
 // AllResultsTypes can be used for ResultsTypes validation.
@@ -119,6 +119,10 @@ func (ts *TaskSpec) ConvertTo(ctx context.Context, sink *v1.TaskSpec, meta *meta
 		p.convertTo(ctx, &new)
 		sink.Params = append(sink.Params, new)
 	}
+	sink.ParallelSteps = nil
+	for _, g := range ts.ParallelSteps {
+		sink.ParallelSteps = append(sink.ParallelSteps, v1.ParallelStepGroup{Steps: g.Steps})
+	}
 	sink.DisplayName = ts.DisplayName
 	sink.Description = ts.Description
 	return nil
@@ -182,6 +186,10 @@ func (ts *TaskSpec) ConvertFrom(ctx context.Context, source *v1.TaskSpec, meta *
 		new.convertFrom(ctx, p)
 		ts.Params = append(ts.Params, new)
 	}
+	ts.ParallelSteps = nil
+	for _, g := range source.ParallelSteps {
+		ts.ParallelSteps = append(ts.ParallelSteps, ParallelStepGroup{Steps: g.Steps})
+	}
 	ts.DisplayName = source.DisplayName
 	ts.Description = source.Description
 	return nil
@@ -111,6 +111,19 @@ spec:
        - input: "$(workspaces.custom.bound)"
          operator: in
          values: ["true"]
+`
+	parallelStepsTaskYAML := `
+metadata:
+  name: foo
+  namespace: bar
+spec:
+  steps:
+    - name: lint
+      image: foo
+    - name: test
+      image: foo
+  parallelSteps:
+    - steps: ["lint", "test"]
 `
 	stepActionTaskYAML := `
 metadata:
@@ -349,6 +362,9 @@ spec:
 	stepWhenTaskV1beta1 := parse.MustParseV1beta1Task(t, stepWhenTaskYAML)
 	stepWhenTaskV1 := parse.MustParseV1Task(t, stepWhenTaskYAML)
 
+	parallelStepsTaskV1beta1 := parse.MustParseV1beta1Task(t, parallelStepsTaskYAML)
+	parallelStepsTaskV1 := parse.MustParseV1Task(t, parallelStepsTaskYAML)
+
 	stepActionTaskV1beta1 := parse.MustParseV1beta1Task(t, stepActionTaskYAML)
 	stepActionTaskV1 := parse.MustParseV1Task(t, stepActionTaskYAML)
 
@@ -398,6 +414,10 @@ spec:
 		name:        "step when in task",
 		v1beta1Task: stepWhenTaskV1beta1,
 		v1Task:      stepWhenTaskV1,
+	}, {
+		name:        "parallel steps in task",
+		v1beta1Task: parallelStepsTaskV1beta1,
+		v1Task:      parallelStepsTaskV1,
 	}, {
 		name:        "step action in task",
 		v1beta1Task: stepActionTaskV1beta1,
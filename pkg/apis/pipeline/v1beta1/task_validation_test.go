@@ -556,6 +556,17 @@ func TestTaskSpecValidate(t *testing.T) {
 				hello "$(context.taskRun.namespace)"`,
 			}},
 		},
+	}, {
+		name: "valid taskrun labels and annotations context",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Image: "my-image",
+				Args:  []string{"$(context.taskRun.labels['tekton.dev/pipeline'])"},
+				Script: `
+				#!/usr/bin/env  bash
+				hello "$(context.taskRun.labels.app) $(context.taskRun.annotations.sha)"`,
+			}},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -825,6 +836,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 		Steps        []v1beta1.Step
 		Volumes      []corev1.Volume
 		StepTemplate *v1beta1.StepTemplate
+		Sidecars     []v1beta1.Sidecar
 		Workspaces   []v1beta1.WorkspaceDeclaration
 		Results      []v1beta1.TaskResult
 		Resources    *v1beta1.TaskResources
@@ -1446,6 +1458,24 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Resources: &v1beta1.TaskResources{},
 		},
 		expectedError: apis.FieldError{Message: "must not set the field(s)", Paths: []string{"resources"}},
+	}, {
+		name: "fallback value for an array param in a sidecar",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name: "tags",
+				Type: v1beta1.ParamTypeArray,
+			}},
+			Steps: validSteps,
+			Sidecars: []v1beta1.Sidecar{{
+				Name:  "mysidecar",
+				Image: "myimage",
+				Args:  []string{`$(params.tags | default "latest")`},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `fallback values are only supported for references to strings in "$(params.tags | default \"latest\")"`,
+			Paths:   []string{"sidecars[0].args[0]"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1454,6 +1484,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 				Steps:        tt.fields.Steps,
 				Volumes:      tt.fields.Volumes,
 				StepTemplate: tt.fields.StepTemplate,
+				Sidecars:     tt.fields.Sidecars,
 				Workspaces:   tt.fields.Workspaces,
 				Results:      tt.fields.Results,
 				Resources:    tt.fields.Resources,
@@ -1975,6 +2006,21 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 				}},
 			}},
 		},
+	}, {
+		name:            "parallel steps require alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:  "lint",
+				Image: "foo",
+			}, {
+				Name:  "test",
+				Image: "foo",
+			}},
+			ParallelSteps: []v1beta1.ParallelStepGroup{{
+				Steps: []string{"lint", "test"},
+			}},
+		},
 	}, {
 		name:            "windows script support requires alpha",
 		requiredVersion: "alpha",
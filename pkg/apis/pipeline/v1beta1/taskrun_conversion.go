@@ -91,7 +91,10 @@ func (trs *TaskRunSpec) ConvertTo(ctx context.Context, sink *v1.TaskRunSpec, met
 	sink.StatusMessage = v1.TaskRunSpecStatusMessage(trs.StatusMessage)
 	sink.Retries = trs.Retries
 	sink.Timeout = trs.Timeout
+	sink.SchedulingTimeout = trs.SchedulingTimeout
+	sink.RetryStrategy = trs.RetryStrategy
 	sink.PodTemplate = trs.PodTemplate
+	sink.PriorityClassName = trs.PriorityClassName
 	sink.Workspaces = nil
 	for _, w := range trs.Workspaces {
 		new := v1.WorkspaceBinding{}
@@ -174,7 +177,10 @@ func (trs *TaskRunSpec) ConvertFrom(ctx context.Context, source *v1.TaskRunSpec,
 	trs.StatusMessage = TaskRunSpecStatusMessage(source.StatusMessage)
 	trs.Retries = source.Retries
 	trs.Timeout = source.Timeout
+	trs.SchedulingTimeout = source.SchedulingTimeout
+	trs.RetryStrategy = source.RetryStrategy
 	trs.PodTemplate = source.PodTemplate
+	trs.PriorityClassName = source.PriorityClassName
 	trs.Workspaces = nil
 	for _, w := range source.Workspaces {
 		new := WorkspaceBinding{}
@@ -202,6 +208,7 @@ func (trd TaskRunDebug) convertTo(ctx context.Context, sink *v1.TaskRunDebug) {
 		sink.Breakpoints = &v1.TaskBreakpoints{}
 		trd.Breakpoints.convertTo(ctx, sink.Breakpoints)
 	}
+	sink.Image = trd.Image
 }
 
 func (trd *TaskRunDebug) convertFrom(ctx context.Context, source v1.TaskRunDebug) {
@@ -210,6 +217,7 @@ func (trd *TaskRunDebug) convertFrom(ctx context.Context, source v1.TaskRunDebug
 		newBreakpoints.convertFrom(ctx, *source.Breakpoints)
 		trd.Breakpoints = &newBreakpoints
 	}
+	trd.Image = source.Image
 }
 
 func (tbp TaskBreakpoints) convertTo(ctx context.Context, sink *v1.TaskBreakpoints) {
@@ -218,6 +226,10 @@ func (tbp TaskBreakpoints) convertTo(ctx context.Context, sink *v1.TaskBreakpoin
 		sink.BeforeSteps = make([]string, 0)
 		sink.BeforeSteps = append(sink.BeforeSteps, tbp.BeforeSteps...)
 	}
+	if len(tbp.Sidecars) > 0 {
+		sink.Sidecars = make([]string, 0)
+		sink.Sidecars = append(sink.Sidecars, tbp.Sidecars...)
+	}
 }
 
 func (tbp *TaskBreakpoints) convertFrom(ctx context.Context, source v1.TaskBreakpoints) {
@@ -226,6 +238,10 @@ func (tbp *TaskBreakpoints) convertFrom(ctx context.Context, source v1.TaskBreak
 		tbp.BeforeSteps = make([]string, 0)
 		tbp.BeforeSteps = append(tbp.BeforeSteps, source.BeforeSteps...)
 	}
+	if len(source.Sidecars) > 0 {
+		tbp.Sidecars = make([]string, 0)
+		tbp.Sidecars = append(tbp.Sidecars, source.Sidecars...)
+	}
 }
 
 func (trso TaskRunStepOverride) convertTo(ctx context.Context, sink *v1.TaskRunStepSpec) {
@@ -254,6 +270,11 @@ func (trs *TaskRunStatus) ConvertTo(ctx context.Context, sink *v1.TaskRunStatus,
 	sink.PodName = trs.PodName
 	sink.StartTime = trs.StartTime
 	sink.CompletionTime = trs.CompletionTime
+	sink.RetryTime = trs.RetryTime
+	sink.PodDisruptions = nil
+	for _, pd := range trs.PodDisruptions {
+		sink.PodDisruptions = append(sink.PodDisruptions, v1.TaskRunPodDisruption{PodName: pd.PodName, NodeName: pd.NodeName, Reason: pd.Reason, Message: pd.Message, Time: pd.Time})
+	}
 	sink.Steps = nil
 	for _, ss := range trs.Steps {
 		new := v1.StepState{}
@@ -275,6 +296,10 @@ func (trs *TaskRunStatus) ConvertTo(ctx context.Context, sink *v1.TaskRunStatus,
 		trr.convertTo(ctx, &new)
 		sink.Results = append(sink.Results, new)
 	}
+	sink.WhenFiles = nil
+	for _, wf := range trs.WhenFiles {
+		sink.WhenFiles = append(sink.WhenFiles, v1.TaskRunWhenFile{Workspace: wf.Workspace, Path: wf.Path, Content: wf.Content})
+	}
 	sink.Sidecars = nil
 	for _, sc := range trs.Sidecars {
 		new := v1.SidecarState{}
@@ -303,6 +328,11 @@ func (trs *TaskRunStatus) ConvertFrom(ctx context.Context, source v1.TaskRunStat
 	trs.PodName = source.PodName
 	trs.StartTime = source.StartTime
 	trs.CompletionTime = source.CompletionTime
+	trs.RetryTime = source.RetryTime
+	trs.PodDisruptions = nil
+	for _, pd := range source.PodDisruptions {
+		trs.PodDisruptions = append(trs.PodDisruptions, TaskRunPodDisruption{PodName: pd.PodName, NodeName: pd.NodeName, Reason: pd.Reason, Message: pd.Message, Time: pd.Time})
+	}
 	trs.Steps = nil
 	for _, ss := range source.Steps {
 		new := StepState{}
@@ -324,6 +354,10 @@ func (trs *TaskRunStatus) ConvertFrom(ctx context.Context, source v1.TaskRunStat
 		new.convertFrom(ctx, trr)
 		trs.TaskRunResults = append(trs.TaskRunResults, new)
 	}
+	trs.WhenFiles = nil
+	for _, wf := range source.WhenFiles {
+		trs.WhenFiles = append(trs.WhenFiles, TaskRunWhenFile{Workspace: wf.Workspace, Path: wf.Path, Content: wf.Content})
+	}
 	trs.Sidecars = nil
 	for _, sc := range source.Sidecars {
 		new := SidecarState{}
@@ -375,6 +409,10 @@ func (ss StepState) convertTo(ctx context.Context, sink *v1.StepState) {
 		sink.Inputs = append(sink.Inputs, new)
 	}
 
+	for _, t := range ss.Trace {
+		sink.Trace = append(sink.Trace, v1.StepTraceSpan{Name: t.Name, Duration: t.Duration})
+	}
+
 	for _, r := range ss.Results {
 		new := v1.TaskRunStepResult{}
 		r.convertTo(ctx, &new)
@@ -408,6 +446,9 @@ func (ss *StepState) convertFrom(ctx context.Context, source v1.StepState) {
 		new.convertFrom(ctx, o)
 		ss.Inputs = append(ss.Inputs, new)
 	}
+	for _, t := range source.Trace {
+		ss.Trace = append(ss.Trace, StepTraceSpan{Name: t.Name, Duration: t.Duration})
+	}
 }
 
 func (trr TaskRunResult) convertTo(ctx context.Context, sink *v1.TaskRunResult) {
@@ -446,6 +487,7 @@ func (t TaskRunStepArtifact) convertTo(ctx context.Context, sink *v1.TaskRunStep
 
 func (t *ArtifactValue) convertFrom(ctx context.Context, source v1.ArtifactValue) {
 	t.Uri = source.Uri
+	t.Size = source.Size
 	if source.Digest != nil {
 		t.Digest = map[Algorithm]string{}
 		for i, a := range source.Digest {
@@ -455,6 +497,7 @@ func (t *ArtifactValue) convertFrom(ctx context.Context, source v1.ArtifactValue
 }
 func (t ArtifactValue) convertTo(ctx context.Context, sink *v1.ArtifactValue) {
 	sink.Uri = t.Uri
+	sink.Size = t.Size
 	if t.Digest != nil {
 		sink.Digest = map[v1.Algorithm]string{}
 		for i, a := range t.Digest {
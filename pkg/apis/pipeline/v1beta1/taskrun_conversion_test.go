@@ -158,7 +158,9 @@ func TestTaskRunConversion(t *testing.T) {
 						Breakpoints: &v1beta1.TaskBreakpoints{
 							OnFailure:   "enabled",
 							BeforeSteps: []string{"step-1", "step-2"},
+							Sidecars:    []string{"sidecar-1"},
 						},
+						Image: "busybox",
 					},
 					Params: v1beta1.Params{{
 						Name: "param-task-1",
@@ -183,6 +185,7 @@ func TestTaskRunConversion(t *testing.T) {
 							"label": "value",
 						},
 					},
+					PriorityClassName: "urgent-release",
 					Workspaces: []v1beta1.WorkspaceBinding{
 						{
 							Name:    "workspace-volumeclaimtemplate",
@@ -304,6 +307,18 @@ func TestTaskRunConversion(t *testing.T) {
 							Type:  v1beta1.ResultsTypeObject,
 							Value: *v1beta1.NewObject(map[string]string{"hello": "world"}),
 						}},
+						WhenFiles: []v1beta1.TaskRunWhenFile{{
+							Workspace: "source",
+							Path:      "summary.txt",
+							Content:   "changed",
+						}},
+						PodDisruptions: []v1beta1.TaskRunPodDisruption{{
+							PodName:  "pod-name-0",
+							NodeName: "node-1",
+							Reason:   "Evicted",
+							Message:  "The node was low on resource: memory.",
+							Time:     metav1.Time{Time: time.Now()},
+						}},
 						TaskSpec: &v1beta1.TaskSpec{
 							Description: "test",
 							Steps: []v1beta1.Step{{
@@ -364,6 +379,29 @@ func TestTaskRunConversion(t *testing.T) {
 					},
 				},
 			},
+		}, {
+			name: "taskrun with trace in step state",
+			in: &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+				},
+				Spec: v1beta1.TaskRunSpec{},
+				Status: v1beta1.TaskRunStatus{
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						Steps: []v1beta1.StepState{{
+							Name: "step",
+							Trace: []v1beta1.StepTraceSpan{{
+								Name:     "wait",
+								Duration: metav1.Duration{Duration: time.Second},
+							}, {
+								Name:     "exec",
+								Duration: metav1.Duration{Duration: time.Minute},
+							}},
+						}},
+					},
+				},
+			},
 		},
 	}
 
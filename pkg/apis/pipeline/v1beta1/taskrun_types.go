@@ -24,6 +24,7 @@ import (
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -68,8 +69,21 @@ type TaskRunSpec struct {
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Time after which a retry attempt fails if its pod has not been scheduled to a node,
+	// e.g. because no node has enough resources, instead of waiting for the Timeout.
+	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
+	// +optional
+	SchedulingTimeout *metav1.Duration `json:"schedulingTimeout,omitempty"`
+	// RetryStrategy specifies how long to wait before each retry attempt. Retry attempts start
+	// immediately if it is not set.
+	// +optional
+	RetryStrategy *v1.RetryStrategy `json:"retryStrategy,omitempty"`
 	// PodTemplate holds pod specific configuration
 	PodTemplate *pod.PodTemplate `json:"podTemplate,omitempty"`
+	// PriorityClassName is the name of the PriorityClass of the pod of the TaskRun. It takes
+	// precedence over the priorityClassName of the PodTemplate.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 	// Workspaces is a list of WorkspaceBindings from volumes to workspaces.
 	// +optional
 	// +listType=atomic
@@ -127,6 +141,10 @@ const (
 type TaskRunDebug struct {
 	// +optional
 	Breakpoints *TaskBreakpoints `json:"breakpoints,omitempty"`
+	// Image is mounted read-only at /tekton/debug/tools in the debugged containers,
+	// to provide tools such as editors or network utilities to breakpoint sessions
+	// +optional
+	Image string `json:"image,omitempty"`
 }
 
 // TaskBreakpoints defines the breakpoint config for a particular Task
@@ -138,6 +156,11 @@ type TaskBreakpoints struct {
 	// +optional
 	// +listType=atomic
 	BeforeSteps []string `json:"beforeSteps,omitempty"`
+	// Sidecars lists the names of the sidecars to pause on failure,
+	// a failed sidecar will not exit until the breakpoint is released
+	// +optional
+	// +listType=atomic
+	Sidecars []string `json:"sidecars,omitempty"`
 }
 
 // NeedsDebugOnFailure return true if the TaskRun is configured to debug on failure
@@ -162,6 +185,14 @@ func (trd *TaskRunDebug) StepNeedsDebug(stepName string) bool {
 	return trd.NeedsDebugOnFailure() || trd.NeedsDebugBeforeStep(stepName)
 }
 
+// SidecarNeedsDebug return true if the sidecar is configured to debug on failure
+func (trd *TaskRunDebug) SidecarNeedsDebug(sidecarName string) bool {
+	if !trd.NeedsDebugOnFailure() {
+		return false
+	}
+	return sets.NewString(trd.Breakpoints.Sidecars...).Has(sidecarName)
+}
+
 // HaveBeforeSteps return true if have any before steps
 func (trd *TaskRunDebug) HaveBeforeSteps() bool {
 	return trd.Breakpoints != nil && len(trd.Breakpoints.BeforeSteps) > 0
@@ -276,6 +307,24 @@ func (trs *TaskRunStatus) MarkResourceFailed(reason TaskRunReason, err error) {
 // +listType=atomic
 type RetriesStatus []TaskRunStatus
 
+// TaskRunPodDisruption records a node-level disruption of the pod of a TaskRun, e.g. its eviction
+// or the loss of its node, after which the pod was recreated.
+type TaskRunPodDisruption struct {
+	// PodName is the name of the disrupted pod.
+	PodName string `json:"podName"`
+	// NodeName is the name of the node the disrupted pod was scheduled to.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+	// Reason is a brief CamelCase reason for the disruption, e.g. Evicted.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message describing the disruption.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Time is the time at which the disruption was detected.
+	Time metav1.Time `json:"time"`
+}
+
 // TaskRunStatusFields holds the fields of TaskRun's status.  This is defined
 // separately and inlined so that other types can readily consume these fields
 // via duck typing.
@@ -323,11 +372,28 @@ type TaskRunStatusFields struct {
 	// +listType=atomic
 	TaskRunResults []TaskRunResult `json:"taskResults,omitempty"`
 
+	// WhenFiles are the contents of the files written to the Workspaces of the TaskRun, which the
+	// WhenExpressions of the PipelineTasks following it test.
+	// +optional
+	// +listType=atomic
+	WhenFiles []TaskRunWhenFile `json:"whenFiles,omitempty"`
+
 	// The list has one entry per sidecar in the manifest. Each entry is
 	// represents the imageid of the corresponding sidecar.
 	// +listType=atomic
 	Sidecars []SidecarState `json:"sidecars,omitempty"`
 
+	// RetryTime is the time at which this retry attempt was scheduled to start, after waiting
+	// for the backoff of the RetryStrategy of the TaskRun.
+	// +optional
+	RetryTime *metav1.Time `json:"retryTime,omitempty"`
+
+	// PodDisruptions are the node-level disruptions, e.g. evictions, of the pods of this attempt of
+	// the TaskRun, after which its pod was recreated.
+	// +optional
+	// +listType=atomic
+	PodDisruptions []TaskRunPodDisruption `json:"podDisruptions,omitempty"`
+
 	// TaskSpec contains the Spec from the dereferenced Task definition used to instantiate this TaskRun.
 	// See Task.spec (API version tekton.dev/v1beta1)
 	// +kubebuilder:pruning:PreserveUnknownFields
@@ -409,6 +475,20 @@ type StepState struct {
 	Provenance            *Provenance           `json:"provenance,omitempty"`
 	Inputs                []TaskRunStepArtifact `json:"inputs,omitempty"`
 	Outputs               []TaskRunStepArtifact `json:"outputs,omitempty"`
+	// Trace is the time the Step spent in each phase of its execution, reported when
+	// the TaskRun has the experimental.tekton.dev/debug-trace annotation.
+	// +optional
+	// +listType=atomic
+	Trace []StepTraceSpan `json:"trace,omitempty"`
+}
+
+// StepTraceSpan is the time a Step spent in one phase of its execution, e.g. waiting
+// for the previous Step, executing its command or capturing its results.
+type StepTraceSpan struct {
+	// Name is the name of the phase: wait, exec or results.
+	Name string `json:"name"`
+	// Duration is the time spent in the phase.
+	Duration metav1.Duration `json:"duration"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
@@ -23,6 +23,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -115,6 +116,21 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		errs = errs.Also(apis.ErrInvalidValue(ts.Timeout.Duration.String()+" should be >= 0", "timeout"))
 	}
 
+	if ts.SchedulingTimeout != nil {
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "schedulingTimeout", config.AlphaAPIFields).ViaField("schedulingTimeout"))
+		if ts.SchedulingTimeout.Duration <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(ts.SchedulingTimeout.Duration.String()+" should be > 0", "schedulingTimeout"))
+		}
+	}
+
+	if ts.RetryStrategy != nil {
+		errs = errs.Also(v1.ValidateRetryStrategy(ctx, ts.RetryStrategy).ViaField("retryStrategy"))
+	}
+
+	if ts.PriorityClassName != "" {
+		errs = errs.Also(v1.ValidatePriorityClassName(ctx, ts.PriorityClassName).ViaField("priorityClassName"))
+	}
+
 	if ts.Resources != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("resources"))
 	}
@@ -289,6 +305,13 @@ func validateDebug(db *TaskRunDebug) (errs *apis.FieldError) {
 		}
 		beforeSteps.Insert(step)
 	}
+	sidecars := sets.NewString()
+	for i, sidecar := range db.Breakpoints.Sidecars {
+		if sidecars.Has(sidecar) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("sidecar must be unique, the same sidecar: %s is defined multiple times at", sidecar), fmt.Sprintf("breakpoints.sidecars[%d]", i)))
+		}
+		sidecars.Insert(sidecar)
+	}
 	return errs
 }
 
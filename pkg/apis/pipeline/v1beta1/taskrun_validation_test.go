@@ -566,6 +566,26 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			Timeout: &metav1.Duration{Duration: -48 * time.Hour},
 		},
 		wantErr: apis.ErrInvalidValue("-48h0m0s should be >= 0", "timeout"),
+	}, {
+		name: "non-positive scheduling timeout",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "taskrefname",
+			},
+			SchedulingTimeout: &metav1.Duration{Duration: -5 * time.Minute},
+		},
+		wantErr: apis.ErrInvalidValue("-5m0s should be > 0", "schedulingTimeout"),
+		wc:      cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "scheduling timeout without alpha api fields",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "taskrefname",
+			},
+			SchedulingTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+		},
+		wantErr: apis.ErrGeneric("schedulingTimeout requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\""),
+		wc:      cfgtesting.EnableBetaAPIFields,
 	}, {
 		name: "wrong taskrun cancel",
 		spec: v1beta1.TaskRunSpec{
@@ -19,10 +19,11 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 
-	"github.com/google/cel-go/cel"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1/types"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -46,6 +47,17 @@ func (wes WhenExpressions) validateWhenExpressionsFields(ctx context.Context) (e
 }
 
 func (we *WhenExpression) validateWhenExpressionFields(ctx context.Context) *apis.FieldError {
+	if we.File != nil {
+		if err := config.ValidateEnabledAPIFields(ctx, "when file", config.AlphaAPIFields); err != nil {
+			return err
+		}
+		if we.CEL != "" || we.Input != "" {
+			return apis.ErrGeneric(fmt.Sprintf("file and cel or input cannot be set in one WhenExpression: %v", we))
+		}
+		if err := we.File.validate(); err != nil {
+			return err.ViaField("file")
+		}
+	}
 	if we.CEL != "" {
 		if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableCELInWhenExpression {
 			return apis.ErrGeneric(fmt.Sprintf("feature flag %s should be set to true to use CEL: %s in WhenExpression", config.EnableCELInWhenExpression, we.CEL), "")
@@ -59,10 +71,8 @@ func (we *WhenExpression) validateWhenExpressionFields(ctx context.Context) *api
 		// e.g.  This is a valid CEL expression: '$(params.foo)' == 'foo';
 		//       But this is not a valid expression since CEL cannot recognize: $(params.foo) == 'foo';
 		//       This is not valid since we don't pass params to CEL's environment: params.foo == 'foo';
-		env, _ := cel.NewEnv()
-		_, iss := env.Compile(we.CEL)
-		if iss.Err() != nil {
-			return apis.ErrGeneric("invalid cel expression: %s with err: %s", we.CEL, iss.Err().Error())
+		if _, err := types.CompileWhenExpressionCEL(we.CEL); err != nil {
+			return apis.ErrGeneric("invalid cel expression: %s with err: %s", we.CEL, err.Error())
 		}
 		return nil
 	}
@@ -80,6 +90,32 @@ func (we *WhenExpression) validateWhenExpressionFields(ctx context.Context) *api
 	return nil
 }
 
+func (wf *WhenFile) validate() (errs *apis.FieldError) {
+	if wf.Task == "" {
+		errs = errs.Also(apis.ErrMissingField("task"))
+	}
+	if wf.Workspace == "" {
+		errs = errs.Also(apis.ErrMissingField("workspace"))
+	}
+	if wf.Path == "" {
+		errs = errs.Also(apis.ErrMissingField("path"))
+	} else if !filepath.IsLocal(wf.Path) {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q must be a path relative to the root of the workspace", wf.Path), "path"))
+	}
+	return errs
+}
+
+// validateNoFiles ensures that none of the WhenExpressions tests a file, which only the
+// WhenExpressions of a PipelineTask can do.
+func (wes WhenExpressions) validateNoFiles() (errs *apis.FieldError) {
+	for idx, we := range wes {
+		if we.File != nil {
+			errs = errs.Also(apis.ErrDisallowedFields("file").ViaFieldIndex("when", idx))
+		}
+	}
+	return errs
+}
+
 func (wes WhenExpressions) validatePipelineParametersVariables(prefix string, paramNames sets.String, arrayParamNames sets.String, objectParamNameKeys map[string][]string) (errs *apis.FieldError) {
 	for idx, we := range wes {
 		errs = errs.Also(validateStringVariable(we.Input, prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaField("input").ViaFieldIndex("when", idx))
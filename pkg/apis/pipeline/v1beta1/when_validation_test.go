@@ -56,7 +56,7 @@ func TestWhenExpressions_Valid(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if err := tt.wes.validate(t.Context()); err != nil {
-				t.Errorf("WhenExpressions.validate() returned an error for valid when expressions: %s", tt.wes)
+				t.Errorf("WhenExpressions.validate() returned an error for valid when expressions: %v", tt.wes)
 			}
 		})
 	}
@@ -99,7 +99,7 @@ func TestWhenExpressions_Invalid(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if err := tt.wes.validate(t.Context()); err == nil {
-				t.Errorf("WhenExpressions.validate() did not return error for invalid when expressions: %s, %s", tt.wes, err)
+				t.Errorf("WhenExpressions.validate() did not return error for invalid when expressions: %v, %s", tt.wes, err)
 			}
 		})
 	}
@@ -148,7 +148,7 @@ func TestCELinWhenExpressions_Valid(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if err := tt.wes.validate(ctx); err != nil {
-				t.Errorf("WhenExpressions.validate() returned an error: %s for valid when expressions: %s", err, tt.wes)
+				t.Errorf("WhenExpressions.validate() returned an error: %s for valid when expressions: %v", err, tt.wes)
 			}
 		})
 	}
@@ -195,7 +195,7 @@ func TestCELWhenExpressions_Invalid(t *testing.T) {
 				},
 			})
 			if err := tt.wes.validate(ctx); err == nil {
-				t.Errorf("WhenExpressions.validate() did not return error for invalid when expressions: %s", tt.wes)
+				t.Errorf("WhenExpressions.validate() did not return error for invalid when expressions: %v", tt.wes)
 			}
 		})
 	}
@@ -82,6 +82,35 @@ func (w WorkspaceBinding) convertTo(ctx context.Context, sink *v1.WorkspaceBindi
 	sink.Secret = w.Secret
 	sink.Projected = w.Projected
 	sink.CSI = w.CSI
+	if w.ObjectStorage != nil {
+		sink.ObjectStorage = &v1.ObjectStorageWorkspaceSource{
+			URL:        w.ObjectStorage.URL,
+			Image:      w.ObjectStorage.Image,
+			SecretName: w.ObjectStorage.SecretName,
+		}
+	}
+	if w.Ephemeral != nil {
+		sink.Ephemeral = &v1.EphemeralWorkspaceSource{
+			Size:             w.Ephemeral.Size,
+			StorageClassName: w.Ephemeral.StorageClassName,
+			AccessModes:      w.Ephemeral.AccessModes,
+		}
+	}
+	if w.Cache != nil {
+		sink.Cache = &v1.CacheWorkspaceSource{
+			Key:              w.Cache.Key,
+			Size:             w.Cache.Size,
+			StorageClassName: w.Cache.StorageClassName,
+			AccessModes:      w.Cache.AccessModes,
+			TTL:              w.Cache.TTL,
+		}
+	}
+	if w.Snapshot != nil {
+		sink.Snapshot = &v1.WorkspaceSnapshot{
+			Size:             w.Snapshot.Size,
+			StorageClassName: w.Snapshot.StorageClassName,
+		}
+	}
 }
 
 // ConvertFrom converts v1beta1 Param from v1 Param
@@ -95,4 +124,33 @@ func (w *WorkspaceBinding) ConvertFrom(ctx context.Context, source v1.WorkspaceB
 	w.Secret = source.Secret
 	w.Projected = source.Projected
 	w.CSI = source.CSI
+	if source.ObjectStorage != nil {
+		w.ObjectStorage = &ObjectStorageWorkspaceSource{
+			URL:        source.ObjectStorage.URL,
+			Image:      source.ObjectStorage.Image,
+			SecretName: source.ObjectStorage.SecretName,
+		}
+	}
+	if source.Ephemeral != nil {
+		w.Ephemeral = &EphemeralWorkspaceSource{
+			Size:             source.Ephemeral.Size,
+			StorageClassName: source.Ephemeral.StorageClassName,
+			AccessModes:      source.Ephemeral.AccessModes,
+		}
+	}
+	if source.Cache != nil {
+		w.Cache = &CacheWorkspaceSource{
+			Key:              source.Cache.Key,
+			Size:             source.Cache.Size,
+			StorageClassName: source.Cache.StorageClassName,
+			AccessModes:      source.Cache.AccessModes,
+			TTL:              source.Cache.TTL,
+		}
+	}
+	if source.Snapshot != nil {
+		w.Snapshot = &WorkspaceSnapshot{
+			Size:             source.Snapshot.Size,
+			StorageClassName: source.Snapshot.StorageClassName,
+		}
+	}
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // WorkspaceDeclaration is a declaration of a volume that a Task requires.
@@ -86,6 +87,81 @@ type WorkspaceBinding struct {
 	// CSI (Container Storage Interface) represents ephemeral storage that is handled by certain external CSI drivers.
 	// +optional
 	CSI *corev1.CSIVolumeSource `json:"csi,omitempty"`
+	// ObjectStorage represents a location in an object storage service, e.g. S3 or GCS, that should populate
+	// this workspace. Its contents are fetched before the Steps run and uploaded back once they have completed.
+	// +optional
+	ObjectStorage *ObjectStorageWorkspaceSource `json:"objectStorage,omitempty"`
+	// Ephemeral represents a generic ephemeral volume, provisioned with the Pod of the TaskRun
+	// and deleted with it, that should populate this workspace.
+	// +optional
+	Ephemeral *EphemeralWorkspaceSource `json:"ephemeral,omitempty"`
+	// Cache represents a PersistentVolumeClaim, provisioned by the controller and reused by
+	// the runs with the same cache key, that should populate this workspace.
+	// +optional
+	Cache *CacheWorkspaceSource `json:"cache,omitempty"`
+	// Snapshot keeps the contents of an EmptyDir workspace between the retries of the TaskRun.
+	// +optional
+	Snapshot *WorkspaceSnapshot `json:"snapshot,omitempty"`
+}
+
+// WorkspaceSnapshot backs an EmptyDir workspace with a PersistentVolumeClaim owned by the TaskRun, so that
+// a retry of the TaskRun starts from the contents left by the previous attempt instead of an empty directory.
+type WorkspaceSnapshot struct {
+	// Size is the storage size requested for the volume, e.g. "1Gi".
+	Size string `json:"size"`
+	// StorageClassName is the name of the StorageClass of the volume. The default
+	// StorageClass of the cluster is used if it is empty.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+}
+
+// CacheWorkspaceSource is a cache backing a workspace. The controller provisions a PersistentVolumeClaim
+// for each cache key, reuses it across runs and deletes it once it has not been used for its TTL.
+// Its key, size and storage class name accept parameter substitution, e.g. "go-$(params.go-version)".
+type CacheWorkspaceSource struct {
+	// Key identifies the cache: the runs with the same key in a namespace share the same volume.
+	Key string `json:"key"`
+	// Size is the storage size requested for the volume when it is created, e.g. "1Gi".
+	Size string `json:"size"`
+	// StorageClassName is the name of the StorageClass of the volume. The default
+	// StorageClass of the cluster is used if it is empty.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// AccessModes contains the access modes of the volume. Defaults to ReadWriteOnce.
+	// +optional
+	// +listType=atomic
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// TTL is how long the volume is kept after it was last used. Defaults to 168h (7 days).
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// EphemeralWorkspaceSource is a template for the PersistentVolumeClaim of a generic ephemeral
+// volume backing a workspace. Its fields accept parameter substitution, e.g. "$(params.size)".
+type EphemeralWorkspaceSource struct {
+	// Size is the storage size requested for the volume, e.g. "1Gi".
+	Size string `json:"size"`
+	// StorageClassName is the name of the StorageClass of the volume. The default
+	// StorageClass of the cluster is used if it is empty.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// AccessModes contains the access modes of the volume. Defaults to ReadWriteOnce.
+	// +optional
+	// +listType=atomic
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+}
+
+// ObjectStorageWorkspaceSource is a location in an object storage service backing a workspace.
+type ObjectStorageWorkspaceSource struct {
+	// URL is the location of the workspace contents, e.g. "s3://my-bucket/path" or "gs://my-bucket/path".
+	URL string `json:"url"`
+	// Image is the container image used to fetch and upload the workspace contents.
+	// It must contain the `aws` CLI for "s3://" URLs and the `gsutil` CLI for "gs://" URLs.
+	Image string `json:"image"`
+	// SecretName is the name of a Secret in the same namespace whose keys are exposed as
+	// environment variables to the fetch and upload containers, e.g. AWS_ACCESS_KEY_ID.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
 }
 
 // WorkspacePipelineDeclaration creates a named slot in a Pipeline that a PipelineRun
@@ -20,7 +20,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -188,3 +191,63 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaceBindingValidateAlphaAPIFields(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		binding *v1beta1.WorkspaceBinding
+		wantErr string
+	}{{
+		name: "objectStorage requires alpha",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			ObjectStorage: &v1beta1.ObjectStorageWorkspaceSource{
+				URL:   "s3://my-bucket/path",
+				Image: "amazon/aws-cli",
+			},
+		},
+		wantErr: `objectStorage requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+	}, {
+		name: "ephemeral requires alpha",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Ephemeral: &v1beta1.EphemeralWorkspaceSource{
+				Size: "1Gi",
+			},
+		},
+		wantErr: `ephemeral requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+	}, {
+		name: "cache requires alpha",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Cache: &v1beta1.CacheWorkspaceSource{
+				Key:  "deps",
+				Size: "1Gi",
+			},
+		},
+		wantErr: `cache requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+	}, {
+		name: "snapshot requires alpha",
+		binding: &v1beta1.WorkspaceBinding{
+			Name:     "beth",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+			Snapshot: &v1beta1.WorkspaceSnapshot{
+				Size: "1Gi",
+			},
+		},
+		wantErr: `snapshot requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.binding.Validate(cfgtesting.EnableBetaAPIFields(t.Context()))
+			if err == nil {
+				t.Fatalf("expected error %q but got none", tc.wantErr)
+			}
+			if d := cmp.Diff(tc.wantErr, err.Message); d != "" {
+				t.Errorf("WorkspaceBinding.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+			if err := tc.binding.Validate(cfgtesting.EnableAlphaAPIFields(t.Context())); err != nil {
+				t.Errorf("didnt expect error with alpha API fields but got: %v", err)
+			}
+		})
+	}
+}
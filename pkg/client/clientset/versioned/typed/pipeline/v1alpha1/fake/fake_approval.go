@@ -0,0 +1,50 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelinev1alpha1 "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/typed/pipeline/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeApprovals implements ApprovalInterface
+type fakeApprovals struct {
+	*gentype.FakeClientWithList[*v1alpha1.Approval, *v1alpha1.ApprovalList]
+	Fake *FakeTektonV1alpha1
+}
+
+func newFakeApprovals(fake *FakeTektonV1alpha1, namespace string) pipelinev1alpha1.ApprovalInterface {
+	return &fakeApprovals{
+		gentype.NewFakeClientWithList[*v1alpha1.Approval, *v1alpha1.ApprovalList](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("approvals"),
+			v1alpha1.SchemeGroupVersion.WithKind("Approval"),
+			func() *v1alpha1.Approval { return &v1alpha1.Approval{} },
+			func() *v1alpha1.ApprovalList { return &v1alpha1.ApprovalList{} },
+			func(dst, src *v1alpha1.ApprovalList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ApprovalList) []*v1alpha1.Approval { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1alpha1.ApprovalList, items []*v1alpha1.Approval) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -28,6 +28,14 @@ type FakeTektonV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTektonV1alpha1) Approvals(namespace string) v1alpha1.ApprovalInterface {
+	return newFakeApprovals(c, namespace)
+}
+
+func (c *FakeTektonV1alpha1) PipelineSchedules(namespace string) v1alpha1.PipelineScheduleInterface {
+	return newFakePipelineSchedules(c, namespace)
+}
+
 func (c *FakeTektonV1alpha1) Runs(namespace string) v1alpha1.RunInterface {
 	return newFakeRuns(c, namespace)
 }
@@ -0,0 +1,52 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelinev1alpha1 "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/typed/pipeline/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakePipelineSchedules implements PipelineScheduleInterface
+type fakePipelineSchedules struct {
+	*gentype.FakeClientWithList[*v1alpha1.PipelineSchedule, *v1alpha1.PipelineScheduleList]
+	Fake *FakeTektonV1alpha1
+}
+
+func newFakePipelineSchedules(fake *FakeTektonV1alpha1, namespace string) pipelinev1alpha1.PipelineScheduleInterface {
+	return &fakePipelineSchedules{
+		gentype.NewFakeClientWithList[*v1alpha1.PipelineSchedule, *v1alpha1.PipelineScheduleList](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("pipelineschedules"),
+			v1alpha1.SchemeGroupVersion.WithKind("PipelineSchedule"),
+			func() *v1alpha1.PipelineSchedule { return &v1alpha1.PipelineSchedule{} },
+			func() *v1alpha1.PipelineScheduleList { return &v1alpha1.PipelineScheduleList{} },
+			func(dst, src *v1alpha1.PipelineScheduleList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.PipelineScheduleList) []*v1alpha1.PipelineSchedule {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.PipelineScheduleList, items []*v1alpha1.PipelineSchedule) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
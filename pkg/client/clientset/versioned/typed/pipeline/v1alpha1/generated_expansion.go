@@ -18,6 +18,10 @@ limitations under the License.
 
 package v1alpha1
 
+type ApprovalExpansion interface{}
+
+type PipelineScheduleExpansion interface{}
+
 type RunExpansion interface{}
 
 type StepActionExpansion interface{}
@@ -28,6 +28,8 @@ import (
 
 type TektonV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ApprovalsGetter
+	PipelineSchedulesGetter
 	RunsGetter
 	StepActionsGetter
 	VerificationPoliciesGetter
@@ -38,6 +40,14 @@ type TektonV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TektonV1alpha1Client) Approvals(namespace string) ApprovalInterface {
+	return newApprovals(c, namespace)
+}
+
+func (c *TektonV1alpha1Client) PipelineSchedules(namespace string) PipelineScheduleInterface {
+	return newPipelineSchedules(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Runs(namespace string) RunInterface {
 	return newRuns(c, namespace)
 }
@@ -24,6 +24,10 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// Approvals returns a ApprovalInformer.
+	Approvals() ApprovalInformer
+	// PipelineSchedules returns a PipelineScheduleInformer.
+	PipelineSchedules() PipelineScheduleInformer
 	// Runs returns a RunInformer.
 	Runs() RunInformer
 	// StepActions returns a StepActionInformer.
@@ -43,6 +47,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// Approvals returns a ApprovalInformer.
+func (v *version) Approvals() ApprovalInformer {
+	return &approvalInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PipelineSchedules returns a PipelineScheduleInformer.
+func (v *version) PipelineSchedules() PipelineScheduleInformer {
+	return &pipelineScheduleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Runs returns a RunInformer.
 func (v *version) Runs() RunInformer {
 	return &runInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
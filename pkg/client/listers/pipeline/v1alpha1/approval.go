@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	pipelinev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ApprovalLister helps list Approvals.
+// All objects returned here must be treated as read-only.
+type ApprovalLister interface {
+	// List lists all Approvals in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*pipelinev1alpha1.Approval, err error)
+	// Approvals returns an object that can list and get Approvals.
+	Approvals(namespace string) ApprovalNamespaceLister
+	ApprovalListerExpansion
+}
+
+// approvalLister implements the ApprovalLister interface.
+type approvalLister struct {
+	listers.ResourceIndexer[*pipelinev1alpha1.Approval]
+}
+
+// NewApprovalLister returns a new ApprovalLister.
+func NewApprovalLister(indexer cache.Indexer) ApprovalLister {
+	return &approvalLister{listers.New[*pipelinev1alpha1.Approval](indexer, pipelinev1alpha1.Resource("approval"))}
+}
+
+// Approvals returns an object that can list and get Approvals.
+func (s *approvalLister) Approvals(namespace string) ApprovalNamespaceLister {
+	return approvalNamespaceLister{listers.NewNamespaced[*pipelinev1alpha1.Approval](s.ResourceIndexer, namespace)}
+}
+
+// ApprovalNamespaceLister helps list and get Approvals.
+// All objects returned here must be treated as read-only.
+type ApprovalNamespaceLister interface {
+	// List lists all Approvals in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*pipelinev1alpha1.Approval, err error)
+	// Get retrieves the Approval from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*pipelinev1alpha1.Approval, error)
+	ApprovalNamespaceListerExpansion
+}
+
+// approvalNamespaceLister implements the ApprovalNamespaceLister
+// interface.
+type approvalNamespaceLister struct {
+	listers.ResourceIndexer[*pipelinev1alpha1.Approval]
+}
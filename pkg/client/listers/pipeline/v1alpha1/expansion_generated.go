@@ -18,6 +18,22 @@ limitations under the License.
 
 package v1alpha1
 
+// ApprovalListerExpansion allows custom methods to be added to
+// ApprovalLister.
+type ApprovalListerExpansion interface{}
+
+// ApprovalNamespaceListerExpansion allows custom methods to be added to
+// ApprovalNamespaceLister.
+type ApprovalNamespaceListerExpansion interface{}
+
+// PipelineScheduleListerExpansion allows custom methods to be added to
+// PipelineScheduleLister.
+type PipelineScheduleListerExpansion interface{}
+
+// PipelineScheduleNamespaceListerExpansion allows custom methods to be added to
+// PipelineScheduleNamespaceLister.
+type PipelineScheduleNamespaceListerExpansion interface{}
+
 // RunListerExpansion allows custom methods to be added to
 // RunLister.
 type RunListerExpansion interface{}
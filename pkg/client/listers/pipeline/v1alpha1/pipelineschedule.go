@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	pipelinev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// PipelineScheduleLister helps list PipelineSchedules.
+// All objects returned here must be treated as read-only.
+type PipelineScheduleLister interface {
+	// List lists all PipelineSchedules in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*pipelinev1alpha1.PipelineSchedule, err error)
+	// PipelineSchedules returns an object that can list and get PipelineSchedules.
+	PipelineSchedules(namespace string) PipelineScheduleNamespaceLister
+	PipelineScheduleListerExpansion
+}
+
+// pipelineScheduleLister implements the PipelineScheduleLister interface.
+type pipelineScheduleLister struct {
+	listers.ResourceIndexer[*pipelinev1alpha1.PipelineSchedule]
+}
+
+// NewPipelineScheduleLister returns a new PipelineScheduleLister.
+func NewPipelineScheduleLister(indexer cache.Indexer) PipelineScheduleLister {
+	return &pipelineScheduleLister{listers.New[*pipelinev1alpha1.PipelineSchedule](indexer, pipelinev1alpha1.Resource("pipelineschedule"))}
+}
+
+// PipelineSchedules returns an object that can list and get PipelineSchedules.
+func (s *pipelineScheduleLister) PipelineSchedules(namespace string) PipelineScheduleNamespaceLister {
+	return pipelineScheduleNamespaceLister{listers.NewNamespaced[*pipelinev1alpha1.PipelineSchedule](s.ResourceIndexer, namespace)}
+}
+
+// PipelineScheduleNamespaceLister helps list and get PipelineSchedules.
+// All objects returned here must be treated as read-only.
+type PipelineScheduleNamespaceLister interface {
+	// List lists all PipelineSchedules in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*pipelinev1alpha1.PipelineSchedule, err error)
+	// Get retrieves the PipelineSchedule from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*pipelinev1alpha1.PipelineSchedule, error)
+	PipelineScheduleNamespaceListerExpansion
+}
+
+// pipelineScheduleNamespaceLister implements the PipelineScheduleNamespaceLister
+// interface.
+type pipelineScheduleNamespaceLister struct {
+	listers.ResourceIndexer[*pipelinev1alpha1.PipelineSchedule]
+}
@@ -49,7 +49,8 @@ func applyContainerReplacements(c *corev1.Container, stringReplacements map[stri
 	c.Args = newArgs
 
 	for ie, e := range c.Env {
-		c.Env[ie].Value = substitution.ApplyReplacements(e.Value, stringReplacements)
+		// Arrays can only be used in env values when joined into a single string, e.g. $(params.list[*]:,).
+		c.Env[ie].Value = substitution.ApplyReplacements(substitution.ApplyArrayJoinReplacements(e.Value, arrayReplacements), stringReplacements)
 		if c.Env[ie].ValueFrom != nil {
 			if e.ValueFrom.SecretKeyRef != nil {
 				c.Env[ie].ValueFrom.SecretKeyRef.LocalObjectReference.Name = substitution.ApplyReplacements(e.ValueFrom.SecretKeyRef.LocalObjectReference.Name, stringReplacements)
@@ -85,4 +86,59 @@ func applyContainerReplacements(c *corev1.Container, stringReplacements map[stri
 		c.VolumeMounts[iv].MountPath = substitution.ApplyReplacements(v.MountPath, stringReplacements)
 		c.VolumeMounts[iv].SubPath = substitution.ApplyReplacements(v.SubPath, stringReplacements)
 	}
+
+	if c.SecurityContext != nil {
+		applySecurityContextReplacements(c.SecurityContext, stringReplacements)
+	}
+}
+
+// applySecurityContextReplacements applies variable interpolation on the string fields of a container's SecurityContext.
+// Numeric fields such as runAsUser and runAsGroup are typed as integers in the API and cannot reference variables.
+func applySecurityContextReplacements(sc *corev1.SecurityContext, stringReplacements map[string]string) {
+	if sc.Capabilities != nil {
+		for i, c := range sc.Capabilities.Add {
+			sc.Capabilities.Add[i] = corev1.Capability(substitution.ApplyReplacements(string(c), stringReplacements))
+		}
+		for i, c := range sc.Capabilities.Drop {
+			sc.Capabilities.Drop[i] = corev1.Capability(substitution.ApplyReplacements(string(c), stringReplacements))
+		}
+	}
+	if sc.SELinuxOptions != nil {
+		sc.SELinuxOptions.User = substitution.ApplyReplacements(sc.SELinuxOptions.User, stringReplacements)
+		sc.SELinuxOptions.Role = substitution.ApplyReplacements(sc.SELinuxOptions.Role, stringReplacements)
+		sc.SELinuxOptions.Type = substitution.ApplyReplacements(sc.SELinuxOptions.Type, stringReplacements)
+		sc.SELinuxOptions.Level = substitution.ApplyReplacements(sc.SELinuxOptions.Level, stringReplacements)
+	}
+	if sc.WindowsOptions != nil {
+		if sc.WindowsOptions.GMSACredentialSpecName != nil {
+			gmsaCredentialSpecName := substitution.ApplyReplacements(*sc.WindowsOptions.GMSACredentialSpecName, stringReplacements)
+			sc.WindowsOptions.GMSACredentialSpecName = &gmsaCredentialSpecName
+		}
+		if sc.WindowsOptions.GMSACredentialSpec != nil {
+			gmsaCredentialSpec := substitution.ApplyReplacements(*sc.WindowsOptions.GMSACredentialSpec, stringReplacements)
+			sc.WindowsOptions.GMSACredentialSpec = &gmsaCredentialSpec
+		}
+		if sc.WindowsOptions.RunAsUserName != nil {
+			runAsUserName := substitution.ApplyReplacements(*sc.WindowsOptions.RunAsUserName, stringReplacements)
+			sc.WindowsOptions.RunAsUserName = &runAsUserName
+		}
+	}
+	if sc.ProcMount != nil {
+		procMount := corev1.ProcMountType(substitution.ApplyReplacements(string(*sc.ProcMount), stringReplacements))
+		sc.ProcMount = &procMount
+	}
+	if sc.SeccompProfile != nil {
+		sc.SeccompProfile.Type = corev1.SeccompProfileType(substitution.ApplyReplacements(string(sc.SeccompProfile.Type), stringReplacements))
+		if sc.SeccompProfile.LocalhostProfile != nil {
+			localhostProfile := substitution.ApplyReplacements(*sc.SeccompProfile.LocalhostProfile, stringReplacements)
+			sc.SeccompProfile.LocalhostProfile = &localhostProfile
+		}
+	}
+	if sc.AppArmorProfile != nil {
+		sc.AppArmorProfile.Type = corev1.AppArmorProfileType(substitution.ApplyReplacements(string(sc.AppArmorProfile.Type), stringReplacements))
+		if sc.AppArmorProfile.LocalhostProfile != nil {
+			localhostProfile := substitution.ApplyReplacements(*sc.AppArmorProfile.LocalhostProfile, stringReplacements)
+			sc.AppArmorProfile.LocalhostProfile = &localhostProfile
+		}
+	}
 }
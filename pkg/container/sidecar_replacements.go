@@ -19,10 +19,74 @@ package container
 import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/substitution"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // ApplySidecarReplacements applies variable interpolation on a Sidecar.
 func ApplySidecarReplacements(sidecar *v1.Sidecar, stringReplacements map[string]string, arrayReplacements map[string][]string) {
 	sidecar.Script = substitution.ApplyReplacements(sidecar.Script, stringReplacements)
+	applyProbeReplacements(sidecar.LivenessProbe, stringReplacements)
+	applyProbeReplacements(sidecar.ReadinessProbe, stringReplacements)
+	applyProbeReplacements(sidecar.StartupProbe, stringReplacements)
+	if sidecar.Lifecycle != nil {
+		applyLifecycleHandlerReplacements(sidecar.Lifecycle.PostStart, stringReplacements)
+		applyLifecycleHandlerReplacements(sidecar.Lifecycle.PreStop, stringReplacements)
+	}
 	applySidecarReplacements(sidecar, stringReplacements, arrayReplacements)
 }
+
+// ApplyPipelineSidecarReplacements applies variable interpolation on a sidecar of a Pipeline.
+// Its name is left as is, as it names the sidecar's Pod and the variables holding its address.
+func ApplyPipelineSidecarReplacements(sidecar *v1.PipelineSidecar, stringReplacements map[string]string, arrayReplacements map[string][]string) {
+	c := &corev1.Container{
+		Image:   sidecar.Image,
+		Command: sidecar.Command,
+		Args:    sidecar.Args,
+		Env:     sidecar.Env,
+	}
+	applyContainerReplacements(c, stringReplacements, arrayReplacements)
+	sidecar.Image = c.Image
+	sidecar.Command = c.Command
+	sidecar.Args = c.Args
+	sidecar.Env = c.Env
+	applyProbeReplacements(sidecar.ReadinessProbe, stringReplacements)
+}
+
+// applyProbeReplacements applies variable interpolation on the exec command and httpGet fields of a Sidecar's probe.
+func applyProbeReplacements(probe *corev1.Probe, stringReplacements map[string]string) {
+	if probe == nil {
+		return
+	}
+	applyExecActionReplacements(probe.Exec, stringReplacements)
+	applyHTTPGetActionReplacements(probe.HTTPGet, stringReplacements)
+}
+
+// applyLifecycleHandlerReplacements applies variable interpolation on the exec command and httpGet fields of a
+// Sidecar's lifecycle hook.
+func applyLifecycleHandlerReplacements(handler *corev1.LifecycleHandler, stringReplacements map[string]string) {
+	if handler == nil {
+		return
+	}
+	applyExecActionReplacements(handler.Exec, stringReplacements)
+	applyHTTPGetActionReplacements(handler.HTTPGet, stringReplacements)
+}
+
+func applyExecActionReplacements(exec *corev1.ExecAction, stringReplacements map[string]string) {
+	if exec == nil {
+		return
+	}
+	for i, c := range exec.Command {
+		exec.Command[i] = substitution.ApplyReplacements(c, stringReplacements)
+	}
+}
+
+func applyHTTPGetActionReplacements(httpGet *corev1.HTTPGetAction, stringReplacements map[string]string) {
+	if httpGet == nil {
+		return
+	}
+	httpGet.Path = substitution.ApplyReplacements(httpGet.Path, stringReplacements)
+	httpGet.Host = substitution.ApplyReplacements(httpGet.Host, stringReplacements)
+	for i, h := range httpGet.HTTPHeaders {
+		httpGet.HTTPHeaders[i].Value = substitution.ApplyReplacements(h.Value, stringReplacements)
+	}
+}
@@ -126,3 +126,44 @@ func TestApplySidecarReplacements(t *testing.T) {
 		t.Errorf("Container replacements failed: %s", d)
 	}
 }
+
+func TestApplyPipelineSidecarReplacements(t *testing.T) {
+	replacements := map[string]string{
+		"replace.me": "replaced!",
+	}
+
+	arrayReplacements := map[string][]string{
+		"array.replace.me": {"val1", "val2"},
+	}
+
+	s := v1.PipelineSidecar{
+		Name:    "$(replace.me)",
+		Image:   "$(replace.me)",
+		Command: []string{"$(array.replace.me)"},
+		Args:    []string{"$(array.replace.me)"},
+		Env: []corev1.EnvVar{{
+			Name:  "not_me",
+			Value: "$(replace.me)",
+		}},
+		ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"$(replace.me)"}},
+		}},
+	}
+	expected := v1.PipelineSidecar{
+		Name:    "$(replace.me)",
+		Image:   "replaced!",
+		Command: []string{"val1", "val2"},
+		Args:    []string{"val1", "val2"},
+		Env: []corev1.EnvVar{{
+			Name:  "not_me",
+			Value: "replaced!",
+		}},
+		ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"replaced!"}},
+		}},
+	}
+	container.ApplyPipelineSidecarReplacements(&s, replacements, arrayReplacements)
+	if d := cmp.Diff(s, expected); d != "" {
+		t.Errorf("Container replacements failed: %s", d)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/container"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/utils/ptr"
 )
 
 func TestApplyStepReplacements(t *testing.T) {
@@ -154,3 +155,82 @@ func TestApplyStepReplacements(t *testing.T) {
 		t.Errorf("Container replacements failed: %s", d)
 	}
 }
+
+func TestApplyStepReplacements_SecurityContext(t *testing.T) {
+	replacements := map[string]string{
+		"params.capability":    "NET_ADMIN",
+		"params.selinux-level": "s0:c123,c456",
+		"params.seccomp":       "profiles/audit.json",
+		"params.windows-user":  "ContainerUser",
+	}
+
+	s := v1.Step{
+		Image: "foo",
+		SecurityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Add:  []corev1.Capability{"$(params.capability)"},
+				Drop: []corev1.Capability{"ALL"},
+			},
+			SELinuxOptions: &corev1.SELinuxOptions{
+				Level: "$(params.selinux-level)",
+			},
+			WindowsOptions: &corev1.WindowsSecurityContextOptions{
+				RunAsUserName: ptr.To("$(params.windows-user)"),
+			},
+			SeccompProfile: &corev1.SeccompProfile{
+				Type:             corev1.SeccompProfileTypeLocalhost,
+				LocalhostProfile: ptr.To("$(params.seccomp)"),
+			},
+		},
+	}
+
+	expected := v1.Step{
+		Image: "foo",
+		SecurityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Add:  []corev1.Capability{"NET_ADMIN"},
+				Drop: []corev1.Capability{"ALL"},
+			},
+			SELinuxOptions: &corev1.SELinuxOptions{
+				Level: "s0:c123,c456",
+			},
+			WindowsOptions: &corev1.WindowsSecurityContextOptions{
+				RunAsUserName: ptr.To("ContainerUser"),
+			},
+			SeccompProfile: &corev1.SeccompProfile{
+				Type:             corev1.SeccompProfileTypeLocalhost,
+				LocalhostProfile: ptr.To("profiles/audit.json"),
+			},
+		},
+	}
+	container.ApplyStepReplacements(&s, replacements, nil)
+	if d := cmp.Diff(expected, s); d != "" {
+		t.Errorf("Container replacements failed: %s", d)
+	}
+}
+
+func TestApplyStepReplacements_ArrayJoin(t *testing.T) {
+	arrayReplacements := map[string][]string{
+		"params.list": {"val1", "val2"},
+	}
+
+	s := v1.Step{
+		Image: "foo",
+		Env: []corev1.EnvVar{{
+			Name:  "LIST",
+			Value: "$(params.list[*]:,)",
+		}},
+	}
+
+	expected := v1.Step{
+		Image: "foo",
+		Env: []corev1.EnvVar{{
+			Name:  "LIST",
+			Value: "val1,val2",
+		}},
+	}
+	container.ApplyStepReplacements(&s, nil, arrayReplacements)
+	if d := cmp.Diff(expected, s); d != "" {
+		t.Errorf("Container replacements failed: %s", d)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 
 	"log"
@@ -28,12 +29,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
-	"github.com/google/cel-go/cel"
 	"github.com/tektoncd/pipeline/internal/artifactref"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1/types"
 	"github.com/tektoncd/pipeline/pkg/entrypoint/pipeline"
@@ -174,6 +175,27 @@ type Entrypointer struct {
 
 	// ArtifactsDirectory is the directory to find artifacts, defaults to pipeline.ArtifactsDir
 	ArtifactsDirectory string
+	// ResultsOverflowWriter receives the results which do not fit in the termination message.
+	// If nil, such results fail the step.
+	ResultsOverflowWriter io.Writer
+	// Trace records the time spent in each phase of the step
+	Trace bool
+
+	// FileResults is the set of task results of type file, whose content is uploaded to an object store
+	FileResults []string
+	// FileResultsURL is the URL under which the content of the results of type file is uploaded
+	FileResultsURL string
+	// MaxFileResultSize is the upper limit in bytes of the content of each result of type file
+	MaxFileResultSize int64
+	// FileResultsUploader encapsulates uploading the content of the results of type file.
+	FileResultsUploader FileResultsUploader
+
+	// WhenFiles are the files tested by the when expressions of the PipelineTasks following the TaskRun,
+	// indexed by the key under which their content is written to the termination message.
+	WhenFiles map[string]string
+	// LogLimiter caps the output of the step. If it truncated the output, this is recorded
+	// in the termination message.
+	LogLimiter *LogLimiter
 }
 
 // Waiter encapsulates waiting for files to exist.
@@ -193,11 +215,26 @@ type PostWriter interface {
 	Write(file, content string)
 }
 
+// FileResultsUploader encapsulates uploading the content of the results of type file.
+type FileResultsUploader interface {
+	// Upload uploads the size bytes of content to the URL.
+	Upload(ctx context.Context, url string, content io.Reader, size int64) error
+}
+
 // Go optionally waits for a file, runs the command, and writes a
 // post file.
 func (e Entrypointer) Go() error {
 	output := []result.RunResult{}
+	var tr *tracer
+	if e.Trace {
+		tr = &tracer{}
+	}
 	defer func() {
+		if traceResult, tErr := tr.write(e.StepMetadataDir); tErr != nil {
+			slog.Error("Error while writing trace:", slog.Any("error", tErr))
+		} else if traceResult != nil {
+			output = append(output, *traceResult)
+		}
 		if wErr := termination.WriteMessage(e.TerminationPath, output); wErr != nil {
 			log.Fatalf("Error while writing message: %s", wErr)
 		}
@@ -209,6 +246,7 @@ func (e Entrypointer) Go() error {
 	if err := os.MkdirAll(filepath.Join(e.StepMetadataDir, "artifacts"), os.ModePerm); err != nil {
 		return err
 	}
+	tr.start(TraceSpanWait)
 	for _, f := range e.WaitFiles {
 		if err := e.Waiter.Wait(context.Background(), f, e.WaitFileContent, e.BreakpointOnFailure); err != nil {
 			// An error happened while waiting, so we bail
@@ -230,6 +268,7 @@ func (e Entrypointer) Go() error {
 			return err
 		}
 	}
+	tr.end()
 
 	var err error
 	if e.DebugBeforeStep {
@@ -272,7 +311,10 @@ func (e Entrypointer) Go() error {
 		case err1 != nil:
 			err = err1
 		case allowExec:
+			slog.Debug("Starting step")
+			tr.start(TraceSpanExec)
 			err = e.Runner.Run(ctx, e.Command...)
+			tr.end()
 		default:
 			slog.Info("Step was skipped due to when expressions were evaluated to false.")
 			output = append(output, e.outputRunResult(TerminationReasonSkipped))
@@ -292,6 +334,9 @@ func (e Entrypointer) Go() error {
 		e.WritePostFile(e.PostFile, ErrContextCanceled)
 		e.WriteExitCodeFile(e.StepMetadataDir, syscall.SIGKILL.String())
 	case errors.Is(err, ErrContextDeadlineExceeded):
+		if e.Timeout != nil {
+			slog.Debug("Step timed out", slog.String("timeout", e.Timeout.String()))
+		}
 		e.WritePostFile(e.PostFile, err)
 		output = append(output, e.outputRunResult(TerminationReasonTimeoutExceeded))
 	case err != nil && e.BreakpointOnFailure:
@@ -315,27 +360,28 @@ func (e Entrypointer) Go() error {
 		e.WritePostFile(e.PostFile, err)
 	}
 
-	// strings.Split(..) with an empty string returns an array that contains one element, an empty string.
-	// This creates an error when trying to open the result folder as a file.
-	if len(e.Results) >= 1 && e.Results[0] != "" {
-		resultPath := pipeline.DefaultResultPath
-		if e.ResultsDirectory != "" {
-			resultPath = e.ResultsDirectory
-		}
-		if err := e.readResultsFromDisk(ctx, resultPath, result.TaskRunResultType); err != nil {
-			slog.Error("Error while substituting step artifacts:", slog.Any("error", err))
-			return err
-		}
+	if e.LogLimiter != nil && e.LogLimiter.Truncated() {
+		output = append(output, result.RunResult{
+			Key:        LogTruncatedKey,
+			Value:      "true",
+			ResultType: result.InternalTektonResultType,
+		})
 	}
-	if len(e.StepResults) >= 1 && e.StepResults[0] != "" {
-		stepResultPath := filepath.Join(e.StepMetadataDir, "results")
-		if e.ResultsDirectory != "" {
-			stepResultPath = e.ResultsDirectory
-		}
-		if err := e.readResultsFromDisk(ctx, stepResultPath, result.StepResultType); err != nil {
-			slog.Error("Error while substituting step artifacts:", slog.Any("error", err))
+
+	// the results span is ended when the trace is written
+	tr.start(TraceSpanResults)
+	if err := e.writeResultsFromDisk(ctx); err != nil {
+		slog.Error("Error while substituting step artifacts:", slog.Any("error", err))
+		return err
+	}
+
+	if err == nil && len(e.WhenFiles) > 0 {
+		whenFiles, err := e.readWhenFiles()
+		if err != nil {
+			slog.Error("Error while reading the files tested by when expressions:", slog.Any("error", err))
 			return err
 		}
+		output = append(output, whenFiles...)
 	}
 
 	if e.ResultExtractionMethod == ResultExtractionMethodTerminationMessage {
@@ -391,16 +437,7 @@ func (e Entrypointer) allowExec() (bool, error) {
 			return false, nil
 		}
 
-		env, err := cel.NewEnv()
-		if err != nil {
-			return false, err
-		}
-		ast, iss := env.Compile(we.CEL)
-		if iss.Err() != nil {
-			return false, iss.Err()
-		}
-		// Generate an evaluable instance of the Ast within the environment
-		prg, err := env.Program(ast)
+		prg, err := v1.NewWhenExpressionCELProgram(we.CEL)
 		if err != nil {
 			return false, err
 		}
@@ -434,7 +471,40 @@ func (e Entrypointer) waitBeforeStepDebug() error {
 	return nil
 }
 
-func (e Entrypointer) readResultsFromDisk(ctx context.Context, resultDir string, resultType result.ResultType) error {
+// writeResultsFromDisk reads the task and step results of the step from disk, and writes them to the
+// termination message at once, so that the results which do not fit in it overflow to the step's log
+// under a single marker.
+func (e Entrypointer) writeResultsFromDisk(ctx context.Context) error {
+	var output []result.RunResult
+	// strings.Split(..) with an empty string returns an array that contains one element, an empty string.
+	// This creates an error when trying to open the result folder as a file.
+	if len(e.Results) >= 1 && e.Results[0] != "" {
+		resultPath := pipeline.DefaultResultPath
+		if e.ResultsDirectory != "" {
+			resultPath = e.ResultsDirectory
+		}
+		results, err := e.readResultsFromDisk(ctx, resultPath, result.TaskRunResultType)
+		if err != nil {
+			return err
+		}
+		output = append(output, results...)
+	}
+	if len(e.StepResults) >= 1 && e.StepResults[0] != "" {
+		stepResultPath := filepath.Join(e.StepMetadataDir, "results")
+		if e.ResultsDirectory != "" {
+			stepResultPath = e.ResultsDirectory
+		}
+		results, err := e.readResultsFromDisk(ctx, stepResultPath, result.StepResultType)
+		if err != nil {
+			return err
+		}
+		output = append(output, results...)
+	}
+	return e.writeResults(output)
+}
+
+// readResultsFromDisk reads the results of the given type from resultDir, signing them if SPIRE is enabled.
+func (e Entrypointer) readResultsFromDisk(ctx context.Context, resultDir string, resultType result.ResultType) ([]result.RunResult, error) {
 	output := []result.RunResult{}
 	results := e.Results
 	if resultType == result.StepResultType {
@@ -444,11 +514,25 @@ func (e Entrypointer) readResultsFromDisk(ctx context.Context, resultDir string,
 		if resultFile == "" {
 			continue
 		}
+		if resultType == result.TaskRunResultType && slices.Contains(e.FileResults, resultFile) {
+			value, err := e.uploadFileResult(ctx, resultDir, resultFile)
+			if os.IsNotExist(err) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			output = append(output, result.RunResult{
+				Key:        resultFile,
+				Value:      value,
+				ResultType: resultType,
+			})
+			continue
+		}
 		fileContents, err := os.ReadFile(filepath.Join(resultDir, resultFile))
 		if os.IsNotExist(err) {
 			continue
 		} else if err != nil {
-			return err
+			return nil, err
 		}
 		// if the file doesn't exist, ignore it
 		output = append(output, result.RunResult{
@@ -460,17 +544,31 @@ func (e Entrypointer) readResultsFromDisk(ctx context.Context, resultDir string,
 
 	signed, err := signResults(ctx, e.SpireWorkloadAPI, output)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	output = append(output, signed...)
-
-	// push output to termination path
-	if e.ResultExtractionMethod == ResultExtractionMethodTerminationMessage && len(output) != 0 {
-		if err := termination.WriteMessage(e.TerminationPath, output); err != nil {
-			return err
+	if len(output) > 0 {
+		names := make([]string, 0, len(output))
+		for _, r := range output {
+			names = append(names, r.Key)
 		}
+		slog.Debug("Captured results", slog.Any("results", names))
 	}
-	return nil
+
+	return output, nil
+}
+
+// writeResults pushes the results to the termination path. Results which do not fit in the
+// termination message overflow to the step's log when a ResultsOverflowWriter is set.
+func (e Entrypointer) writeResults(output []result.RunResult) error {
+	if e.ResultExtractionMethod != ResultExtractionMethodTerminationMessage || len(output) == 0 {
+		return nil
+	}
+	// signed results must stay together in the termination message
+	if e.ResultsOverflowWriter != nil && e.SpireWorkloadAPI == nil {
+		return termination.WriteMessageWithOverflow(e.TerminationPath, output, e.ResultsOverflowWriter)
+	}
+	return termination.WriteMessage(e.TerminationPath, output)
 }
 
 // BreakpointExitCode reads the post file and returns the exit code it contains
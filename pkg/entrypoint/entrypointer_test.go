@@ -17,17 +17,21 @@ limitations under the License.
 package entrypoint
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -406,7 +410,6 @@ func TestReadResultsFromDisk(t *testing.T) {
 		},
 	} {
 		t.Run(c.desc, func(t *testing.T) {
-			ctx := t.Context()
 			terminationPath := "termination"
 			if terminationFile, err := os.CreateTemp(t.TempDir(), "termination"); err != nil {
 				t.Fatalf("unexpected error creating temporary termination file: %v", err)
@@ -439,7 +442,7 @@ func TestReadResultsFromDisk(t *testing.T) {
 				TerminationPath:        terminationPath,
 				ResultExtractionMethod: config.ResultExtractionMethodTerminationMessage,
 			}
-			if err := e.readResultsFromDisk(ctx, "", c.resultType); err != nil {
+			if err := e.writeResults(mustReadResultsFromDisk(t, e, "", c.resultType)); err != nil {
 				t.Fatal(err)
 			}
 			msg, err := os.ReadFile(terminationPath)
@@ -459,6 +462,396 @@ func TestReadResultsFromDisk(t *testing.T) {
 	}
 }
 
+func TestReadResultsFromDisk_Overflow(t *testing.T) {
+	dir := t.TempDir()
+	terminationPath := filepath.Join(dir, "termination")
+	large := strings.Repeat("a", termination.MaxContainerTerminationMessageLength)
+	if err := os.WriteFile(filepath.Join(dir, "large"), []byte(large), 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "small"), []byte("hello"), 0o777); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs strings.Builder
+	e := Entrypointer{
+		Results:                []string{"large", "small"},
+		TerminationPath:        terminationPath,
+		ResultExtractionMethod: config.ResultExtractionMethodTerminationMessage,
+		ResultsOverflowWriter:  &logs,
+	}
+	if err := e.writeResults(mustReadResultsFromDisk(t, e, dir, result.TaskRunResultType)); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := os.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, _ := logging.NewLogger("", "status")
+	got, err := termination.ParseMessage(logger, string(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Key != termination.OverflowResultsKey {
+		t.Fatalf("expected the overflowed results marker in the termination message, got %v", got)
+	}
+	want := []result.RunResult{{
+		Key:        "small",
+		Value:      "hello",
+		ResultType: result.TaskRunResultType,
+	}}
+	if d := cmp.Diff(want, got[1:]); d != "" {
+		t.Errorf("termination message %s", diff.PrintWantGot(d))
+	}
+	nonce, count, err := termination.ParseOverflowMarker(got[0].Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotOverflow, err := termination.ParseOverflowResults(strings.NewReader(logs.String()), nonce, count, 2*termination.MaxContainerTerminationMessageLength)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantOverflow := []result.RunResult{{
+		Key:        "large",
+		Value:      large,
+		ResultType: result.TaskRunResultType,
+	}}
+	if d := cmp.Diff(wantOverflow, gotOverflow); d != "" {
+		t.Errorf("overflowed results %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestWriteResultsFromDisk_OverflowTaskAndStepResults(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	terminationPath := filepath.Join(dir, "termination")
+	large := strings.Repeat("a", termination.MaxContainerTerminationMessageLength)
+	for name, content := range map[string]string{"task-large": large, "step-large": large, "small": "hello"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o777); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var logs strings.Builder
+	e := Entrypointer{
+		Results:                []string{"task-large", "small"},
+		StepResults:            []string{"step-large"},
+		ResultsDirectory:       dir,
+		TerminationPath:        terminationPath,
+		ResultExtractionMethod: config.ResultExtractionMethodTerminationMessage,
+		ResultsOverflowWriter:  &logs,
+	}
+	if err := e.writeResultsFromDisk(ctx); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := os.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, _ := logging.NewLogger("", "status")
+	got, err := termination.ParseMessage(logger, string(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := slices.IndexFunc(got, func(r result.RunResult) bool { return r.Key == termination.OverflowResultsKey })
+	if i < 0 {
+		t.Fatalf("expected the overflowed results marker in the termination message, got %v", got)
+	}
+	nonce, count, err := termination.ParseOverflowMarker(got[i].Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(logs.String(), termination.OverflowMarkerPrefix) != 1 {
+		t.Errorf("expected a single marker in the step log, got %q", logs.String())
+	}
+
+	gotOverflow, err := termination.ParseOverflowResults(strings.NewReader(logs.String()), nonce, count, 2*termination.MaxContainerTerminationMessageLength)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantOverflow := []result.RunResult{{
+		Key:        "task-large",
+		Value:      large,
+		ResultType: result.TaskRunResultType,
+	}, {
+		Key:        "step-large",
+		Value:      large,
+		ResultType: result.StepResultType,
+	}}
+	if d := cmp.Diff(wantOverflow, gotOverflow); d != "" {
+		t.Errorf("overflowed results %s", diff.PrintWantGot(d))
+	}
+}
+
+// mustReadResultsFromDisk reads the results of the given type from dir, failing the test on error.
+func mustReadResultsFromDisk(t *testing.T, e Entrypointer, dir string, resultType result.ResultType) []result.RunResult {
+	t.Helper()
+	results, err := e.readResultsFromDisk(t.Context(), dir, resultType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return results
+}
+
+func TestReadResultsFromDisk_FileResults(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	terminationPath := filepath.Join(dir, "termination")
+	if err := os.WriteFile(filepath.Join(dir, "sbom"), []byte("hello"), 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "small"), []byte("world"), 0o777); err != nil {
+		t.Fatal(err)
+	}
+
+	uploader := &fakeFileResultsUploader{uploads: map[string]string{}}
+	e := Entrypointer{
+		Results:                []string{"sbom", "small"},
+		TerminationPath:        terminationPath,
+		ResultExtractionMethod: config.ResultExtractionMethodTerminationMessage,
+		FileResults:            []string{"sbom"},
+		FileResultsURL:         "https://objects.example.com/ns/pod",
+		MaxFileResultSize:      5,
+		FileResultsUploader:    uploader,
+	}
+	// the results are read at the end of each step, the unchanged file result is only uploaded once
+	for range 2 {
+		if err := e.writeResults(mustReadResultsFromDisk(t, e, dir, result.TaskRunResultType)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := cmp.Diff(map[string]string{"https://objects.example.com/ns/pod/sbom": "hello"}, uploader.uploads); d != "" {
+		t.Errorf("uploads %s", diff.PrintWantGot(d))
+	}
+	if uploader.count != 1 {
+		t.Errorf("expected the file result to be uploaded once, got %d uploads", uploader.count)
+	}
+
+	msg, err := os.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, _ := logging.NewLogger("", "status")
+	got, err := termination.ParseMessage(logger, string(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []result.RunResult{{
+		Key:        "sbom",
+		Value:      `{"digest":"sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824","uri":"https://objects.example.com/ns/pod/sbom"}`,
+		ResultType: result.TaskRunResultType,
+	}, {
+		Key:        "small",
+		Value:      "world",
+		ResultType: result.TaskRunResultType,
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("termination message %s", diff.PrintWantGot(d))
+	}
+
+	e.MaxFileResultSize = 4
+	wantErr := `result "sbom" of type file is 5 bytes, which exceeds the maximum of 4 bytes`
+	if _, err := e.readResultsFromDisk(ctx, dir, result.TaskRunResultType); err == nil || err.Error() != wantErr {
+		t.Errorf("expected error %q, got %v", wantErr, err)
+	}
+}
+
+func TestReadWhenFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "summary.txt"), []byte("changed\n"), 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "large.txt"), []byte(strings.Repeat("a", MaxWhenFileSize+1)), 0o777); err != nil {
+		t.Fatal(err)
+	}
+
+	e := Entrypointer{
+		WhenFiles: map[string]string{
+			"source/summary.txt": filepath.Join(dir, "summary.txt"),
+			"source/missing.txt": filepath.Join(dir, "missing.txt"),
+		},
+	}
+	got, err := e.readWhenFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []result.RunResult{{
+		Key:        "source/summary.txt",
+		Value:      "changed\n",
+		ResultType: result.WhenFileResultType,
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("when files %s", diff.PrintWantGot(d))
+	}
+
+	e.WhenFiles["source/large.txt"] = filepath.Join(dir, "large.txt")
+	wantErr := fmt.Sprintf("file %q tested by a when expression is 1025 bytes, which exceeds the maximum of 1024 bytes", filepath.Join(dir, "large.txt"))
+	if _, err := e.readWhenFiles(); err == nil || err.Error() != wantErr {
+		t.Errorf("expected error %q, got %v", wantErr, err)
+	}
+}
+
+func TestEntrypointerTrace(t *testing.T) {
+	dir := t.TempDir()
+	terminationPath := filepath.Join(dir, "termination")
+	e := Entrypointer{
+		Command:         []string{"echo", "hello"},
+		WaitFiles:       []string{"waitforme"},
+		TerminationPath: terminationPath,
+		Waiter:          &fakeWaiter{},
+		Runner:          &fakeRunner{},
+		PostWriter:      &fakePostWriter{},
+		StepMetadataDir: dir,
+		Trace:           true,
+	}
+	if err := e.Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, TraceFile))
+	if err != nil {
+		t.Fatalf("Error reading trace file: %v", err)
+	}
+	var spans []TraceSpan
+	if err := json.Unmarshal(b, &spans); err != nil {
+		t.Fatalf("Error parsing trace file: %v", err)
+	}
+	var names []string
+	for _, s := range spans {
+		if _, err := time.ParseDuration(s.Duration); err != nil {
+			t.Errorf("span %q has an invalid duration %q", s.Name, s.Duration)
+		}
+		names = append(names, s.Name)
+	}
+	if d := cmp.Diff([]string{TraceSpanWait, TraceSpanExec, TraceSpanResults}, names); d != "" {
+		t.Errorf("trace spans %s", diff.PrintWantGot(d))
+	}
+
+	msg, err := os.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, _ := logging.NewLogger("", "status")
+	results, err := termination.ParseMessage(logger, string(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, r := range results {
+		if r.Key == TraceResultKey && r.ResultType == result.InternalTektonResultType {
+			found = true
+			if r.Value != string(b) {
+				t.Errorf("trace result %q does not match the trace file %q", r.Value, string(b))
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected a %q result in the termination message, got %v", TraceResultKey, results)
+	}
+}
+
+func TestLogLimiter(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		maxBytes      int64
+		maxLines      int64
+		writes        []string
+		want          string
+		wantTruncated bool
+	}{{
+		name:     "within the limits",
+		maxBytes: 100,
+		maxLines: 10,
+		writes:   []string{"one\n", "two\n"},
+		want:     "one\ntwo\n",
+	}, {
+		name:     "exactly at the byte limit",
+		maxBytes: 8,
+		writes:   []string{"one\n", "two\n"},
+		want:     "one\ntwo\n",
+	}, {
+		name:          "byte limit",
+		maxBytes:      6,
+		writes:        []string{"one\n", "two\n", "three\n"},
+		want:          "one\ntw\n[tekton] the output of the step exceeded the limit of 6 bytes, the rest of it is discarded\n",
+		wantTruncated: true,
+	}, {
+		name:          "line limit",
+		maxLines:      2,
+		writes:        []string{"one\ntwo\nthree\n", "four\n"},
+		want:          "one\ntwo\n\n[tekton] the output of the step exceeded the limit of 2 lines, the rest of it is discarded\n",
+		wantTruncated: true,
+	}, {
+		name:          "line limit reached by a previous write",
+		maxBytes:      100,
+		maxLines:      1,
+		writes:        []string{"one\n", "two\n"},
+		want:          "one\n\n[tekton] the output of the step exceeded the limit of 100 bytes or 1 lines, the rest of it is discarded\n",
+		wantTruncated: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewLogLimiter(tc.maxBytes, tc.maxLines)
+			var buf bytes.Buffer
+			w := l.Writer(&buf)
+			for _, s := range tc.writes {
+				n, err := w.Write([]byte(s))
+				if err != nil {
+					t.Fatalf("Write() = %v", err)
+				}
+				if n != len(s) {
+					t.Errorf("Write() = %d, want %d", n, len(s))
+				}
+			}
+			if d := cmp.Diff(tc.want, buf.String()); d != "" {
+				t.Errorf("output %s", diff.PrintWantGot(d))
+			}
+			if l.Truncated() != tc.wantTruncated {
+				t.Errorf("Truncated() = %t, want %t", l.Truncated(), tc.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestNewLogLimiterNoLimit(t *testing.T) {
+	if l := NewLogLimiter(0, 0); l != nil {
+		t.Errorf("NewLogLimiter(0, 0) = %v, want nil", l)
+	}
+}
+
+func TestEntrypointerLogTruncated(t *testing.T) {
+	dir := t.TempDir()
+	terminationPath := filepath.Join(dir, "termination")
+	limiter := NewLogLimiter(4, 0)
+	e := Entrypointer{
+		Command:         []string{"echo", "hello"},
+		TerminationPath: terminationPath,
+		Waiter:          &fakeWaiter{},
+		Runner:          &fakeOutputRunner{output: limiter.Writer(io.Discard)},
+		PostWriter:      &fakePostWriter{},
+		StepMetadataDir: dir,
+		LogLimiter:      limiter,
+	}
+	if err := e.Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	msg, err := os.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, _ := logging.NewLogger("", "status")
+	results, err := termination.ParseMessage(logger, string(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.ContainsFunc(results, func(r result.RunResult) bool {
+		return r.Key == LogTruncatedKey && r.Value == "true" && r.ResultType == result.InternalTektonResultType
+	}) {
+		t.Errorf("expected a %q result in the termination message, got %v", LogTruncatedKey, results)
+	}
+}
+
 func TestEntrypointer_ReadBreakpointExitCodeFromDisk(t *testing.T) {
 	expectedExitCode := 1
 	// setup test
@@ -2187,6 +2580,16 @@ func (f *fakeRunner) Run(ctx context.Context, args ...string) error {
 	return f.runError
 }
 
+// fakeOutputRunner writes the command to output
+type fakeOutputRunner struct {
+	output io.Writer
+}
+
+func (f *fakeOutputRunner) Run(ctx context.Context, args ...string) error {
+	_, err := fmt.Fprintln(f.output, strings.Join(args, " "))
+	return err
+}
+
 type fakePostWriter struct {
 	wrote        *string
 	exitCodeFile *string
@@ -2202,6 +2605,21 @@ func (f *fakePostWriter) Write(file, content string) {
 	}
 }
 
+type fakeFileResultsUploader struct {
+	uploads map[string]string
+	count   int
+}
+
+func (f *fakeFileResultsUploader) Upload(_ context.Context, url string, content io.Reader, _ int64) error {
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	f.uploads[url] = string(b)
+	f.count++
+	return nil
+}
+
 type fakeErrorWaiter struct{ waited *string }
 
 func (f *fakeErrorWaiter) Wait(ctx context.Context, file string, expectContent bool, breakpointOnFailure bool) error {
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1/types"
+)
+
+// uploadedFileResultSuffix is the suffix of the files recording the digest of the last upload of a
+// result of type file, so that the steps following the one which produced it do not upload it again.
+const uploadedFileResultSuffix = ".uploaded"
+
+// uploadFileResult uploads the content of the result of type file with the given name, and returns
+// the value of the result: an object holding the URI and the digest of the upload.
+func (e Entrypointer) uploadFileResult(ctx context.Context, resultDir, name string) (string, error) {
+	f, err := os.Open(filepath.Join(resultDir, name))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	size := info.Size()
+	if e.MaxFileResultSize > 0 && size > e.MaxFileResultSize {
+		return "", fmt.Errorf("result %q of type file is %d bytes, which exceeds the maximum of %d bytes", name, size, e.MaxFileResultSize)
+	}
+	if e.FileResultsUploader == nil || e.FileResultsURL == "" {
+		return "", fmt.Errorf("no object store is configured to upload result %q of type file", name)
+	}
+
+	h := sha256.New()
+	if _, err := io.CopyN(h, f, size); err != nil {
+		return "", fmt.Errorf("computing the digest of result %q: %w", name, err)
+	}
+	digest := "sha256:" + hex.EncodeToString(h.Sum(nil))
+	uri := e.FileResultsURL + "/" + name
+
+	uploaded := filepath.Join(resultDir, "."+name+uploadedFileResultSuffix)
+	if previous, err := os.ReadFile(uploaded); err != nil || string(previous) != digest {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		}
+		if err := e.FileResultsUploader.Upload(ctx, uri, io.LimitReader(f, size), size); err != nil {
+			return "", fmt.Errorf("uploading result %q of type file: %w", name, err)
+		}
+		// failing to record the upload only means that the next steps upload the result again
+		_ = os.WriteFile(uploaded, []byte(digest), 0o644)
+	}
+
+	value, err := json.Marshal(map[string]string{
+		v1.FileResultURIKey:    uri,
+		v1.FileResultDigestKey: digest,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// LogTruncatedKey is the key of the internal result written to the termination message
+// of a step whose output was truncated by its LogLimiter.
+const LogTruncatedKey = "LogTruncated"
+
+// LogLimiter caps the volume of the output of a step, stdout and stderr combined, to a number
+// of bytes and lines. The output beyond the limits is discarded, without failing the step.
+type LogLimiter struct {
+	maxBytes int64
+	maxLines int64
+
+	mu        sync.Mutex
+	bytes     int64
+	lines     int64
+	truncated bool
+}
+
+// NewLogLimiter returns a LogLimiter for the given limits, 0 meaning no limit.
+// It returns nil if there is no limit at all.
+func NewLogLimiter(maxBytes, maxLines int64) *LogLimiter {
+	if maxBytes <= 0 && maxLines <= 0 {
+		return nil
+	}
+	return &LogLimiter{maxBytes: maxBytes, maxLines: maxLines}
+}
+
+// Writer returns a writer which forwards to w the output within the limits shared
+// by all the writers of the LogLimiter.
+func (l *LogLimiter) Writer(w io.Writer) io.Writer {
+	return &limitedWriter{limiter: l, w: w}
+}
+
+// Truncated returns true if some of the output was discarded.
+func (l *LogLimiter) Truncated() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.truncated
+}
+
+type limitedWriter struct {
+	limiter *LogLimiter
+	w       io.Writer
+}
+
+// Write always reports p as written, so that the step does not fail on a broken pipe once
+// its output is discarded.
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	l := lw.limiter
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.truncated || len(p) == 0 {
+		return len(p), nil
+	}
+
+	n := len(p)
+	if l.maxBytes > 0 && l.bytes+int64(n) > l.maxBytes {
+		n = int(l.maxBytes - l.bytes)
+	}
+	lines := l.lines
+	if l.maxLines > 0 {
+		if lines >= l.maxLines {
+			n = 0
+		}
+		for i := 0; i < n; {
+			j := bytes.IndexByte(p[i:n], '\n')
+			if j < 0 {
+				break
+			}
+			i += j + 1
+			lines++
+			if lines == l.maxLines {
+				n = i
+			}
+		}
+	}
+
+	if _, err := lw.w.Write(p[:n]); err != nil {
+		return 0, err
+	}
+	l.bytes += int64(n)
+	l.lines = lines
+	if n < len(p) {
+		l.truncated = true
+		if _, err := fmt.Fprintf(lw.w, "\n[tekton] the output of the step exceeded the limit of %s, the rest of it is discarded\n", l.limits()); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (l *LogLimiter) limits() string {
+	switch {
+	case l.maxBytes > 0 && l.maxLines > 0:
+		return fmt.Sprintf("%d bytes or %d lines", l.maxBytes, l.maxLines)
+	case l.maxBytes > 0:
+		return fmt.Sprintf("%d bytes", l.maxBytes)
+	default:
+		return fmt.Sprintf("%d lines", l.maxLines)
+	}
+}
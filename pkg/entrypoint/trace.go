@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/result"
+)
+
+const (
+	// TraceResultKey is the key of the internal result carrying the trace of the step.
+	TraceResultKey = "Trace"
+	// TraceFile is the name of the file in the step metadata directory the trace of the step is written to.
+	TraceFile = "trace.json"
+
+	// TraceSpanWait is the time spent waiting for the previous step (or for the Pod to be ready) before starting.
+	TraceSpanWait = "wait"
+	// TraceSpanExec is the time spent executing the step's command.
+	TraceSpanExec = "exec"
+	// TraceSpanResults is the time spent capturing the step's results.
+	TraceSpanResults = "results"
+)
+
+// TraceSpan is the time spent by the entrypoint in one phase of the step.
+type TraceSpan struct {
+	Name      string `json:"name"`
+	StartedAt string `json:"startedAt"`
+	Duration  string `json:"duration"`
+
+	start time.Time
+}
+
+// tracer records the phases of the step. A nil tracer records nothing.
+type tracer struct {
+	spans []TraceSpan
+	open  *TraceSpan
+}
+
+// start ends the current phase, if any, and starts the given one.
+func (t *tracer) start(name string) {
+	if t == nil {
+		return
+	}
+	t.end()
+	now := time.Now()
+	t.open = &TraceSpan{Name: name, StartedAt: now.Format(timeFormat), start: now}
+}
+
+// end ends the current phase, if any.
+func (t *tracer) end() {
+	if t == nil || t.open == nil {
+		return
+	}
+	t.open.Duration = time.Since(t.open.start).String()
+	t.spans = append(t.spans, *t.open)
+	t.open = nil
+}
+
+// write ends the current phase and writes the trace to the step metadata directory. The
+// trace is also returned as an internal result for the controller to report it in the
+// TaskRun status.
+func (t *tracer) write(stepMetadataDir string) (*result.RunResult, error) {
+	if t == nil {
+		return nil, nil
+	}
+	t.end()
+	b, err := json.Marshal(t.spans)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(stepMetadataDir, TraceFile), b, 0o644); err != nil {
+		return nil, err
+	}
+	return &result.RunResult{
+		Key:        TraceResultKey,
+		Value:      string(b),
+		ResultType: result.InternalTektonResultType,
+	}, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/tektoncd/pipeline/pkg/result"
+)
+
+// MaxWhenFileSize is the upper limit in bytes of the content of a file tested by a when expression,
+// which has to fit in the termination message of the step along with its results.
+const MaxWhenFileSize = 1024
+
+// readWhenFiles reads the content of the files tested by the when expressions of the PipelineTasks
+// following the TaskRun. The files which don't exist are left out.
+func (e Entrypointer) readWhenFiles() ([]result.RunResult, error) {
+	var output []result.RunResult
+	for _, key := range slices.Sorted(maps.Keys(e.WhenFiles)) {
+		path := e.WhenFiles[key]
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if info.Size() > MaxWhenFileSize {
+			return nil, fmt.Errorf("file %q tested by a when expression is %d bytes, which exceeds the maximum of %d bytes", path, info.Size(), MaxWhenFileSize)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, result.RunResult{
+			Key:        key,
+			Value:      string(content),
+			ResultType: result.WhenFileResultType,
+		})
+	}
+	return output, nil
+}
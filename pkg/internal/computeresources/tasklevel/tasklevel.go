@@ -17,13 +17,40 @@ limitations under the License.
 package tasklevel
 
 import (
+	"fmt"
+
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
-// ApplyTaskLevelComputeResources applies the task-level compute resource requirements to each Step.
-func ApplyTaskLevelComputeResources(steps []v1.Step, computeResources *corev1.ResourceRequirements) {
+// Strategy is the strategy used to distribute the task-level compute resources across the Steps.
+type Strategy string
+
+const (
+	// StrategyAverage divides the task-level requests evenly across the Steps, while each Step
+	// is limited by the task-level limits. It is the default Strategy.
+	StrategyAverage Strategy = "average"
+	// StrategySplit divides both the task-level requests and limits evenly across the Steps,
+	// so that the limits of the Steps add up to the task-level limits.
+	StrategySplit Strategy = "split"
+)
+
+// ParseStrategy returns the Strategy named by s, StrategyAverage if s is empty.
+func ParseStrategy(s string) (Strategy, error) {
+	switch Strategy(s) {
+	case "", StrategyAverage:
+		return StrategyAverage, nil
+	case StrategySplit:
+		return StrategySplit, nil
+	default:
+		return "", fmt.Errorf("unknown compute resources strategy %q, must be one of %q or %q", s, StrategyAverage, StrategySplit)
+	}
+}
+
+// ApplyTaskLevelComputeResources applies the task-level compute resource requirements to each Step,
+// distributing them according to the given Strategy.
+func ApplyTaskLevelComputeResources(steps []v1.Step, computeResources *corev1.ResourceRequirements, strategy Strategy) {
 	if computeResources == nil {
 		return
 	}
@@ -32,6 +59,10 @@ func ApplyTaskLevelComputeResources(steps []v1.Step, computeResources *corev1.Re
 	}
 	averageRequests := computeAverageRequests(computeResources.Requests, len(steps))
 	averageLimits := computeAverageRequests(computeResources.Limits, len(steps))
+	limits := computeResources.Limits
+	if strategy == StrategySplit {
+		limits = averageLimits
+	}
 	for i := range steps {
 		// if no requests are specified in step or task level, the limits are used to avoid
 		// unnecessary higher requests by Kubernetes default behavior.
@@ -40,7 +71,7 @@ func ApplyTaskLevelComputeResources(steps []v1.Step, computeResources *corev1.Re
 		} else {
 			steps[i].ComputeResources.Requests = averageRequests
 		}
-		steps[i].ComputeResources.Limits = computeResources.Limits
+		steps[i].ComputeResources.Limits = limits
 	}
 }
 
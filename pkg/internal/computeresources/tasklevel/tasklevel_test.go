@@ -33,6 +33,7 @@ func TestApplyTaskLevelResourceRequirements(t *testing.T) {
 		desc                     string
 		Steps                    []v1.Step
 		ComputeResources         corev1.ResourceRequirements
+		strategy                 tasklevel.Strategy
 		expectedComputeResources []corev1.ResourceRequirements
 	}{{
 		desc: "only with requests",
@@ -221,11 +222,76 @@ func TestApplyTaskLevelResourceRequirements(t *testing.T) {
 			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
 			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
 		}},
+	}, {
+		desc: "split strategy with requests and limits",
+		Steps: []v1.Step{{
+			Name:    "1st-step",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Name:    "2nd-step",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+		ComputeResources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("2Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Mi"),
+			},
+		},
+		strategy: tasklevel.StrategySplit,
+		expectedComputeResources: []corev1.ResourceRequirements{{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("2Mi"),
+			},
+		}, {
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("2Mi"),
+			},
+		}},
+	}, {
+		desc: "split strategy with only limits",
+		Steps: []v1.Step{{
+			Name:    "1st-step",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Name:    "2nd-step",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+		ComputeResources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			},
+		},
+		strategy: tasklevel.StrategySplit,
+		expectedComputeResources: []corev1.ResourceRequirements{{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+		}, {
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+		}},
 	}}
 
 	for _, tc := range testcases {
 		t.Run(tc.desc, func(t *testing.T) {
-			tasklevel.ApplyTaskLevelComputeResources(tc.Steps, &tc.ComputeResources)
+			tasklevel.ApplyTaskLevelComputeResources(tc.Steps, &tc.ComputeResources, tc.strategy)
 
 			if err := verifyTaskLevelComputeResources(tc.Steps, tc.expectedComputeResources); err != nil {
 				t.Errorf("verifyTaskLevelComputeResources: %v", err)
@@ -234,6 +300,29 @@ func TestApplyTaskLevelResourceRequirements(t *testing.T) {
 	}
 }
 
+func TestParseStrategy(t *testing.T) {
+	for _, tc := range []struct {
+		value   string
+		want    tasklevel.Strategy
+		wantErr bool
+	}{
+		{value: "", want: tasklevel.StrategyAverage},
+		{value: "average", want: tasklevel.StrategyAverage},
+		{value: "split", want: tasklevel.StrategySplit},
+		{value: "foo", wantErr: true},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := tasklevel.ParseStrategy(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseStrategy(%q) error = %v, wantErr %t", tc.value, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseStrategy(%q) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
 // verifyTaskLevelComputeResources verifies that the given TaskRun's containers have the expected compute resources.
 func verifyTaskLevelComputeResources(steps []v1.Step, expectedComputeResources []corev1.ResourceRequirements) error {
 	if len(expectedComputeResources) != len(steps) {
@@ -18,13 +18,22 @@ package defaultresourcerequirements
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/pod"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/yaml"
 )
 
+// StepResourceRequirementsKey is the key of the namespace-scoped ConfigMap holding the default
+// resource requirements of the steps which declare none.
+const StepResourceRequirementsKey = "default-step-resource-requirements"
+
 // NewTransformer returns a pod.Transformer that will modify container resources if needed
 func NewTransformer(ctx context.Context) pod.Transformer {
 	// update init container and containers resource requirements
@@ -35,6 +44,51 @@ func NewTransformer(ctx context.Context) pod.Transformer {
 	}
 }
 
+// ErrStepResourcesConfigMapUnavailable is returned when the ConfigMap holding the default resource
+// requirements of the steps of a namespace couldn't be read. It is transient, the TaskRun is retried.
+var ErrStepResourcesConfigMapUnavailable = errors.New("default step resources ConfigMap unavailable")
+
+// NewNamespaceTransformer returns a pod.Transformer that will set the resource requirements of the step
+// containers which have none to the defaults of the namespace. The defaults are read from the ConfigMap
+// named by "default-step-resources-configmap" in config-defaults, in the namespace of the TaskRun.
+func NewNamespaceTransformer(ctx context.Context, namespace string, lister corev1listers.ConfigMapLister) pod.Transformer {
+	configMapName := config.FromContextOrDefaults(ctx).Defaults.DefaultStepResourcesConfigMap
+	return func(p *corev1.Pod) (*corev1.Pod, error) {
+		if configMapName == "" {
+			return p, nil
+		}
+		cm, err := lister.ConfigMaps(namespace).Get(configMapName)
+		if k8serrors.IsNotFound(err) {
+			return p, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: failed to get %s/%s: %w", ErrStepResourcesConfigMapUnavailable, namespace, configMapName, err)
+		}
+		value, ok := cm.Data[StepResourceRequirementsKey]
+		if !ok {
+			return p, nil
+		}
+		var resourceRequirements corev1.ResourceRequirements
+		if err := yaml.UnmarshalStrict([]byte(value), &resourceRequirements); err != nil {
+			return nil, fmt.Errorf("failed to parse %q of the default step resources ConfigMap %s/%s: %w", StepResourceRequirementsKey, namespace, configMapName, err)
+		}
+		return updateStepResourceRequirements(resourceRequirements, p), nil
+	}
+}
+
+// updates the resource requirements of the step containers of a pod which have none.
+func updateStepResourceRequirements(resourceRequirements corev1.ResourceRequirements, p *corev1.Pod) *corev1.Pod {
+	if resourceRequirements.Size() == 0 {
+		return p
+	}
+	for index := range p.Spec.Containers {
+		c := &p.Spec.Containers[index]
+		if pod.IsContainerStep(c.Name) && c.Resources.Size() == 0 {
+			c.Resources = *resourceRequirements.DeepCopy()
+		}
+	}
+	return p
+}
+
 // updates init containers and containers resource requirements of a pod base of config_defaults configmap.
 func updateResourceRequirements(resourceRequirementsMap map[string]corev1.ResourceRequirements, pod *corev1.Pod) *corev1.Pod {
 	if len(resourceRequirementsMap) == 0 {
@@ -17,6 +17,7 @@ limitations under the License.
 package defaultresourcerequirements
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -25,6 +26,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 func TestNewTransformer(t *testing.T) {
@@ -413,3 +417,150 @@ func TestNewTransformer(t *testing.T) {
 		})
 	}
 }
+
+func TestNewNamespaceTransformer(t *testing.T) {
+	stepResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+	}
+	ownResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("1"),
+		},
+	}
+	testPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "custom-ns"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: "prepare"},
+			},
+			Containers: []corev1.Container{
+				{Name: "step-build"},
+				{Name: "step-push", Resources: ownResources},
+				{Name: "sidecar-registry"},
+			},
+		},
+	}
+	withStepResources := func() *corev1.Pod {
+		p := testPod.DeepCopy()
+		p.Spec.Containers[0].Resources = stepResources
+		return p
+	}
+	configMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "tekton-step-defaults", Namespace: "custom-ns"},
+			Data:       data,
+		}
+	}
+
+	tcs := []struct {
+		name          string
+		configMapName string
+		configMaps    []*corev1.ConfigMap
+		expectedPod   *corev1.Pod
+		expectedError bool
+	}{{
+		name:          "not configured",
+		configMapName: "",
+		configMaps: []*corev1.ConfigMap{configMap(map[string]string{
+			StepResourceRequirementsKey: "requests:\n  cpu: 100m\n",
+		})},
+		expectedPod: testPod.DeepCopy(),
+	}, {
+		name:          "configmap not found",
+		configMapName: "tekton-step-defaults",
+		expectedPod:   testPod.DeepCopy(),
+	}, {
+		name:          "key missing",
+		configMapName: "tekton-step-defaults",
+		configMaps:    []*corev1.ConfigMap{configMap(map[string]string{"foo": "bar"})},
+		expectedPod:   testPod.DeepCopy(),
+	}, {
+		name:          "configmap in another namespace",
+		configMapName: "tekton-step-defaults",
+		configMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: "tekton-step-defaults", Namespace: "other-ns"},
+			Data: map[string]string{
+				StepResourceRequirementsKey: "requests:\n  cpu: 100m\n",
+			},
+		}},
+		expectedPod: testPod.DeepCopy(),
+	}, {
+		name:          "applied to steps without resources",
+		configMapName: "tekton-step-defaults",
+		configMaps: []*corev1.ConfigMap{configMap(map[string]string{
+			StepResourceRequirementsKey: "requests:\n  memory: 128Mi\n  cpu: 100m\nlimits:\n  memory: 256Mi\n",
+		})},
+		expectedPod: withStepResources(),
+	}, {
+		name:          "invalid resource requirements",
+		configMapName: "tekton-step-defaults",
+		configMaps: []*corev1.ConfigMap{configMap(map[string]string{
+			StepResourceRequirementsKey: "requests:\n  cpu: 100m\nfoo: bar\n",
+		})},
+		expectedError: true,
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				Defaults: &config.Defaults{
+					DefaultStepResourcesConfigMap: tc.configMapName,
+				},
+			})
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, cm := range tc.configMaps {
+				if err := indexer.Add(cm); err != nil {
+					t.Fatalf("failed to add configmap: %v", err)
+				}
+			}
+
+			transformer := NewNamespaceTransformer(ctx, "custom-ns", corev1listers.NewConfigMapLister(indexer))
+			transformedPod, err := transformer(testPod.DeepCopy())
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if d := cmp.Diff(tc.expectedPod, transformedPod); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// failingConfigMapLister is a ConfigMapLister whose reads fail.
+type failingConfigMapLister struct{}
+
+func (failingConfigMapLister) List(labels.Selector) ([]*corev1.ConfigMap, error) {
+	return nil, errors.New("cache not synced")
+}
+
+func (l failingConfigMapLister) ConfigMaps(string) corev1listers.ConfigMapNamespaceLister {
+	return l
+}
+
+func (failingConfigMapLister) Get(string) (*corev1.ConfigMap, error) {
+	return nil, errors.New("cache not synced")
+}
+
+func TestNewNamespaceTransformerUnavailableConfigMap(t *testing.T) {
+	ctx := config.ToContext(t.Context(), &config.Config{
+		Defaults: &config.Defaults{
+			DefaultStepResourcesConfigMap: "tekton-step-defaults",
+		},
+	})
+	transformer := NewNamespaceTransformer(ctx, "custom-ns", failingConfigMapLister{})
+	if _, err := transformer(&corev1.Pod{}); !errors.Is(err, ErrStepResourcesConfigMapUnavailable) {
+		t.Errorf("expected an ErrStepResourcesConfigMapUnavailable error, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologyaffinity
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/workspace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// nodeAffinityWeight is the weight of the preferred pod affinity term that
+	// schedules the TaskRun pods of a PipelineRun to the same node.
+	nodeAffinityWeight = 100
+	// zoneAffinityWeight is the weight of the preferred pod affinity term that
+	// schedules the TaskRun pods of a PipelineRun to the same zone.
+	zoneAffinityWeight = 50
+)
+
+// NewTransformer returns a pod.Transformer that will add preferred pod affinity terms
+// so that the pods of the TaskRuns of a PipelineRun are scheduled to the same node,
+// or at least the same zone, if "enable-topology-aware-scheduling" is enabled.
+func NewTransformer(ctx context.Context) pod.Transformer {
+	enabled := config.FromContextOrDefaults(ctx).FeatureFlags.EnableTopologyAwareScheduling
+	return func(p *corev1.Pod) (*corev1.Pod, error) {
+		if !enabled {
+			return p, nil
+		}
+		pipelineRunUID := p.Labels[pipeline.PipelineRunUIDLabelKey]
+		if pipelineRunUID == "" {
+			return p, nil
+		}
+		// The pod is already required to run on the node of the Affinity Assistant.
+		if p.Annotations[workspace.AnnotationAffinityAssistantName] != "" {
+			return p, nil
+		}
+		if p.Spec.Affinity == nil {
+			p.Spec.Affinity = &corev1.Affinity{}
+		}
+		mergeAffinityWithPipelineRun(p.Spec.Affinity, pipelineRunUID)
+		return p, nil
+	}
+}
+
+func mergeAffinityWithPipelineRun(affinity *corev1.Affinity, pipelineRunUID string) {
+	if affinity.PodAffinity == nil {
+		affinity.PodAffinity = &corev1.PodAffinity{}
+	}
+
+	affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+		affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		weightedPodAffinityTermUsingPipelineRun(pipelineRunUID, corev1.LabelHostname, nodeAffinityWeight),
+		weightedPodAffinityTermUsingPipelineRun(pipelineRunUID, corev1.LabelTopologyZone, zoneAffinityWeight),
+	)
+}
+
+// weightedPodAffinityTermUsingPipelineRun achieves a preferred pod affinity term for
+// taskRun pods so that they are scheduled to the topology domain, given by topologyKey,
+// where the other taskRun pods of the same PipelineRun are running.
+func weightedPodAffinityTermUsingPipelineRun(pipelineRunUID, topologyKey string, weight int32) corev1.WeightedPodAffinityTerm {
+	return corev1.WeightedPodAffinityTerm{
+		Weight: weight,
+		PodAffinityTerm: corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					pipeline.PipelineRunUIDLabelKey: pipelineRunUID,
+				},
+			},
+			TopologyKey: topologyKey,
+		},
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package topologyaffinity_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/internal/topologyaffinity"
+	"github.com/tektoncd/pipeline/pkg/workspace"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewTransformer(t *testing.T) {
+	podAffinityTerm := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"from": "podtemplate",
+			},
+		},
+		TopologyKey: "kubernetes.io/hostname",
+	}
+	pipelineRunAffinityTerms := []corev1.WeightedPodAffinityTerm{{
+		Weight: 100,
+		PodAffinityTerm: corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					pipeline.PipelineRunUIDLabelKey: "some-uid",
+				},
+			},
+			TopologyKey: "kubernetes.io/hostname",
+		},
+	}, {
+		Weight: 50,
+		PodAffinityTerm: corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					pipeline.PipelineRunUIDLabelKey: "some-uid",
+				},
+			},
+			TopologyKey: "topology.kubernetes.io/zone",
+		},
+	}}
+
+	for _, tc := range []struct {
+		description string
+		enabled     bool
+		pod         *corev1.Pod
+		expected    *corev1.Affinity
+	}{{
+		description: "feature flag disabled",
+		pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{pipeline.PipelineRunUIDLabelKey: "some-uid"},
+			},
+		},
+	}, {
+		description: "taskrun not part of a pipelinerun",
+		enabled:     true,
+		pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{pipeline.TaskRunLabelKey: "some-taskrun"},
+			},
+		},
+	}, {
+		description: "pod scheduled with the affinity assistant",
+		enabled:     true,
+		pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{pipeline.PipelineRunUIDLabelKey: "some-uid"},
+				Annotations: map[string]string{workspace.AnnotationAffinityAssistantName: "affinity-assistant"},
+			},
+		},
+	}, {
+		description: "taskrun part of a pipelinerun",
+		enabled:     true,
+		pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{pipeline.PipelineRunUIDLabelKey: "some-uid"},
+			},
+		},
+		expected: &corev1.Affinity{
+			PodAffinity: &corev1.PodAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: pipelineRunAffinityTerms,
+			},
+		},
+	}, {
+		description: "taskrun part of a pipelinerun and pod contains podAffinity",
+		enabled:     true,
+		pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{pipeline.PipelineRunUIDLabelKey: "some-uid"},
+			},
+			Spec: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					PodAffinity: &corev1.PodAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{podAffinityTerm},
+					},
+				},
+			},
+		},
+		expected: &corev1.Affinity{
+			PodAffinity: &corev1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution:  []corev1.PodAffinityTerm{podAffinityTerm},
+				PreferredDuringSchedulingIgnoredDuringExecution: pipelineRunAffinityTerms,
+			},
+		},
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			featureFlags, err := config.NewFeatureFlagsFromMap(map[string]string{
+				config.EnableTopologyAwareScheduling: strconv.FormatBool(tc.enabled),
+			})
+			if err != nil {
+				t.Fatalf("Failed to create feature flags: %v", err)
+			}
+			ctx := config.ToContext(t.Context(), &config.Config{FeatureFlags: featureFlags})
+			f := topologyaffinity.NewTransformer(ctx)
+			got, err := f(tc.pod)
+			if err != nil {
+				t.Fatalf("Transformer failed: %v", err)
+			}
+			if d := cmp.Diff(tc.expected, got.Spec.Affinity); d != "" {
+				t.Errorf("Affinity diff: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -124,15 +124,17 @@ var (
 // command, we must have fetched the image's ENTRYPOINT before calling this
 // method, using entrypoint_lookup.go.
 // Additionally, Step timeouts are added as entrypoint flag.
-func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, steps []corev1.Container, taskSpec *v1.TaskSpec, breakpointConfig *v1.TaskRunDebug, waitForReadyAnnotation, enableKeepPodOnCancel bool) ([]corev1.Container, error) {
+// If trace is set, the entrypoint records the time each step spends in each phase of its execution.
+func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, steps []corev1.Container, taskSpec *v1.TaskSpec, breakpointConfig *v1.TaskRunDebug, waitForReadyAnnotation, enableKeepPodOnCancel, trace bool) ([]corev1.Container, error) {
 	if len(steps) == 0 {
 		return nil, errors.New("no steps specified")
 	}
 
+	stages := stepStages(taskSpec, len(steps))
 	for i, s := range steps {
 		var argsForEntrypoint = []string{}
 		idx := strconv.Itoa(i)
-		if i == 0 {
+		if stages[i] == 0 {
 			if waitForReadyAnnotation {
 				argsForEntrypoint = append(argsForEntrypoint,
 					// First step waits for the Downward volume file.
@@ -141,7 +143,13 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 				)
 			}
 		} else { // Not the first step - wait for previous
-			argsForEntrypoint = append(argsForEntrypoint, "-wait_file", filepath.Join(RunDir, strconv.Itoa(i-1), "out"))
+			var waitFiles []string
+			for j := range i {
+				if stages[j] == stages[i]-1 {
+					waitFiles = append(waitFiles, filepath.Join(RunDir, strconv.Itoa(j), "out"))
+				}
+			}
+			argsForEntrypoint = append(argsForEntrypoint, "-wait_file", strings.Join(waitFiles, ","))
 		}
 		argsForEntrypoint = append(argsForEntrypoint,
 			// Start next step.
@@ -151,6 +159,9 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 		)
 
 		argsForEntrypoint = append(argsForEntrypoint, commonExtraEntrypointArgs...)
+		if config.FromContextOrDefaults(ctx).FeatureFlags.EntrypointLogFormat == config.EntrypointLogFormatJSON {
+			argsForEntrypoint = append(argsForEntrypoint, "-step_name", TrimStepPrefix(s.Name))
+		}
 		if taskSpec != nil {
 			if taskSpec.Steps != nil && len(taskSpec.Steps) >= i+1 {
 				if taskSpec.Steps[i].OnError != "" {
@@ -192,6 +203,9 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 		if breakpointConfig != nil && breakpointConfig.NeedsDebugBeforeStep(s.Name) {
 			argsForEntrypoint = append(argsForEntrypoint, "-debug_before_step")
 		}
+		if trace {
+			argsForEntrypoint = append(argsForEntrypoint, "-trace")
+		}
 
 		cmd, args := s.Command, s.Args
 		if len(cmd) > 0 {
@@ -206,8 +220,8 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 		steps[i].Command = []string{entrypointBinary}
 		steps[i].Args = argsForEntrypoint
 		steps[i].TerminationMessagePath = terminationPath
-		if (i == 0 && waitForReadyAnnotation) || enableKeepPodOnCancel {
-			// Mount the Downward volume into the first step container.
+		if (stages[i] == 0 && waitForReadyAnnotation) || enableKeepPodOnCancel {
+			// Mount the Downward volume into the first step container(s).
 			// if enableKeepPodOnCancel is true, mount the Downward volume into all the steps.
 			steps[i].VolumeMounts = append(steps[i].VolumeMounts, downwardMount)
 		}
@@ -216,6 +230,32 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 	return steps, nil
 }
 
+// stepStages returns the stage of each of the n steps. The steps of a parallel step group share
+// a stage, and the other steps have a stage of their own. The steps of a stage start together,
+// once all the steps of the previous stage are done.
+func stepStages(taskSpec *v1.TaskSpec, n int) []int {
+	group := map[string]int{}
+	if taskSpec != nil {
+		for g, psg := range taskSpec.ParallelSteps {
+			for _, name := range psg.Steps {
+				group[name] = g
+			}
+		}
+	}
+	stages := make([]int, n)
+	for i := 1; i < n; i++ {
+		stages[i] = stages[i-1] + 1
+		if taskSpec == nil || len(taskSpec.Steps) <= i {
+			continue
+		}
+		g, ok := group[taskSpec.Steps[i].Name]
+		if prev, prevOk := group[taskSpec.Steps[i-1].Name]; ok && prevOk && g == prev {
+			stages[i] = stages[i-1]
+		}
+	}
+	return stages
+}
+
 // stepResultArgument creates the cli arguments for step results to the entrypointer.
 func stepResultArgument(stepResults []v1.StepResult) []string {
 	if len(stepResults) == 0 {
@@ -245,6 +285,54 @@ func collectResultsName(results []v1.TaskResult) string {
 	return strings.Join(resultNames, ",")
 }
 
+// collectFileResultsName returns the names of the results of type file, whose content the
+// entrypoint uploads to the object store.
+func collectFileResultsName(results []v1.TaskResult) []string {
+	var resultNames []string
+	for _, r := range results {
+		if r.Type == v1.ResultsTypeFile {
+			resultNames = append(resultNames, r.Name)
+		}
+	}
+	return resultNames
+}
+
+// collectWhenFiles returns the files written to the Workspaces of the TaskRun, which the when expressions
+// of the PipelineTasks following it test, as the JSON object the entrypoint expects: the path of each
+// file in the steps, indexed by the key under which its content is written to the termination message.
+func collectWhenFiles(taskRun *v1.TaskRun, taskSpec v1.TaskSpec) (string, error) {
+	annotation, ok := taskRun.Annotations[v1.WhenFilesAnnotation]
+	if !ok {
+		return "", nil
+	}
+	var files []v1.WhenFile
+	if err := json.Unmarshal([]byte(annotation), &files); err != nil {
+		return "", fmt.Errorf("failed to parse the %s annotation: %w", v1.WhenFilesAnnotation, err)
+	}
+	paths := map[string]string{}
+	for _, f := range files {
+		for _, w := range taskSpec.Workspaces {
+			if w.Name == f.Workspace {
+				paths[whenFileKey(f.Workspace, f.Path)] = filepath.Join(w.GetMountPath(), f.Path)
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(paths)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// whenFileKey returns the key under which the content of a file tested by a when expression is
+// written to the termination message.
+func whenFileKey(workspace, path string) string {
+	return workspace + "/" + path
+}
+
 var replaceReadyPatchBytes, replaceCancelPatchBytes []byte
 
 func init() {
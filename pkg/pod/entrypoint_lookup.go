@@ -19,12 +19,20 @@ package pod
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
+// ErrUnsupportedImagePlatform is returned when an image looked up in the container image registry
+// does not provide the platform targeted by the node selector of the TaskRun.
+var ErrUnsupportedImagePlatform = errors.New("image does not provide the platform targeted by the node selector")
+
 // EntrypointCache looks up an image's entrypoint (command) in a container
 // image registry, possibly using the given service account's credentials.
 type EntrypointCache interface {
@@ -50,7 +58,10 @@ type imageData struct {
 //
 // Images that are not specified by digest will be specified by digest after
 // lookup in the resulting list of containers.
-func resolveEntrypoints(ctx context.Context, cache EntrypointCache, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference, steps []corev1.Container) ([]corev1.Container, error) {
+//
+// If the node selector targets an architecture, the images must provide it,
+// rather than failing at runtime with an exec format error.
+func resolveEntrypoints(ctx context.Context, cache EntrypointCache, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference, nodeSelector map[string]string, steps []corev1.Container) ([]corev1.Container, error) {
 	// Keep a local cache of name->imageData lookups, just for the scope of
 	// resolving this set of steps. If the image is pushed to before the
 	// next run, we need to resolve its digest and commands again, but we
@@ -82,6 +93,10 @@ func resolveEntrypoints(ctx context.Context, cache EntrypointCache, namespace, s
 			localCache[ref] = *lid
 		}
 
+		if err := checkPlatform(s.Image, id.commands, nodeSelector); err != nil {
+			return nil, err
+		}
+
 		// Resolve the original reference to a reference by digest.
 		steps[i].Image = ref.Context().Digest(id.digest.String()).String()
 
@@ -97,3 +112,63 @@ func resolveEntrypoints(ctx context.Context, cache EntrypointCache, namespace, s
 	}
 	return steps, nil
 }
+
+// checkPlatform returns an error if the node selector targets an architecture, and possibly an OS,
+// that none of the platforms of the image, keys of its map of platform->command, provides.
+func checkPlatform(image string, commands map[string][]string, nodeSelector map[string]string) error {
+	arch := nodeSelector[ArchSelectorLabel]
+	if arch == "" {
+		return nil
+	}
+	os := nodeSelector[OsSelectorLabel]
+	platforms := make([]string, 0, len(commands))
+	for plat := range commands {
+		// Platforms are formatted as os/arch[/variant][:osversion].
+		p, _, _ := strings.Cut(plat, ":")
+		parts := strings.Split(p, "/")
+		if len(parts) >= 2 && parts[1] == arch && (os == "" || parts[0] == os) {
+			return nil
+		}
+		platforms = append(platforms, plat)
+	}
+	sort.Strings(platforms)
+	target := arch
+	if os != "" {
+		target = os + "/" + arch
+	}
+	return fmt.Errorf("%w: image %q only provides [%s], not %q", ErrUnsupportedImagePlatform, image, strings.Join(platforms, ", "), target)
+}
+
+// resolveImageDigests replaces the image references of the given containers that are not specified
+// by digest with references by digest, looked up in the container image registry. This is used to pin
+// the images of steps specifying a Command, and of sidecars, whose entrypoints are not resolved.
+//
+// Like resolveEntrypoints, it checks that the images looked up provide the architecture targeted
+// by the node selector. Images already specified by digest are not looked up, so they are not checked.
+func resolveImageDigests(ctx context.Context, cache EntrypointCache, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference, nodeSelector map[string]string, containers []corev1.Container) ([]corev1.Container, error) {
+	localCache := map[name.Reference]imageData{}
+	for i, c := range containers {
+		ref, err := name.ParseReference(c.Image, name.WeakValidation)
+		if err != nil {
+			return nil, err
+		}
+		// If the image is already specified by digest, there's nothing to resolve.
+		if _, ok := ref.(name.Digest); ok {
+			continue
+		}
+		id, found := localCache[ref]
+		if !found {
+			lid, err := cache.get(ctx, ref, namespace, serviceAccountName, imagePullSecrets, len(c.Args) > 0)
+			if err != nil {
+				return nil, err
+			}
+			id = *lid
+			localCache[ref] = id
+		}
+		if err := checkPlatform(c.Image, id.commands, nodeSelector); err != nil {
+			return nil, err
+		}
+		containers[i].Image = ref.Context().Digest(id.digest.String()).String()
+	}
+	return containers, nil
+}
@@ -18,6 +18,7 @@ package pod
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -66,7 +67,7 @@ func TestResolveEntrypoints(t *testing.T) {
 		"reg.io/multi/arch:latest":        &data{id: multi},
 	}
 
-	got, err := resolveEntrypoints(ctx, cache, "namespace", "serviceAccountName", []corev1.LocalObjectReference{{Name: "imageSecret"}}, []corev1.Container{{
+	got, err := resolveEntrypoints(ctx, cache, "namespace", "serviceAccountName", []corev1.LocalObjectReference{{Name: "imageSecret"}}, nil, []corev1.Container{{
 		// This step specifies its command, so there's nothing to
 		// resolve.
 		Image:   "fully-specified",
@@ -137,6 +138,127 @@ func TestResolveEntrypoints(t *testing.T) {
 	}
 }
 
+func TestResolveEntrypointsPlatform(t *testing.T) {
+	img, err := random.Image(1, 1)
+	if err != nil {
+		t.Fatalf("random.Image: %v", err)
+	}
+	dig, err := img.Digest()
+	if err != nil {
+		t.Fatalf("image.Digest: %v", err)
+	}
+	cache := fakeCache{
+		"reg.io/multi/arch:latest": &data{id: &imageData{
+			digest: dig,
+			commands: map[string][]string{
+				"linux/amd64":              {"amd64"},
+				"linux/arm/v7":             {"arm"},
+				"windows/amd64:10.0.17763": {"windows"},
+				"linux/s390x":              {"s390x"},
+			},
+		}},
+	}
+
+	for _, tc := range []struct {
+		name         string
+		nodeSelector map[string]string
+		wantErr      string
+	}{{
+		name: "no node selector",
+	}, {
+		name:         "architecture provided",
+		nodeSelector: map[string]string{ArchSelectorLabel: "arm"},
+	}, {
+		name:         "os and architecture provided",
+		nodeSelector: map[string]string{OsSelectorLabel: "windows", ArchSelectorLabel: "amd64"},
+	}, {
+		name:         "architecture not provided",
+		nodeSelector: map[string]string{ArchSelectorLabel: "arm64"},
+		wantErr:      `image does not provide the platform targeted by the node selector: image "reg.io/multi/arch" only provides [linux/amd64, linux/arm/v7, linux/s390x, windows/amd64:10.0.17763], not "arm64"`,
+	}, {
+		name:         "architecture not provided for the os",
+		nodeSelector: map[string]string{OsSelectorLabel: "windows", ArchSelectorLabel: "s390x"},
+		wantErr:      `image does not provide the platform targeted by the node selector: image "reg.io/multi/arch" only provides [linux/amd64, linux/arm/v7, linux/s390x, windows/amd64:10.0.17763], not "windows/s390x"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resolveEntrypoints(t.Context(), cache, "namespace", "serviceAccountName", nil, tc.nodeSelector, []corev1.Container{{
+				Image: "reg.io/multi/arch",
+			}})
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("resolveEntrypoints: %v", err)
+			case tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr):
+				t.Errorf("resolveEntrypoints() error = %v, want %q", err, tc.wantErr)
+			case tc.wantErr != "" && !errors.Is(err, ErrUnsupportedImagePlatform):
+				t.Errorf("resolveEntrypoints() error = %v, want an ErrUnsupportedImagePlatform", err)
+			}
+
+			// The images of sidecars and of steps specifying a command are checked the same way.
+			_, err = resolveImageDigests(t.Context(), cache, "namespace", "serviceAccountName", nil, tc.nodeSelector, []corev1.Container{{
+				Image:   "reg.io/multi/arch",
+				Command: []string{"specified", "command"},
+			}})
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("resolveImageDigests: %v", err)
+			case tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr):
+				t.Errorf("resolveImageDigests() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveImageDigests(t *testing.T) {
+	img, err := random.Image(1, 1)
+	if err != nil {
+		t.Fatalf("random.Image: %v", err)
+	}
+	dig, err := img.Digest()
+	if err != nil {
+		t.Fatalf("image.Digest: %v", err)
+	}
+
+	cache := fakeCache{
+		"gcr.io/my/image:latest": &data{id: &imageData{digest: dig}},
+		"gcr.io/my/sidecar:v1":   &data{id: &imageData{digest: dig}},
+	}
+
+	got, err := resolveImageDigests(t.Context(), cache, "namespace", "serviceAccountName", nil, nil, []corev1.Container{{
+		// The command of this step is specified, but its image is still pinned.
+		Image:   "gcr.io/my/image",
+		Command: []string{"specified", "command"},
+	}, {
+		// This image is already specified by digest, so there's nothing to resolve.
+		Image: "reg.io/other/image@" + dig.String(),
+	}, {
+		Image: "gcr.io/my/sidecar:v1",
+	}})
+	if err != nil {
+		t.Fatalf("resolveImageDigests: %v", err)
+	}
+
+	want := []corev1.Container{{
+		Image:   "gcr.io/my/image@" + dig.String(),
+		Command: []string{"specified", "command"},
+	}, {
+		Image: "reg.io/other/image@" + dig.String(),
+	}, {
+		Image: "gcr.io/my/sidecar@" + dig.String(),
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolveImageDigestsError(t *testing.T) {
+	_, err := resolveImageDigests(t.Context(), fakeCache{}, "namespace", "serviceAccountName", nil, nil, []corev1.Container{{
+		Image: "gcr.io/my/image",
+	}})
+	if err == nil {
+		t.Fatal("resolveImageDigests: expected an error for an image that can't be looked up")
+	}
+}
+
 type fakeCache map[string]*data
 type data struct {
 	id   *imageData
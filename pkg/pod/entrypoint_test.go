@@ -98,7 +98,7 @@ func TestOrderContainers(t *testing.T) {
 		},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	got, err := orderContainers(t.Context(), []string{}, steps, nil, nil, true, false)
+	got, err := orderContainers(t.Context(), []string{}, steps, nil, nil, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -166,7 +166,7 @@ func TestOrderContainersWithResultsSidecarLogs(t *testing.T) {
 		},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	got, err := orderContainers(t.Context(), []string{"-dont_send_results_to_termination_path"}, steps, nil, nil, true, false)
+	got, err := orderContainers(t.Context(), []string{"-dont_send_results_to_termination_path"}, steps, nil, nil, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -212,7 +212,7 @@ func TestOrderContainersWithNoWait(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{volumeMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	got, err := orderContainers(t.Context(), []string{}, steps, nil, nil, false, false)
+	got, err := orderContainers(t.Context(), []string{}, steps, nil, nil, false, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -248,7 +248,7 @@ func TestOrderContainersWithDebugOnFailure(t *testing.T) {
 			OnFailure: "enabled",
 		},
 	}
-	got, err := orderContainers(t.Context(), []string{}, steps, nil, taskRunDebugConfig, true, false)
+	got, err := orderContainers(t.Context(), []string{}, steps, nil, taskRunDebugConfig, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -285,7 +285,7 @@ func TestTestOrderContainersWithDebugBeforeStep(t *testing.T) {
 			BeforeSteps: []string{"my-task"},
 		},
 	}
-	got, err := orderContainers(t.Context(), []string{}, steps, nil, taskRunDebugConfig, true, false)
+	got, err := orderContainers(t.Context(), []string{}, steps, nil, taskRunDebugConfig, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -324,7 +324,7 @@ func TestTestOrderContainersWithAllBreakpoints(t *testing.T) {
 			BeforeSteps: []string{"my-task"},
 		},
 	}
-	got, err := orderContainers(t.Context(), []string{}, steps, nil, taskRunDebugConfig, true, false)
+	got, err := orderContainers(t.Context(), []string{}, steps, nil, taskRunDebugConfig, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -352,7 +352,119 @@ func TestOrderContainersWithEnabelKeepPodOnCancel(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{downwardMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	got, err := orderContainers(t.Context(), []string{}, steps, nil, nil, false, true)
+	got, err := orderContainers(t.Context(), []string{}, steps, nil, nil, false, true, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestOrderContainersWithTrace(t *testing.T) {
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+		Args:    []string{"arg1", "arg2"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/0/status",
+			"-trace",
+			"-entrypoint", "cmd", "--",
+			"arg1", "arg2",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	got, err := orderContainers(t.Context(), []string{}, steps, nil, nil, false, false, true)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestOrderContainersWithParallelSteps(t *testing.T) {
+	taskSpec := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name: "build",
+		}, {
+			Name: "lint",
+		}, {
+			Name: "test",
+		}, {
+			Name: "publish",
+		}},
+		ParallelSteps: []v1.ParallelStepGroup{{
+			Steps: []string{"lint", "test"},
+		}},
+	}
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-3",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-4",
+		Command: []string{"cmd"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/0/status",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/run/0/out",
+			"-post_file", "/tekton/run/1/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/1/status",
+			"-entrypoint", "cmd", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-3",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/run/0/out",
+			"-post_file", "/tekton/run/2/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/2/status",
+			"-entrypoint", "cmd", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-4",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/run/1/out,/tekton/run/2/out",
+			"-post_file", "/tekton/run/3/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/3/status",
+			"-entrypoint", "cmd", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -428,7 +540,7 @@ func TestEntryPointStepActionResults(t *testing.T) {
 		TerminationMessagePath: "/tekton/termination",
 	}}
 	ctx := t.Context()
-	got, err := orderContainers(ctx, []string{}, steps, &taskSpec, nil, true, false)
+	got, err := orderContainers(ctx, []string{}, steps, &taskSpec, nil, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -451,7 +563,7 @@ func TestEntryPointStepWhen(t *testing.T) {
 			When:    v1.StepWhenExpressions{{Input: "foo", Operator: selection.In, Values: []string{"foo", "bar"}}},
 		},
 	}}
-	got, err := orderContainers(t.Context(), []string{}, containers, &ts, nil, true, false)
+	got, err := orderContainers(t.Context(), []string{}, containers, &ts, nil, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -546,7 +658,7 @@ func TestEntryPointResults(t *testing.T) {
 		},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, true, false)
+	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -587,7 +699,7 @@ func TestEntryPointResultsSingleStep(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{downwardMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, true, false)
+	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -624,7 +736,7 @@ func TestEntryPointSingleResultsSingleStep(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{downwardMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, true, false)
+	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -695,7 +807,7 @@ func TestEntryPointOnError(t *testing.T) {
 		err: errors.New("task step onError must be either \"continue\" or \"stopAndFail\" but it is set to an invalid value \"invalid-on-error\""),
 	}} {
 		t.Run(tc.desc, func(t *testing.T) {
-			got, err := orderContainers(t.Context(), []string{}, steps, &tc.taskSpec, nil, true, false)
+			got, err := orderContainers(t.Context(), []string{}, steps, &tc.taskSpec, nil, true, false, false)
 			if len(tc.wantContainers) == 0 {
 				if err == nil {
 					t.Fatalf("expected an error for an invalid value for onError but received none")
@@ -794,7 +906,7 @@ func TestEntryPointStepOutputConfigs(t *testing.T) {
 		},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, true, false)
+	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, true, false, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -919,6 +1031,53 @@ const nopImage = "nop-image"
 
 // TestStopSidecars tests stopping sidecars by updating their images to a nop
 // image.
+func TestCollectWhenFiles(t *testing.T) {
+	taskSpec := v1.TaskSpec{
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name: "source",
+		}, {
+			Name:      "reports",
+			MountPath: "/reports",
+		}},
+	}
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		want        string
+	}{{
+		name: "no annotation",
+		want: "",
+	}, {
+		name: "files written to the workspaces",
+		annotations: map[string]string{
+			v1.WhenFilesAnnotation: `[{"task":"diff","workspace":"source","path":"summary.txt"},{"task":"diff","workspace":"reports","path":"lint/result.txt"}]`,
+		},
+		want: `{"reports/lint/result.txt":"/reports/lint/result.txt","source/summary.txt":"/workspace/source/summary.txt"}`,
+	}, {
+		name: "file written to an undeclared workspace",
+		annotations: map[string]string{
+			v1.WhenFilesAnnotation: `[{"task":"diff","workspace":"cache","path":"summary.txt"}]`,
+		},
+		want: "",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			got, err := collectWhenFiles(tr, taskSpec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+
+	tr := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{v1.WhenFilesAnnotation: "not json"}}}
+	if _, err := collectWhenFiles(tr, taskSpec); err == nil {
+		t.Error("expected an error for an invalid annotation")
+	}
+}
+
 func TestStopSidecars(t *testing.T) {
 	stepContainer := corev1.Container{
 		Name:  stepPrefix + "my-step",
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// IsHermetic returns true if the TaskRun requests the hermetic execution mode.
+func IsHermetic(tr *v1.TaskRun) bool {
+	return tr.Annotations[ExecutionModeAnnotation] == ExecutionModeHermetic
+}
+
+// MakeHermeticNetworkPolicy returns a NetworkPolicy denying all the egress traffic of the pods of the TaskRun.
+// Unlike the TEKTON_HERMETIC env var, which only drops the networking of the step processes, it also applies
+// to the sidecars and to the steps whose image does not run the entrypoint binary as expected.
+func MakeHermeticNetworkPolicy(tr *v1.TaskRun) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            kmeta.ChildName(tr.Name, "-hermetic"),
+			Namespace:       tr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(tr)},
+			Labels: map[string]string{
+				pipeline.TaskRunLabelKey: tr.Name,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					pipeline.TaskRunUIDLabelKey: string(tr.UID),
+				},
+			},
+			// An Egress policy without any rule denies all the egress traffic.
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		},
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsHermetic(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{{
+		name: "no annotation",
+	}, {
+		name:        "hermetic",
+		annotations: map[string]string{ExecutionModeAnnotation: ExecutionModeHermetic},
+		want:        true,
+	}, {
+		name:        "other execution mode",
+		annotations: map[string]string{ExecutionModeAnnotation: "other"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if got := IsHermetic(tr); got != tc.want {
+				t.Errorf("IsHermetic() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMakeHermeticNetworkPolicy(t *testing.T) {
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "taskrun",
+			Namespace: "foo",
+			UID:       "taskrun-uid",
+		},
+	}
+	want := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "taskrun-hermetic",
+			Namespace: "foo",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         "tekton.dev/v1",
+				Kind:               "TaskRun",
+				Name:               "taskrun",
+				UID:                "taskrun-uid",
+				Controller:         &[]bool{true}[0],
+				BlockOwnerDeletion: &[]bool{true}[0],
+			}},
+			Labels: map[string]string{
+				"tekton.dev/taskRun": "taskrun",
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"tekton.dev/taskRunUID": "taskrun-uid",
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		},
+	}
+	if d := cmp.Diff(want, MakeHermeticNetworkPolicy(tr)); d != "" {
+		t.Errorf("NetworkPolicy mismatch %s", diff.PrintWantGot(d))
+	}
+}
@@ -19,6 +19,7 @@ package pod
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -51,12 +52,31 @@ const (
 	// TektonHermeticEnvVar is the env var we set in containers to indicate they should be run hermetically
 	TektonHermeticEnvVar = "TEKTON_HERMETIC"
 
+	// TektonPipelineEnvVar is the env var injected into steps with the name of the Pipeline of the TaskRun
+	TektonPipelineEnvVar = "TEKTON_PIPELINE"
+	// TektonPipelineRunEnvVar is the env var injected into steps with the name of the PipelineRun of the TaskRun
+	TektonPipelineRunEnvVar = "TEKTON_PIPELINE_RUN"
+	// TektonTaskEnvVar is the env var injected into steps with the name of the Task of the TaskRun
+	TektonTaskEnvVar = "TEKTON_TASK"
+	// TektonTaskRunEnvVar is the env var injected into steps with the name of the TaskRun
+	TektonTaskRunEnvVar = "TEKTON_TASK_RUN"
+	// TektonNamespaceEnvVar is the env var injected into steps with the namespace of the TaskRun
+	TektonNamespaceEnvVar = "TEKTON_NAMESPACE"
+
 	// ExecutionModeAnnotation is an experimental optional annotation to set the execution mode on a TaskRun
 	ExecutionModeAnnotation = "experimental.tekton.dev/execution-mode"
 
 	// ExecutionModeHermetic indicates hermetic execution mode
 	ExecutionModeHermetic = "hermetic"
 
+	// DebugTraceAnnotation is an experimental optional annotation which, when set to "true" on a TaskRun,
+	// makes the entrypoint trace the time spent by each step in each phase of its execution
+	DebugTraceAnnotation = "experimental.tekton.dev/debug-trace"
+
+	// ComputeResourcesStrategyAnnotation is an experimental optional annotation selecting how the task-level
+	// compute resources of a TaskRun are distributed across its steps, either "average" (default) or "split"
+	ComputeResourcesStrategyAnnotation = "experimental.tekton.dev/compute-resources-strategy"
+
 	// deadlineFactor is the factor we multiply the taskrun timeout with to determine the activeDeadlineSeconds of the Pod.
 	// It has to be higher than the timeout (to not be killed before)
 	deadlineFactor = 1.5
@@ -66,6 +86,8 @@ const (
 
 	// OsSelectorLabel is the label Kubernetes uses for OS-specific workloads (https://kubernetes.io/docs/reference/labels-annotations-taints/#kubernetes-io-os)
 	OsSelectorLabel = "kubernetes.io/os"
+	// ArchSelectorLabel is the label Kubernetes uses for architecture-specific workloads (https://kubernetes.io/docs/reference/labels-annotations-taints/#kubernetes-io-arch)
+	ArchSelectorLabel = "kubernetes.io/arch"
 
 	// TerminationReasonTimeoutExceeded indicates a step execution timed out.
 	TerminationReasonTimeoutExceeded = "TimeoutExceeded"
@@ -164,6 +186,10 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	setSecurityContextReadOnlyRootFilesystem := config.FromContextOrDefaults(ctx).FeatureFlags.SetSecurityContextReadOnlyRootFilesystem
 	defaultManagedByLabelValue := config.FromContextOrDefaults(ctx).Defaults.DefaultManagedByLabelValue
 
+	if featureFlags.EnableMetadataEnv {
+		implicitEnvVars = append(implicitEnvVars, metadataEnvVars(taskRun, config.FromContextOrDefaults(ctx).Defaults.DefaultMetadataEnv)...)
+	}
+
 	// Add our implicit volumes first, so they can be overridden by the user if they prefer.
 	volumes = append(volumes, implicitVolumes...)
 	volumeMounts = append(volumeMounts, implicitVolumeMounts...)
@@ -195,20 +221,30 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		return nil, err
 	}
 	if taskRun.Spec.ComputeResources != nil {
-		tasklevel.ApplyTaskLevelComputeResources(steps, taskRun.Spec.ComputeResources)
+		strategy, err := tasklevel.ParseStrategy(taskRun.Annotations[ComputeResourcesStrategyAnnotation])
+		if err != nil {
+			return nil, err
+		}
+		tasklevel.ApplyTaskLevelComputeResources(steps, taskRun.Spec.ComputeResources, strategy)
+	}
+
+	windows := usesWindows(taskRun)
+	windowsHostProcess := windows && usesWindowsHostProcess(taskRun, steps)
+	if windowsHostProcess && !featureFlags.EnableWindowsHostProcess {
+		return nil, fmt.Errorf("running steps as Windows HostProcess containers requires %q feature flag to be \"true\"", config.EnableWindowsHostProcess)
 	}
 
 	securityContextConfig := SecurityContextConfig{
 		SetSecurityContext:        setSecurityContext,
 		SetReadOnlyRootFilesystem: setSecurityContextReadOnlyRootFilesystem,
+		WindowsHostProcess:        windowsHostProcess,
 	}
 
-	windows := usesWindows(taskRun)
 	pollingInterval := config.FromContextOrDefaults(ctx).Defaults.DefaultSidecarLogPollingInterval
 	if sidecarLogsResultsEnabled {
 		if taskSpec.Results != nil || artifactsPathReferenced(steps) {
 			// create a results sidecar
-			resultsSidecar, err := createResultsSidecar(taskSpec, b.Images.SidecarLogResultsImage, securityContextConfig, windows, pollingInterval)
+			resultsSidecar, err := createResultsSidecar(taskSpec, b.Images.SidecarLogResultsImage, securityContextConfig, windows, pollingInterval, config.IsSpireEnabled(ctx))
 			if err != nil {
 				return nil, err
 			}
@@ -217,6 +253,44 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		}
 	}
 
+	if featureFlags.EnableResultOverflow && !sidecarLogsResultsEnabled {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-result_overflow")
+	}
+
+	if featureFlags.EntrypointLogFormat == config.EntrypointLogFormatJSON {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-log_format", config.EntrypointLogFormatJSON, "-taskrun_uid", string(taskRun.UID))
+	}
+
+	if featureFlags.MaxStepLogSize > 0 {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-max_log_size", strconv.Itoa(featureFlags.MaxStepLogSize))
+	}
+	if featureFlags.MaxStepLogLines > 0 {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-max_log_lines", strconv.Itoa(featureFlags.MaxStepLogLines))
+	}
+
+	if fileResults := collectFileResultsName(taskSpec.Results); len(fileResults) > 0 {
+		switch {
+		case sidecarLogsResultsEnabled:
+			return nil, errors.New("results of type file can't be extracted from the sidecar logs, \"results-from\" must be \"termination-message\"")
+		case featureFlags.FileResultsStoreURL == "":
+			return nil, errors.New("results of type file require \"file-results-store-url\" to be set in the feature flags")
+		}
+		// the content is uploaded under a URL unique to the pod, so that retries don't overwrite it
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs,
+			"-file_results", strings.Join(fileResults, ","),
+			"-file_results_url", fmt.Sprintf("%s/%s/%s", featureFlags.FileResultsStoreURL, taskRun.Namespace, getPodName(taskRun)),
+			"-max_file_result_size", strconv.Itoa(featureFlags.MaxFileResultSize),
+		)
+	}
+
+	whenFiles, err := collectWhenFiles(taskRun, taskSpec)
+	if err != nil {
+		return nil, err
+	}
+	if whenFiles != "" {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-when_files", whenFiles)
+	}
+
 	sidecars, err := v1.MergeSidecarsWithSpecs(taskSpec.Sidecars, taskRun.Spec.SidecarSpecs)
 	if err != nil {
 		return nil, err
@@ -238,9 +312,15 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		initContainers = append(initContainers, *scriptsInit)
 		volumes = append(volumes, scriptsVolume)
 	}
+	setPreStopHooks(steps, stepContainers)
 	if alphaAPIEnabled && taskRun.Spec.Debug != nil && taskRun.Spec.Debug.NeedsDebug() {
 		volumes = append(volumes, debugScriptsVolume, debugInfoVolume)
 	}
+	if alphaAPIEnabled {
+		if debugToolsVolume := placeDebugToolsInContainers(taskRun.Spec.Debug, stepContainers, sidecarContainers); debugToolsVolume != nil {
+			volumes = append(volumes, *debugToolsVolume)
+		}
+	}
 	// Initialize any workingDirs under /workspace.
 	if workingDirInit := workingDirInit(b.Images.WorkingDirInitImage, stepContainers, securityContextConfig, windows); workingDirInit != nil {
 		initContainers = append(initContainers, *workingDirInit)
@@ -253,18 +333,37 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		podTemplate = *taskRun.Spec.PodTemplate
 	}
 
+	podRuntimeClassName, err := runtimeClassName(steps, podTemplate.RuntimeClassName)
+	if err != nil {
+		return nil, err
+	}
+	imagePullSecrets := mergeImagePullSecrets(steps, podTemplate.ImagePullSecrets)
+
 	// Resolve entrypoint for any steps that don't specify command.
-	stepContainers, err = resolveEntrypoints(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, podTemplate.ImagePullSecrets, stepContainers)
+	stepContainers, err = resolveEntrypoints(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, imagePullSecrets, podTemplate.NodeSelector, stepContainers)
 	if err != nil {
 		return nil, err
 	}
 
+	// Pin the images of the remaining steps and of the sidecars to the digests they currently resolve to.
+	if featureFlags.EnableImageDigestPinning {
+		stepContainers, err = resolveImageDigests(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, imagePullSecrets, podTemplate.NodeSelector, stepContainers)
+		if err != nil {
+			return nil, err
+		}
+		sidecarContainers, err = resolveImageDigests(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, imagePullSecrets, podTemplate.NodeSelector, sidecarContainers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	readyImmediately := isPodReadyImmediately(*featureFlags, taskSpec.Sidecars)
 
 	if alphaAPIEnabled {
-		stepContainers, err = orderContainers(ctx, commonExtraEntrypointArgs, stepContainers, &taskSpec, taskRun.Spec.Debug, !readyImmediately, enableKeepPodOnCancel)
+		trace := taskRun.Annotations[DebugTraceAnnotation] == "true"
+		stepContainers, err = orderContainers(ctx, commonExtraEntrypointArgs, stepContainers, &taskSpec, taskRun.Spec.Debug, !readyImmediately, enableKeepPodOnCancel, trace)
 	} else {
-		stepContainers, err = orderContainers(ctx, commonExtraEntrypointArgs, stepContainers, &taskSpec, nil, !readyImmediately, enableKeepPodOnCancel)
+		stepContainers, err = orderContainers(ctx, commonExtraEntrypointArgs, stepContainers, &taskSpec, nil, !readyImmediately, enableKeepPodOnCancel, false)
 	}
 	if err != nil {
 		return nil, err
@@ -302,7 +401,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		}
 	}
 	// Add env var if hermetic execution was requested & if the alpha API is enabled
-	if taskRun.Annotations[ExecutionModeAnnotation] == ExecutionModeHermetic && alphaAPIEnabled {
+	if IsHermetic(taskRun) && alphaAPIEnabled {
 		for i, s := range stepContainers {
 			// Add it at the end so it overrides
 			env := append(s.Env, corev1.EnvVar{Name: TektonHermeticEnvVar, Value: "1"}) //nolint:gocritic
@@ -324,6 +423,12 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 			s.VolumeMounts = append(s.VolumeMounts, *vm)
 		}
 
+		// Mount the ServiceAccount tokens requested by a Step only into this Step.
+		if v, vm := serviceAccountTokensVolume(i, steps[i].ServiceAccountTokens); v != nil {
+			volumes = append(volumes, *v)
+			s.VolumeMounts = append(s.VolumeMounts, *vm)
+		}
+
 		// Add /tekton/run state volumes.
 		// Each step should only mount their own volume as RW,
 		// all other steps should be mounted RO.
@@ -420,6 +525,18 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 				ReadOnly:  readonly,
 			})
 		}
+		for i := range sidecarContainers {
+			// mount SPIRE's CSI volume to the results sidecar which signs the results extracted from the sidecar logs
+			c := &sidecarContainers[i]
+			if c.Name != pipeline.ReservedResultsSidecarName {
+				continue
+			}
+			c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+				Name:      spire.WorkloadAPI,
+				MountPath: spire.VolumeMountPath,
+				ReadOnly:  readonly,
+			})
+		}
 	}
 
 	mergedPodContainers := stepContainers
@@ -476,7 +593,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	}
 
 	var priorityClassName string
-	if podTemplate.PriorityClassName != nil {
+	if taskRun.Spec.PriorityClassName != "" {
+		priorityClassName = taskRun.Spec.PriorityClassName
+	} else if podTemplate.PriorityClassName != nil {
 		priorityClassName = *podTemplate.PriorityClassName
 	}
 
@@ -494,10 +613,6 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		activeDeadlineSeconds = MaxActiveDeadlineSeconds
 	}
 
-	podNameSuffix := "-pod"
-	if taskRunRetries := len(taskRun.Status.RetriesStatus); taskRunRetries > 0 {
-		podNameSuffix = fmt.Sprintf("%s-retry%d", podNameSuffix, taskRunRetries)
-	}
 	newPod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			// We execute the build's pod in the same namespace as where the build was
@@ -506,7 +621,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 			// Generate a unique name based on the build's name.
 			// The name is univocally generated so that in case of
 			// stale informer cache, we never create duplicate Pods
-			Name: kmeta.ChildName(taskRun.Name, podNameSuffix),
+			Name: getPodName(taskRun),
 			// If our parent TaskRun is deleted, then we should be as well.
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(taskRun, groupVersionKind),
@@ -524,22 +639,27 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 			Tolerations:                  podTemplate.Tolerations,
 			Affinity:                     podTemplate.Affinity,
 			SecurityContext:              podTemplate.SecurityContext,
-			RuntimeClassName:             podTemplate.RuntimeClassName,
+			RuntimeClassName:             podRuntimeClassName,
 			AutomountServiceAccountToken: podTemplate.AutomountServiceAccountToken,
 			SchedulerName:                podTemplate.SchedulerName,
 			HostNetwork:                  podTemplate.HostNetwork,
 			HostUsers:                    podTemplate.HostUsers,
+			ShareProcessNamespace:        podTemplate.ShareProcessNamespace,
 			DNSPolicy:                    dnsPolicy,
 			DNSConfig:                    podTemplate.DNSConfig,
 			EnableServiceLinks:           podTemplate.EnableServiceLinks,
 			PriorityClassName:            priorityClassName,
-			ImagePullSecrets:             podTemplate.ImagePullSecrets,
+			ImagePullSecrets:             imagePullSecrets,
 			HostAliases:                  podTemplate.HostAliases,
 			TopologySpreadConstraints:    podTemplate.TopologySpreadConstraints,
 			ActiveDeadlineSeconds:        &activeDeadlineSeconds, // Set ActiveDeadlineSeconds to mark the pod as "terminating" (like a Job)
 		},
 	}
 
+	if windowsHostProcess {
+		setWindowsHostProcess(&newPod.Spec)
+	}
+
 	for _, f := range transformers {
 		newPod, err = f(newPod)
 		if err != nil {
@@ -550,6 +670,19 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	return newPod, nil
 }
 
+// getPodName returns the name of the Pod of the TaskRun, which differs for each of its retries
+// and for each Pod recreated after a disruption.
+func getPodName(taskRun *v1.TaskRun) string {
+	podNameSuffix := "-pod"
+	if taskRunRetries := len(taskRun.Status.RetriesStatus); taskRunRetries > 0 {
+		podNameSuffix = fmt.Sprintf("%s-retry%d", podNameSuffix, taskRunRetries)
+	}
+	if podDisruptions := len(taskRun.Status.PodDisruptions); podDisruptions > 0 {
+		podNameSuffix = fmt.Sprintf("%s-disruption%d", podNameSuffix, podDisruptions)
+	}
+	return kmeta.ChildName(taskRun.Name, podNameSuffix)
+}
+
 // makeLabels constructs the labels we will propagate from TaskRuns to Pods.
 func makeLabels(s *v1.TaskRun, defaultManagedByLabelValue string) map[string]string {
 	labels := make(map[string]string, len(s.ObjectMeta.Labels)+1)
@@ -637,7 +770,7 @@ func entrypointInitContainer(image string, steps []v1.Step, securityContext Secu
 // whether it will run on a windows node, and whether the sidecar should include a security context
 // that will allow it to run in namespaces with "restricted" pod security admission.
 // It will also provide arguments to the binary that allow it to surface the step results.
-func createResultsSidecar(taskSpec v1.TaskSpec, image string, securityContext SecurityContextConfig, windows bool, pollingInterval time.Duration) (v1.Sidecar, error) {
+func createResultsSidecar(taskSpec v1.TaskSpec, image string, securityContext SecurityContextConfig, windows bool, pollingInterval time.Duration, enableSpire bool) (v1.Sidecar, error) {
 	names := make([]string, 0, len(taskSpec.Results))
 	for _, r := range taskSpec.Results {
 		names = append(names, r.Name)
@@ -676,6 +809,11 @@ func createResultsSidecar(taskSpec v1.TaskSpec, image string, securityContext Se
 		command = append(command, "-step-results", string(stepResultsBytes))
 	}
 
+	// The sidecar signs the task results it extracts with the TaskRun SVID when spire is enabled
+	if enableSpire {
+		command = append(command, "-enable_spire")
+	}
+
 	// When using Kubernetes native sidecar support, add the kubernetes-sidecar-mode flag
 	// to prevent the sidecar from exiting after processing results
 	if config.FromContextOrDefaults(context.Background()).FeatureFlags.EnableKubernetesSidecar {
@@ -712,6 +850,55 @@ func usesWindows(tr *v1.TaskRun) bool {
 	return osSelector == "windows"
 }
 
+// setPreStopHooks sets the PreStop hooks of the steps on their containers.
+func setPreStopHooks(steps []v1.Step, stepContainers []corev1.Container) {
+	for i, s := range steps {
+		if s.PreStop == nil {
+			continue
+		}
+		if stepContainers[i].Lifecycle == nil {
+			stepContainers[i].Lifecycle = &corev1.Lifecycle{}
+		}
+		stepContainers[i].Lifecycle.PreStop = s.PreStop
+	}
+}
+
+// usesWindowsHostProcess returns true if the Pod template or any of the steps of the TaskRun
+// request to run as a Windows HostProcess container.
+// See https://kubernetes.io/docs/tasks/configure-pod-container/create-hostprocess-pod/ for more info.
+func usesWindowsHostProcess(tr *v1.TaskRun, steps []v1.Step) bool {
+	if tr.Spec.PodTemplate != nil && tr.Spec.PodTemplate.SecurityContext != nil {
+		if wo := tr.Spec.PodTemplate.SecurityContext.WindowsOptions; wo != nil && wo.HostProcess != nil && *wo.HostProcess {
+			return true
+		}
+	}
+	for _, s := range steps {
+		if s.SecurityContext == nil {
+			continue
+		}
+		if wo := s.SecurityContext.WindowsOptions; wo != nil && wo.HostProcess != nil && *wo.HostProcess {
+			return true
+		}
+	}
+	return false
+}
+
+// setWindowsHostProcess runs all the containers of the Pod as Windows HostProcess containers,
+// which Kubernetes requires as soon as one of them is, and uses the host network as
+// HostProcess containers require.
+func setWindowsHostProcess(spec *corev1.PodSpec) {
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	} else {
+		spec.SecurityContext = spec.SecurityContext.DeepCopy()
+	}
+	if spec.SecurityContext.WindowsOptions == nil {
+		spec.SecurityContext.WindowsOptions = &corev1.WindowsSecurityContextOptions{}
+	}
+	spec.SecurityContext.WindowsOptions.HostProcess = &hostProcess
+	spec.HostNetwork = true
+}
+
 func artifactsPathReferenced(steps []v1.Step) bool {
 	for _, step := range steps {
 		if artifactPathReferencedInStep(step) {
@@ -760,3 +947,26 @@ func IsNativeSidecarSupport(serverVersion *version.Info) bool {
 	}
 	return false
 }
+
+// metadataEnvVars returns the env vars describing the TaskRun and the PipelineRun it belongs to,
+// restricted to the names in allowed unless it is empty. Values are empty for a TaskRun which
+// is not part of a PipelineRun, so that the set of env vars is the same for all steps.
+func metadataEnvVars(taskRun *v1.TaskRun, allowed []string) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{
+		{Name: TektonPipelineEnvVar, Value: taskRun.Labels[pipeline.PipelineLabelKey]},
+		{Name: TektonPipelineRunEnvVar, Value: taskRun.Labels[pipeline.PipelineRunLabelKey]},
+		{Name: TektonTaskEnvVar, Value: taskRun.Labels[pipeline.TaskLabelKey]},
+		{Name: TektonTaskRunEnvVar, Value: taskRun.Name},
+		{Name: TektonNamespaceEnvVar, Value: taskRun.Namespace},
+	}
+	if len(allowed) == 0 {
+		return envVars
+	}
+	filtered := []corev1.EnvVar{}
+	for _, e := range envVars {
+		if slices.Contains(allowed, e.Name) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
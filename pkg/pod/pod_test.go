@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -373,6 +374,57 @@ func TestPodBuild(t *testing.T) {
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 		},
+		{
+			desc: "with-priority-class-name-overriding-pod-template",
+			ts: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			trs: v1.TaskRunSpec{
+				PodTemplate: &pod.Template{
+					PriorityClassName: &priorityClassName,
+				},
+				PriorityClassName: "urgent-release",
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{
+						binROMount, runMount(0, false),
+						downwardMount,
+						{Name: "tekton-creds-init-home-0", MountPath: "/tekton/creds"},
+					}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				PriorityClassName:     "urgent-release",
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
 		{
 			desc: "very long step name",
 			ts: v1.TaskSpec{
@@ -1811,6 +1863,58 @@ _EOF_
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 		},
+		{
+			desc:           "metadata env vars",
+			featureFlags:   map[string]string{"enable-metadata-env": "true"},
+			configDefaults: map[string]string{"default-metadata-env": "TEKTON_TASK_RUN,TEKTON_NAMESPACE"},
+			ts: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+					Env:     []corev1.EnvVar{{Name: "TEKTON_NAMESPACE", Value: "overridden"}},
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+					Env: []corev1.EnvVar{
+						{Name: "TEKTON_TASK_RUN", Value: taskRunName},
+						{Name: "TEKTON_NAMESPACE", Value: "default"},
+						// the step env var comes last to take precedence
+						{Name: "TEKTON_NAMESPACE", Value: "overridden"},
+					},
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
 		{
 			desc: "pod for a taskRun with retries",
 			ts: v1.TaskSpec{
@@ -2944,6 +3048,7 @@ func TestPodBuild_TaskLevelResourceRequirements(t *testing.T) {
 		desc                     string
 		ts                       v1.TaskSpec
 		trs                      v1.TaskRunSpec
+		annotations              map[string]string
 		expectedComputeResources []ExpectedComputeResources
 	}{{
 		desc: "overwrite stepTemplate resources requirements",
@@ -3088,6 +3193,43 @@ func TestPodBuild_TaskLevelResourceRequirements(t *testing.T) {
 				},
 			},
 		}},
+	}, {
+		desc: "split strategy",
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:    "1st-step",
+				Image:   "image",
+				Command: []string{"cmd"},
+			}, {
+				Name:    "2nd-step",
+				Image:   "image",
+				Command: []string{"cmd"},
+			}},
+		},
+		trs: v1.TaskRunSpec{
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("2"),
+				},
+			},
+		},
+		annotations: map[string]string{ComputeResourcesStrategyAnnotation: "split"},
+		expectedComputeResources: []ExpectedComputeResources{{
+			name: "step-1st-step",
+			ResourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		}, {
+			name: "step-2nd-step",
+			ResourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		}},
 	}}
 
 	for _, tc := range testcases {
@@ -3111,8 +3253,9 @@ func TestPodBuild_TaskLevelResourceRequirements(t *testing.T) {
 			}
 			tr := &v1.TaskRun{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo-taskrun",
-					Namespace: "default",
+					Name:        "foo-taskrun",
+					Namespace:   "default",
+					Annotations: tc.annotations,
 				},
 				Spec: tc.trs,
 			}
@@ -3129,6 +3272,39 @@ func TestPodBuild_TaskLevelResourceRequirements(t *testing.T) {
 	}
 }
 
+func TestPodBuild_TaskLevelResourceRequirementsInvalidStrategy(t *testing.T) {
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	builder := Builder{
+		Images:     images,
+		KubeClient: kubeclient,
+	}
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo-taskrun",
+			Namespace:   "default",
+			Annotations: map[string]string{ComputeResourcesStrategyAnnotation: "foo"},
+		},
+		Spec: v1.TaskRunSpec{
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		},
+	}
+	ts := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:    "step",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+
+	if _, err := builder.Build(t.Context(), tr, ts); err == nil {
+		t.Fatal("expected an error for an unknown compute resources strategy")
+	}
+}
+
 func TestPodBuildwithSpireEnabled(t *testing.T) {
 	initContainers := []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)}
 	readonly := true
@@ -3292,6 +3468,67 @@ func TestPodBuildwithSpireEnabled(t *testing.T) {
 	}
 }
 
+func TestPodBuildwithSpireEnabled_SidecarLogs(t *testing.T) {
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"enforce-nonfalsifiability": "spire",
+				"results-from":              "sidecar-logs",
+			},
+		},
+	)
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "taskrun-name",
+			Namespace:   "default",
+			Annotations: map[string]string{ReleaseAnnotation: fakeVersion},
+		},
+	}
+	ts := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:    "name",
+			Image:   "image",
+			Command: []string{"cmd"}, // avoid entrypoint lookup.
+		}},
+		Results: []v1.TaskResult{{Name: "foo", Type: v1.ResultsTypeString}},
+	}
+	builder := Builder{
+		Images:          images,
+		KubeClient:      kubeclient,
+		EntrypointCache: fakeCache{},
+	}
+
+	got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+
+	var sidecar *corev1.Container
+	for i, c := range got.Spec.Containers {
+		if c.Name == pipeline.ReservedResultsSidecarContainerName {
+			sidecar = &got.Spec.Containers[i]
+		}
+	}
+	if sidecar == nil {
+		t.Fatalf("results sidecar not found in pod containers %v", got.Spec.Containers)
+	}
+	if !slices.Contains(sidecar.Command, "-enable_spire") {
+		t.Errorf("expected the results sidecar to sign the results, got command %v", sidecar.Command)
+	}
+	if !slices.Contains(sidecar.VolumeMounts, corev1.VolumeMount{
+		Name:      spire.WorkloadAPI,
+		MountPath: spire.VolumeMountPath,
+		ReadOnly:  true,
+	}) {
+		t.Errorf("expected the spire workload api to be mounted in the results sidecar, got %v", sidecar.VolumeMounts)
+	}
+}
+
 // verifyTaskLevelComputeResources verifies that the given TaskRun's containers have the expected compute resources.
 func verifyTaskLevelComputeResources(expectedComputeResources []ExpectedComputeResources, containers []corev1.Container) error {
 	if len(expectedComputeResources) != len(containers) {
@@ -3575,6 +3812,329 @@ func TestUsesWindows(t *testing.T) {
 	}
 }
 
+func TestSetPreStopHooks(t *testing.T) {
+	preStop := &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{Command: []string{"pkill", "-TERM", "dockerd"}},
+	}
+	postStart := &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{Command: []string{"echo", "started"}},
+	}
+	steps := []v1.Step{{Name: "no-hook"}, {Name: "hook", PreStop: preStop}, {Name: "both-hooks", PreStop: preStop}}
+	stepContainers := []corev1.Container{{Name: "step-no-hook"}, {Name: "step-hook"}, {
+		Name:      "step-both-hooks",
+		Lifecycle: &corev1.Lifecycle{PostStart: postStart},
+	}}
+	want := []corev1.Container{{Name: "step-no-hook"}, {
+		Name:      "step-hook",
+		Lifecycle: &corev1.Lifecycle{PreStop: preStop},
+	}, {
+		Name:      "step-both-hooks",
+		Lifecycle: &corev1.Lifecycle{PostStart: postStart, PreStop: preStop},
+	}}
+
+	setPreStopHooks(steps, stepContainers)
+	if d := cmp.Diff(want, stepContainers); d != "" {
+		t.Errorf("Step Containers Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestUsesWindowsHostProcess(t *testing.T) {
+	hostProcess := true
+	notHostProcess := false
+	tcs := []struct {
+		name    string
+		taskRun *v1.TaskRun
+		steps   []v1.Step
+		want    bool
+	}{{
+		name:    "no pod template or step security context",
+		taskRun: &v1.TaskRun{Spec: v1.TaskRunSpec{}},
+		steps:   []v1.Step{{Name: "step"}},
+		want:    false,
+	}, {
+		name: "pod template requests host process",
+		taskRun: &v1.TaskRun{Spec: v1.TaskRunSpec{PodTemplate: &pod.Template{SecurityContext: &corev1.PodSecurityContext{
+			WindowsOptions: &corev1.WindowsSecurityContextOptions{HostProcess: &hostProcess},
+		}}}},
+		steps: []v1.Step{{Name: "step"}},
+		want:  true,
+	}, {
+		name:    "step requests host process",
+		taskRun: &v1.TaskRun{Spec: v1.TaskRunSpec{}},
+		steps: []v1.Step{{Name: "step"}, {Name: "admin", SecurityContext: &corev1.SecurityContext{
+			WindowsOptions: &corev1.WindowsSecurityContextOptions{HostProcess: &hostProcess},
+		}}},
+		want: true,
+	}, {
+		name:    "step does not request host process",
+		taskRun: &v1.TaskRun{Spec: v1.TaskRunSpec{}},
+		steps: []v1.Step{{Name: "step", SecurityContext: &corev1.SecurityContext{
+			WindowsOptions: &corev1.WindowsSecurityContextOptions{HostProcess: &notHostProcess},
+		}}},
+		want: false,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := usesWindowsHostProcess(tc.taskRun, tc.steps)
+			if tc.want != got {
+				t.Errorf("wanted usesWindowsHostProcess to be %t but was %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPodBuildWindowsHostProcess(t *testing.T) {
+	hostProcess := true
+	taskRun := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"},
+		Spec: v1.TaskRunSpec{PodTemplate: &pod.Template{
+			NodeSelector: map[string]string{OsSelectorLabel: "windows"},
+			SecurityContext: &corev1.PodSecurityContext{
+				WindowsOptions: &corev1.WindowsSecurityContextOptions{HostProcess: &hostProcess},
+			},
+		}},
+	}
+	taskSpec := v1.TaskSpec{Steps: []v1.Step{{
+		Name:    "admin",
+		Image:   "image",
+		Command: []string{"cmd"},
+	}}}
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	builder := Builder{
+		Images:     images,
+		KubeClient: kubeclient,
+	}
+
+	if _, err := builder.Build(t.Context(), taskRun, taskSpec); err == nil {
+		t.Fatal("expected an error building a HostProcess pod without the feature flag")
+	}
+
+	cfg := config.FromContextOrDefaults(t.Context())
+	cfg.FeatureFlags.EnableWindowsHostProcess = true
+	got, err := builder.Build(config.ToContext(t.Context(), cfg), taskRun, taskSpec)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+	if !got.Spec.HostNetwork {
+		t.Error("expected the pod to use the host network")
+	}
+	wantSecurityContext := &corev1.PodSecurityContext{
+		WindowsOptions: &corev1.WindowsSecurityContextOptions{HostProcess: &hostProcess},
+	}
+	if d := cmp.Diff(wantSecurityContext, got.Spec.SecurityContext); d != "" {
+		t.Errorf("Pod SecurityContext Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPodBuildResultOverflow(t *testing.T) {
+	taskRun := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"},
+	}
+	taskSpec := v1.TaskSpec{
+		Results: []v1.TaskResult{{Name: "foo"}},
+		Steps: []v1.Step{{
+			Name:    "name",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	builder := Builder{
+		Images:     images,
+		KubeClient: kubeclient,
+	}
+
+	for _, tc := range []struct {
+		desc                   string
+		enableResultOverflow   bool
+		resultExtractionMethod string
+		want                   bool
+	}{{
+		desc:                   "disabled",
+		resultExtractionMethod: config.ResultExtractionMethodTerminationMessage,
+	}, {
+		desc:                   "enabled",
+		enableResultOverflow:   true,
+		resultExtractionMethod: config.ResultExtractionMethodTerminationMessage,
+		want:                   true,
+	}, {
+		desc:                   "enabled with results from the sidecar logs",
+		enableResultOverflow:   true,
+		resultExtractionMethod: config.ResultExtractionMethodSidecarLogs,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := config.FromContextOrDefaults(t.Context())
+			cfg.FeatureFlags.EnableResultOverflow = tc.enableResultOverflow
+			cfg.FeatureFlags.ResultExtractionMethod = tc.resultExtractionMethod
+			got, err := builder.Build(config.ToContext(t.Context(), cfg), taskRun, taskSpec)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+			if gotArg := slices.Contains(got.Spec.Containers[0].Args, "-result_overflow"); gotArg != tc.want {
+				t.Errorf("expected the step args %q to contain -result_overflow: %t", got.Spec.Containers[0].Args, tc.want)
+			}
+		})
+	}
+}
+
+func TestPodBuildMaxStepLog(t *testing.T) {
+	taskRun := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"},
+	}
+	taskSpec := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:    "name",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	builder := Builder{
+		Images:     images,
+		KubeClient: kubeclient,
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		maxSize  int
+		maxLines int
+		want     []string
+	}{{
+		desc: "no limit",
+	}, {
+		desc:    "size",
+		maxSize: 1024,
+		want:    []string{"-max_log_size", "1024"},
+	}, {
+		desc:     "size and lines",
+		maxSize:  1024,
+		maxLines: 10,
+		want:     []string{"-max_log_size", "1024", "-max_log_lines", "10"},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := config.FromContextOrDefaults(t.Context())
+			cfg.FeatureFlags.MaxStepLogSize = tc.maxSize
+			cfg.FeatureFlags.MaxStepLogLines = tc.maxLines
+			got, err := builder.Build(config.ToContext(t.Context(), cfg), taskRun, taskSpec)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+			var gotArgs []string
+			args := got.Spec.Containers[0].Args
+			for i, arg := range args {
+				if (arg == "-max_log_size" || arg == "-max_log_lines") && i+1 < len(args) {
+					gotArgs = append(gotArgs, arg, args[i+1])
+				}
+			}
+			if d := cmp.Diff(tc.want, gotArgs); d != "" {
+				t.Errorf("step args %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPodBuildEntrypointLogFormat(t *testing.T) {
+	taskRun := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default", UID: "taskrun-uid"},
+	}
+	taskSpec := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:    "build",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	builder := Builder{
+		Images:     images,
+		KubeClient: kubeclient,
+	}
+
+	for _, tc := range []struct {
+		format string
+		want   []string
+	}{{
+		format: config.EntrypointLogFormatText,
+	}, {
+		format: config.EntrypointLogFormatJSON,
+		want:   []string{"-log_format", "json", "-taskrun_uid", "taskrun-uid", "-step_name", "build"},
+	}} {
+		t.Run(tc.format, func(t *testing.T) {
+			cfg := config.FromContextOrDefaults(t.Context())
+			cfg.FeatureFlags.EntrypointLogFormat = tc.format
+			got, err := builder.Build(config.ToContext(t.Context(), cfg), taskRun, taskSpec)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+			var gotArgs []string
+			args := got.Spec.Containers[0].Args
+			for i, arg := range args {
+				if (arg == "-log_format" || arg == "-taskrun_uid" || arg == "-step_name") && i+1 < len(args) {
+					gotArgs = append(gotArgs, arg, args[i+1])
+				}
+			}
+			if d := cmp.Diff(tc.want, gotArgs); d != "" {
+				t.Errorf("step args %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPodBuildFileResults(t *testing.T) {
+	taskRun := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"},
+	}
+	taskSpec := v1.TaskSpec{
+		Results: []v1.TaskResult{{Name: "sbom", Type: v1.ResultsTypeFile}},
+		Steps: []v1.Step{{
+			Name:    "name",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	builder := Builder{
+		Images:     images,
+		KubeClient: kubeclient,
+	}
+
+	cfg := config.FromContextOrDefaults(t.Context())
+	if _, err := builder.Build(config.ToContext(t.Context(), cfg), taskRun, taskSpec); err == nil {
+		t.Fatal("expected an error building a pod with file results without a store URL")
+	}
+
+	cfg.FeatureFlags.FileResultsStoreURL = "https://results.example.com"
+	cfg.FeatureFlags.MaxFileResultSize = 1024
+	got, err := builder.Build(config.ToContext(t.Context(), cfg), taskRun, taskSpec)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+	wantArgs := []string{
+		"-file_results", "sbom",
+		"-file_results_url", "https://results.example.com/default/taskrun-name-pod",
+		"-max_file_result_size", "1024",
+	}
+	args := strings.Join(got.Spec.Containers[0].Args, " ")
+	if !strings.Contains(args, strings.Join(wantArgs, " ")) {
+		t.Errorf("expected the step args %q to contain %q", args, strings.Join(wantArgs, " "))
+	}
+
+	cfg.FeatureFlags.ResultExtractionMethod = config.ResultExtractionMethodSidecarLogs
+	if _, err := builder.Build(config.ToContext(t.Context(), cfg), taskRun, taskSpec); err == nil {
+		t.Fatal("expected an error building a pod with file results extracted from the sidecar logs")
+	}
+}
+
 func Test_artifactsPathReferenced(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -3707,6 +4267,7 @@ func TestPodBuildWithK8s129(t *testing.T) {
 			Name:    "name",
 			Image:   "image",
 			Command: []string{"cmd"}, // avoid entrypoint lookup.
+			Timeout: &metav1.Duration{Duration: time.Minute},
 		}},
 		Sidecars: []v1.Sidecar{{
 			Name:    "name",
@@ -3760,6 +4321,8 @@ func TestPodBuildWithK8s129(t *testing.T) {
 				"/tekton/termination",
 				"-step_metadata_dir",
 				"/tekton/run/0/status",
+				"-timeout",
+				"1m0s",
 				"-entrypoint",
 				"cmd",
 				"--",
@@ -3839,6 +4402,10 @@ func TestPodBuildWithK8s129(t *testing.T) {
 	if d := cmp.Diff(want.InitContainers[1].RestartPolicy, got.Spec.InitContainers[1].RestartPolicy); d != "" {
 		t.Errorf("Sidecar does not have RestartPolicy Always: %s", diff.PrintWantGot(d))
 	}
+
+	if d := cmp.Diff(want.Containers[0].Args, got.Spec.Containers[0].Args); d != "" {
+		t.Errorf("Step timeout is not passed to the entrypoint: %s", diff.PrintWantGot(d))
+	}
 }
 func TestIsNativeSidecarSupport(t *testing.T) {
 	tests := []struct {
@@ -4062,3 +4629,44 @@ func TestCreateResultsSidecarWithWaitForever(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadataEnvVars(t *testing.T) {
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pr-build",
+			Namespace: "ns",
+			Labels: map[string]string{
+				"tekton.dev/pipeline":    "pipeline",
+				"tekton.dev/pipelineRun": "pr",
+				"tekton.dev/task":        "build",
+			},
+		},
+	}
+	for _, tc := range []struct {
+		name    string
+		allowed []string
+		want    []corev1.EnvVar
+	}{{
+		name: "all env vars",
+		want: []corev1.EnvVar{
+			{Name: "TEKTON_PIPELINE", Value: "pipeline"},
+			{Name: "TEKTON_PIPELINE_RUN", Value: "pr"},
+			{Name: "TEKTON_TASK", Value: "build"},
+			{Name: "TEKTON_TASK_RUN", Value: "pr-build"},
+			{Name: "TEKTON_NAMESPACE", Value: "ns"},
+		},
+	}, {
+		name:    "restricted env vars",
+		allowed: []string{"TEKTON_PIPELINE_RUN", "TEKTON_UNKNOWN"},
+		want: []corev1.EnvVar{
+			{Name: "TEKTON_PIPELINE_RUN", Value: "pr"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := metadataEnvVars(tr, tc.allowed)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("metadataEnvVars() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// mergeImagePullSecrets returns the imagePullSecrets of the TaskRun's Pod: the ones of the Pod template followed
+// by the additional ones requested by the Steps. Since the kubelet uses the imagePullSecrets of a Pod for all of
+// its images, the merged secrets are also the ones used to look up the entrypoints and digests of the images.
+func mergeImagePullSecrets(steps []v1.Step, podTemplateSecrets []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	var merged []corev1.LocalObjectReference
+	merged = append(merged, podTemplateSecrets...)
+	seen := map[string]bool{}
+	for _, s := range podTemplateSecrets {
+		seen[s.Name] = true
+	}
+	for _, step := range steps {
+		for _, s := range step.ImagePullSecrets {
+			if seen[s.Name] {
+				continue
+			}
+			seen[s.Name] = true
+			merged = append(merged, s)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMergeImagePullSecrets(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		steps              []v1.Step
+		podTemplateSecrets []corev1.LocalObjectReference
+		want               []corev1.LocalObjectReference
+	}{{
+		name:  "no image pull secrets",
+		steps: []v1.Step{{Name: "foo"}},
+	}, {
+		name:               "image pull secrets of the pod template",
+		steps:              []v1.Step{{Name: "foo"}},
+		podTemplateSecrets: []corev1.LocalObjectReference{{Name: "template-creds"}},
+		want:               []corev1.LocalObjectReference{{Name: "template-creds"}},
+	}, {
+		name: "image pull secrets of the steps",
+		steps: []v1.Step{
+			{Name: "foo", ImagePullSecrets: []corev1.LocalObjectReference{{Name: "foo-creds"}, {Name: "template-creds"}}},
+			{Name: "bar"},
+			{Name: "baz", ImagePullSecrets: []corev1.LocalObjectReference{{Name: "baz-creds"}, {Name: "foo-creds"}}},
+		},
+		podTemplateSecrets: []corev1.LocalObjectReference{{Name: "template-creds"}},
+		want:               []corev1.LocalObjectReference{{Name: "template-creds"}, {Name: "foo-creds"}, {Name: "baz-creds"}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mergeImagePullSecrets(tc.steps, tc.podTemplateSecrets)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("mergeImagePullSecrets() diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// runtimeClassName returns the RuntimeClass of the TaskRun's Pod. Since all the Steps run in the same Pod, the
+// Steps requesting a RuntimeClass must agree with each other and with the RuntimeClass of the Pod template, if any.
+func runtimeClassName(steps []v1.Step, podTemplateRuntimeClassName *string) (*string, error) {
+	var requested *string
+	for _, s := range steps {
+		if s.RuntimeClassName == nil {
+			continue
+		}
+		if requested != nil && *requested != *s.RuntimeClassName {
+			return nil, fmt.Errorf("step %q requests runtimeClassName %q which conflicts with runtimeClassName %q requested by another step", s.Name, *s.RuntimeClassName, *requested)
+		}
+		requested = s.RuntimeClassName
+	}
+	if requested == nil {
+		return podTemplateRuntimeClassName, nil
+	}
+	if podTemplateRuntimeClassName != nil && *podTemplateRuntimeClassName != *requested {
+		return nil, fmt.Errorf("steps request runtimeClassName %q which conflicts with runtimeClassName %q of the pod template", *requested, *podTemplateRuntimeClassName)
+	}
+	return requested, nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/utils/ptr"
+)
+
+func TestRuntimeClassName(t *testing.T) {
+	for _, tc := range []struct {
+		name                        string
+		steps                       []v1.Step
+		podTemplateRuntimeClassName *string
+		want                        *string
+		wantErr                     string
+	}{{
+		name:  "no runtime class",
+		steps: []v1.Step{{Name: "foo"}},
+	}, {
+		name:                        "runtime class of the pod template",
+		steps:                       []v1.Step{{Name: "foo"}},
+		podTemplateRuntimeClassName: ptr.To("kata"),
+		want:                        ptr.To("kata"),
+	}, {
+		name:  "steps requesting the same runtime class",
+		steps: []v1.Step{{Name: "foo", RuntimeClassName: ptr.To("gvisor")}, {Name: "bar"}, {Name: "baz", RuntimeClassName: ptr.To("gvisor")}},
+		want:  ptr.To("gvisor"),
+	}, {
+		name:                        "steps requesting the runtime class of the pod template",
+		steps:                       []v1.Step{{Name: "foo", RuntimeClassName: ptr.To("gvisor")}},
+		podTemplateRuntimeClassName: ptr.To("gvisor"),
+		want:                        ptr.To("gvisor"),
+	}, {
+		name:    "steps requesting different runtime classes",
+		steps:   []v1.Step{{Name: "foo", RuntimeClassName: ptr.To("gvisor")}, {Name: "bar", RuntimeClassName: ptr.To("kata")}},
+		wantErr: `step "bar" requests runtimeClassName "kata" which conflicts with runtimeClassName "gvisor" requested by another step`,
+	}, {
+		name:                        "steps requesting a different runtime class than the pod template",
+		steps:                       []v1.Step{{Name: "foo", RuntimeClassName: ptr.To("gvisor")}},
+		podTemplateRuntimeClassName: ptr.To("kata"),
+		wantErr:                     `steps request runtimeClassName "gvisor" which conflicts with runtimeClassName "kata" of the pod template`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := runtimeClassName(tc.steps, tc.podTemplateRuntimeClassName)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("runtimeClassName() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runtimeClassName() unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("runtimeClassName() diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -32,10 +32,12 @@ const (
 	scriptsVolumeName      = "tekton-internal-scripts"
 	debugScriptsVolumeName = "tekton-internal-debug-scripts"
 	debugInfoVolumeName    = "tekton-internal-debug-info"
+	debugToolsVolumeName   = "tekton-internal-debug-tools"
 	scriptsDir             = "/tekton/scripts"
 	debugScriptsDir        = "/tekton/debug/scripts"
 	defaultScriptPreamble  = "#!/bin/sh\nset -e\n"
 	debugInfoDir           = "/tekton/debug/info"
+	debugToolsDir          = "/tekton/debug/tools"
 )
 
 var (
@@ -67,6 +69,11 @@ var (
 		Name:         debugInfoVolumeName,
 		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 	}
+	debugToolsVolumeMount = corev1.VolumeMount{
+		Name:      debugToolsVolumeName,
+		MountPath: debugToolsDir,
+		ReadOnly:  true,
+	}
 )
 
 // convertScripts creates an init container that mounts any Scripts specified by
@@ -76,8 +83,9 @@ var (
 //   - shellImageLinux and shellImageWindows: the images that should be used by the init container,
 //     depending on the OS the Task will run on
 //   - debugConfig: the TaskRun's debug configuration
-//   - setSecurityContext: whether the init container should include a security context that will
-//     allow it to run in a namespace with "restricted" pod security admission
+//   - securityContext: whether the init container should include a security context that will
+//     allow it to run in a namespace with "restricted" pod security admission, and whether the
+//     containers run as Windows HostProcess containers
 func convertScripts(shellImageLinux string, shellImageWin string, steps []v1.Step, sidecars []v1.Sidecar, debugConfig *v1.TaskRunDebug, securityContext SecurityContextConfig) (*corev1.Container, []corev1.Container, []corev1.Container) {
 	// Place scripts is an init container used for creating scripts in the
 	// /tekton/scripts directory which would be later used by the step containers
@@ -112,8 +120,8 @@ func convertScripts(shellImageLinux string, shellImageWin string, steps []v1.Ste
 		placeScriptsInit.VolumeMounts = append(placeScriptsInit.VolumeMounts, debugScriptsVolumeMount)
 	}
 
-	convertedStepContainers := convertListOfSteps(steps, &placeScriptsInit, debugConfig, "script")
-	sidecarContainers := convertListOfSidecars(sidecars, &placeScriptsInit, "sidecar-script")
+	convertedStepContainers := convertListOfSteps(steps, &placeScriptsInit, debugConfig, "script", securityContext.WindowsHostProcess)
+	sidecarContainers := convertListOfSidecars(sidecars, &placeScriptsInit, debugConfig, "sidecar-script", securityContext.WindowsHostProcess)
 
 	if hasScripts(steps, sidecars, debugConfig) {
 		return &placeScriptsInit, convertedStepContainers, sidecarContainers
@@ -123,26 +131,27 @@ func convertScripts(shellImageLinux string, shellImageWin string, steps []v1.Ste
 
 // convertListOfSidecars iterates through the list of sidecars, generates the script file name and heredoc termination string,
 // adds an entry to the init container args, sets up the step container to run the script, and sets the volume mounts.
-func convertListOfSidecars(sidecars []v1.Sidecar, initContainer *corev1.Container, namePrefix string) []corev1.Container {
+func convertListOfSidecars(sidecars []v1.Sidecar, initContainer *corev1.Container, debugConfig *v1.TaskRunDebug, namePrefix string, windowsHostProcess bool) []corev1.Container {
 	containers := []corev1.Container{}
 	for i, s := range sidecars {
 		c := s.ToK8sContainer()
 		if s.Script != "" {
-			placeScriptInContainer(s.Script, getScriptFile(scriptsDir, fmt.Sprintf("%s-%d", namePrefix, i)), c, initContainer)
+			placeScriptInContainer(s.Script, getScriptFile(scriptsDir, fmt.Sprintf("%s-%d", namePrefix, i)), c, initContainer, windowsHostProcess)
 		}
 		containers = append(containers, *c)
 	}
+	placeDebugScriptInSidecars(sidecars, containers, initContainer, debugConfig)
 	return containers
 }
 
 // convertListOfSteps iterates through the list of steps, generates the script file name and heredoc termination string,
 // adds an entry to the init container args, sets up the step container to run the script, and sets the volume mounts.
-func convertListOfSteps(steps []v1.Step, initContainer *corev1.Container, debugConfig *v1.TaskRunDebug, namePrefix string) []corev1.Container {
+func convertListOfSteps(steps []v1.Step, initContainer *corev1.Container, debugConfig *v1.TaskRunDebug, namePrefix string, windowsHostProcess bool) []corev1.Container {
 	containers := []corev1.Container{}
 	for i, s := range steps {
 		c := steps[i].ToK8sContainer()
 		if s.Script != "" {
-			placeScriptInContainer(s.Script, getScriptFile(scriptsDir, fmt.Sprintf("%s-%d", namePrefix, i)), c, initContainer)
+			placeScriptInContainer(s.Script, getScriptFile(scriptsDir, fmt.Sprintf("%s-%d", namePrefix, i)), c, initContainer, windowsHostProcess)
 		}
 		containers = append(containers, *c)
 	}
@@ -156,8 +165,9 @@ func getScriptFile(scriptsDir, scriptName string) string {
 
 // placeScriptInContainer given a piece of script to be executed, placeScriptInContainer firstly modifies initContainer
 // so that it capsules the target script into scriptFile, then it modifies the container so that it can execute the scriptFile
-// in runtime.
-func placeScriptInContainer(script, scriptFile string, c *corev1.Container, initContainer *corev1.Container) {
+// in runtime. If windowsHostProcess is true, Windows scripts are referenced by Windows paths since
+// HostProcess containers run their commands directly on the node.
+func placeScriptInContainer(script, scriptFile string, c *corev1.Container, initContainer *corev1.Container, windowsHostProcess bool) {
 	if script == "" {
 		return
 	}
@@ -172,6 +182,9 @@ func placeScriptInContainer(script, scriptFile string, c *corev1.Container, init
 	// Append to the place-scripts script to place the
 	// script file in a known location in the scripts volume.
 	if requiresWindows {
+		if windowsHostProcess {
+			scriptFile = windowsPath(scriptFile)
+		}
 		command, args, script, scriptFile := extractWindowsScriptComponents(script, scriptFile)
 		initContainer.Args[1] += fmt.Sprintf(`@"
 %s
@@ -265,6 +278,76 @@ func placeDebugScriptInContainers(containers []corev1.Container, initContainer *
 	}
 }
 
+// placeDebugScriptInSidecars wraps the command of the sidecars targeted by a breakpoint so that a failed sidecar
+// does not exit until debug-sidecar-continue is run in it. Sidecars without a command, or running a Windows script,
+// cannot be wrapped and are left untouched.
+func placeDebugScriptInSidecars(sidecars []v1.Sidecar, containers []corev1.Container, initContainer *corev1.Container, debugConfig *v1.TaskRunDebug) {
+	if debugConfig == nil || !debugConfig.NeedsDebugOnFailure() {
+		return
+	}
+
+	var needDebugSidecar bool
+	for i, s := range sidecars {
+		c := &containers[i]
+		if !debugConfig.SidecarNeedsDebug(s.Name) || len(c.Command) == 0 || strings.HasPrefix(strings.TrimSpace(s.Script), "#!win") {
+			continue
+		}
+		needDebugSidecar = true
+		c.Args = append(append([]string{}, c.Command...), c.Args...)
+		c.Command = []string{filepath.Join(debugScriptsDir, "debug-sidecar")}
+		c.VolumeMounts = append(c.VolumeMounts, debugScriptsVolumeMount, corev1.VolumeMount{
+			Name:      debugInfoVolumeName,
+			MountPath: debugInfoDir,
+			SubPath:   "sidecar-" + s.Name,
+		})
+	}
+	if !needDebugSidecar {
+		return
+	}
+
+	debugScripts := []struct {
+		name    string
+		content string
+	}{{
+		name:    "sidecar",
+		content: defaultScriptPreamble + fmt.Sprintf(debugSidecarScriptTemplate, debugInfoDir),
+	}, {
+		name:    "sidecar-continue",
+		content: defaultScriptPreamble + fmt.Sprintf(debugSidecarContinueScriptTemplate, debugInfoDir),
+	}}
+	for _, debugScript := range debugScripts {
+		tmpFile := filepath.Join(debugScriptsDir, fmt.Sprintf("%s-%s", "debug", debugScript.name))
+		heredoc := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s-heredoc-randomly-generated", "debug", debugScript.name))
+
+		initContainer.Args[1] += fmt.Sprintf(initScriptDirective, tmpFile, heredoc, debugScript.content, heredoc)
+	}
+}
+
+// placeDebugToolsInContainers mounts the debug image of the TaskRun as a read-only toolbox volume in the step
+// containers and in the sidecars halted on failure, and returns that volume. It returns nil if no debug image is set.
+func placeDebugToolsInContainers(debugConfig *v1.TaskRunDebug, stepContainers, sidecarContainers []corev1.Container) *corev1.Volume {
+	if debugConfig == nil || debugConfig.Image == "" {
+		return nil
+	}
+	for i := range stepContainers {
+		stepContainers[i].VolumeMounts = append(stepContainers[i].VolumeMounts, debugToolsVolumeMount)
+	}
+	for i := range sidecarContainers {
+		if debugConfig.SidecarNeedsDebug(sidecarContainers[i].Name) {
+			sidecarContainers[i].VolumeMounts = append(sidecarContainers[i].VolumeMounts, debugToolsVolumeMount)
+		}
+	}
+	return &corev1.Volume{
+		Name: debugToolsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Image: &corev1.ImageVolumeSource{
+				Reference:  debugConfig.Image,
+				PullPolicy: corev1.PullIfNotPresent,
+			},
+		},
+	}
+}
+
 // hasScripts determines if we need to generate scripts in InitContainer given steps, sidecars and breakpoints.
 func hasScripts(steps []v1.Step, sidecars []v1.Sidecar, debugConfig *v1.TaskRunDebug) bool {
 	for _, s := range steps {
@@ -298,6 +381,12 @@ func checkWindowsRequirement(steps []v1.Step, sidecars []v1.Sidecar) bool {
 	return false
 }
 
+// windowsPath converts a path in the Pod to a Windows path, e.g. /tekton/scripts/script-0 to
+// C:\tekton\scripts\script-0, so that it can be used by cmd.exe on the node.
+func windowsPath(path string) string {
+	return `C:` + strings.ReplaceAll(path, "/", `\`)
+}
+
 func extractWindowsScriptComponents(script string, fileName string) ([]string, []string, string, string) {
 	// Set the command to execute the correct script in the mounted volume.
 	shebangLine := strings.Split(script, "\n")[0]
@@ -17,6 +17,7 @@ limitations under the License.
 package pod
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -638,6 +639,103 @@ debug-beforestep-fail-continue-heredoc-randomly-generated-6nl7g
 	}
 }
 
+func TestConvertScripts_WithSidecarBreakpoints(t *testing.T) {
+	names.TestingSeed()
+
+	gotInit, _, gotSidecars := convertScripts(images.ShellImage, images.ShellImageWin, []v1.Step{{
+		Image: "step-1",
+	}}, []v1.Sidecar{{
+		Name: "db",
+		Script: `#!/bin/sh
+sidecar-1`,
+		Image: "sidecar-1",
+	}, {
+		Name:    "proxy",
+		Image:   "sidecar-2",
+		Command: []string{"proxy"},
+		Args:    []string{"--port", "8080"},
+	}, {
+		// No command to wrap here.
+		Name:  "cache",
+		Image: "sidecar-3",
+	}, {
+		// Not targeted by the breakpoint.
+		Name:    "other",
+		Image:   "sidecar-4",
+		Command: []string{"other"},
+	}}, &v1.TaskRunDebug{
+		Breakpoints: &v1.TaskBreakpoints{
+			OnFailure: "enabled",
+			Sidecars:  []string{"db", "proxy", "cache"},
+		},
+	}, SecurityContextConfig{})
+
+	wantInitSuffix := `tmpfile="/tekton/debug/scripts/debug-sidecar"
+touch ${tmpfile} && chmod +x ${tmpfile}
+cat > ${tmpfile} << 'debug-sidecar-heredoc-randomly-generated-78c5n'
+#!/bin/sh
+set -e
+
+debugInfo=/tekton/debug/info
+
+exitCode=0
+"$@" || exitCode=$?
+if [ $exitCode -ne 0 ]; then
+	touch ${debugInfo}/breakpoint
+	echo "Sidecar exited with code $exitCode, waiting for debug-sidecar-continue..."
+	while [ ! -f ${debugInfo}/continue ]; do
+		sleep 1
+	done
+fi
+exit $exitCode
+debug-sidecar-heredoc-randomly-generated-78c5n
+tmpfile="/tekton/debug/scripts/debug-sidecar-continue"
+touch ${tmpfile} && chmod +x ${tmpfile}
+cat > ${tmpfile} << 'debug-sidecar-continue-heredoc-randomly-generated-6nl7g'
+#!/bin/sh
+set -e
+
+debugInfo=/tekton/debug/info
+
+if [ ! -f ${debugInfo}/breakpoint ]; then
+	echo "Sidecar has not failed, breakpoint exiting !"
+	exit 0
+fi
+touch ${debugInfo}/continue
+echo "Exiting sidecar..."
+debug-sidecar-continue-heredoc-randomly-generated-6nl7g
+`
+	if !strings.HasSuffix(gotInit.Args[1], wantInitSuffix) {
+		t.Errorf("Init Container script does not end with the sidecar debug scripts, got %s", gotInit.Args[1])
+	}
+
+	wantSidecars := []corev1.Container{{
+		Name:    "db",
+		Image:   "sidecar-1",
+		Command: []string{"/tekton/debug/scripts/debug-sidecar"},
+		Args:    []string{"/tekton/scripts/sidecar-script-0-mssqb"},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount, debugScriptsVolumeMount,
+			{Name: debugInfoVolumeName, MountPath: "/tekton/debug/info", SubPath: "sidecar-db"}},
+	}, {
+		Name:    "proxy",
+		Image:   "sidecar-2",
+		Command: []string{"/tekton/debug/scripts/debug-sidecar"},
+		Args:    []string{"proxy", "--port", "8080"},
+		VolumeMounts: []corev1.VolumeMount{debugScriptsVolumeMount,
+			{Name: debugInfoVolumeName, MountPath: "/tekton/debug/info", SubPath: "sidecar-proxy"}},
+	}, {
+		Name:  "cache",
+		Image: "sidecar-3",
+	}, {
+		Name:    "other",
+		Image:   "sidecar-4",
+		Command: []string{"other"},
+	}}
+	if d := cmp.Diff(wantSidecars, gotSidecars); d != "" {
+		t.Errorf("Sidecar Containers Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestConvertScripts_WithSidecar(t *testing.T) {
 	names.TestingSeed()
 
@@ -949,3 +1047,114 @@ sidecar-1
 		t.Errorf("Wanted 1 sidecar, got %v", len(gotSidecars))
 	}
 }
+
+func TestConvertScripts_Windows_HostProcess(t *testing.T) {
+	names.TestingSeed()
+
+	gotInit, gotSteps, _ := convertScripts(images.ShellImage, images.ShellImageWin, []v1.Step{{
+		Script: `#!win powershell -File
+script-1`,
+		Image: "step-1",
+	}, {
+		Script: `#!win
+echo script-2`,
+		Image: "step-2",
+	}}, []v1.Sidecar{}, nil, SecurityContextConfig{SetSecurityContext: true, WindowsHostProcess: true})
+	wantInit := &corev1.Container{
+		Name:    "place-scripts",
+		Image:   images.ShellImageWin,
+		Command: []string{"pwsh"},
+		Args: []string{"-Command", `@"
+#!win powershell -File
+script-1
+"@ | Out-File -FilePath C:\tekton\scripts\script-0-9l9zj.ps1
+@"
+echo script-2
+"@ | Out-File -FilePath C:\tekton\scripts\script-1-mz4c7.cmd
+`},
+		VolumeMounts:    []corev1.VolumeMount{writeScriptsVolumeMount, binMount},
+		SecurityContext: WindowsHostProcessSecurityContext,
+	}
+	want := []corev1.Container{{
+		Image:        "step-1",
+		Command:      []string{"powershell"},
+		Args:         []string{"-File", `C:\tekton\scripts\script-0-9l9zj.ps1`},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+	}, {
+		Image:        "step-2",
+		Command:      []string{`C:\tekton\scripts\script-1-mz4c7.cmd`},
+		Args:         []string{},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+	}}
+	if d := cmp.Diff(wantInit, gotInit); d != "" {
+		t.Errorf("Init Container Diff %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(want, gotSteps); d != "" {
+		t.Errorf("Step Containers Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPlaceDebugToolsInContainers(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		debugConfig  *v1.TaskRunDebug
+		wantVolume   *corev1.Volume
+		wantSteps    []corev1.Container
+		wantSidecars []corev1.Container
+	}{{
+		name:         "no debug",
+		wantSteps:    []corev1.Container{{Name: "step-1"}},
+		wantSidecars: []corev1.Container{{Name: "db"}},
+	}, {
+		name: "no debug image",
+		debugConfig: &v1.TaskRunDebug{
+			Breakpoints: &v1.TaskBreakpoints{OnFailure: "enabled"},
+		},
+		wantSteps:    []corev1.Container{{Name: "step-1"}},
+		wantSidecars: []corev1.Container{{Name: "db"}},
+	}, {
+		name: "debug image",
+		debugConfig: &v1.TaskRunDebug{
+			Image: "busybox",
+		},
+		wantVolume: &corev1.Volume{
+			Name: debugToolsVolumeName,
+			VolumeSource: corev1.VolumeSource{Image: &corev1.ImageVolumeSource{
+				Reference:  "busybox",
+				PullPolicy: corev1.PullIfNotPresent,
+			}},
+		},
+		wantSteps:    []corev1.Container{{Name: "step-1", VolumeMounts: []corev1.VolumeMount{debugToolsVolumeMount}}},
+		wantSidecars: []corev1.Container{{Name: "db"}},
+	}, {
+		name: "debug image with sidecar breakpoint",
+		debugConfig: &v1.TaskRunDebug{
+			Breakpoints: &v1.TaskBreakpoints{OnFailure: "enabled", Sidecars: []string{"db"}},
+			Image:       "busybox",
+		},
+		wantVolume: &corev1.Volume{
+			Name: debugToolsVolumeName,
+			VolumeSource: corev1.VolumeSource{Image: &corev1.ImageVolumeSource{
+				Reference:  "busybox",
+				PullPolicy: corev1.PullIfNotPresent,
+			}},
+		},
+		wantSteps:    []corev1.Container{{Name: "step-1", VolumeMounts: []corev1.VolumeMount{debugToolsVolumeMount}}},
+		wantSidecars: []corev1.Container{{Name: "db", VolumeMounts: []corev1.VolumeMount{debugToolsVolumeMount}}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			steps := []corev1.Container{{Name: "step-1"}}
+			sidecars := []corev1.Container{{Name: "db"}}
+			gotVolume := placeDebugToolsInContainers(tc.debugConfig, steps, sidecars)
+			if d := cmp.Diff(tc.wantVolume, gotVolume); d != "" {
+				t.Errorf("Volume Diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantSteps, steps); d != "" {
+				t.Errorf("Step Containers Diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantSidecars, sidecars); d != "" {
+				t.Errorf("Sidecar Containers Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -80,6 +80,28 @@ else
 	echo "Last step (no. $stepNumber) has already been executed, before step breakpoint exiting !"
 	exit 0
 fi`
+	debugSidecarScriptTemplate = `
+debugInfo=%s
+
+exitCode=0
+"$@" || exitCode=$?
+if [ $exitCode -ne 0 ]; then
+	touch ${debugInfo}/breakpoint
+	echo "Sidecar exited with code $exitCode, waiting for debug-sidecar-continue..."
+	while [ ! -f ${debugInfo}/continue ]; do
+		sleep 1
+	done
+fi
+exit $exitCode`
+	debugSidecarContinueScriptTemplate = `
+debugInfo=%s
+
+if [ ! -f ${debugInfo}/breakpoint ]; then
+	echo "Sidecar has not failed, breakpoint exiting !"
+	exit 0
+fi
+touch ${debugInfo}/continue
+echo "Exiting sidecar..."`
 	initScriptDirective = `tmpfile="%s"
 touch ${tmpfile} && chmod +x ${tmpfile}
 cat > ${tmpfile} << '%s'
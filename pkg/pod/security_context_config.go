@@ -25,6 +25,7 @@ var (
 	allowPrivilegeEscalation = false
 	runAsNonRoot             = true
 	readOnlyRootFilesystem   = true
+	hostProcess              = true
 
 	// LinuxSecurityContext allow init containers to run in namespaces
 	// with "restricted" pod security admission
@@ -44,15 +45,30 @@ var (
 	WindowsSecurityContext = &corev1.SecurityContext{
 		RunAsNonRoot: &runAsNonRoot,
 	}
+
+	// WindowsHostProcessSecurityContext runs init containers as Windows HostProcess
+	// containers, which is required when the steps of the Pod run as HostProcess containers.
+	// HostProcess containers run as a user of the node, so RunAsNonRoot is not set.
+	// See https://kubernetes.io/docs/tasks/configure-pod-container/create-hostprocess-pod/
+	WindowsHostProcessSecurityContext = &corev1.SecurityContext{
+		WindowsOptions: &corev1.WindowsSecurityContextOptions{
+			HostProcess: &hostProcess,
+		},
+	}
 )
 
 // SecurityContextConfig is configuration for setting security context for init containers and affinity assistant container.
 type SecurityContextConfig struct {
 	SetSecurityContext        bool
 	SetReadOnlyRootFilesystem bool
+	// WindowsHostProcess is true if the containers of the Pod run as Windows HostProcess containers.
+	WindowsHostProcess bool
 }
 
 func (c SecurityContextConfig) GetSecurityContext(isWindows bool) *corev1.SecurityContext {
+	if isWindows && c.WindowsHostProcess {
+		return WindowsHostProcessSecurityContext
+	}
 	if isWindows {
 		return WindowsSecurityContext
 	}
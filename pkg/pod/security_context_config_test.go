@@ -74,6 +74,17 @@ func TestGetSecurityContext(t *testing.T) {
 				RunAsNonRoot: &runAsNonRoot,
 			},
 		},
+		{
+			name: "Windows HostProcess",
+			config: SecurityContextConfig{
+				SetSecurityContext: true,
+				WindowsHostProcess: true,
+			},
+			isWindows: true,
+			expectedSecurityContext: &corev1.SecurityContext{
+				WindowsOptions: &corev1.WindowsSecurityContextOptions{HostProcess: &hostProcess},
+			},
+		},
 	}
 
 	for _, tt := range tests {
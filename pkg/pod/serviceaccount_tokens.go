@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	serviceAccountTokensVolumePrefix = "tekton-internal-sa-tokens"
+	// ServiceAccountTokensDir is the directory the ServiceAccount tokens requested by a Step are mounted to.
+	ServiceAccountTokensDir = "/var/run/secrets/tekton.dev/tokens"
+)
+
+// serviceAccountTokensVolume returns a projected Volume containing the ServiceAccount tokens requested
+// by the Step at index idx, and the VolumeMount to add to this Step only. It returns nil if the Step
+// didn't request any token.
+func serviceAccountTokensVolume(idx int, tokens []v1.ServiceAccountToken) (*corev1.Volume, *corev1.VolumeMount) {
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	name := fmt.Sprintf("%s-%d", serviceAccountTokensVolumePrefix, idx)
+	sources := make([]corev1.VolumeProjection, 0, len(tokens))
+	for _, t := range tokens {
+		sources = append(sources, corev1.VolumeProjection{
+			ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+				Audience:          t.Audience,
+				ExpirationSeconds: t.ExpirationSeconds,
+				Path:              t.Name,
+			},
+		})
+	}
+	v := corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+			Sources: sources,
+		}},
+	}
+	vm := corev1.VolumeMount{
+		Name:      name,
+		MountPath: ServiceAccountTokensDir,
+		ReadOnly:  true,
+	}
+	return &v, &vm
+}
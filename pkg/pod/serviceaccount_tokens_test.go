@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestServiceAccountTokensVolume(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		tokens     []v1.ServiceAccountToken
+		wantVolume *corev1.Volume
+		wantMount  *corev1.VolumeMount
+	}{{
+		name: "no tokens",
+	}, {
+		name: "tokens with audiences",
+		tokens: []v1.ServiceAccountToken{{
+			Name:     "vault",
+			Audience: "vault.example.com",
+		}, {
+			Name:              "sts",
+			Audience:          "sts.amazonaws.com",
+			ExpirationSeconds: ptr.To[int64](900),
+		}},
+		wantVolume: &corev1.Volume{
+			Name: "tekton-internal-sa-tokens-1",
+			VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience: "vault.example.com",
+						Path:     "vault",
+					},
+				}, {
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          "sts.amazonaws.com",
+						ExpirationSeconds: ptr.To[int64](900),
+						Path:              "sts",
+					},
+				}},
+			}},
+		},
+		wantMount: &corev1.VolumeMount{
+			Name:      "tekton-internal-sa-tokens-1",
+			MountPath: "/var/run/secrets/tekton.dev/tokens",
+			ReadOnly:  true,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, vm := serviceAccountTokensVolume(1, tc.tokens)
+			if d := cmp.Diff(tc.wantVolume, v); d != "" {
+				t.Errorf("Diff volume %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantMount, vm); d != "" {
+				t.Errorf("Diff volumeMount %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
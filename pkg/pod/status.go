@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -29,7 +30,9 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/pkg/termination"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -63,6 +66,10 @@ const (
 	// to resource constraints on the node
 	ReasonExceededNodeResources = "ExceededNodeResources"
 
+	// ReasonPodDisrupted indicates that the TaskRun's pod was disrupted, e.g. evicted, and is
+	// recreated
+	ReasonPodDisrupted = "PodDisrupted"
+
 	// ReasonPullImageFailed indicates that the TaskRun's pod failed to pull image
 	ReasonPullImageFailed = "PullImageFailed"
 
@@ -77,10 +84,18 @@ const (
 	// ReasonPodAdmissionFailed indicates that the TaskRun's pod failed to pass admission validation
 	ReasonPodAdmissionFailed = "PodAdmissionFailed"
 
+	// ReasonUnsupportedImagePlatform indicates that the TaskRun failed to create a pod because
+	// one of its images does not provide the platform targeted by the node selector
+	ReasonUnsupportedImagePlatform = "UnsupportedImagePlatform"
+
 	// ReasonPending indicates that the pod is in corev1.Pending, and the reason is not
 	// ReasonExceededNodeResources or isPodHitConfigError
 	ReasonPodPending = "Pending"
 
+	// StepLogsTruncatedAnnotation is the TaskRun status annotation which lists the steps
+	// whose output was truncated by the entrypoint because it exceeded the configured limits
+	StepLogsTruncatedAnnotation = "tekton.dev/step-logs-truncated"
+
 	// timeFormat is RFC3339 with millisecond
 	timeFormat = "2006-01-02T15:04:05.000Z07:00"
 )
@@ -88,10 +103,20 @@ const (
 const (
 	oomKilled = "OOMKilled"
 	evicted   = "Evicted"
+	// nodeLost is the reason of the status of a pod whose node became unreachable
+	nodeLost = "NodeLost"
+	// nodeShutdown is the reason of the status of a pod terminated by a graceful node shutdown
+	nodeShutdown = "Terminated"
 )
 
+// podDisruptionReasons are the reasons of the status of a pod terminated because of a node-level
+// disruption, which are checked on clusters that do not set the DisruptionTarget condition.
+var podDisruptionReasons = []string{evicted, nodeLost, nodeShutdown}
+
 // SidecarsReady returns true if all of the Pod's sidecars are Ready or
-// Terminated.
+// Terminated. Sidecars running as native Kubernetes sidecars, i.e. as init
+// containers, are considered too, so that their readiness probes gate the
+// start of the first step like the ones of sidecars running as containers.
 func SidecarsReady(podStatus corev1.PodStatus) bool {
 	if podStatus.Phase != corev1.PodRunning {
 		return false
@@ -104,17 +129,29 @@ func SidecarsReady(podStatus corev1.PodStatus) bool {
 		if IsContainerStep(s.Name) {
 			continue
 		}
-		if s.State.Running != nil && s.Ready {
-			continue
+		if !sidecarReady(s) {
+			return false
 		}
-		if s.State.Terminated != nil {
+	}
+	for _, s := range podStatus.InitContainerStatuses {
+		// Init containers other than native sidecars have completed
+		// by the time the Pod is running.
+		if !IsContainerSidecar(s.Name) {
 			continue
 		}
-		return false
+		if !sidecarReady(s) {
+			return false
+		}
 	}
 	return true
 }
 
+// sidecarReady returns true if the sidecar is running and has passed its
+// readiness probe, if any, or if it has terminated.
+func sidecarReady(s corev1.ContainerStatus) bool {
+	return (s.State.Running != nil && s.Ready) || s.State.Terminated != nil
+}
+
 // MakeTaskRunStatus returns a TaskRunStatus based on the Pod's status.
 func MakeTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, tr v1.TaskRun, pod *corev1.Pod, kubeclient kubernetes.Interface, ts *v1.TaskSpec) (v1.TaskRunStatus, error) {
 	trs := &tr.Status
@@ -224,6 +261,35 @@ func getStepResultsFromSidecarLogs(sidecarLogResults []result.RunResult, contain
 	return stepResultsFromSidecarLogs, nil
 }
 
+// verifySidecarLogResults verifies the spire signatures of the results and artifacts extracted from the sidecar
+// logs and records the outcome in the TaskRunConditionResultsVerified condition of the TaskRun.
+// It returns false when they can't be trusted and none of them must be stored in the TaskRun status.
+func verifySidecarLogResults(ctx context.Context, logger *zap.SugaredLogger, spireClient spire.ControllerAPIClient, tr *v1.TaskRun, sidecarLogResults []result.RunResult) bool {
+	err := errors.New("spire controller client is not available")
+	if spireClient != nil {
+		// the spire client identifies the TaskRun by its namespace and name only
+		// the task results, step results and artifacts are signed together
+		err = spireClient.VerifyTaskRunResults(ctx, sidecarlogresults.SignableResults(sidecarLogResults), &v1beta1.TaskRun{ObjectMeta: tr.ObjectMeta})
+	}
+	if err != nil {
+		logger.Errorf("Failed to verify the results of TaskRun %s/%s extracted from the sidecar logs: %v", tr.Namespace, tr.Name, err)
+		tr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionType(v1.TaskRunConditionResultsVerified.String()),
+			Status:  corev1.ConditionFalse,
+			Reason:  v1.TaskRunReasonsResultsVerificationFailed.String(),
+			Message: err.Error(),
+		})
+		return false
+	}
+	tr.Status.SetCondition(&apis.Condition{
+		Type:    apis.ConditionType(v1.TaskRunConditionResultsVerified.String()),
+		Status:  corev1.ConditionTrue,
+		Reason:  v1.TaskRunReasonResultsVerified.String(),
+		Message: "Successfully verified all spire signed taskrun results",
+	})
+	return true
+}
+
 func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredLogger, stepStatuses []corev1.ContainerStatus, tr *v1.TaskRun, podPhase corev1.PodPhase, kubeclient kubernetes.Interface, ts *v1.TaskSpec) error {
 	trs := &tr.Status
 	var errs []error
@@ -253,6 +319,12 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 			sidecarLogResults = append(sidecarLogResults, slr...)
 		}
 	}
+	// Results signed by the sidecar with the TaskRun SVID must be verified, once the TaskRun is done, before they
+	// are stored. None of the task results, step results and artifacts taken from the sidecar logs are stored otherwise.
+	if config.IsSpireEnabled(ctx) && len(sidecarLogResults) > 0 &&
+		(!tr.IsDone() || !verifySidecarLogResults(ctx, logger, spire.GetControllerAPIClient(ctx), tr, sidecarLogResults)) {
+		sidecarLogResults = nil
+	}
 	// Populate Task results from sidecar logs
 	taskResultsFromSidecarLogs := getTaskResultsFromSidecarLogs(sidecarLogResults)
 	taskResults, _, _ := filterResults(taskResultsFromSidecarLogs, specResults, nil)
@@ -275,6 +347,7 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 	}
 
 	// Continue with extraction of termination messages
+	var truncatedLogSteps []string
 	orderedStepStates := make([]v1.StepState, len(stepStatuses))
 	for i, s := range stepStatuses {
 		// Avoid changing the original value by modifying the pointer value.
@@ -317,6 +390,7 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 
 		// Parse termination messages
 		terminationReason := ""
+		var trace []v1.StepTraceSpan
 		if state.Terminated != nil && len(state.Terminated.Message) != 0 {
 			msg := state.Terminated.Message
 
@@ -325,6 +399,12 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 				logger.Errorf("termination message could not be parsed sas JSON: %v", err)
 				errs = append(errs, err)
 			} else {
+				overflowResults, overflowErr := getOverflowResults(ctx, kubeclient, tr, s.Name, results)
+				if overflowErr != nil {
+					logger.Errorf("error reading the overflowed results of step %q in taskrun %q: %v", s.Name, tr.Name, overflowErr)
+					errs = append(errs, overflowErr)
+				}
+				results = append(results, overflowResults...)
 				err := setStepArtifactsValueFromTerminationMessageRunResult(results, &sas)
 				if err != nil {
 					logger.Errorf("error setting step artifacts of step %q in taskrun %q: %v", s.Name, tr.Name, err)
@@ -340,6 +420,14 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 					logger.Errorf("error extracting the exit code of step %q in taskrun %q: %v", s.Name, tr.Name, err)
 					errs = append(errs, err)
 				}
+				trace, err = extractTraceFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the trace of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					errs = append(errs, err)
+				}
+				if isLogTruncatedInResults(results) {
+					truncatedLogSteps = append(truncatedLogSteps, TrimStepPrefix(s.Name))
+				}
 
 				taskResults, stepRunRes, filteredResults := filterResults(results, specResults, stepResults)
 				if tr.IsDone() {
@@ -356,6 +444,7 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 					}
 					trs.Artifacts.Merge(&tras)
 					trs.Artifacts.Merge(&sas)
+					trs.WhenFiles = mergeWhenFiles(trs.WhenFiles, results)
 				}
 				msg, err = createMessageFromResults(filteredResults)
 				if err != nil {
@@ -384,6 +473,7 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 			TerminationReason: terminationReason,
 			Inputs:            sas.Inputs,
 			Outputs:           sas.Outputs,
+			Trace:             trace,
 		}
 		if stepStateProvenance, exist := stepStateProvenances[stepState.Name]; exist {
 			stepState.Provenance = stepStateProvenance
@@ -393,6 +483,12 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 	if len(orderedStepStates) > 0 {
 		trs.Steps = orderedStepStates
 	}
+	if len(truncatedLogSteps) > 0 {
+		if trs.Annotations == nil {
+			trs.Annotations = map[string]string{}
+		}
+		trs.Annotations[StepLogsTruncatedAnnotation] = strings.Join(truncatedLogSteps, ",")
+	}
 
 	return errors.Join(errs...)
 }
@@ -495,6 +591,10 @@ func filterResults(results []result.RunResult, specResults []v1.TaskResult, step
 					Type:  v1.ResultsType(v.Type),
 					Value: v,
 				}
+				// the value of a result of type file is the object referencing its upload
+				if neededTypes[r.Key] == v1.ResultsTypeFile {
+					taskRunResult.Type = v1.ResultsTypeFile
+				}
 			}
 			taskResults = append(taskResults, taskRunResult)
 			filteredResults = append(filteredResults, r)
@@ -536,6 +636,26 @@ func filterResults(results []result.RunResult, specResults []v1.TaskResult, step
 	return taskResults, taskRunStepResults, filteredResults
 }
 
+// mergeWhenFiles merges into whenFiles the content of the files tested by when expressions, which a step
+// wrote to its termination message. The content written by a step replaces the one of the previous steps.
+func mergeWhenFiles(whenFiles []v1.TaskRunWhenFile, results []result.RunResult) []v1.TaskRunWhenFile {
+	for _, r := range results {
+		if r.ResultType != result.WhenFileResultType {
+			continue
+		}
+		workspace, path, _ := strings.Cut(r.Key, "/")
+		whenFile := v1.TaskRunWhenFile{Workspace: workspace, Path: path, Content: r.Value}
+		if i := slices.IndexFunc(whenFiles, func(wf v1.TaskRunWhenFile) bool {
+			return wf.Workspace == workspace && wf.Path == path
+		}); i >= 0 {
+			whenFiles[i] = whenFile
+		} else {
+			whenFiles = append(whenFiles, whenFile)
+		}
+	}
+	return whenFiles
+}
+
 func removeDuplicateResults(taskRunResult []v1.TaskRunResult) []v1.TaskRunResult {
 	if len(taskRunResult) == 0 {
 		return nil
@@ -569,6 +689,19 @@ func extractStartedAtTimeFromResults(results []result.RunResult) (*metav1.Time,
 	return nil, nil //nolint:nilnil // would be more ergonomic to return a sentinel error
 }
 
+func extractTraceFromResults(results []result.RunResult) ([]v1.StepTraceSpan, error) {
+	for _, r := range results {
+		if r.ResultType == result.InternalTektonResultType && r.Key == "Trace" {
+			var trace []v1.StepTraceSpan
+			if err := json.Unmarshal([]byte(r.Value), &trace); err != nil {
+				return nil, fmt.Errorf("could not parse trace value %q in Trace field: %w", r.Value, err)
+			}
+			return trace, nil
+		}
+	}
+	return nil, nil
+}
+
 func extractExitCodeFromResults(results []result.RunResult) (*int32, error) {
 	for _, result := range results {
 		if result.Key == "ExitCode" {
@@ -584,6 +717,15 @@ func extractExitCodeFromResults(results []result.RunResult) (*int32, error) {
 	return nil, nil //nolint:nilnil // would be more ergonomic to return a sentinel error
 }
 
+func isLogTruncatedInResults(results []result.RunResult) bool {
+	for _, r := range results {
+		if r.ResultType == result.InternalTektonResultType && r.Key == "LogTruncated" {
+			return r.Value == "true"
+		}
+	}
+	return false
+}
+
 func extractTerminationReasonFromResults(results []result.RunResult) string {
 	for _, r := range results {
 		if r.ResultType == result.InternalTektonResultType && r.Key == "Reason" {
@@ -610,6 +752,8 @@ func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1.TaskRunStat
 		msg := getFailureMessage(logger, pod)
 		if onError == v1.PipelineTaskContinue {
 			markStatusFailure(trs, v1.TaskRunReasonFailureIgnored.String(), msg)
+		} else if recommendation := getOOMKilledRecommendation(pod); recommendation != "" {
+			markStatusFailure(trs, v1.TaskRunReasonOOMKilled.String(), msg+"; "+recommendation)
 		} else {
 			markStatusFailure(trs, v1.TaskRunReasonFailed.String(), msg)
 		}
@@ -686,13 +830,36 @@ func DidTaskRunFail(pod *corev1.Pod) bool {
 	return false
 }
 
-// IsPodArchived indicates if a pod is archived in the retriesStatus.
+// IsPodDisrupted reports whether the pod is, or is about to be, terminated because of a node-level
+// disruption, e.g. its eviction, its preemption or the loss of its node, rather than because of its
+// containers. It returns the reason and message of the disruption.
+func IsPodDisrupted(pod *corev1.Pod) (bool, string, string) {
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return false, "", ""
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.DisruptionTarget && c.Status == corev1.ConditionTrue {
+			return true, c.Reason, c.Message
+		}
+	}
+	if pod.Status.Phase == corev1.PodFailed && slices.Contains(podDisruptionReasons, pod.Status.Reason) {
+		return true, pod.Status.Reason, pod.Status.Message
+	}
+	return false, "", ""
+}
+
+// IsPodArchived indicates if a pod is archived in the retriesStatus or in the podDisruptions.
 func IsPodArchived(pod *corev1.Pod, trs *v1.TaskRunStatus) bool {
 	for _, retryStatus := range trs.RetriesStatus {
 		if retryStatus.PodName == pod.GetName() {
 			return true
 		}
 	}
+	for _, disruption := range trs.PodDisruptions {
+		if disruption.PodName == pod.GetName() {
+			return true
+		}
+	}
 	return false
 }
 
@@ -762,7 +929,13 @@ func getFailureMessage(logger *zap.SugaredLogger, pod *corev1.Pod) string {
 	}
 
 	// First, try to surface an error about the actual init container that failed.
+	// Kubernetes native sidecars are init containers too, but they are stopped once the
+	// Steps have completed, so they are only considered after the Steps, e.g. to report
+	// a Step that exceeded its timeout rather than the sidecar terminated along with it.
 	for _, status := range pod.Status.InitContainerStatuses {
+		if IsContainerSidecar(status.Name) {
+			continue
+		}
 		if msg := extractContainerFailureMessage(logger, status, pod.ObjectMeta); len(msg) > 0 {
 			return "init container failed, " + msg
 		}
@@ -774,6 +947,16 @@ func getFailureMessage(logger *zap.SugaredLogger, pod *corev1.Pod) string {
 			return msg
 		}
 	}
+
+	// Next, try to surface an error about a Kubernetes native sidecar that failed.
+	for _, status := range pod.Status.InitContainerStatuses {
+		if !IsContainerSidecar(status.Name) {
+			continue
+		}
+		if msg := extractContainerFailureMessage(logger, status, pod.ObjectMeta); len(msg) > 0 {
+			return "init container failed, " + msg
+		}
+	}
 	// Next, return the Pod's status message if it has one.
 	if pod.Status.Message != "" {
 		return pod.Status.Message
@@ -793,6 +976,35 @@ func getFailureMessage(logger *zap.SugaredLogger, pod *corev1.Pod) string {
 	return "build failed for unspecified reasons."
 }
 
+// getOverflowResults reads the results which the entrypoint wrote to the step's log because they did
+// not fit in its termination message, if the termination message results say so. The log is only read
+// once the TaskRun is done. It is searched for the marker with the nonce of the termination message rather
+// than read from its last lines, as processes of the step may still write to it after the entrypoint.
+func getOverflowResults(ctx context.Context, kubeclient kubernetes.Interface, tr *v1.TaskRun, containerName string, results []result.RunResult) ([]result.RunResult, error) {
+	featureFlags := config.FromContextOrDefaults(ctx).FeatureFlags
+	if !featureFlags.EnableResultOverflow || !tr.IsDone() {
+		return nil, nil
+	}
+	i := slices.IndexFunc(results, func(r result.RunResult) bool {
+		return r.ResultType == result.InternalTektonResultType && r.Key == termination.OverflowResultsKey
+	})
+	if i < 0 {
+		return nil, nil
+	}
+	nonce, count, err := termination.ParseOverflowMarker(results[i].Value)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := kubeclient.CoreV1().Pods(tr.Namespace).GetLogs(tr.Status.PodName, &corev1.PodLogOptions{
+		Container: containerName,
+	}).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer logs.Close()
+	return termination.ParseOverflowResults(logs, nonce, count, featureFlags.MaxResultSize)
+}
+
 // extractContainerFailureMessage returns the container failure message by container status or init container status.
 func extractContainerFailureMessage(logger *zap.SugaredLogger, status corev1.ContainerStatus, podMetaData metav1.ObjectMeta) string {
 	term := status.State.Terminated
@@ -960,6 +1172,26 @@ func isOOMKilled(s corev1.ContainerStatus) bool {
 	return s.State.Terminated.Reason == oomKilled
 }
 
+// getOOMKilledRecommendation returns a recommendation to rerun the TaskRun with more memory if one of
+// its steps was OOMKilled, mentioning the memory limit the step reached if it has one, or "" otherwise.
+func getOOMKilledRecommendation(pod *corev1.Pod) string {
+	for _, s := range pod.Status.ContainerStatuses {
+		if !IsContainerStep(s.Name) || s.State.Terminated == nil || !isOOMKilled(s) {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			if c.Name != s.Name {
+				continue
+			}
+			if limit, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+				return fmt.Sprintf("%q reached its memory limit of %s, rerun the TaskRun with a higher memory limit", s.Name, limit.String())
+			}
+		}
+		return fmt.Sprintf("%q ran out of memory, rerun the TaskRun with more memory", s.Name)
+	}
+	return ""
+}
+
 func isSubPathDirectoryError(pod *corev1.Pod) bool {
 	for _, containerStatus := range pod.Status.ContainerStatuses {
 		if containerStatus.State.Waiting != nil &&
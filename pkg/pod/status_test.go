@@ -28,9 +28,13 @@ import (
 	"github.com/tektoncd/pipeline/internal/sidecarlogresults"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/pkg/spire"
+	"github.com/tektoncd/pipeline/pkg/termination"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 	"knative.dev/pkg/apis"
@@ -1024,7 +1028,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 			}},
 		},
 		want: v1.TaskRunStatus{
-			Status: statusFailure(v1.TaskRunReasonFailed.String(), "OOMKilled"),
+			Status: statusFailure(v1.TaskRunReasonOOMKilled.String(), "OOMKilled; \"step-step-push\" ran out of memory, rerun the TaskRun with more memory"),
 			TaskRunStatusFields: v1.TaskRunStatusFields{
 				Steps: []v1.StepState{{
 					ContainerState: corev1.ContainerState{
@@ -1467,7 +1471,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 			}},
 		},
 		want: v1.TaskRunStatus{
-			Status: statusFailure(v1.TaskRunReasonFailed.String(), "\"step-one\" exited with code 137: OOMKilled"),
+			Status: statusFailure(v1.TaskRunReasonOOMKilled.String(), "\"step-one\" exited with code 137: OOMKilled; \"step-one\" ran out of memory, rerun the TaskRun with more memory"),
 			TaskRunStatusFields: v1.TaskRunStatusFields{
 				Steps: []v1.StepState{{
 					ContainerState: corev1.ContainerState{
@@ -2200,6 +2204,38 @@ func TestMakeRunStatus_OnError(t *testing.T) {
 	}
 }
 
+func TestMakeTaskRunStatus_StepLogsTruncated(t *testing.T) {
+	terminated := func(name, message string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name: name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Message: message,
+			}},
+		}
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{
+				terminated("step-build", `[{"key":"LogTruncated","value":"true","type":3}]`),
+				terminated("step-test", `[{"key":"ExitCode","value":"0","type":3}]`),
+				terminated("step-lint", `[{"key":"LogTruncated","value":"true","type":3}]`),
+			},
+		},
+	}
+	tr := v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+
+	logger, _ := logging.NewLogger("", "status")
+	got, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, fakek8s.NewSimpleClientset(), &v1.TaskSpec{})
+	if err != nil {
+		t.Fatalf("MakeTaskRunStatus: %v", err)
+	}
+	if d := cmp.Diff("build,lint", got.Annotations[StepLogsTruncatedAnnotation]); d != "" {
+		t.Errorf("%s annotation %s", StepLogsTruncatedAnnotation, diff.PrintWantGot(d))
+	}
+}
+
 func TestMakeTaskRunStatus_SidecarNotCompleted(t *testing.T) {
 	for _, c := range []struct {
 		desc      string
@@ -2497,6 +2533,55 @@ func TestMakeTaskRunStatus_KubernetesNativeSidecar(t *testing.T) {
 		want: v1.TaskRunStatus{
 			Status: statusSuccess(),
 		},
+	}, {
+		desc: "test step timeout reported over sidecar terminated with the pod",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "step-foo",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  `[{"key":"StartedAt","value":"2023-11-26T19:53:29.452Z","type":3},{"key":"Reason","value":"TimeoutExceeded","type":3}]`,
+						},
+					},
+				},
+				{
+					Name: "step-bar",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+						},
+					},
+				}},
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "sidecar-baz-1",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 137,
+							Reason:   "Error",
+						},
+					},
+				},
+				{
+					Name: "sidecar-baz-2",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 0,
+						},
+					},
+				},
+			},
+		},
+		taskSpec: v1.TaskSpec{
+			Steps:    []v1.Step{{Name: "foo"}, {Name: "bar"}},
+			Sidecars: []v1.Sidecar{{Name: "baz"}},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusFailure(v1.TaskRunReasonFailed.String(), "\"step-foo\" exited because the step exceeded the specified timeout limit"),
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			if reflect.DeepEqual(c.pod, corev1.Pod{}) {
@@ -2966,9 +3051,10 @@ func TestMakeRunStatusJSONError(t *testing.T) {
 
 func TestSidecarsReady(t *testing.T) {
 	for _, c := range []struct {
-		desc     string
-		statuses []corev1.ContainerStatus
-		want     bool
+		desc         string
+		statuses     []corev1.ContainerStatus
+		initStatuses []corev1.ContainerStatus
+		want         bool
 	}{{
 		desc: "no sidecars",
 		statuses: []corev1.ContainerStatus{
@@ -3041,11 +3127,49 @@ func TestSidecarsReady(t *testing.T) {
 			{Name: "step-ignore-me"},
 		},
 		want: false,
+	}, {
+		desc: "native sidecar running but not ready",
+		initStatuses: []corev1.ContainerStatus{
+			{
+				Name: "prepare",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			},
+			{
+				Name:  "sidecar-db",
+				Ready: false, // Readiness probe not passed yet.
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{
+						StartedAt: metav1.NewTime(time.Now()),
+					},
+				},
+			},
+		},
+		statuses: []corev1.ContainerStatus{{Name: "step-ignore-me"}},
+		want:     false,
+	}, {
+		desc: "native sidecar ready",
+		initStatuses: []corev1.ContainerStatus{
+			{Name: "prepare"},
+			{
+				Name:  "sidecar-db",
+				Ready: true,
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{
+						StartedAt: metav1.NewTime(time.Now()),
+					},
+				},
+			},
+		},
+		statuses: []corev1.ContainerStatus{{Name: "step-ignore-me"}},
+		want:     true,
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			got := SidecarsReady(corev1.PodStatus{
-				Phase:             corev1.PodRunning,
-				ContainerStatuses: c.statuses,
+				Phase:                 corev1.PodRunning,
+				ContainerStatuses:     c.statuses,
+				InitContainerStatuses: c.initStatuses,
 			})
 			if got != c.want {
 				t.Errorf("SidecarsReady got %t, want %t", got, c.want)
@@ -3104,10 +3228,11 @@ func TestMarkStatusSuccess(t *testing.T) {
 
 func TestIsPodArchived(t *testing.T) {
 	for _, tc := range []struct {
-		name          string
-		podName       string
-		retriesStatus []v1.TaskRunStatus
-		want          bool
+		name           string
+		podName        string
+		retriesStatus  []v1.TaskRunStatus
+		want           bool
+		podDisruptions []v1.TaskRunPodDisruption
 	}{{
 		name:          "Pod is not in the empty retriesStatus",
 		podName:       "pod",
@@ -3133,12 +3258,20 @@ func TestIsPodArchived(t *testing.T) {
 			},
 		},
 		want: true,
+	}, {
+		name:    "Pod is in the podDisruptions",
+		podName: "pod",
+		podDisruptions: []v1.TaskRunPodDisruption{{
+			PodName: "pod",
+		}},
+		want: true,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			trs := v1.TaskRunStatus{
 				TaskRunStatusFields: v1.TaskRunStatusFields{
-					PodName:       "pod",
-					RetriesStatus: tc.retriesStatus,
+					PodName:        "pod",
+					RetriesStatus:  tc.retriesStatus,
+					PodDisruptions: tc.podDisruptions,
 				},
 			}
 			got := IsPodArchived(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: tc.podName}}, &trs)
@@ -3149,6 +3282,82 @@ func TestIsPodArchived(t *testing.T) {
 	}
 }
 
+func TestIsPodDisrupted(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		status      corev1.PodStatus
+		want        bool
+		wantReason  string
+		wantMessage string
+	}{{
+		name:   "running pod",
+		status: corev1.PodStatus{Phase: corev1.PodRunning},
+		want:   false,
+	}, {
+		name:   "pod failed because of its containers",
+		status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Error"},
+		want:   false,
+	}, {
+		name:        "evicted pod",
+		status:      corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted", Message: "The node was low on resource: memory."},
+		want:        true,
+		wantReason:  "Evicted",
+		wantMessage: "The node was low on resource: memory.",
+	}, {
+		name:       "pod on a lost node",
+		status:     corev1.PodStatus{Phase: corev1.PodFailed, Reason: "NodeLost"},
+		want:       true,
+		wantReason: "NodeLost",
+	}, {
+		name: "pod about to be preempted",
+		status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.DisruptionTarget,
+				Status:  corev1.ConditionTrue,
+				Reason:  "PreemptionByScheduler",
+				Message: "Preempted in order to admit critical pod",
+			}},
+		},
+		want:        true,
+		wantReason:  "PreemptionByScheduler",
+		wantMessage: "Preempted in order to admit critical pod",
+	}, {
+		name: "disruption target condition not true",
+		status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{
+				Type:   corev1.DisruptionTarget,
+				Status: corev1.ConditionFalse,
+			}},
+		},
+		want: false,
+	}, {
+		name: "succeeded pod is never disrupted",
+		status: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			Conditions: []corev1.PodCondition{{
+				Type:   corev1.DisruptionTarget,
+				Status: corev1.ConditionTrue,
+			}},
+		},
+		want: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, reason, message := IsPodDisrupted(&corev1.Pod{Status: tc.status})
+			if got != tc.want {
+				t.Errorf("got: %v, want: %v", got, tc.want)
+			}
+			if reason != tc.wantReason {
+				t.Errorf("got reason: %q, want: %q", reason, tc.wantReason)
+			}
+			if message != tc.wantMessage {
+				t.Errorf("got message: %q, want: %q", message, tc.wantMessage)
+			}
+		})
+	}
+}
+
 func statusRunning() duckv1.Status {
 	var trs v1.TaskRunStatus
 	markStatusRunning(&trs, v1.TaskRunReasonRunning.String(), "Not all Steps in the Task have finished executing")
@@ -3501,6 +3710,118 @@ func TestGetStepTerminationReasonFromContainerStatus(t *testing.T) {
 	}
 }
 
+func TestVerifySidecarLogResults(t *testing.T) {
+	tr := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "foo"}}
+	results := []result.RunResult{{Key: "foo", Value: "bar", ResultType: result.TaskRunResultType}}
+	signer := &spire.MockClient{}
+	if err := signer.CreateEntries(t.Context(), &v1beta1.TaskRun{ObjectMeta: tr.ObjectMeta}, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	signer.SignIdentities = []string{signer.GetIdentity(&v1beta1.TaskRun{ObjectMeta: tr.ObjectMeta})}
+	signed, err := signer.Sign(t.Context(), results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedResults := append(append([]result.RunResult{}, results...), signed...)
+	tamperedResults := append([]result.RunResult{{Key: "foo", Value: "tampered", ResultType: result.TaskRunResultType}}, signed...)
+
+	// the step results and artifacts are signed with the task results
+	withStepData := append(append([]result.RunResult{}, results...),
+		result.RunResult{Key: "step-foo.res", Value: "step-value", ResultType: result.StepResultType},
+		result.RunResult{Key: "step-foo", Value: `{"inputs":[]}`, ResultType: result.StepArtifactsResultType})
+	signer.SignIdentities = []string{signer.GetIdentity(&v1beta1.TaskRun{ObjectMeta: tr.ObjectMeta})}
+	signedStepData, err := signer.Sign(t.Context(), sidecarlogresults.SignableResults(withStepData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedWithStepData := append(append([]result.RunResult{}, withStepData...), signedStepData...)
+	tampered := func(key, value string) []result.RunResult {
+		rs := append([]result.RunResult{}, signedWithStepData...)
+		for i := range rs {
+			if rs[i].Key == key {
+				rs[i].Value = value
+			}
+		}
+		return rs
+	}
+	unsignedStepResult := append(append([]result.RunResult{}, signed...), results...)
+	unsignedStepResult = append(unsignedStepResult, result.RunResult{Key: "step-foo.res", Value: "injected", ResultType: result.StepResultType})
+
+	for _, tc := range []struct {
+		desc        string
+		spireClient spire.ControllerAPIClient
+		results     []result.RunResult
+		want        bool
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+	}{{
+		desc:        "signed results are verified",
+		spireClient: &spire.MockClient{},
+		results:     signedResults,
+		want:        true,
+		wantStatus:  corev1.ConditionTrue,
+		wantReason:  v1.TaskRunReasonResultsVerified.String(),
+	}, {
+		desc:        "tampered results fail verification",
+		spireClient: &spire.MockClient{},
+		results:     tamperedResults,
+		wantStatus:  corev1.ConditionFalse,
+		wantReason:  v1.TaskRunReasonsResultsVerificationFailed.String(),
+	}, {
+		desc:        "signed step results and artifacts are verified",
+		spireClient: &spire.MockClient{},
+		results:     signedWithStepData,
+		want:        true,
+		wantStatus:  corev1.ConditionTrue,
+		wantReason:  v1.TaskRunReasonResultsVerified.String(),
+	}, {
+		desc:        "tampered step results fail verification",
+		spireClient: &spire.MockClient{},
+		results:     tampered("step-foo.res", "tampered"),
+		wantStatus:  corev1.ConditionFalse,
+		wantReason:  v1.TaskRunReasonsResultsVerificationFailed.String(),
+	}, {
+		desc:        "tampered artifacts fail verification",
+		spireClient: &spire.MockClient{},
+		results:     tampered("step-foo", `{"inputs":[{"name":"forged"}]}`),
+		wantStatus:  corev1.ConditionFalse,
+		wantReason:  v1.TaskRunReasonsResultsVerificationFailed.String(),
+	}, {
+		desc:        "injected step results fail verification",
+		spireClient: &spire.MockClient{},
+		results:     unsignedStepResult,
+		wantStatus:  corev1.ConditionFalse,
+		wantReason:  v1.TaskRunReasonsResultsVerificationFailed.String(),
+	}, {
+		desc:        "unsigned results fail verification",
+		spireClient: &spire.MockClient{},
+		results:     results,
+		wantStatus:  corev1.ConditionFalse,
+		wantReason:  v1.TaskRunReasonsResultsVerificationFailed.String(),
+	}, {
+		desc:       "missing spire client fails verification",
+		results:    signedResults,
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1.TaskRunReasonsResultsVerificationFailed.String(),
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr := tr.DeepCopy()
+			logger, _ := logging.NewLogger("", "status")
+			got := verifySidecarLogResults(t.Context(), logger, tc.spireClient, tr, tc.results)
+			if got != tc.want {
+				t.Errorf("verifySidecarLogResults() = %t, want %t", got, tc.want)
+			}
+			cond := tr.Status.GetCondition(apis.ConditionType(v1.TaskRunConditionResultsVerified.String()))
+			if cond == nil {
+				t.Fatal("expected the results verified condition to be set")
+			}
+			if cond.Status != tc.wantStatus || cond.Reason != tc.wantReason {
+				t.Errorf("unexpected results verified condition %v, want status %s and reason %s", cond, tc.wantStatus, tc.wantReason)
+			}
+		})
+	}
+}
+
 func TestGetTaskResultsFromSidecarLogs(t *testing.T) {
 	sidecarLogResults := []result.RunResult{{
 		Key:        "step-foo.step-res",
@@ -3522,6 +3843,136 @@ func TestGetTaskResultsFromSidecarLogs(t *testing.T) {
 	}
 }
 
+func TestGetOverflowResults(t *testing.T) {
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{PodName: "task-run-pod"},
+		},
+	}
+	tr.Status.SetCondition(&apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionTrue,
+	})
+	overflow := []result.RunResult{{
+		Key:        termination.OverflowResultsKey,
+		Value:      "0123456789abcdef:1",
+		ResultType: result.InternalTektonResultType,
+	}}
+	for _, tc := range []struct {
+		desc                 string
+		enableResultOverflow bool
+		results              []result.RunResult
+		wantErr              bool
+	}{{
+		desc:                 "no overflow",
+		enableResultOverflow: true,
+		results: []result.RunResult{{
+			Key:        "task-res",
+			Value:      "task-bar",
+			ResultType: result.TaskRunResultType,
+		}},
+	}, {
+		desc:    "overflow with the feature flag disabled",
+		results: overflow,
+	}, {
+		desc:                 "overflow without results in the step log",
+		enableResultOverflow: true,
+		results:              overflow,
+		wantErr:              true,
+	}, {
+		desc:                 "invalid overflow marker",
+		enableResultOverflow: true,
+		results: []result.RunResult{{
+			Key:        termination.OverflowResultsKey,
+			Value:      "true",
+			ResultType: result.InternalTektonResultType,
+		}},
+		wantErr: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := config.FromContextOrDefaults(t.Context())
+			cfg.FeatureFlags.EnableResultOverflow = tc.enableResultOverflow
+			ctx := config.ToContext(t.Context(), cfg)
+			got, err := getOverflowResults(ctx, fakek8s.NewSimpleClientset(), tr, "step-foo", tc.results)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %t, got %v", tc.wantErr, err)
+			}
+			if len(got) != 0 {
+				t.Errorf("expected no overflowed results, got %v", got)
+			}
+		})
+	}
+}
+
+func TestExtractTraceFromResults(t *testing.T) {
+	results := []result.RunResult{{
+		Key:        "StartedAt",
+		Value:      "2026-10-16T13:00:00.000Z",
+		ResultType: result.InternalTektonResultType,
+	}, {
+		Key:        "Trace",
+		Value:      `[{"name":"wait","startedAt":"2026-10-16T13:00:00.000Z","duration":"1.5s"},{"name":"exec","startedAt":"2026-10-16T13:00:01.500Z","duration":"2m0s"}]`,
+		ResultType: result.InternalTektonResultType,
+	}}
+	want := []v1.StepTraceSpan{{
+		Name:     "wait",
+		Duration: metav1.Duration{Duration: 1500 * time.Millisecond},
+	}, {
+		Name:     "exec",
+		Duration: metav1.Duration{Duration: 2 * time.Minute},
+	}}
+	got, err := extractTraceFromResults(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+
+	if _, err := extractTraceFromResults([]result.RunResult{{Key: "Trace", Value: "not json", ResultType: result.InternalTektonResultType}}); err == nil {
+		t.Error("expected an error for an invalid trace")
+	}
+
+	userResult := []result.RunResult{{Key: "Trace", Value: "not json", ResultType: result.TaskRunResultType}}
+	if got, err := extractTraceFromResults(userResult); err != nil || got != nil {
+		t.Errorf("expected a task result named Trace to be ignored, got %v, %v", got, err)
+	}
+}
+
+func TestMergeWhenFiles(t *testing.T) {
+	whenFiles := []v1.TaskRunWhenFile{{
+		Workspace: "source",
+		Path:      "summary.txt",
+		Content:   "unchanged",
+	}}
+	results := []result.RunResult{{
+		Key:        "StartedAt",
+		Value:      "2026-10-16T13:00:00.000Z",
+		ResultType: result.InternalTektonResultType,
+	}, {
+		Key:        "source/summary.txt",
+		Value:      "changed",
+		ResultType: result.WhenFileResultType,
+	}, {
+		Key:        "source/reports/lint.txt",
+		Value:      "0 issues",
+		ResultType: result.WhenFileResultType,
+	}}
+	want := []v1.TaskRunWhenFile{{
+		Workspace: "source",
+		Path:      "summary.txt",
+		Content:   "changed",
+	}, {
+		Workspace: "source",
+		Path:      "reports/lint.txt",
+		Content:   "0 issues",
+	}}
+	if d := cmp.Diff(want, mergeWhenFiles(whenFiles, results)); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
 func TestIsSubPathDirectoryError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -3659,3 +4110,64 @@ func TestUpdateIncompleteTaskRunStatus_SubPathError(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOOMKilledRecommendation(t *testing.T) {
+	oomKilledStatus := corev1.ContainerStatus{
+		Name: "step-build",
+		State: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{Reason: oomKilled, ExitCode: 137},
+		},
+	}
+	for _, tc := range []struct {
+		desc string
+		pod  *corev1.Pod
+		want string
+	}{{
+		desc: "no oom",
+		pod: &corev1.Pod{
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+				},
+			}}},
+		},
+		want: "",
+	}, {
+		desc: "oom of a sidecar",
+		pod: &corev1.Pod{
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "sidecar-db",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{Reason: oomKilled, ExitCode: 137},
+				},
+			}}},
+		},
+		want: "",
+	}, {
+		desc: "oom of a step without memory limit",
+		pod: &corev1.Pod{
+			Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "step-build"}}},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{oomKilledStatus}},
+		},
+		want: `"step-build" ran out of memory, rerun the TaskRun with more memory`,
+	}, {
+		desc: "oom of a step with memory limit",
+		pod: &corev1.Pod{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "step-build",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+				},
+			}}},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{oomKilledStatus}},
+		},
+		want: `"step-build" reached its memory limit of 256Mi, rerun the TaskRun with a higher memory limit`,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := getOOMKilledRecommendation(tc.pod); got != tc.want {
+				t.Errorf("getOOMKilledRecommendation() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	customrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/customrun"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+)
+
+const (
+	// ReasonWaitingForApproval indicates that the approval gate waits for the decisions of its approvers.
+	ReasonWaitingForApproval = "WaitingForApproval"
+	// ReasonApproved indicates that enough approvers approved the approval gate.
+	ReasonApproved = "Approved"
+	// ReasonRejected indicates that an approver rejected the approval gate.
+	ReasonRejected = "Rejected"
+	// ReasonInvalidApproval indicates that the params of the approval gate are invalid.
+	ReasonInvalidApproval = "InvalidApproval"
+
+	// ParamApprovers is the array param holding the approvers of an approval gate.
+	ParamApprovers = "approvers"
+	// ParamNumberOfApprovalsRequired is the param holding the number of approvals required.
+	ParamNumberOfApprovalsRequired = "numberOfApprovalsRequired"
+	// ParamDescription is the param holding the description of an approval gate.
+	ParamDescription = "description"
+	// ResultApprovedBy is the result holding the comma separated approvers who approved.
+	ResultApprovedBy = "approvedBy"
+)
+
+// ErrInvalidApproval indicates that the params of an approval gate are invalid.
+var ErrInvalidApproval = errors.New("invalid approval gate params")
+
+// Reconciler implements controller.Reconciler for the CustomRuns of approval gates.
+type Reconciler struct {
+	PipelineClientSet clientset.Interface
+	Clock             clock.PassiveClock
+	approvalLister    listers.ApprovalLister
+}
+
+// Check that our Reconciler implements customrunreconciler.Interface
+var _ customrunreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind creates the Approval of the CustomRun of an approval gate and completes the
+// CustomRun once the Approval is approved or rejected, or when it times out.
+func (c *Reconciler) ReconcileKind(ctx context.Context, run *v1beta1.CustomRun) reconciler.Event {
+	logger := logging.FromContext(ctx)
+
+	if run.Spec.CustomRef == nil || run.Spec.CustomRef.APIVersion != v1alpha1.SchemeGroupVersion.String() || run.Spec.CustomRef.Kind != v1alpha1.ApprovalKind {
+		// This is not a CustomRun we should have been notified about; do nothing.
+		return nil
+	}
+	if run.IsDone() {
+		return nil
+	}
+	if !run.HasStarted() {
+		run.Status.InitializeConditions()
+		run.Status.StartTime = &metav1.Time{Time: c.Clock.Now()}
+	}
+
+	if run.IsCancelled() {
+		msg := string(run.Spec.StatusMessage)
+		if msg == "" {
+			msg = fmt.Sprintf("CustomRun %q was cancelled", run.Name)
+		}
+		run.Status.CompletionTime = &metav1.Time{Time: c.Clock.Now()}
+		run.Status.MarkCustomRunFailed(v1beta1.CustomRunReasonCancelled.String(), msg)
+		return nil
+	}
+
+	approval, err := c.getOrCreateApproval(ctx, run)
+	if err != nil {
+		if errors.Is(err, ErrInvalidApproval) || apierrors.IsInvalid(err) {
+			run.Status.CompletionTime = &metav1.Time{Time: c.Clock.Now()}
+			run.Status.MarkCustomRunFailed(ReasonInvalidApproval, "Approval gate %q is invalid: %v", run.Name, err)
+			return nil
+		}
+		return err
+	}
+
+	state, approvedBy := approval.Spec.GetState()
+	if approval.Status.State != state || !slices.Equal(approval.Status.ApprovedBy, approvedBy) {
+		approval = approval.DeepCopy()
+		approval.Status.State = state
+		approval.Status.ApprovedBy = approvedBy
+		if _, err := c.PipelineClientSet.TektonV1alpha1().Approvals(run.Namespace).UpdateStatus(ctx, approval, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update the status of Approval %s/%s: %w", run.Namespace, approval.Name, err)
+		}
+	}
+	run.Status.Results = []v1beta1.CustomRunResult{{Name: ResultApprovedBy, Value: strings.Join(approvedBy, ",")}}
+
+	switch state {
+	case v1alpha1.ApprovalStateApproved:
+		logger.Infof("Approval gate %s/%s was approved by %v", run.Namespace, run.Name, approvedBy)
+		run.Status.CompletionTime = &metav1.Time{Time: c.Clock.Now()}
+		run.Status.MarkCustomRunSucceeded(ReasonApproved, "Approved by %s", strings.Join(approvedBy, ", "))
+		return nil
+	case v1alpha1.ApprovalStateRejected:
+		logger.Infof("Approval gate %s/%s was rejected", run.Namespace, run.Name)
+		run.Status.CompletionTime = &metav1.Time{Time: c.Clock.Now()}
+		run.Status.MarkCustomRunFailed(ReasonRejected, "Rejected by %s", rejectedBy(approval))
+		return nil
+	}
+
+	if run.HasTimedOut(c.Clock) {
+		run.Status.CompletionTime = &metav1.Time{Time: c.Clock.Now()}
+		run.Status.MarkCustomRunFailed(v1beta1.CustomRunReasonTimedOut.String(), "Approval gate %q timed out after %s", run.Name, run.GetTimeout())
+		return nil
+	}
+	run.Status.MarkCustomRunRunning(ReasonWaitingForApproval, "Waiting for %d of %d approvals", approval.Spec.GetNumberOfApprovalsRequired()-len(approvedBy), approval.Spec.GetNumberOfApprovalsRequired())
+	if timeout := run.GetTimeout(); timeout > 0 {
+		return controller.NewRequeueAfter(timeout - c.Clock.Since(run.Status.StartTime.Time))
+	}
+	return nil
+}
+
+// getOrCreateApproval returns the Approval of the CustomRun, creating it from its params if it does not exist yet.
+func (c *Reconciler) getOrCreateApproval(ctx context.Context, run *v1beta1.CustomRun) (*v1alpha1.Approval, error) {
+	approval, err := c.approvalLister.Approvals(run.Namespace).Get(run.Name)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return approval, err
+	}
+	approval, err = newApproval(ctx, run)
+	if err != nil {
+		return nil, err
+	}
+	return c.PipelineClientSet.TektonV1alpha1().Approvals(run.Namespace).Create(ctx, approval, metav1.CreateOptions{})
+}
+
+// newApproval returns the Approval of the CustomRun of an approval gate, built from its params.
+func newApproval(ctx context.Context, run *v1beta1.CustomRun) (*v1alpha1.Approval, error) {
+	approval := &v1alpha1.Approval{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            run.Name,
+			Namespace:       run.Namespace,
+			Labels:          maps.Clone(run.Labels),
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(run)},
+		},
+	}
+	for _, p := range run.Spec.Params {
+		switch p.Name {
+		case ParamApprovers:
+			for _, name := range p.Value.ArrayVal {
+				approval.Spec.Approvers = append(approval.Spec.Approvers, v1alpha1.ApproverDecision{Name: name})
+			}
+		case ParamNumberOfApprovalsRequired:
+			n, err := strconv.Atoi(p.Value.StringVal)
+			if err != nil {
+				return nil, fmt.Errorf("%w: param %s must be an integer: %w", ErrInvalidApproval, ParamNumberOfApprovalsRequired, err)
+			}
+			approval.Spec.NumberOfApprovalsRequired = n
+		case ParamDescription:
+			approval.Spec.Description = p.Value.StringVal
+		default:
+			return nil, fmt.Errorf("%w: unexpected param %s", ErrInvalidApproval, p.Name)
+		}
+	}
+	approval.SetDefaults(ctx)
+	if err := approval.Spec.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidApproval, err)
+	}
+	return approval, nil
+}
+
+// rejectedBy returns the names of the approvers who rejected the Approval.
+func rejectedBy(approval *v1alpha1.Approval) string {
+	var names []string
+	for _, a := range approval.Spec.Approvers {
+		if a.Input == v1alpha1.ApprovalInputReject {
+			names = append(names, a.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approval
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/apis"
+	cminformer "knative.dev/pkg/configmap/informer"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+	_ "knative.dev/pkg/system/testing" // Setup system.Namespace()
+)
+
+var (
+	now       = time.Date(2026, time.January, 1, 1, 0, 0, 0, time.UTC)
+	testClock = clock.NewFakePassiveClock(now)
+)
+
+func initializeApprovalControllerAssets(t *testing.T, d test.Data) (test.Assets, func()) {
+	t.Helper()
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	test.EnsureConfigurationConfigMapsExist(&d)
+	c, informers := test.SeedTestData(t, ctx, d)
+	configMapWatcher := cminformer.NewInformedWatcher(c.Kube, system.Namespace())
+	ctl := NewController(testClock)(ctx, configMapWatcher)
+	if err := configMapWatcher.Start(ctx.Done()); err != nil {
+		t.Fatalf("error starting configmap watcher: %v", err)
+	}
+
+	if la, ok := ctl.Reconciler.(pkgreconciler.LeaderAware); ok {
+		la.Promote(pkgreconciler.UniversalBucket(), func(pkgreconciler.Bucket, types.NamespacedName) {})
+	}
+
+	return test.Assets{
+		Logger:     logging.FromContext(ctx),
+		Controller: ctl,
+		Clients:    c,
+		Informers:  informers,
+		Recorder:   controller.GetEventRecorder(ctx).(*record.FakeRecorder),
+		Ctx:        ctx,
+	}, cancel
+}
+
+func newCustomRun(params v1beta1.Params, started time.Time, status v1beta1.CustomRunSpecStatus) *v1beta1.CustomRun {
+	run := &v1beta1.CustomRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "release-approve",
+			Namespace: "foo",
+			UID:       "release-approve-uid",
+			Labels:    map[string]string{"tekton.dev/pipelineTask": "approve"},
+		},
+		Spec: v1beta1.CustomRunSpec{
+			CustomRef: &v1beta1.TaskRef{APIVersion: "tekton.dev/v1alpha1", Kind: "Approval"},
+			Params:    params,
+			Timeout:   &metav1.Duration{Duration: time.Hour},
+			Status:    status,
+		},
+	}
+	if !started.IsZero() {
+		run.Status.InitializeConditions()
+		run.Status.StartTime = &metav1.Time{Time: started}
+	}
+	return run
+}
+
+func newApprovalFor(run *v1beta1.CustomRun, inputs ...v1alpha1.ApprovalInput) *v1alpha1.Approval {
+	approval := &v1alpha1.Approval{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            run.Name,
+			Namespace:       run.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(run)},
+		},
+		Spec: v1alpha1.ApprovalSpec{NumberOfApprovalsRequired: 1},
+	}
+	for i, name := range []string{"alice", "group:release-managers"} {
+		approval.Spec.Approvers = append(approval.Spec.Approvers, v1alpha1.ApproverDecision{Name: name, Input: inputs[i]})
+	}
+	return approval
+}
+
+func TestReconcile(t *testing.T) {
+	params := v1beta1.Params{
+		{Name: ParamApprovers, Value: *v1beta1.NewStructuredValues("alice", "group:release-managers")},
+		{Name: ParamDescription, Value: *v1beta1.NewStructuredValues("Deploy to production")},
+	}
+	started := now.Add(-10 * time.Minute)
+	running := newCustomRun(params, started, "")
+
+	for _, tc := range []struct {
+		name              string
+		run               *v1beta1.CustomRun
+		approval          *v1alpha1.Approval
+		wantStatus        corev1.ConditionStatus
+		wantReason        string
+		wantApprovedBy    string
+		wantApproval      *v1alpha1.ApprovalSpec
+		wantApprovalState v1alpha1.ApprovalState
+	}{{
+		name:       "creates the Approval and waits for it",
+		run:        newCustomRun(params, time.Time{}, ""),
+		wantStatus: corev1.ConditionUnknown,
+		wantReason: ReasonWaitingForApproval,
+		wantApproval: &v1alpha1.ApprovalSpec{
+			Description: "Deploy to production",
+			Approvers: []v1alpha1.ApproverDecision{
+				{Name: "alice", Input: v1alpha1.ApprovalInputPending},
+				{Name: "group:release-managers", Input: v1alpha1.ApprovalInputPending},
+			},
+			NumberOfApprovalsRequired: 1,
+		},
+		wantApprovalState: v1alpha1.ApprovalStatePending,
+	}, {
+		name:              "approved",
+		run:               running,
+		approval:          newApprovalFor(running, v1alpha1.ApprovalInputPending, v1alpha1.ApprovalInputApprove),
+		wantStatus:        corev1.ConditionTrue,
+		wantReason:        ReasonApproved,
+		wantApprovedBy:    "group:release-managers",
+		wantApprovalState: v1alpha1.ApprovalStateApproved,
+	}, {
+		name:              "rejected",
+		run:               running,
+		approval:          newApprovalFor(running, v1alpha1.ApprovalInputReject, v1alpha1.ApprovalInputApprove),
+		wantStatus:        corev1.ConditionFalse,
+		wantReason:        ReasonRejected,
+		wantApprovalState: v1alpha1.ApprovalStateRejected,
+	}, {
+		name:              "timed out",
+		run:               newCustomRun(params, now.Add(-2*time.Hour), ""),
+		approval:          newApprovalFor(running, v1alpha1.ApprovalInputPending, v1alpha1.ApprovalInputPending),
+		wantStatus:        corev1.ConditionFalse,
+		wantReason:        v1beta1.CustomRunReasonTimedOut.String(),
+		wantApprovalState: v1alpha1.ApprovalStatePending,
+	}, {
+		name:       "cancelled",
+		run:        newCustomRun(params, started, v1beta1.CustomRunSpecStatusCancelled),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1beta1.CustomRunReasonCancelled.String(),
+	}, {
+		name: "invalid params",
+		run: newCustomRun(v1beta1.Params{
+			{Name: ParamApprovers, Value: *v1beta1.NewStructuredValues("alice")},
+			{Name: ParamNumberOfApprovalsRequired, Value: *v1beta1.NewStructuredValues("2")},
+		}, time.Time{}, ""),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: ReasonInvalidApproval,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{CustomRuns: []*v1beta1.CustomRun{tc.run}}
+			if tc.approval != nil {
+				d.Approvals = []*v1alpha1.Approval{tc.approval}
+			}
+			testAssets, cancel := initializeApprovalControllerAssets(t, d)
+			defer cancel()
+			c := testAssets.Clients.Pipeline
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, strings.Join([]string{tc.run.Namespace, tc.run.Name}, "/"))
+			if ok, _ := controller.IsRequeueKey(err); err != nil && !ok {
+				t.Fatalf("did not expect an error, but got %v", err)
+			}
+
+			reconciled, err := c.TektonV1beta1().CustomRuns(tc.run.Namespace).Get(testAssets.Ctx, tc.run.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting reconciled CustomRun: %v", err)
+			}
+			condition := reconciled.Status.GetCondition(apis.ConditionSucceeded)
+			if condition == nil || condition.Status != tc.wantStatus || condition.Reason != tc.wantReason {
+				t.Errorf("expected condition %s with reason %s, got %v", tc.wantStatus, tc.wantReason, condition)
+			}
+			if tc.wantApprovedBy != "" {
+				wantResults := []v1beta1.CustomRunResult{{Name: ResultApprovedBy, Value: tc.wantApprovedBy}}
+				if d := cmp.Diff(wantResults, reconciled.Status.Results); d != "" {
+					t.Errorf("CustomRun results %s", diff.PrintWantGot(d))
+				}
+			}
+
+			approval, err := c.TektonV1alpha1().Approvals(tc.run.Namespace).Get(testAssets.Ctx, tc.run.Name, metav1.GetOptions{})
+			if tc.wantApprovalState == "" {
+				if err == nil {
+					t.Errorf("expected no Approval, got %v", approval)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getting Approval: %v", err)
+			}
+			if tc.wantApproval != nil {
+				if d := cmp.Diff(*tc.wantApproval, approval.Spec); d != "" {
+					t.Errorf("Approval spec %s", diff.PrintWantGot(d))
+				}
+				if !metav1.IsControlledBy(approval, tc.run) {
+					t.Errorf("expected Approval to be controlled by the CustomRun, got owner references %v", approval.OwnerReferences)
+				}
+			}
+			if approval.Status.State != tc.wantApprovalState {
+				t.Errorf("expected Approval state %s, got %s", tc.wantApprovalState, approval.Status.State)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package approval provides a reconciler for the CustomRuns of approval gates, i.e.
+pipeline tasks referencing the custom task kind tekton.dev/v1alpha1 Approval.
+The reconciler creates an Approval for each of them and completes the CustomRun
+once its approvers approve or reject it, without running any pod in between.
+*/
+package approval
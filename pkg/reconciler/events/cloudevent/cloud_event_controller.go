@@ -24,6 +24,7 @@ import (
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cache"
 	corev1 "k8s.io/api/core/v1"
@@ -34,22 +35,10 @@ import (
 	"knative.dev/pkg/logging"
 )
 
-func cloudEventsSink(ctx context.Context) string {
-	configs := config.FromContextOrDefaults(ctx)
-	// Try the sink configuration first
-	sink := configs.Events.Sink
-	if sink == "" {
-		// Fall back to the deprecated flag is the new one is not set
-		// This ensures no changes in behaviour for existing users of the deprecated flag
-		sink = configs.Defaults.DefaultCloudEventsSink
-	}
-	return sink
-}
-
 // EmitCloudEvents emits CloudEvents (only) for object
 func EmitCloudEvents(ctx context.Context, object runtime.Object) {
 	logger := logging.FromContext(ctx)
-	if sink := cloudEventsSink(ctx); sink != "" {
+	if sink := cloudEventsSink(ctx, object); sink != "" {
 		ctx = cloudevents.ContextWithTarget(ctx, sink)
 		err := SendCloudEventWithRetries(ctx, object)
 		if err != nil {
@@ -61,7 +50,7 @@ func EmitCloudEvents(ctx context.Context, object runtime.Object) {
 // EmitCloudEventsWhenConditionChange emits CloudEvents when there is a change in condition
 func EmitCloudEventsWhenConditionChange(ctx context.Context, beforeCondition *apis.Condition, afterCondition *apis.Condition, object runtime.Object) {
 	logger := logging.FromContext(ctx)
-	if sink := cloudEventsSink(ctx); sink != "" {
+	if sink := cloudEventsSink(ctx, object); sink != "" {
 		ctx = cloudevents.ContextWithTarget(ctx, sink)
 
 		// Only send events if the new condition represents a change
@@ -74,6 +63,49 @@ func EmitCloudEventsWhenConditionChange(ctx context.Context, beforeCondition *ap
 	}
 }
 
+// EmitStepCloudEvents emits CloudEvents for the steps of the TaskRun that started
+// or completed since beforeSteps were recorded. Events are only emitted when enabled
+// via the "send-cloudevents-for-steps" feature flag and a sink is configured.
+func EmitStepCloudEvents(ctx context.Context, beforeSteps []v1.StepState, tr *v1.TaskRun) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.SendCloudEventsForSteps {
+		return
+	}
+	sink := cloudEventsSink(ctx, tr)
+	if sink == "" {
+		return
+	}
+	ctx = cloudevents.ContextWithTarget(ctx, sink)
+	logger := logging.FromContext(ctx)
+	ceClient := Get(ctx)
+	if ceClient == nil {
+		logger.Warnf("Failed to emit cloud events for steps: no cloud events client found in the context")
+		return
+	}
+
+	before := make(map[string]v1.StepState, len(beforeSteps))
+	for _, s := range beforeSteps {
+		before[s.Name] = s
+	}
+	for _, step := range tr.Status.Steps {
+		prev := before[step.Name]
+		var eventTypes []TektonEventType
+		if (step.Running != nil || step.Terminated != nil) && prev.Running == nil && prev.Terminated == nil {
+			eventTypes = append(eventTypes, StepStartedEventV1)
+		}
+		if step.Terminated != nil && prev.Terminated == nil {
+			eventTypes = append(eventTypes, StepCompletedEventV1)
+		}
+		for _, eventType := range eventTypes {
+			event, err := EventForStep(tr, step, eventType)
+			if err != nil {
+				logger.Warnf("Failed to emit cloud event for step %q: %v", step.Name, err)
+				continue
+			}
+			sendEventWithRetries(ctx, ceClient, tr, event, nil)
+		}
+	}
+}
+
 // SendCloudEventWithRetries sends a cloud event for the specified resource.
 // It does not block and it perform retries with backoff using the cloudevents
 // sdk-go capabilities.
@@ -88,7 +120,6 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 	if o, ok = object.(objectWithCondition); !ok {
 		return errors.New("input object does not satisfy objectWithCondition")
 	}
-	logger := logging.FromContext(ctx)
 	ceClient := Get(ctx)
 	if ceClient == nil {
 		return errors.New("no cloud events client found in the context")
@@ -98,11 +129,18 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 		return err
 	}
 	// Events for CustomRuns require a cache of events that have been sent
-	_, isCustomRun := object.(*v1beta1.CustomRun)
-	if isCustomRun {
+	if _, isCustomRun := object.(*v1beta1.CustomRun); isCustomRun {
 		cacheClient = cache.Get(ctx)
 	}
+	sendEventWithRetries(ctx, ceClient, object, event, cacheClient)
+	return nil
+}
 
+// sendEventWithRetries sends the event without blocking, retrying with backoff.
+// When a cacheClient is provided, events already in the cache are not sent again.
+// Failures to send are recorded as k8s events on the object.
+func sendEventWithRetries(ctx context.Context, ceClient CEClient, object runtime.Object, event *cloudevents.Event, cacheClient *lru.Cache) {
+	logger := logging.FromContext(ctx)
 	wasIn := make(chan error)
 
 	ceClient.addCount()
@@ -111,7 +149,7 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 		wasIn <- nil
 		logger.Debugf("Sending cloudevent of type %q", event.Type())
 		// In case of Run event, check cache if cloudevent is already sent
-		if isCustomRun {
+		if cacheClient != nil {
 			cloudEventSent, err := cache.ContainsOrAddCloudEvent(cacheClient, event)
 			if err != nil {
 				logger.Errorf("Error while checking cache: %s", err)
@@ -132,5 +170,5 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 		}
 	}()
 
-	return <-wasIn
+	<-wasIn
 }
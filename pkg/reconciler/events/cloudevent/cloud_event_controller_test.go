@@ -279,3 +279,83 @@ func setupFakeContext(t *testing.T, behaviour cloudevent.FakeClientBehaviour, wi
 	}
 	return ctx
 }
+
+func TestEmitStepCloudEvents(t *testing.T) {
+	running := v1.StepState{
+		Name:           "build",
+		ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+	terminated := v1.StepState{
+		Name: "build",
+		ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode:   1,
+			StartedAt:  metav1.Unix(100, 0),
+			FinishedAt: metav1.Unix(130, 0),
+		}},
+	}
+	waiting := v1.StepState{
+		Name:           "build",
+		ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		enabled         bool
+		before          []v1.StepState
+		after           v1.StepState
+		wantCloudEvents []string
+	}{{
+		name:            "step started",
+		enabled:         true,
+		before:          []v1.StepState{waiting},
+		after:           running,
+		wantCloudEvents: []string{`(?s)dev.tekton.event.step.started.v1.*"step": "build"`},
+	}, {
+		name:            "step completed",
+		enabled:         true,
+		before:          []v1.StepState{running},
+		after:           terminated,
+		wantCloudEvents: []string{`(?s)dev.tekton.event.step.completed.v1.*"exitCode": 1,.*"duration": "30s"`},
+	}, {
+		name:    "step started and completed between reconciles",
+		enabled: true,
+		after:   terminated,
+		wantCloudEvents: []string{
+			`(?s)dev.tekton.event.step.started.v1.*"step": "build"\n  }`,
+			`(?s)dev.tekton.event.step.completed.v1.*"step": "build",.*"exitCode": 1`,
+		},
+	}, {
+		name:    "no transition",
+		enabled: true,
+		before:  []v1.StepState{terminated},
+		after:   terminated,
+	}, {
+		name:   "disabled",
+		before: []v1.StepState{running},
+		after:  terminated,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun", Namespace: "foo"},
+				Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+					Steps: []v1.StepState{tc.after},
+				}},
+			}
+
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ctx = cloudevent.WithFakeClient(ctx, &cloudevent.FakeClientBehaviour{SendSuccessfully: true}, len(tc.wantCloudEvents))
+			fakeClient := cloudevent.Get(ctx).(cloudevent.FakeClient)
+
+			featureFlags := config.DefaultFeatureFlags.DeepCopy()
+			featureFlags.SendCloudEventsForSteps = tc.enabled
+			eventsConfig, _ := config.NewEventsFromMap(map[string]string{"sink": "http://mysink"})
+			ctx = config.ToContext(ctx, &config.Config{
+				Events:       eventsConfig,
+				FeatureFlags: featureFlags,
+			})
+
+			cloudevent.EmitStepCloudEvents(ctx, tc.before, tr)
+			fakeClient.CheckCloudEventsUnordered(t, tc.name, tc.wantCloudEvents)
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"knative.dev/pkg/apis"
@@ -71,6 +72,10 @@ const (
 	CustomRunSuccessfulEventV1 TektonEventType = "dev.tekton.event.customrun.successful.v1"
 	// CustomRunFailedEventV1 is sent for CustomRuns with "ConditionSucceeded" "False"
 	CustomRunFailedEventV1 TektonEventType = "dev.tekton.event.customrun.failed.v1"
+	// StepStartedEventV1 is sent when the container of a TaskRun step starts running
+	StepStartedEventV1 TektonEventType = "dev.tekton.event.step.started.v1"
+	// StepCompletedEventV1 is sent when the container of a TaskRun step terminates
+	StepCompletedEventV1 TektonEventType = "dev.tekton.event.step.completed.v1"
 )
 
 func (t TektonEventType) String() string {
@@ -95,6 +100,18 @@ type TektonCloudEventData struct {
 	CustomRun   *v1beta1.CustomRun   `json:"customRun,omitempty"`
 }
 
+// StepCloudEventData type is used to marshal and unmarshal the payload of
+// a Tekton cloud event sent for a step of a TaskRun
+type StepCloudEventData struct {
+	TaskRun   string `json:"taskRun"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+	Step      string `json:"step"`
+	// ExitCode and Duration are only set once the step has completed
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
 // newTektonCloudEventData returns a new instance of TektonCloudEventData
 func newTektonCloudEventData(ctx context.Context, runObject objectWithCondition) (TektonCloudEventData, error) {
 	tektonCloudEventData := TektonCloudEventData{}
@@ -159,6 +176,37 @@ func EventForObjectWithCondition(ctx context.Context, runObject objectWithCondit
 	return &event, nil
 }
 
+// EventForStep creates a new event of the given type for a step of a TaskRun
+func EventForStep(tr *v1.TaskRun, step v1.StepState, eventType TektonEventType) (*cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.New().String())
+	event.SetSubject(tr.Name + "/" + step.Name)
+	event.SetSource(fmt.Sprintf("/apis/%s/%s/namespaces/%s/%s/%s",
+		v1.SchemeGroupVersion.Group,
+		v1.SchemeGroupVersion.Version,
+		tr.Namespace,
+		pipeline.TaskRunControllerName,
+		tr.Name))
+	event.SetType(eventType.String())
+
+	data := StepCloudEventData{
+		TaskRun:   tr.Name,
+		Namespace: tr.Namespace,
+		UID:       string(tr.UID),
+		Step:      step.Name,
+	}
+	if t := step.Terminated; t != nil && eventType == StepCompletedEventV1 {
+		data.ExitCode = &t.ExitCode
+		if !t.StartedAt.IsZero() && !t.FinishedAt.IsZero() {
+			data.Duration = t.FinishedAt.Sub(t.StartedAt.Time).String()
+		}
+	}
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
 func getEventType(runObject objectWithCondition) (*TektonEventType, error) {
 	var eventType TektonEventType
 	c := runObject.GetStatusCondition().GetCondition(apis.ConditionSucceeded)
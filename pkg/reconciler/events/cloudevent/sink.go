@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/logging"
+)
+
+// SinkAnnotation is the annotation a run can use to select its CloudEvents sink.
+// The sink must be listed in the "allowed-sinks" of the events configuration.
+const SinkAnnotation = "tekton.dev/cloudevents-sink"
+
+// cloudEventsSink returns the sink for the CloudEvents of object. The sink selected via
+// SinkAnnotation comes first if allowed, then the sink of the namespace and the global one.
+func cloudEventsSink(ctx context.Context, object runtime.Object) string {
+	configs := config.FromContextOrDefaults(ctx)
+	var sink string
+	if o, err := meta.Accessor(object); err == nil {
+		if annotated := o.GetAnnotations()[SinkAnnotation]; annotated != "" {
+			if configs.Events.IsAllowedSink(annotated) {
+				return annotated
+			}
+			logging.FromContext(ctx).Warnf("Ignoring CloudEvents sink %q of %s/%s, it is not in the allowed sinks", annotated, o.GetNamespace(), o.GetName())
+		}
+		sink = configs.Events.SinkForNamespace(o.GetNamespace())
+	} else {
+		sink = configs.Events.Sink
+	}
+	if sink == "" {
+		// Fall back to the deprecated flag is the new one is not set
+		// This ensures no changes in behaviour for existing users of the deprecated flag
+		sink = configs.Defaults.DefaultCloudEventsSink
+	}
+	return sink
+}
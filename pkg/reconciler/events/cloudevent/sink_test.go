@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCloudEventsSink(t *testing.T) {
+	eventsConfig, err := config.NewEventsFromMap(map[string]string{
+		"sink":            "http://events.sink",
+		"namespace-sinks": "team-a=http://team-a.sink",
+		"allowed-sinks":   "http://audit.sink",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaultsConfig, _ := config.NewDefaultsFromMap(map[string]string{})
+
+	for _, tc := range []struct {
+		name        string
+		namespace   string
+		annotations map[string]string
+		want        string
+	}{{
+		name:      "global sink",
+		namespace: "team-b",
+		want:      "http://events.sink",
+	}, {
+		name:      "namespace sink",
+		namespace: "team-a",
+		want:      "http://team-a.sink",
+	}, {
+		name:        "allowed sink from annotation",
+		namespace:   "team-a",
+		annotations: map[string]string{SinkAnnotation: "http://audit.sink"},
+		want:        "http://audit.sink",
+	}, {
+		name:        "sink from annotation not allowed",
+		namespace:   "team-a",
+		annotations: map[string]string{SinkAnnotation: "http://attacker.sink"},
+		want:        "http://team-a.sink",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := config.ToContext(context.Background(), &config.Config{
+				Events:   eventsConfig,
+				Defaults: defaultsConfig,
+			})
+			pr := &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+				Name:        "pr",
+				Namespace:   tc.namespace,
+				Annotations: tc.annotations,
+			}}
+			if d := cmp.Diff(tc.want, cloudEventsSink(ctx, pr)); d != "" {
+				t.Errorf("Diff(-want,+got):\n%s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// checkConcurrency returns whether the PipelineRun has to wait before it starts, because
+// its concurrency limit is reached by the PipelineRuns with the same concurrency key which
+// are running or which were queued before it, and a message explaining why.
+func checkConcurrency(lister listers.PipelineRunLister, pr *v1.PipelineRun) (bool, string, error) {
+	prs, err := lister.PipelineRuns(pr.Namespace).List(labels.Everything())
+	if err != nil {
+		return false, "", fmt.Errorf("failed to list PipelineRuns in namespace %s: %w", pr.Namespace, err)
+	}
+	maxConcurrent := pr.Spec.Concurrency.MaxConcurrent
+	if maxConcurrent == 0 {
+		maxConcurrent = 1
+	}
+
+	running, ahead := 0, 0
+	for _, other := range prs {
+		if other.Name == pr.Name || !sameConcurrencyKey(other, pr.Spec.Concurrency.Key) || other.IsDone() {
+			continue
+		}
+		switch {
+		case other.HasStarted():
+			running++
+		case !other.IsPending() && !other.IsCancelled() && queuedBefore(other, pr):
+			ahead++
+		}
+	}
+	if running+ahead < maxConcurrent {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("PipelineRun %q is waiting for %d running and %d earlier queued PipelineRuns with concurrency key %q, of which at most %d can run at a time",
+		pr.Name, running, ahead, pr.Spec.Concurrency.Key, maxConcurrent), nil
+}
+
+// sameConcurrencyKey returns whether the PipelineRun has the given concurrency key.
+func sameConcurrencyKey(pr *v1.PipelineRun, key string) bool {
+	return pr.Spec.Concurrency != nil && pr.Spec.Concurrency.Key == key
+}
+
+// queuedBefore returns whether the PipelineRun a was created before the PipelineRun b, so that
+// queued PipelineRuns start in the order they were created.
+func queuedBefore(a, b *v1.PipelineRun) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// enqueueQueuedPipelineRuns enqueues the PipelineRuns which are queued behind the given
+// PipelineRun, so that they can start as soon as it completes.
+func enqueueQueuedPipelineRuns(lister listers.PipelineRunLister, enqueue func(interface{}), obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pr, ok := obj.(*v1.PipelineRun)
+	if !ok || pr.Spec.Concurrency == nil {
+		return
+	}
+	prs, err := lister.PipelineRuns(pr.Namespace).List(labels.Everything())
+	if err != nil {
+		return
+	}
+	for _, other := range prs {
+		if other.Name != pr.Name && sameConcurrencyKey(other, pr.Spec.Concurrency.Key) && !other.HasStarted() {
+			enqueue(other)
+		}
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pipelineErrors "github.com/tektoncd/pipeline/pkg/apis/pipeline/errors"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
@@ -72,6 +73,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/utils/clock"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/controller"
@@ -166,6 +168,7 @@ type Reconciler struct {
 	taskRunLister            listers.TaskRunLister
 	customRunLister          beta1listers.CustomRunLister
 	verificationPolicyLister alpha1listers.VerificationPolicyLister
+	podLister                corev1listers.PodLister
 	cloudEventClient         cloudevent.CEClient
 	metrics                  *pipelinerunmetrics.Recorder
 	pvcHandler               volumeclaim.PvcHandler
@@ -210,6 +213,19 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1.PipelineRun) pkgr
 		return controller.NewPermanentError(errors.New("PipelineRun has timed out for a long time"))
 	}
 
+	// If the concurrency limit of the PipelineRun is reached by other PipelineRuns with the
+	// same concurrency key, queue it until one of them completes.
+	if !pr.HasStarted() && !pr.IsPending() && !pr.IsCancelled() && pr.Spec.Concurrency != nil {
+		queued, message, err := checkConcurrency(c.pipelineRunLister, pr)
+		if err != nil {
+			return err
+		}
+		if queued {
+			pr.Status.MarkRunning(v1.PipelineRunReasonQueued.String(), message)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
+		}
+	}
+
 	if !pr.HasStarted() && !pr.IsPending() {
 		pr.Status.InitializeConditions(c.Clock)
 		// In case node time was not synchronized, when controller has been scheduled to other nodes.
@@ -243,6 +259,9 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1.PipelineRun) pkgr
 		if err != nil {
 			logger.Errorf("Failed to delete StatefulSet or PVC for PipelineRun %s: %v", pr.Name, err)
 		}
+		if err := c.cleanupPipelineSidecars(ctx, pr); err != nil {
+			logger.Errorf("Failed to delete the sidecars of PipelineRun %s: %v", pr.Name, err)
+		}
 		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 	}
 
@@ -442,6 +461,7 @@ func (c *Reconciler) resolvePipelineState(
 		}
 
 		if resolvedTask.ResolvedTask != nil && resolvedTask.ResolvedTask.VerificationResult != nil {
+			storeVerificationResult(ctx, pr, resolvedTask.ResolvedTask.VerificationResult, resolvedTask.ResolvedTask.TaskName, string(resolvedTask.ResolvedTask.Kind), pipelineTask.Name)
 			cond, err := conditionFromVerificationResult(resolvedTask.ResolvedTask.VerificationResult, pr, pipelineTask.Name)
 			pr.Status.SetCondition(cond)
 			if err != nil {
@@ -496,6 +516,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 	}
 
 	if pipelineMeta.VerificationResult != nil {
+		storeVerificationResult(ctx, pr, pipelineMeta.VerificationResult, pipelineMeta.Name, "Pipeline", "")
 		cond, err := conditionFromVerificationResult(pipelineMeta.VerificationResult, pr, pipelineMeta.Name)
 		pr.Status.SetCondition(cond)
 		if err != nil {
@@ -627,6 +648,17 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		return controller.NewPermanentError(err)
 	}
 
+	// Reuse the TaskRuns of the previous PipelineRun before the first TaskRun is created,
+	// so that the pipeline tasks which succeeded are resolved as done with their results.
+	if pr.Spec.RetryFrom != nil && len(pr.Status.ChildReferences) == 0 {
+		if err := c.reuseRetriedTaskRuns(pr, d); err != nil {
+			logger.Errorf("Failed to retry PipelineRun %s/%s: %v", pr.Namespace, pr.Name, err)
+			pr.Status.MarkFailed(ReasonCouldntRetryFrom,
+				"PipelineRun %s/%s can't be retried: %s", pr.Namespace, pr.Name, err)
+			return controller.NewPermanentError(err)
+		}
+	}
+
 	// pipelineRunState holds a list of pipeline tasks after fetching their resolved Task specs.
 	// pipelineRunState also holds a taskRun for each pipeline task after the taskRun is created
 	// pipelineRunState is instantiated and updated on every reconcile cycle
@@ -695,6 +727,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		TimeoutsState: resources.PipelineRunTimeoutsState{
 			Clock: c.Clock,
 		},
+		EnableCompletedWithErrors: config.FromContextOrDefaults(ctx).FeatureFlags.EnableCompletedWithErrors,
 	}
 	if pr.Status.StartTime != nil {
 		pipelineRunFacts.TimeoutsState.StartTime = &pr.Status.StartTime.Time
@@ -808,6 +841,26 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		}
 	}
 
+	if len(pipelineSpec.Sidecars) > 0 {
+		beforeFirstTaskRun := pipelineRunFacts.State.IsBeforeFirstTaskRun()
+		ready, err := c.reconcilePipelineSidecars(ctx, pr, pipelineSpec.Sidecars, beforeFirstTaskRun)
+		switch {
+		case errors.Is(err, ErrPipelineSidecarCreationFailed):
+			logger.Errorf("Failed to start the sidecars of PipelineRun %s: %v", pr.Name, err)
+			pr.Status.MarkFailed(ReasonCouldntCreatePipelineSidecar, "Failed to start the sidecars of PipelineRun %s/%s: %s", pr.Namespace, pr.Name, err)
+			return controller.NewPermanentError(err)
+		case errors.Is(err, ErrPipelineSidecarFailed):
+			logger.Errorf("A sidecar of PipelineRun %s failed: %v", pr.Name, err)
+			pr.Status.MarkFailed(ReasonPipelineSidecarFailed, "A sidecar of PipelineRun %s/%s failed: %s", pr.Namespace, pr.Name, err)
+			return controller.NewPermanentError(err)
+		case err != nil:
+			return err
+		case !ready && beforeFirstTaskRun:
+			pr.Status.MarkRunning(ReasonPending, "Waiting for the sidecars of PipelineRun %s/%s to be ready", pr.Namespace, pr.Name)
+			return nil
+		}
+	}
+
 	if pr.Status.FinallyStartTime == nil {
 		if pr.HaveTasksTimedOut(ctx, c.Clock) {
 			tasksToTimeOut := sets.NewString()
@@ -844,7 +897,10 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		}
 	}
 
-	if err := c.runNextSchedulableTask(ctx, pr, pipelineRunFacts); err != nil {
+	if err := c.runNextSchedulableTask(ctx, pr, pipelineMeta.Name, pipelineRunFacts); err != nil {
+		if errors.Is(err, ErrPipelineSidecarFailed) {
+			pr.Status.MarkFailed(ReasonPipelineSidecarFailed, "A sidecar of PipelineRun %s/%s failed: %s", pr.Namespace, pr.Name, err)
+		}
 		return err
 	}
 
@@ -900,7 +956,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 // runNextSchedulableTask gets the next schedulable Tasks from the dag based on the current
 // pipeline run state, and starts them
 // after all DAG tasks are done, it's responsible for scheduling final tasks and start executing them
-func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.PipelineRun, pipelineRunFacts *resources.PipelineRunFacts) error {
+func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.PipelineRun, pipelineName string, pipelineRunFacts *resources.PipelineRunFacts) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "runNextSchedulableTask")
 	defer span.End()
 
@@ -1010,7 +1066,7 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 				return err
 			}
 		default:
-			rpt.TaskRuns, err = c.createTaskRuns(ctx, rpt, pr, pipelineRunFacts)
+			rpt.TaskRuns, err = c.createTaskRuns(ctx, rpt, pr, pipelineName, pipelineRunFacts)
 			if err != nil {
 				recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunsCreationFailed", "Failed to create TaskRuns %q: %v", rpt.TaskRunNames, err)
 				err = fmt.Errorf("error creating TaskRuns called %s for PipelineTask %s from PipelineRun %s: %w", rpt.TaskRunNames, rpt.PipelineTask.Name, pr.Name, err)
@@ -1093,7 +1149,7 @@ func (c *Reconciler) createChildPipelineRun(
 		Create(ctx, newChildPipelineRun, metav1.CreateOptions{})
 }
 
-func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) ([]*v1.TaskRun, error) {
+func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, pipelineName string, facts *resources.PipelineRunFacts) ([]*v1.TaskRun, error) {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createTaskRuns")
 	defer span.End()
 
@@ -1119,13 +1175,29 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 		}
 	}
 
-	var taskRuns []*v1.TaskRun
+	// a Matrix limited by maxParallel starts its remaining combinations as the earlier ones finish
+	taskRuns := rpt.TaskRuns
+	started := sets.New[string]()
+	running := 0
+	for _, taskRun := range taskRuns {
+		started.Insert(taskRun.Name)
+		if !taskRun.IsDone() {
+			running++
+		}
+	}
 	for i, taskRunName := range rpt.TaskRunNames {
+		if started.Has(taskRunName) {
+			continue
+		}
+		if !canStartMatrixRun(rpt.PipelineTask, running) {
+			break
+		}
 		var params v1.Params
 		if len(matrixCombinations) > i {
 			params = matrixCombinations[i]
 		}
-		taskRun, err := c.createTaskRun(ctx, taskRunName, params, rpt, pr, facts)
+		running++
+		taskRun, err := c.createTaskRun(ctx, taskRunName, params, rpt, pr, pipelineName, facts)
 		if err != nil {
 			err := c.handleRunCreationError(pr, err)
 			return nil, err
@@ -1136,13 +1208,43 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 	return taskRuns, nil
 }
 
-func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, params v1.Params, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) (*v1.TaskRun, error) {
+// canStartMatrixRun returns whether another combination of the PipelineTask can start while the given
+// number of its runs are running, i.e. whether the PipelineTask's Matrix isn't limited by maxParallel
+// or its limit isn't reached.
+func canStartMatrixRun(pt *v1.PipelineTask, running int) bool {
+	if !pt.IsMatrixed() || pt.Matrix.MaxParallel == 0 {
+		return true
+	}
+	return running < pt.Matrix.MaxParallel
+}
+
+func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, params v1.Params, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, pipelineName string, facts *resources.PipelineRunFacts) (*v1.TaskRun, error) {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createTaskRun")
 	defer span.End()
 	logger := logging.FromContext(ctx)
 	rpt.PipelineTask = resources.ApplyPipelineTaskContexts(rpt.PipelineTask, pr.Status, facts)
 	taskRunSpec := pr.GetTaskRunSpec(rpt.PipelineTask.Name)
 	params = append(params, rpt.PipelineTask.Params...)
+
+	resolvedResultRefs, _, err := resources.ResolvePodTemplateResultRefs(facts.State, taskRunSpec.PodTemplate)
+	if err != nil {
+		return nil, controller.NewPermanentError(err)
+	}
+	podTemplate, err := resources.ApplyPodTemplateReplacements(taskRunSpec.PodTemplate, pr.Status.PipelineSpec, pipelineName, pr, params, resolvedResultRefs)
+	if err != nil {
+		return nil, controller.NewPermanentError(err)
+	}
+	if pr.Status.PipelineSpec != nil && len(pr.Status.PipelineSpec.Sidecars) > 0 {
+		env, err := c.pipelineSidecarEnv(pr, pr.Status.PipelineSpec.Sidecars)
+		if err != nil {
+			return nil, err
+		}
+		if podTemplate == nil {
+			podTemplate = &pod.Template{}
+		}
+		podTemplate.Env = append(podTemplate.Env, env...)
+	}
+
 	tr := &v1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            taskRunName,
@@ -1153,9 +1255,10 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 		},
 		Spec: v1.TaskRunSpec{
 			Retries:            rpt.PipelineTask.Retries,
+			RetryStrategy:      rpt.PipelineTask.RetryStrategy,
 			Params:             params,
 			ServiceAccountName: taskRunSpec.ServiceAccountName,
-			PodTemplate:        taskRunSpec.PodTemplate,
+			PodTemplate:        podTemplate,
 			StepSpecs:          taskRunSpec.StepSpecs,
 			SidecarSpecs:       taskRunSpec.SidecarSpecs,
 			ComputeResources:   taskRunSpec.ComputeResources,
@@ -1170,6 +1273,13 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 	if rpt.PipelineTask.OnError == v1.PipelineTaskContinue {
 		tr.Annotations[v1.PipelineTaskOnErrorAnnotation] = string(v1.PipelineTaskContinue)
 	}
+	if whenFiles := facts.State.GetWhenFiles(rpt.PipelineTask.Name); len(whenFiles) > 0 {
+		b, err := json.Marshal(whenFiles)
+		if err != nil {
+			return nil, err
+		}
+		tr.Annotations[v1.WhenFilesAnnotation] = string(b)
+	}
 
 	if rpt.PipelineTask.Timeout != nil {
 		tr.Spec.Timeout = rpt.PipelineTask.Timeout
@@ -1182,6 +1292,14 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 		tr.Spec.Timeout = taskRunSpec.Timeout
 	}
 
+	// the priorityClassName of the PipelineRun podTemplate overrides the ones of the pipeline task and of the pipeline
+	if podTemplate == nil || podTemplate.PriorityClassName == nil {
+		tr.Spec.PriorityClassName = rpt.PipelineTask.PriorityClassName
+		if tr.Spec.PriorityClassName == "" && pr.Status.PipelineSpec != nil {
+			tr.Spec.PriorityClassName = pr.Status.PipelineSpec.PriorityClassName
+		}
+	}
+
 	if rpt.ResolvedTask.TaskName != "" {
 		// We pass the entire, original task ref because it may contain additional references like a Bundle url.
 		tr.Spec.TaskRef = rpt.PipelineTask.TaskRef
@@ -1190,7 +1308,6 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 	}
 
 	var pipelinePVCWorkspaceName string
-	var err error
 	tr.Spec.Workspaces, pipelinePVCWorkspaceName, err = c.getTaskrunWorkspaces(ctx, pr, rpt)
 	if err != nil {
 		return nil, err
@@ -1251,7 +1368,7 @@ func (c *Reconciler) handleRunCreationError(pr *v1.PipelineRun, err error) error
 }
 
 func (c *Reconciler) createCustomRuns(ctx context.Context, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) ([]*v1beta1.CustomRun, error) {
-	var customRuns []*v1beta1.CustomRun
+	customRuns := rpt.CustomRuns
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createCustomRuns")
 	defer span.End()
 	var matrixCombinations []v1.Params
@@ -1259,7 +1376,23 @@ func (c *Reconciler) createCustomRuns(ctx context.Context, rpt *resources.Resolv
 	if rpt.PipelineTask.IsMatrixed() {
 		matrixCombinations = rpt.PipelineTask.Matrix.FanOut()
 	}
+	// a Matrix limited by maxParallel starts its remaining combinations as the earlier ones finish
+	started := sets.New[string]()
+	running := 0
+	for _, customRun := range customRuns {
+		started.Insert(customRun.Name)
+		if !customRun.IsDone() {
+			running++
+		}
+	}
 	for i, customRunName := range rpt.CustomRunNames {
+		if started.Has(customRunName) {
+			continue
+		}
+		if !canStartMatrixRun(rpt.PipelineTask, running) {
+			break
+		}
+		running++
 		var params v1.Params
 		if len(matrixCombinations) > i {
 			params = matrixCombinations[i]
@@ -1988,6 +2121,22 @@ func conditionFromVerificationResult(verificationResult *trustedresources.Verifi
 	return condition, err
 }
 
+// storeVerificationResult records the verification outcome of a resource referenced by the PipelineRun
+// in its provenance, so the outcome can be audited from the PipelineRun status.
+func storeVerificationResult(ctx context.Context, pr *v1.PipelineRun, verificationResult *trustedresources.VerificationResult, name, kind, pipelineTask string) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableProvenanceInStatus {
+		return
+	}
+	rv := verificationResult.ResourceVerification(name, kind, pipelineTask)
+	if rv == nil {
+		return
+	}
+	if pr.Status.Provenance == nil {
+		pr.Status.Provenance = &v1.Provenance{}
+	}
+	pr.Status.Provenance.SetVerification(*rv)
+}
+
 // validatePipelineSpecAfterApplyParameters validates the PipelineSpec after apply parameters
 // Maybe some fields are modified during apply parameters, need to validate again. For example, tasks[].OnError.
 func validatePipelineSpecAfterApplyParameters(ctx context.Context, pipelineSpec *v1.PipelineSpec) (errs *apis.FieldError) {
@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -1179,6 +1180,79 @@ status:
 	}
 }
 
+// TestPipelineTaskErrorIsIgnoredResultsUnavailable tests that the results a PipelineTask with onError:continue fails
+// to produce are unavailable, so that the PipelineTasks consuming them are skipped and the PipelineRun completes with errors
+func TestPipelineTaskErrorIsIgnoredResultsUnavailable(t *testing.T) {
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-ignored-failure
+  namespace: foo
+spec:
+  serviceAccountName: test-sa-0
+  pipelineSpec:
+    tasks:
+    - name: task1
+      onError: continue
+      taskSpec:
+        results:
+        - name: result1
+          type: string
+        steps:
+        - name: failing-step
+          image: busybox
+          script: 'exit 1; echo -n 123 | tee $(results.result1.path)'
+    - name: task2
+      params:
+      - name: param1
+        value: $(tasks.task1.results.result1)
+      taskSpec:
+        params:
+        - name: param1
+          type: string
+        steps:
+        - name: foo
+          image: busybox
+          script: 'echo $(params.param1)'
+`)}
+	trs := []*v1.TaskRun{parse.MustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-ignored-failure-task1", "foo",
+			"test-pipeline-ignored-failure", "test-pipeline", "task1", true),
+		`
+spec:
+  serviceAccountName: test-sa
+  timeout: 1h0m0s
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+    reason: FailureIgnored
+`)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		TaskRuns:     trs,
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{"enable-completed-with-errors": "true"},
+		}},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-ignored-failure", []string{}, false)
+	cond := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if cond.Status != corev1.ConditionTrue || cond.Reason != v1.PipelineRunReasonCompletedWithErrors.String() {
+		t.Fatalf("expected PipelineRun status to be True with reason %s but got %s with reason %s", v1.PipelineRunReasonCompletedWithErrors, cond.Status, cond.Reason)
+	}
+	if d := cmp.Diff("Tasks Completed: 1 (Failed: 1 (Ignored: 1), Cancelled 0), Skipped: 1", cond.Message); d != "" {
+		t.Errorf("unexpected PipelineRun message %s", diff.PrintWantGot(d))
+	}
+	wantSkippedTasks := []v1.SkippedTask{{Name: "task2", Reason: v1.MissingResultsSkip}}
+	if d := cmp.Diff(wantSkippedTasks, reconciledRun.Status.SkippedTasks); d != "" {
+		t.Errorf("unexpected skipped Tasks %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestMissingResultWhenStepErrorIsIgnored(t *testing.T) {
 	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
 metadata:
@@ -2247,63 +2321,623 @@ status:
 				t.Errorf("Expected PipelineRun status to be complete and unknown, but was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
 			}
 
-			if len(reconciledRun.Status.ChildReferences) != tc.childRefInStatusCount {
-				t.Fatalf("Expected %d ChildRerences in status but got %d", tc.childRefInStatusCount, len(reconciledRun.Status.ChildReferences))
+			if len(reconciledRun.Status.ChildReferences) != tc.childRefInStatusCount {
+				t.Fatalf("Expected %d ChildRerences in status but got %d", tc.childRefInStatusCount, len(reconciledRun.Status.ChildReferences))
+			}
+
+			if d := cmp.Diff(tc.skippedTasks, reconciledRun.Status.SkippedTasks); d != "" {
+				t.Fatalf("Didn't get the expected list of skipped tasks. Diff: %s", diff.PrintWantGot(d))
+			}
+
+			actions := clients.Pipeline.Actions()
+			patchCount := 0
+			for _, action := range actions {
+				if _, ok := action.(ktesting.PatchAction); ok {
+					patchCount++
+				}
+			}
+			if patchCount != 0 {
+				t.Errorf("Expected no patch action, but was %v", patchCount)
+			}
+		})
+	}
+}
+
+func TestReconcileOnPendingPipelineRun(t *testing.T) {
+	// TestReconcileOnPendingPipelineRun runs "Reconcile" on a PipelineRun that is pending.
+	// It verifies that reconcile is successful, the pipeline status updated and events generated.
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-pending
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa
+  status: PipelineRunPending
+`)}
+	ps := []*v1.Pipeline{simpleHelloWorldPipeline}
+	var ts []*v1.Task
+	var trs []*v1.TaskRun
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	var wantEvents []string
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-pending", wantEvents, false)
+
+	th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionUnknown, v1.PipelineRunReasonPending.String())
+
+	if reconciledRun.Status.StartTime != nil {
+		t.Errorf("Start time should be nil, not: %s", reconciledRun.Status.StartTime)
+	}
+}
+
+func TestReconcileOnQueuedPipelineRun(t *testing.T) {
+	// TestReconcileOnQueuedPipelineRun runs "Reconcile" on a PipelineRun with a concurrency key.
+	// It verifies that the PipelineRun is queued while the concurrency limit of its key is reached
+	// by running PipelineRuns or by PipelineRuns queued before it, and starts otherwise.
+	pipelineRun := func(name, created, status string) *v1.PipelineRun {
+		return parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: foo
+  creationTimestamp: "%s"
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa
+  concurrency:
+    key: deploy-main
+%s
+`, name, created, status))
+	}
+	running := `status:
+  startTime: "2021-12-31T23:00:00Z"
+  conditions:
+  - status: Unknown
+    type: Succeeded
+    reason: Running`
+	done := `status:
+  startTime: "2021-12-31T23:00:00Z"
+  completionTime: "2021-12-31T23:30:00Z"
+  conditions:
+  - status: "True"
+    type: Succeeded
+    reason: Succeeded`
+
+	for _, tc := range []struct {
+		name       string
+		others     []*v1.PipelineRun
+		wantQueued bool
+	}{{
+		name:       "another PipelineRun with the key is running",
+		others:     []*v1.PipelineRun{pipelineRun("test-pipeline-run-running", "2021-12-31T23:00:00Z", running)},
+		wantQueued: true,
+	}, {
+		name:       "another PipelineRun with the key was queued before",
+		others:     []*v1.PipelineRun{pipelineRun("test-pipeline-run-earlier", "2021-12-31T23:00:00Z", "")},
+		wantQueued: true,
+	}, {
+		name: "the other PipelineRuns with the key are done or were queued after",
+		others: []*v1.PipelineRun{
+			pipelineRun("test-pipeline-run-done", "2021-12-31T23:00:00Z", done),
+			pipelineRun("test-pipeline-run-later", "2022-01-01T00:00:00Z", ""),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := pipelineRun("test-pipeline-run-queued", "2021-12-31T23:59:00Z", "")
+			d := test.Data{
+				PipelineRuns: append([]*v1.PipelineRun{pr}, tc.others...),
+				Pipelines:    []*v1.Pipeline{simpleHelloWorldPipeline},
+				Tasks:        []*v1.Task{simpleHelloWorldTask},
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", pr.Name, nil, false)
+
+			if !tc.wantQueued {
+				if reconciledRun.Status.StartTime == nil {
+					t.Errorf("Expected PipelineRun to start, got condition %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+				}
+				return
+			}
+			th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionUnknown, v1.PipelineRunReasonQueued.String())
+			if reconciledRun.Status.StartTime != nil {
+				t.Errorf("Start time should be nil, not: %s", reconciledRun.Status.StartTime)
+			}
+			taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list TaskRuns: %v", err)
+			}
+			if len(taskRuns.Items) != 0 {
+				t.Errorf("Expected no TaskRuns for a queued PipelineRun, got %d", len(taskRuns.Items))
+			}
+		})
+	}
+}
+
+func TestReconcileWithContextInPodTemplateOfEmbeddedPipeline(t *testing.T) {
+	// TestReconcileWithContextInPodTemplateOfEmbeddedPipeline runs "Reconcile" on a PipelineRun with an
+	// embedded pipelineSpec whose pod template references $(context.pipeline.name). It verifies that the
+	// variable is replaced with the name of the Pipeline, as in the rest of the pipelineSpec.
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-embedded
+  namespace: foo
+spec:
+  taskRunTemplate:
+    serviceAccountName: test-sa
+    podTemplate:
+      nodeSelector:
+        pipeline: $(context.pipeline.name)
+  pipelineSpec:
+    tasks:
+    - name: unit-test-1
+      taskRef:
+        name: hello-world
+`)
+	d := test.Data{
+		PipelineRuns: []*v1.PipelineRun{pr},
+		Tasks:        []*v1.Task{simpleHelloWorldTask},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", pr.Name, nil, false)
+
+	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", pr.Name)
+	validateTaskRunsCount(t, taskRuns, 1)
+	tr := getTaskRunByName(t, taskRuns, "test-pipeline-run-embedded-unit-test-1")
+	want := map[string]string{"pipeline": "test-pipeline-run-embedded"}
+	if d := cmp.Diff(want, tr.Spec.PodTemplate.NodeSelector); d != "" {
+		t.Errorf("TaskRun pod template node selector %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithPipelineSidecars(t *testing.T) {
+	// TestReconcileWithPipelineSidecars runs "Reconcile" on a PipelineRun whose Pipeline has sidecars.
+	// It verifies that the Pods of the sidecars are created, that the Tasks wait for them to be ready,
+	// that their address is passed to the TaskRuns and that the PipelineRun fails if a sidecar fails.
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-sidecars
+  namespace: foo
+spec:
+  taskRunTemplate:
+    serviceAccountName: test-sa
+  pipelineSpec:
+    sidecars:
+    - name: test-db
+      image: postgres
+      ports:
+      - containerPort: 5432
+    tasks:
+    - name: unit-test-1
+      taskRef:
+        name: hello-world
+`)
+	sidecarPod := func(phase corev1.PodPhase, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "test-pipeline-run-sidecars-sidecar-test-db",
+				Namespace:       "foo",
+				OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+				Labels:          map[string]string{pipeline.PipelineSidecarLabelKey: "test-db"},
+			},
+			Status: corev1.PodStatus{
+				Phase:      phase,
+				PodIP:      "10.0.0.7",
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"enable-api-fields": config.AlphaAPIFields},
+	}}
+
+	for _, tc := range []struct {
+		name           string
+		pods           []*corev1.Pod
+		wantReason     string
+		wantTaskRunEnv []corev1.EnvVar
+	}{{
+		name:       "sidecar pod is created",
+		wantReason: ReasonPending,
+	}, {
+		name:       "sidecar pod is not ready",
+		pods:       []*corev1.Pod{sidecarPod(corev1.PodRunning, corev1.ConditionFalse)},
+		wantReason: ReasonPending,
+	}, {
+		name:       "sidecar pod is ready",
+		pods:       []*corev1.Pod{sidecarPod(corev1.PodRunning, corev1.ConditionTrue)},
+		wantReason: v1.PipelineRunReasonRunning.String(),
+		wantTaskRunEnv: []corev1.EnvVar{
+			{Name: "TEKTON_SIDECAR_TEST_DB_HOST", Value: "10.0.0.7"},
+			{Name: "TEKTON_SIDECAR_TEST_DB_PORT", Value: "5432"},
+		},
+	}, {
+		name:       "sidecar pod has failed",
+		pods:       []*corev1.Pod{sidecarPod(corev1.PodFailed, corev1.ConditionFalse)},
+		wantReason: ReasonPipelineSidecarFailed,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{pr.DeepCopy()},
+				Tasks:        []*v1.Task{simpleHelloWorldTask},
+				Pods:         tc.pods,
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			failed := tc.wantReason == ReasonPipelineSidecarFailed
+			reconciledRun, clients := prt.reconcileRun("foo", pr.Name, nil, failed)
+
+			wantStatus := corev1.ConditionUnknown
+			if failed {
+				wantStatus = corev1.ConditionFalse
+			}
+			th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, wantStatus, tc.wantReason)
+
+			if _, err := clients.Kube.CoreV1().Pods("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-sidecars-sidecar-test-db", metav1.GetOptions{}); err != nil {
+				t.Errorf("Expected the Pod of the sidecar to exist: %v", err)
+			}
+			taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", pr.Name)
+			if tc.wantTaskRunEnv == nil {
+				validateTaskRunsCount(t, taskRuns, 0)
+				return
+			}
+			validateTaskRunsCount(t, taskRuns, 1)
+			tr := getTaskRunByName(t, taskRuns, "test-pipeline-run-sidecars-unit-test-1")
+			if d := cmp.Diff(tc.wantTaskRunEnv, tr.Spec.PodTemplate.Env); d != "" {
+				t.Errorf("TaskRun pod template env %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileWithFailedPipelineSidecarAfterTasksStarted(t *testing.T) {
+	// TestReconcileWithFailedPipelineSidecarAfterTasksStarted runs "Reconcile" on a PipelineRun whose
+	// first TaskRun is running. It verifies that the PipelineRun fails if the Pod of one of its sidecars
+	// has been evicted, is being deleted or no longer exists, and that the Pod is not recreated.
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-sidecars
+  namespace: foo
+spec:
+  taskRunTemplate:
+    serviceAccountName: test-sa
+  pipelineSpec:
+    sidecars:
+    - name: test-db
+      image: postgres
+    tasks:
+    - name: unit-test-1
+      taskRef:
+        name: hello-world
+status:
+  conditions:
+  - message: running...
+    reason: Running
+    status: Unknown
+    type: Succeeded
+  childReferences:
+  - name: test-pipeline-run-sidecars-unit-test-1
+    pipelineTaskName: unit-test-1
+    kind: TaskRun
+    apiVersion: tekton.dev/v1
+`)
+	tr := createHelloWorldTaskRunWithStatus(t, "test-pipeline-run-sidecars-unit-test-1", "foo", pr.Name, "", "test-pipeline-run-sidecars-unit-test-1-pod",
+		apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown, Reason: v1.TaskRunReasonRunning.String()})
+	tr.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(pr)}
+	tr.Labels[pipeline.PipelineTaskLabelKey] = "unit-test-1"
+	sidecarPod := func(phase corev1.PodPhase, reason string, deleting bool) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "test-pipeline-run-sidecars-sidecar-test-db",
+				Namespace:       "foo",
+				OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+				Labels:          map[string]string{pipeline.PipelineSidecarLabelKey: "test-db"},
+			},
+			Status: corev1.PodStatus{Phase: phase, Reason: reason, PodIP: "10.0.0.7"},
+		}
+		if deleting {
+			p.DeletionTimestamp = &metav1.Time{Time: now}
+		}
+		return p
+	}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"enable-api-fields": config.AlphaAPIFields},
+	}}
+
+	for _, tc := range []struct {
+		name        string
+		pods        []*corev1.Pod
+		wantMessage string
+	}{{
+		name:        "sidecar pod was evicted",
+		pods:        []*corev1.Pod{sidecarPod(corev1.PodFailed, "Evicted", false)},
+		wantMessage: "has terminated with phase Failed: Evicted",
+	}, {
+		name:        "sidecar pod is being deleted",
+		pods:        []*corev1.Pod{sidecarPod(corev1.PodRunning, "", true)},
+		wantMessage: "is being deleted",
+	}, {
+		name:        "sidecar pod no longer exists",
+		wantMessage: "no longer exists",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{pr.DeepCopy()},
+				Tasks:        []*v1.Task{simpleHelloWorldTask},
+				TaskRuns:     []*v1.TaskRun{tr},
+				Pods:         tc.pods,
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", pr.Name, nil, true)
+
+			th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionFalse, ReasonPipelineSidecarFailed)
+			if msg := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Message; !strings.Contains(msg, tc.wantMessage) {
+				t.Errorf("Expected the message of the PipelineRun to contain %q, got %q", tc.wantMessage, msg)
+			}
+			if tc.pods == nil {
+				if _, err := clients.Kube.CoreV1().Pods("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-sidecars-sidecar-test-db", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+					t.Errorf("Expected the Pod of the sidecar not to be recreated, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileCleansUpPipelineSidecars(t *testing.T) {
+	// TestReconcileCleansUpPipelineSidecars runs "Reconcile" on a PipelineRun which is done.
+	// It verifies that the Pods of its sidecars are deleted.
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-sidecars
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  startTime: "2021-12-31T23:00:00Z"
+  completionTime: "2021-12-31T23:30:00Z"
+  conditions:
+  - status: "True"
+    type: Succeeded
+    reason: Succeeded
+  pipelineSpec:
+    sidecars:
+    - name: test-db
+      image: postgres
+    tasks:
+    - name: unit-test-1
+      taskRef:
+        name: hello-world
+`)
+	d := test.Data{
+		PipelineRuns: []*v1.PipelineRun{pr},
+		Pods: []*corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "test-pipeline-run-sidecars-sidecar-test-db",
+				Namespace:       "foo",
+				OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+			},
+		}},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", pr.Name, nil, false)
+
+	if _, err := clients.Kube.CoreV1().Pods("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-sidecars-sidecar-test-db", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the Pod of the sidecar to be deleted, got %v", err)
+	}
+}
+
+func TestReconcileWithRetryFrom(t *testing.T) {
+	// TestReconcileWithRetryFrom runs "Reconcile" on a PipelineRun which is retried from a previous
+	// PipelineRun where task "build" succeeded, task "test" failed and task "lint" succeeded.
+	// It verifies that the TaskRuns of the tasks which don't need to run again are reused, with
+	// their results, and that only the TaskRuns of the other tasks are created.
+	pipelineSpec := `
+    tasks:
+    - name: build
+      taskSpec:
+        results:
+        - name: image
+        steps:
+        - image: busybox
+          script: echo build
+    - name: test
+      params:
+      - name: image
+        value: $(tasks.build.results.image)
+      taskSpec:
+        params:
+        - name: image
+        steps:
+        - image: busybox
+          script: echo test
+    - name: deploy
+      runAfter: [test]
+      taskSpec:
+        steps:
+        - image: busybox
+          script: echo deploy
+    - name: lint
+      taskSpec:
+        steps:
+        - image: busybox
+          script: echo lint
+`
+	previous := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineSpec:`+pipelineSpec+`
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+    reason: Failed
+  childReferences:
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-build
+    pipelineTaskName: build
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-test
+    pipelineTaskName: test
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-lint
+    pipelineTaskName: lint
+`)
+	previousTaskRun := func(pipelineTask string, status corev1.ConditionStatus, results string) *v1.TaskRun {
+		return parse.MustParseV1TaskRun(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline-run-%s
+  namespace: foo
+  labels:
+    tekton.dev/pipelineRun: test-pipeline-run
+    tekton.dev/pipelineTask: %s
+status:
+  conditions:
+  - status: %q
+    type: Succeeded
+  results:%s
+`, pipelineTask, pipelineTask, status, results))
+	}
+	taskRuns := []*v1.TaskRun{
+		previousTaskRun("build", corev1.ConditionTrue, `
+  - name: image
+    value: registry.example.com/app:abc`),
+		previousTaskRun("test", corev1.ConditionFalse, " []"),
+		previousTaskRun("lint", corev1.ConditionTrue, " []"),
+	}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"enable-api-fields": config.AlphaAPIFields},
+	}}
+
+	for _, tc := range []struct {
+		name             string
+		retryFrom        string
+		previous         *v1.PipelineRun
+		wantTaskRuns     []string
+		wantReusedTasks  []string
+		wantFailedReason string
+	}{{
+		name:            "retry the tasks which did not succeed",
+		retryFrom:       "{pipelineRun: test-pipeline-run}",
+		previous:        previous,
+		wantTaskRuns:    []string{"test-pipeline-run-retry-test"},
+		wantReusedTasks: []string{"build", "lint"},
+	}, {
+		name:            "retry from a task",
+		retryFrom:       "{pipelineRun: test-pipeline-run, task: build}",
+		previous:        previous,
+		wantTaskRuns:    []string{"test-pipeline-run-retry-build"},
+		wantReusedTasks: []string{"lint"},
+	}, {
+		name:             "retry from a task which is not in the pipeline",
+		retryFrom:        "{pipelineRun: test-pipeline-run, task: package}",
+		previous:         previous,
+		wantFailedReason: ReasonCouldntRetryFrom,
+	}, {
+		name:             "retry from a PipelineRun which does not exist",
+		retryFrom:        "{pipelineRun: test-pipeline-run}",
+		wantFailedReason: ReasonCouldntRetryFrom,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-retry
+  namespace: foo
+spec:
+  retryFrom: `+tc.retryFrom+`
+  pipelineSpec:`+pipelineSpec)
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{pr},
+				TaskRuns:     taskRuns,
+				ConfigMaps:   cms,
+			}
+			if tc.previous != nil {
+				d.PipelineRuns = append(d.PipelineRuns, tc.previous)
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			failed := tc.wantFailedReason != ""
+			reconciledRun, clients := prt.reconcileRun("foo", pr.Name, nil, failed)
+			if failed {
+				th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionFalse, tc.wantFailedReason)
+				return
 			}
+			th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String())
 
-			if d := cmp.Diff(tc.skippedTasks, reconciledRun.Status.SkippedTasks); d != "" {
-				t.Fatalf("Didn't get the expected list of skipped tasks. Diff: %s", diff.PrintWantGot(d))
+			created := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", pr.Name)
+			validateTaskRunsCount(t, created, len(tc.wantTaskRuns))
+			for _, name := range tc.wantTaskRuns {
+				getTaskRunByName(t, created, name)
 			}
 
-			actions := clients.Pipeline.Actions()
-			patchCount := 0
-			for _, action := range actions {
-				if _, ok := action.(ktesting.PatchAction); ok {
-					patchCount++
+			var reusedTasks []string
+			for _, cr := range reconciledRun.Status.ChildReferences {
+				if cr.Name == "test-pipeline-run-"+cr.PipelineTaskName {
+					reusedTasks = append(reusedTasks, cr.PipelineTaskName)
 				}
 			}
-			if patchCount != 0 {
-				t.Errorf("Expected no patch action, but was %v", patchCount)
+			if d := cmp.Diff(tc.wantReusedTasks, reusedTasks); d != "" {
+				t.Errorf("Reused TaskRuns %s", diff.PrintWantGot(d))
 			}
 		})
 	}
-}
 
-func TestReconcileOnPendingPipelineRun(t *testing.T) {
-	// TestReconcileOnPendingPipelineRun runs "Reconcile" on a PipelineRun that is pending.
-	// It verifies that reconcile is successful, the pipeline status updated and events generated.
-	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+	// The results of the reused TaskRuns are consumed by the TaskRuns which are created.
+	t.Run("results of the reused TaskRuns", func(t *testing.T) {
+		pr := parse.MustParseV1PipelineRun(t, `
 metadata:
-  name: test-pipeline-run-pending
+  name: test-pipeline-run-retry
   namespace: foo
 spec:
-  pipelineRef:
-    name: test-pipeline
-  taskRunTemplate:
-    serviceAccountName: test-sa
-  status: PipelineRunPending
-`)}
-	ps := []*v1.Pipeline{simpleHelloWorldPipeline}
-	var ts []*v1.Task
-	var trs []*v1.TaskRun
-
-	d := test.Data{
-		PipelineRuns: prs,
-		Pipelines:    ps,
-		Tasks:        ts,
-		TaskRuns:     trs,
-	}
-	prt := newPipelineRunTest(t, d)
-	defer prt.Cancel()
-
-	var wantEvents []string
-	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-pending", wantEvents, false)
-
-	th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionUnknown, v1.PipelineRunReasonPending.String())
+  retryFrom:
+    pipelineRun: test-pipeline-run
+  pipelineSpec:`+pipelineSpec)
+		d := test.Data{
+			PipelineRuns: []*v1.PipelineRun{pr, previous},
+			TaskRuns:     taskRuns,
+			ConfigMaps:   cms,
+		}
+		prt := newPipelineRunTest(t, d)
+		defer prt.Cancel()
 
-	if reconciledRun.Status.StartTime != nil {
-		t.Errorf("Start time should be nil, not: %s", reconciledRun.Status.StartTime)
-	}
+		_, clients := prt.reconcileRun("foo", pr.Name, nil, false)
+		created := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", pr.Name)
+		tr := getTaskRunByName(t, created, "test-pipeline-run-retry-test")
+		wantParams := v1.Params{{Name: "image", Value: *v1.NewStructuredValues("registry.example.com/app:abc")}}
+		if d := cmp.Diff(wantParams, tr.Spec.Params); d != "" {
+			t.Errorf("TaskRun params %s", diff.PrintWantGot(d))
+		}
+	})
 }
 
 func TestReconcileWithTimeouts_Pipeline(t *testing.T) {
@@ -4444,6 +5078,112 @@ spec:
 	}
 }
 
+// TestReconcilePriorityClassNamePropagationToTaskRun tests how the priorityClassNames of
+// the Pipeline and of its tasks propagate to the child TaskRuns.
+func TestReconcilePriorityClassNamePropagationToTaskRun(t *testing.T) {
+	tcs := []struct {
+		name        string
+		pipeline    string
+		podTemplate string
+		expected    string
+	}{{
+		name: "no priorityClassName",
+		pipeline: `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world`,
+		expected: "",
+	}, {
+		name: "pipeline priorityClassName used as default",
+		pipeline: `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  priorityClassName: low-priority
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world`,
+		expected: "low-priority",
+	}, {
+		name: "pipeline task priorityClassName takes precedence over pipeline priorityClassName",
+		pipeline: `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  priorityClassName: low-priority
+  tasks:
+  - name: hello-world-1
+    priorityClassName: urgent-release
+    taskRef:
+      name: hello-world`,
+		expected: "urgent-release",
+	}, {
+		name: "pipelineRun podTemplate priorityClassName takes precedence",
+		pipeline: `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    priorityClassName: urgent-release
+    taskRef:
+      name: hello-world`,
+		podTemplate: `
+    podTemplate:
+      priorityClassName: high-priority`,
+		expected: "",
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			namespace := "foo"
+			prName := "test-pipeline-run"
+			trName := "test-pipeline-run-hello-world-1"
+
+			ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, tc.pipeline)}
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunSpecs:
+  - pipelineTaskName: hello-world-1`+tc.podTemplate)}
+			ts := []*v1.Task{simpleHelloWorldTask}
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				ConfigMaps:   th.NewAlphaFeatureFlagsConfigMapInSlice(),
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			_, clients := prt.reconcileRun(namespace, prName, []string{}, false)
+
+			taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prName)
+			validateTaskRunsCount(t, taskRuns, 1)
+
+			actual := getTaskRunByName(t, taskRuns, trName)
+			if actual.Spec.PriorityClassName != tc.expected {
+				t.Errorf("expected TaskRun priorityClassName to be %q, but was %q", tc.expected, actual.Spec.PriorityClassName)
+			}
+		})
+	}
+}
+
 // TestReconcileFinallyTimeoutPropagatedToTaskRun tests that spec.timeouts.finally
 // is propagated to finally TaskRuns when no per-task timeout is set.
 func TestReconcileFinallyTimeoutPropagatedToTaskRun(t *testing.T) {
@@ -14811,6 +15551,232 @@ spec:
 	}
 }
 
+func TestReconciler_PipelineTaskMatrixMaxParallel(t *testing.T) {
+	// TestReconciler_PipelineTaskMatrixMaxParallel runs "Reconcile" on a PipelineRun with a Matrix limited by
+	// maxParallel. It verifies that at most maxParallel TaskRuns run at the same time, and that the remaining
+	// combinations are started as the earlier ones finish.
+	names.TestingSeed()
+
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  pipelineSpec:
+    tasks:
+    - name: platforms
+      matrix:
+        maxParallel: 2
+        params:
+        - name: platform
+          value: [linux, mac, windows]
+      taskSpec:
+        params:
+        - name: platform
+        steps:
+        - name: echo
+          image: alpine
+          script: echo $(params.platform)
+`)
+	taskRun := func(name, platform, status string) *v1.TaskRun {
+		return parse.MustParseTaskRunWithObjectMeta(t, taskRunObjectMeta(name, "foo", "pr", "pr", "platforms", false), fmt.Sprintf(`
+spec:
+  params:
+  - name: platform
+    value: %s
+status:
+  conditions:
+  - type: Succeeded
+    status: %q
+`, platform, status))
+	}
+	childRef := func(name string) v1.ChildStatusReference {
+		return v1.ChildStatusReference{
+			TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+			Name:             name,
+			PipelineTaskName: "platforms",
+		}
+	}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"enable-api-fields": config.AlphaAPIFields},
+	}}
+
+	for _, tc := range []struct {
+		name         string
+		taskRuns     []*v1.TaskRun
+		wantTaskRuns []string
+	}{{
+		name:         "the first combinations start",
+		wantTaskRuns: []string{"pr-platforms-0", "pr-platforms-1"},
+	}, {
+		name: "no combination starts while maxParallel TaskRuns are running",
+		taskRuns: []*v1.TaskRun{
+			taskRun("pr-platforms-0", "linux", "Unknown"),
+			taskRun("pr-platforms-1", "mac", "Unknown"),
+		},
+		wantTaskRuns: []string{"pr-platforms-0", "pr-platforms-1"},
+	}, {
+		name: "the next combination starts when a TaskRun finishes",
+		taskRuns: []*v1.TaskRun{
+			taskRun("pr-platforms-0", "linux", "True"),
+			taskRun("pr-platforms-1", "mac", "Unknown"),
+		},
+		wantTaskRuns: []string{"pr-platforms-0", "pr-platforms-1", "pr-platforms-2"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := pr.DeepCopy()
+			for _, tr := range tc.taskRuns {
+				pr.Status.ChildReferences = append(pr.Status.ChildReferences, childRef(tr.Name))
+			}
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{pr},
+				TaskRuns:     tc.taskRuns,
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "pr", nil, false)
+			th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String())
+
+			taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list TaskRuns: %v", err)
+			}
+			var got []string
+			for _, tr := range taskRuns.Items {
+				got = append(got, tr.Name)
+			}
+			if d := cmp.Diff(tc.wantTaskRuns, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+				t.Errorf("Unexpected TaskRuns %s", diff.PrintWantGot(d))
+			}
+			var gotChildRefs []string
+			for _, cr := range reconciledRun.Status.ChildReferences {
+				gotChildRefs = append(gotChildRefs, cr.Name)
+			}
+			if d := cmp.Diff(tc.wantTaskRuns, gotChildRefs); d != "" {
+				t.Errorf("Unexpected child references %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconciler_PipelineTaskWhenFiles(t *testing.T) {
+	// TestReconciler_PipelineTaskWhenFiles runs "Reconcile" on a PipelineRun with a PipelineTask guarded by a
+	// when expression testing a file written by a previous PipelineTask. It verifies that the TaskRun writing
+	// the file is asked to record its content, and that the guarded PipelineTask runs depending on it.
+	names.TestingSeed()
+
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  workspaces:
+  - name: ws
+    emptyDir: {}
+  pipelineSpec:
+    workspaces:
+    - name: ws
+    tasks:
+    - name: diff
+      workspaces:
+      - name: source
+        workspace: ws
+      taskSpec:
+        workspaces:
+        - name: source
+        steps:
+        - name: diff
+          image: alpine
+          script: git diff --stat > $(workspaces.source.path)/summary.txt
+    - name: deploy
+      when:
+      - file:
+          task: diff
+          workspace: source
+          path: summary.txt
+        operator: notin
+        values: [""]
+      taskSpec:
+        steps:
+        - name: deploy
+          image: alpine
+          script: echo deploy
+`)
+	diffTaskRun := func(whenFiles string) *v1.TaskRun {
+		return parse.MustParseTaskRunWithObjectMeta(t, taskRunObjectMeta("pr-diff", "foo", "pr", "pr", "diff", false), `
+status:
+  conditions:
+  - type: Succeeded
+    status: "True"
+  whenFiles: `+whenFiles)
+	}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"enable-api-fields": config.AlphaAPIFields},
+	}}
+
+	for _, tc := range []struct {
+		name             string
+		taskRun          *v1.TaskRun
+		wantTaskRun      string
+		wantAnnotation   string
+		wantSkippedTasks []string
+	}{{
+		name:           "the TaskRun writing the file records its content",
+		wantTaskRun:    "pr-diff",
+		wantAnnotation: `[{"task":"diff","workspace":"source","path":"summary.txt"}]`,
+	}, {
+		name:        "the guarded PipelineTask runs when the file has the expected content",
+		taskRun:     diffTaskRun(`[{"workspace": "source", "path": "summary.txt", "content": "1 file changed"}]`),
+		wantTaskRun: "pr-deploy",
+	}, {
+		name:             "the guarded PipelineTask is skipped when the file doesn't have the expected content",
+		taskRun:          diffTaskRun(`[]`),
+		wantSkippedTasks: []string{"deploy"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := pr.DeepCopy()
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{pr},
+				ConfigMaps:   cms,
+			}
+			if tc.taskRun != nil {
+				pr.Status.ChildReferences = []v1.ChildStatusReference{{
+					TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+					Name:             tc.taskRun.Name,
+					PipelineTaskName: "diff",
+				}}
+				d.TaskRuns = []*v1.TaskRun{tc.taskRun}
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+			var gotSkippedTasks []string
+			for _, st := range reconciledRun.Status.SkippedTasks {
+				gotSkippedTasks = append(gotSkippedTasks, st.Name)
+			}
+			if d := cmp.Diff(tc.wantSkippedTasks, gotSkippedTasks); d != "" {
+				t.Errorf("Unexpected skipped tasks %s", diff.PrintWantGot(d))
+			}
+			if tc.wantTaskRun == "" {
+				return
+			}
+			tr, err := clients.Pipeline.TektonV1().TaskRuns("foo").Get(prt.TestAssets.Ctx, tc.wantTaskRun, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get TaskRun %s: %v", tc.wantTaskRun, err)
+			}
+			if d := cmp.Diff(tc.wantAnnotation, tr.Annotations[v1.WhenFilesAnnotation]); d != "" {
+				t.Errorf("Unexpected %s annotation %s", v1.WhenFilesAnnotation, diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReconciler_PipelineTaskMatrixExplicitCombosResultsAndMatrixContextVars(t *testing.T) {
 	names.TestingSeed()
 	task1 := parse.MustParseV1Task(t, `
@@ -16816,6 +17782,7 @@ spec:
 		noMatchPolicy                 string
 		verificationPolicies          []*v1alpha1.VerificationPolicy
 		wantTrustedResourcesCondition *apis.Condition
+		wantVerificationOutcome       v1.VerificationOutcome
 	}{
 		{
 			name:                          "ignore no match policy",
@@ -16827,16 +17794,19 @@ spec:
 			noMatchPolicy:                 config.WarnNoMatchPolicy,
 			verificationPolicies:          noMatchPolicy,
 			wantTrustedResourcesCondition: failNoMatchCondition,
+			wantVerificationOutcome:       v1.VerificationOutcomeWarned,
 		}, {
 			name:                          "pass enforce policy",
 			noMatchPolicy:                 config.FailNoMatchPolicy,
 			verificationPolicies:          vps,
 			wantTrustedResourcesCondition: passCondition,
+			wantVerificationOutcome:       v1.VerificationOutcomePassed,
 		}, {
 			name:                          "only fail warn policy",
 			noMatchPolicy:                 config.FailNoMatchPolicy,
 			verificationPolicies:          warnPolicy,
 			wantTrustedResourcesCondition: failNoKeysCondition,
+			wantVerificationOutcome:       v1.VerificationOutcomeWarned,
 		},
 	}
 	for _, tc := range testCases {
@@ -16869,6 +17839,18 @@ spec:
 			if d := cmp.Diff(tc.wantTrustedResourcesCondition, gotVerificationCondition, ignoreLastTransitionTime); d != "" {
 				t.Error(diff.PrintWantGot(d))
 			}
+			// The Pipeline and its Task are both recorded in the provenance unless the verification is skipped.
+			var gotOutcomes []v1.VerificationOutcome
+			for _, rv := range reconciledRun.Status.Provenance.Verification {
+				gotOutcomes = append(gotOutcomes, rv.Outcome)
+			}
+			var wantOutcomes []v1.VerificationOutcome
+			if tc.wantVerificationOutcome != "" {
+				wantOutcomes = []v1.VerificationOutcome{tc.wantVerificationOutcome, tc.wantVerificationOutcome}
+			}
+			if d := cmp.Diff(wantOutcomes, gotOutcomes); d != "" {
+				t.Errorf("verification outcomes in provenance %s", diff.PrintWantGot(d))
+			}
 		})
 	}
 }
@@ -18497,7 +19479,7 @@ func Test_runNextSchedulableTask(t *testing.T) {
 				PipelineClientSet: testAssets.Clients.Pipeline,
 				tracerProvider:    tracing.New("pipelinerun", logging.FromContext(ctx)),
 			}
-			err := c.runNextSchedulableTask(ctx, tc.pr, tc.pipelineRunFacts)
+			err := c.runNextSchedulableTask(ctx, tc.pr, tc.pr.Name, tc.pipelineRunFacts)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("runNextSchedulableTask() error = %v, wantErr %v", err, tc.wantErr)
 			}
@@ -18752,7 +19734,7 @@ spec:
 				},
 			})
 
-			result, err := r.createTaskRun(ctx, trName, nil, rpt, pr, facts)
+			result, err := r.createTaskRun(ctx, trName, nil, rpt, pr, prName, facts)
 			if tc.expectErr {
 				if err == nil {
 					t.Fatalf("expected error, got nil")
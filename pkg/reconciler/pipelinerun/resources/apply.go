@@ -22,8 +22,13 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/container"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"github.com/tektoncd/pipeline/pkg/workspace"
@@ -87,6 +92,18 @@ var paramPatterns = []string{
 //  5. Resolve object params with substitution
 //  6. Apply all replacements to PipelineSpec
 func ApplyParameters(p *v1.PipelineSpec, pr *v1.PipelineRun) (*v1.PipelineSpec, error) {
+	resolvedStringParams, resolvedArrayParams, resolvedObjectParams, err := resolveParameters(p, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	// ===== Phase 6: Apply all replacements to PipelineSpec =====
+	return ApplyReplacements(p, resolvedStringParams, resolvedArrayParams, resolvedObjectParams), nil
+}
+
+// resolveParameters implements phases 1 to 5 of ApplyParameters and returns the resolved
+// string, array and object replacements for the params of the PipelineSpec.
+func resolveParameters(p *v1.PipelineSpec, pr *v1.PipelineRun) (map[string]string, map[string][]string, map[string]map[string]string, error) {
 	// ===== Phase 1: Get params from PipelineRun =====
 	resolvedStringParams, resolvedArrayParams, resolvedObjectParams := paramsFromPipelineRun(pr)
 
@@ -110,7 +127,7 @@ func ApplyParameters(p *v1.PipelineSpec, pr *v1.PipelineRun) (*v1.PipelineSpec,
 			visiting,
 			0,
 		); err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 	}
 
@@ -124,8 +141,7 @@ func ApplyParameters(p *v1.PipelineSpec, pr *v1.PipelineRun) (*v1.PipelineSpec,
 		resolveObjectParam(paramKey, paramValue, resolvedStringParams, resolvedObjectParams)
 	}
 
-	// ===== Phase 6: Apply all replacements to PipelineSpec =====
-	return ApplyReplacements(p, resolvedStringParams, resolvedArrayParams, resolvedObjectParams), nil
+	return resolvedStringParams, resolvedArrayParams, resolvedObjectParams, nil
 }
 
 func paramsFromPipelineRun(pr *v1.PipelineRun) (map[string]string, map[string][]string, map[string]map[string]string) {
@@ -427,6 +443,7 @@ func filterMatrixContextVar(params v1.Params) v1.Params {
 }
 
 // ApplyPipelineTaskContexts applies the substitution from $(context.pipelineTask.*) with the specified values.
+// $(context.pipelineTask.index) is the position of the PipelineTask within the tasks or finally tasks of the Pipeline.
 // Uses "0" as a default if a value is not available as well as matrix context variables
 // $(tasks.<pipelineTaskName>.matrix.length) and $(tasks.<pipelineTaskName>.matrix.<resultName>.length)
 func ApplyPipelineTaskContexts(pt *v1.PipelineTask, pipelineRunStatus v1.PipelineRunStatus, facts *PipelineRunFacts) *v1.PipelineTask {
@@ -437,6 +454,7 @@ func ApplyPipelineTaskContexts(pt *v1.PipelineTask, pipelineRunStatus v1.Pipelin
 
 	replacements := map[string]string{
 		"context.pipelineTask.retries": strconv.Itoa(pt.Retries),
+		"context.pipelineTask.index":   strconv.Itoa(pipelineTaskIndex(pt.Name, pipelineRunStatus.PipelineSpec)),
 	}
 
 	filteredParams := filterMatrixContextVar(pt.Params)
@@ -471,14 +489,31 @@ func ApplyPipelineTaskContexts(pt *v1.PipelineTask, pipelineRunStatus v1.Pipelin
 	pt.Params = pt.Params.ReplaceVariables(replacements, map[string][]string{}, map[string]map[string]string{})
 	if pt.IsMatrixed() {
 		pt.Matrix.Params = pt.Matrix.Params.ReplaceVariables(replacements, map[string][]string{}, map[string]map[string]string{})
-		for i := range pt.Matrix.Include {
-			pt.Matrix.Include[i].Params = pt.Matrix.Include[i].Params.ReplaceVariables(replacements, map[string][]string{}, map[string]map[string]string{})
-		}
+		pt.Matrix.ReplaceIncludeAndExcludeVariables(replacements)
 	}
 	pt.DisplayName = substitution.ApplyReplacements(pt.DisplayName, replacements)
 	return pt
 }
 
+// pipelineTaskIndex returns the zero-based position of the PipelineTask with the given name in the tasks of the
+// PipelineSpec, or in its finally tasks for a finally task. It returns 0 if the PipelineTask is not found.
+func pipelineTaskIndex(name string, ps *v1.PipelineSpec) int {
+	if ps == nil {
+		return 0
+	}
+	for i, task := range ps.Tasks {
+		if task.Name == name {
+			return i
+		}
+	}
+	for i, task := range ps.Finally {
+		if task.Name == name {
+			return i
+		}
+	}
+	return 0
+}
+
 // ApplyTaskResults applies the ResolvedResultRef to each PipelineTask.Params and Pipeline.When in targets
 func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResultRefs) {
 	stringReplacements := resolvedResultRefs.getStringReplacements()
@@ -493,10 +528,8 @@ func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResul
 				// 1. String replacements from string, array or object results
 				// 2. array replacements from array results are supported
 				pipelineTask.Matrix.Params = pipelineTask.Matrix.Params.ReplaceVariables(stringReplacements, arrayReplacements, nil)
-				for i := range pipelineTask.Matrix.Include {
-					// matrix include parameters can only be type string
-					pipelineTask.Matrix.Include[i].Params = pipelineTask.Matrix.Include[i].Params.ReplaceVariables(stringReplacements, nil, nil)
-				}
+				// matrix include and exclude parameters can only be type string
+				pipelineTask.Matrix.ReplaceIncludeAndExcludeVariables(stringReplacements)
 			}
 			pipelineTask.When = pipelineTask.When.ReplaceVariables(stringReplacements, arrayReplacements)
 			if pipelineTask.TaskRef != nil {
@@ -556,9 +589,7 @@ func replaceVariablesInPipelineTasks(tasks []v1.PipelineTask, replacements map[s
 		tasks[i].Params = tasks[i].Params.ReplaceVariables(replacements, arrayReplacements, objectReplacements)
 		if tasks[i].IsMatrixed() {
 			tasks[i].Matrix.Params = tasks[i].Matrix.Params.ReplaceVariables(replacements, arrayReplacements, nil)
-			for j := range tasks[i].Matrix.Include {
-				tasks[i].Matrix.Include[j].Params = tasks[i].Matrix.Include[j].Params.ReplaceVariables(replacements, nil, nil)
-			}
+			tasks[i].Matrix.ReplaceIncludeAndExcludeVariables(replacements)
 		} else {
 			tasks[i].DisplayName = substitution.ApplyReplacements(tasks[i].DisplayName, replacements)
 		}
@@ -585,6 +616,10 @@ func ApplyReplacements(p *v1.PipelineSpec, replacements map[string]string, array
 	replaceVariablesInPipelineTasks(p.Tasks, replacements, arrayReplacements, objectReplacements)
 	replaceVariablesInPipelineTasks(p.Finally, replacements, arrayReplacements, objectReplacements)
 
+	for i := range p.Sidecars {
+		container.ApplyPipelineSidecarReplacements(&p.Sidecars[i], replacements, arrayReplacements)
+	}
+
 	return p
 }
 
@@ -645,7 +680,7 @@ func ApplyResultsToWorkspaceBindings(trResults map[string][]v1.TaskRunResult, pr
 				stringReplacements[fmt.Sprintf("tasks.%s.results.%s", taskName, res.Name)] = res.Value.StringVal
 			case v1.ResultsTypeArray:
 				continue
-			case v1.ResultsTypeObject:
+			case v1.ResultsTypeObject, v1.ResultsTypeFile:
 				for k, v := range res.Value.ObjectVal {
 					stringReplacements[fmt.Sprintf("tasks.%s.results.%s.%s", taskName, res.Name, k)] = v
 				}
@@ -670,7 +705,7 @@ func PropagateResults(rpt *ResolvedPipelineTask, runStates PipelineRunState) {
 				stringReplacements[fmt.Sprintf("tasks.%s.results.%s", taskName, res.Name)] = res.Value.StringVal
 			case v1.ResultsTypeArray:
 				arrayReplacements[fmt.Sprintf("tasks.%s.results.%s", taskName, res.Name)] = res.Value.ArrayVal
-			case v1.ResultsTypeObject:
+			case v1.ResultsTypeObject, v1.ResultsTypeFile:
 				for k, v := range res.Value.ObjectVal {
 					stringReplacements[fmt.Sprintf("tasks.%s.results.%s.%s", taskName, res.Name, k)] = v
 				}
@@ -731,7 +766,21 @@ func ApplyTaskResultsToPipelineResults(
 	stringReplacements := map[string]string{}
 	arrayReplacements := map[string][]string{}
 	objectReplacements := map[string]map[string]string{}
+	var invalidExpressionResults []string
 	for _, pipelineResult := range results {
+		if pipelineResult.Expression != "" {
+			value, err := evaluatePipelineResultExpression(pipelineResult, taskRunResults, customTaskResults, taskstatus)
+			switch {
+			case err != nil:
+				invalidExpressionResults = append(invalidExpressionResults, fmt.Sprintf("%s: %v", pipelineResult.Name, err))
+			case value != nil:
+				runResults = append(runResults, v1.PipelineRunResult{
+					Name:  pipelineResult.Name,
+					Value: *value,
+				})
+			}
+			continue
+		}
 		variablesInPipelineResult, _ := pipelineResult.GetVarSubstitutionExpressions()
 		if len(variablesInPipelineResult) == 0 {
 			continue
@@ -837,10 +886,107 @@ func ApplyTaskResultsToPipelineResults(
 	if len(invalidPipelineResults) > 0 {
 		return runResults, fmt.Errorf("invalid pipelineresults %v, the referenced results don't exist", invalidPipelineResults)
 	}
+	if len(invalidExpressionResults) > 0 {
+		return runResults, fmt.Errorf("invalid pipelineresults, their expressions couldn't be evaluated: %s", strings.Join(invalidExpressionResults, "; "))
+	}
 
 	return runResults, nil
 }
 
+// evaluatePipelineResultExpression computes the value of a PipelineResult from its CEL expression, in which
+// the results of the PipelineTasks are available as tasks.<pipelineTaskName>.results.<resultName>.
+// It returns a nil value if the expression can't be evaluated because a PipelineTask it references isn't successful.
+func evaluatePipelineResultExpression(
+	pipelineResult v1.PipelineResult,
+	taskRunResults map[string][]v1.TaskRunResult,
+	customTaskResults map[string][]v1beta1.CustomRunResult,
+	taskstatus map[string]string,
+) (*v1.ResultValue, error) {
+	prg, referencedTasks, err := v1.CompileResultExpression(pipelineResult.Expression)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make(map[string]interface{}, len(taskRunResults)+len(customTaskResults))
+	for taskName, trResults := range taskRunResults {
+		values := make(map[string]interface{}, len(trResults))
+		for _, trResult := range trResults {
+			switch trResult.Value.Type {
+			case v1.ParamTypeArray:
+				values[trResult.Name] = trResult.Value.ArrayVal
+			case v1.ParamTypeObject:
+				values[trResult.Name] = trResult.Value.ObjectVal
+			default:
+				values[trResult.Name] = trResult.Value.StringVal
+			}
+		}
+		tasks[taskName] = map[string]interface{}{v1.ResultResultPart: values}
+	}
+	for taskName, runResults := range customTaskResults {
+		values := make(map[string]interface{}, len(runResults))
+		for _, runResult := range runResults {
+			values[runResult.Name] = runResult.Value
+		}
+		tasks[taskName] = map[string]interface{}{v1.ResultResultPart: values}
+	}
+	out, _, err := prg.Eval(map[string]interface{}{v1.ResultTaskPart: tasks, v1.ResultFinallyPart: tasks})
+	if err != nil {
+		// if a task is not successful (e.g. skipped or failed) and its results are missing, don't return error
+		for _, taskName := range referencedTasks {
+			if status, ok := taskstatus[PipelineTaskStatusPrefix+taskName+PipelineTaskStatusSuffix]; ok && status != v1.TaskRunReasonSuccessful.String() {
+				return nil, nil
+			}
+		}
+		return nil, err
+	}
+	return pipelineResultExpressionValue(pipelineResult.Type, out)
+}
+
+// pipelineResultExpressionValue converts the value returned by the expression of a PipelineResult to
+// a value of the type of the PipelineResult.
+func pipelineResultExpressionValue(resultType v1.ResultsType, out ref.Val) (*v1.ResultValue, error) {
+	switch resultType {
+	case v1.ResultsTypeArray:
+		lister, ok := out.(traits.Lister)
+		if !ok {
+			return nil, fmt.Errorf("expected a list but got a value of type %s", out.Type().TypeName())
+		}
+		values := []string{}
+		for it := lister.Iterator(); it.HasNext() == types.True; {
+			value, err := v1.FormatResultExpressionValue(it.Next())
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return &v1.ResultValue{Type: v1.ParamTypeArray, ArrayVal: values}, nil
+	case v1.ResultsTypeObject:
+		mapper, ok := out.(traits.Mapper)
+		if !ok {
+			return nil, fmt.Errorf("expected a map but got a value of type %s", out.Type().TypeName())
+		}
+		values := map[string]string{}
+		for it := mapper.Iterator(); it.HasNext() == types.True; {
+			key := it.Next()
+			k, ok := key.Value().(string)
+			if !ok {
+				return nil, fmt.Errorf("expected the keys of the map to be strings but got a key of type %s", key.Type().TypeName())
+			}
+			value, err := v1.FormatResultExpressionValue(mapper.Get(key))
+			if err != nil {
+				return nil, err
+			}
+			values[k] = value
+		}
+		return v1.NewObject(values), nil
+	default:
+		value, err := v1.FormatResultExpressionValue(out)
+		if err != nil {
+			return nil, err
+		}
+		return v1.NewStructuredValues(value), nil
+	}
+}
+
 // taskResultValue returns the result value for a given pipeline task name and result name in a map of TaskRunResults for
 // pipeline task names. It returns nil if either the pipeline task name isn't present in the map, or if there is no
 // result with the result name in the pipeline task name's slice of results.
@@ -865,6 +1011,54 @@ func runResultValue(taskName string, resultName string, runResults map[string][]
 	return nil
 }
 
+// ApplyPodTemplateReplacements applies the substitution of $(params.*) and $(context.pipelineRun.*)
+// variables from the PipelineRun, and of the resolved $(tasks.<pipelineTaskName>.results.*) references,
+// to a PodTemplate that is about to be passed to a TaskRun.
+// Params that are also passed to the TaskRun in taskRunParams are left untouched, so they
+// keep being resolved against the TaskRun's own params (e.g. matrix combinations) by the
+// TaskRun reconciler.
+func ApplyPodTemplateReplacements(podTemplate *pod.Template, p *v1.PipelineSpec, pipelineName string, pr *v1.PipelineRun, taskRunParams v1.Params, resolvedResultRefs ResolvedResultRefs) (*pod.Template, error) {
+	if podTemplate == nil {
+		return nil, nil
+	}
+
+	stringReplacements := map[string]string{}
+	if p != nil {
+		resolvedStringParams, _, _, err := resolveParameters(p, pr)
+		if err != nil {
+			return nil, err
+		}
+		stringReplacements = resolvedStringParams
+	}
+	for _, param := range taskRunParams {
+		for key := range stringReplacements {
+			if isParamReference(key, param.Name) {
+				delete(stringReplacements, key)
+			}
+		}
+	}
+	for key, value := range GetContextReplacements(pipelineName, pr) {
+		stringReplacements[key] = value
+	}
+	for key, value := range resolvedResultRefs.getStringReplacements() {
+		stringReplacements[key] = value
+	}
+
+	return resources.ApplyPodTemplateStringReplacements(podTemplate, stringReplacements), nil
+}
+
+// isParamReference returns true if the replacement key refers to the param with the given name,
+// either as a whole or through one of its array indices or object keys.
+func isParamReference(key, paramName string) bool {
+	for _, pattern := range paramPatterns {
+		ref := fmt.Sprintf(pattern, paramName)
+		if key == ref || strings.HasPrefix(key, ref+"[") || strings.HasPrefix(key, ref+".") {
+			return true
+		}
+	}
+	return false
+}
+
 // ApplyParametersToWorkspaceBindings applies parameters from PipelineSpec and  PipelineRun to the WorkspaceBindings in a PipelineRun. It replaces
 // placeholders in various binding types with values from provided parameters.
 func ApplyParametersToWorkspaceBindings(pr *v1.PipelineRun) {
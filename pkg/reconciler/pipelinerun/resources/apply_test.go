@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
@@ -71,6 +72,37 @@ func TestApplyParameters(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: "parameters in pipeline sidecars",
+			original: v1.PipelineSpec{
+				Params: []v1.ParamSpec{
+					{Name: "db-image", Type: v1.ParamTypeString},
+					{Name: "db-args", Type: v1.ParamTypeArray},
+				},
+				Sidecars: []v1.PipelineSidecar{{
+					Name:  "db",
+					Image: "$(params.db-image)",
+					Args:  []string{"$(params.db-args[*])"},
+					Env:   []corev1.EnvVar{{Name: "IMAGE", Value: "$(params.db-image)"}},
+				}},
+			},
+			params: v1.Params{
+				{Name: "db-image", Value: *v1.NewStructuredValues("postgres")},
+				{Name: "db-args", Value: *v1.NewStructuredValues("-c", "fsync=off")},
+			},
+			expected: v1.PipelineSpec{
+				Params: []v1.ParamSpec{
+					{Name: "db-image", Type: v1.ParamTypeString},
+					{Name: "db-args", Type: v1.ParamTypeArray},
+				},
+				Sidecars: []v1.PipelineSidecar{{
+					Name:  "db",
+					Image: "postgres",
+					Args:  []string{"-c", "fsync=off"},
+					Env:   []corev1.EnvVar{{Name: "IMAGE", Value: "postgres"}},
+				}},
+			},
+		},
 		{
 			name: "parameter propagation string no task or task default winner pipeline",
 			original: v1.PipelineSpec{
@@ -3919,6 +3951,65 @@ func TestApplyPipelineTaskContexts(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		description: "context index replacement",
+		pt: v1.PipelineTask{
+			Name: "test",
+			Params: v1.Params{{
+				Name:  "index",
+				Value: *v1.NewStructuredValues("$(context.pipelineTask.index)"),
+			}},
+		},
+		prstatus: v1.PipelineRunStatus{
+			PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				PipelineSpec: &v1.PipelineSpec{
+					Tasks: []v1.PipelineTask{{
+						Name: "build",
+					}, {
+						Name: "test",
+					}},
+				},
+			},
+		},
+		want: v1.PipelineTask{
+			Name: "test",
+			Params: v1.Params{{
+				Name:  "index",
+				Value: *v1.NewStructuredValues("1"),
+			}},
+		},
+	}, {
+		description: "context index replacement for finally task",
+		pt: v1.PipelineTask{
+			Name: "notify",
+			Params: v1.Params{{
+				Name:  "index",
+				Value: *v1.NewStructuredValues("$(context.pipelineTask.index)"),
+			}},
+		},
+		prstatus: v1.PipelineRunStatus{
+			PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				PipelineSpec: &v1.PipelineSpec{
+					Tasks: []v1.PipelineTask{{
+						Name: "build",
+					}, {
+						Name: "test",
+					}},
+					Finally: []v1.PipelineTask{{
+						Name: "cleanup",
+					}, {
+						Name: "notify",
+					}},
+				},
+			},
+		},
+		want: v1.PipelineTask{
+			Name: "notify",
+			Params: v1.Params{{
+				Name:  "index",
+				Value: *v1.NewStructuredValues("1"),
+			}},
+		},
 	}, {
 		description: "matrix length context variable",
 		pt: v1.PipelineTask{
@@ -4575,6 +4666,76 @@ func TestApplyTaskResultsToPipelineResults_Success(t *testing.T) {
 			Name:  "foo",
 			Value: *v1.NewStructuredValues("do", "rae", "mi"),
 		}},
+	}, {
+		description: "expression-results",
+		results: []v1.PipelineResult{{
+			Name:       "total",
+			Expression: "sum([tasks.unit.results.count, tasks.e2e.results.count])",
+		}, {
+			Name:       "slowest",
+			Expression: "max(tasks.shard.results.duration)",
+		}, {
+			Name:       "reports",
+			Expression: `join(tasks.shard.results.report, ",")`,
+		}, {
+			Name:       "secure-reports",
+			Type:       v1.ResultsTypeArray,
+			Expression: "tasks.shard.results.report.filter(r, r.startsWith('https://'))",
+		}, {
+			Name:       "summary",
+			Type:       v1.ResultsTypeObject,
+			Expression: "{'total': sum([tasks.unit.results.count, tasks.e2e.results.count]), 'passed': tasks.e2e.results.passed}",
+		}},
+		taskResults: map[string][]v1.TaskRunResult{
+			"unit": {{
+				Name:  "count",
+				Value: *v1.NewStructuredValues("3"),
+			}},
+			"e2e": {{
+				Name:  "count",
+				Value: *v1.NewStructuredValues("4"),
+			}, {
+				Name:  "passed",
+				Value: *v1.NewStructuredValues("true"),
+			}},
+			"shard": {{
+				Name:  "duration",
+				Value: *v1.NewStructuredValues("1.5", "3", "2"),
+			}, {
+				Name:  "report",
+				Value: *v1.NewStructuredValues("https://a", "http://b"),
+			}},
+		},
+		expectedResults: []v1.PipelineRunResult{{
+			Name:  "total",
+			Value: *v1.NewStructuredValues("7"),
+		}, {
+			Name:  "slowest",
+			Value: *v1.NewStructuredValues("3"),
+		}, {
+			Name:  "reports",
+			Value: *v1.NewStructuredValues("https://a,http://b"),
+		}, {
+			Name:  "secure-reports",
+			Value: v1.ResultValue{Type: v1.ParamTypeArray, ArrayVal: []string{"https://a"}},
+		}, {
+			Name:  "summary",
+			Value: *v1.NewObject(map[string]string{"total": "7", "passed": "true"}),
+		}},
+	}, {
+		description: "unsuccessful-taskrun-no-returned-result-expression",
+		results: []v1.PipelineResult{{
+			Name:       "total",
+			Expression: "sum([tasks.unit.results.count, tasks.e2e.results.count])",
+		}},
+		taskResults: map[string][]v1.TaskRunResult{
+			"unit": {{
+				Name:  "count",
+				Value: *v1.NewStructuredValues("3"),
+			}},
+		},
+		taskstatus:      map[string]string{resources.PipelineTaskStatusPrefix + "e2e" + resources.PipelineTaskStatusSuffix: v1beta1.TaskRunReasonFailed.String()},
+		expectedResults: nil,
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
 			received, err := resources.ApplyTaskResultsToPipelineResults(tc.results, tc.taskResults, tc.runResults, tc.taskstatus)
@@ -4791,6 +4952,31 @@ func TestApplyTaskResultsToPipelineResults_Error(t *testing.T) {
 		},
 		expectedResults: nil,
 		expectedError:   errors.New("invalid pipelineresults [foo], the referenced results don't exist"),
+	}, {
+		description: "expression-results-not-evaluated",
+		results: []v1.PipelineResult{{
+			Name:       "total",
+			Expression: "sum([tasks.unit.results.count])",
+		}, {
+			Name:       "reports",
+			Expression: "sum(tasks.shard.results.report)",
+		}, {
+			Name:       "slowest",
+			Type:       v1.ResultsTypeArray,
+			Expression: "max(tasks.shard.results.duration)",
+		}},
+		taskResults: map[string][]v1.TaskRunResult{
+			"unit": {},
+			"shard": {{
+				Name:  "duration",
+				Value: *v1.NewStructuredValues("1.5", "3"),
+			}, {
+				Name:  "report",
+				Value: *v1.NewStructuredValues("https://a", "https://b"),
+			}},
+		},
+		expectedResults: nil,
+		expectedError:   errors.New(`invalid pipelineresults, their expressions couldn't be evaluated: total: no such key: count; reports: sum: "https://a" is not a number; slowest: expected a list but got a value of type double`),
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
 			received, err := resources.ApplyTaskResultsToPipelineResults(tc.results, tc.taskResults, tc.runResults, nil /*skipped tasks*/)
@@ -5707,6 +5893,114 @@ func TestApplyParametersToWorkspaceBindings(t *testing.T) {
 	}
 }
 
+func TestApplyPodTemplateReplacements(t *testing.T) {
+	ps := &v1.PipelineSpec{
+		Params: []v1.ParamSpec{
+			{Name: "arch", Type: v1.ParamTypeString},
+			{Name: "env", Type: v1.ParamTypeString, Default: v1.NewStructuredValues("staging")},
+			{Name: "zones", Type: v1.ParamTypeArray, Default: v1.NewStructuredValues("us-east1-b", "us-east1-c")},
+		},
+	}
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pr",
+			Namespace: "ns",
+			UID:       "1234",
+		},
+		Spec: v1.PipelineRunSpec{
+			Params: v1.Params{
+				{Name: "arch", Value: *v1.NewStructuredValues("amd64")},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name               string
+		podTemplate        *pod.Template
+		taskRunParams      v1.Params
+		resolvedResultRefs resources.ResolvedResultRefs
+		want               *pod.Template
+	}{{
+		name: "nil pod template",
+	}, {
+		name: "pipeline params, defaults and context",
+		podTemplate: &pod.Template{
+			NodeSelector: map[string]string{
+				"kubernetes.io/arch":      "$(params.arch)",
+				"topology/zone":           "$(params.zones[1])",
+				"example.com/pipelinerun": "$(context.pipelineRun.name)",
+			},
+			Env: []corev1.EnvVar{
+				{Name: "ENVIRONMENT", Value: "$(params.env)"},
+				{Name: "RUN_UID", Value: "$(context.pipelineRun.uid)"},
+				{Name: "PIPELINE", Value: "$(context.pipeline.name)"},
+			},
+		},
+		want: &pod.Template{
+			NodeSelector: map[string]string{
+				"kubernetes.io/arch":      "amd64",
+				"topology/zone":           "us-east1-c",
+				"example.com/pipelinerun": "pr",
+			},
+			Env: []corev1.EnvVar{
+				{Name: "ENVIRONMENT", Value: "staging"},
+				{Name: "RUN_UID", Value: "1234"},
+				{Name: "PIPELINE", Value: "my-pipeline"},
+			},
+		},
+	}, {
+		name: "taskrun params are left for the taskrun reconciler",
+		podTemplate: &pod.Template{
+			NodeSelector: map[string]string{
+				"kubernetes.io/arch": "$(params.arch)",
+				"environment":        "$(params.env)",
+			},
+		},
+		taskRunParams: v1.Params{
+			{Name: "arch", Value: *v1.NewStructuredValues("arm64")},
+		},
+		want: &pod.Template{
+			NodeSelector: map[string]string{
+				"kubernetes.io/arch": "$(params.arch)",
+				"environment":        "staging",
+			},
+		},
+	}, {
+		name: "task results in env",
+		podTemplate: &pod.Template{
+			Env: []corev1.EnvVar{
+				{Name: "IMAGE_DIGEST", Value: "$(tasks.build.results.digest)"},
+				{Name: "IMAGE_URL", Value: "$(tasks.build.results.image.url)"},
+			},
+		},
+		resolvedResultRefs: resources.ResolvedResultRefs{{
+			Value:           *v1.NewStructuredValues("sha256:1234"),
+			ResultReference: v1.ResultRef{PipelineTask: "build", Result: "digest"},
+			FromTaskRun:     "build-taskrun",
+		}, {
+			Value:           *v1.NewObject(map[string]string{"url": "registry/image"}),
+			ResultReference: v1.ResultRef{PipelineTask: "build", Result: "image"},
+			FromTaskRun:     "build-taskrun",
+		}},
+		want: &pod.Template{
+			Env: []corev1.EnvVar{
+				{Name: "IMAGE_DIGEST", Value: "sha256:1234"},
+				{Name: "IMAGE_URL", Value: "registry/image"},
+			},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resources.ApplyPodTemplateReplacements(tc.podTemplate, ps, "my-pipeline", pr, tc.taskRunParams, tc.resolvedResultRefs)
+			if err != nil {
+				t.Fatalf("ApplyPodTemplateReplacements() unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("ApplyPodTemplateReplacements() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestApplyResultsToWorkspaceBindings(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -22,12 +22,13 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
-	"github.com/google/cel-go/cel"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pipelineErrors "github.com/tektoncd/pipeline/pkg/apis/pipeline/errors"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1/types"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/remote"
@@ -98,40 +99,90 @@ func (t *ResolvedPipelineTask) EvaluateCEL() error {
 			}
 			_, ok := t.EvaluatedCEL[we.CEL]
 			if !ok {
-				// Create a program environment configured with the standard library of CEL functions and macros
-				// The error is omitted because not environment declarations are passed in.
-				env, _ := cel.NewEnv()
-				// Parse and Check the CEL to get the Abstract Syntax Tree
-				ast, iss := env.Compile(we.CEL)
-				if iss.Err() != nil {
-					return iss.Err()
-				}
-				// Generate an evaluatable instance of the Ast within the environment
-				prg, err := env.Program(ast)
-				if err != nil {
-					return err
-				}
-				// Evaluate the CEL expression
-				out, _, err := prg.Eval(map[string]interface{}{})
+				b, err := evaluateCEL(we.CEL)
 				if err != nil {
 					return err
 				}
+				t.EvaluatedCEL[we.CEL] = b
+			}
+		}
+	}
+	return nil
+}
 
-				b, ok := out.Value().(bool)
-				if ok {
-					t.EvaluatedCEL[we.CEL] = b
-				} else {
-					return fmt.Errorf("The CEL expression %s is not evaluated to a boolean", we.CEL)
-				}
+// evaluateCEL evaluates a CEL expression which doesn't reference any variable to a boolean
+func evaluateCEL(expression string) (bool, error) {
+	// Compile the CEL in the environment of WhenExpressions, which provides the standard library of
+	// CEL functions and macros along with the string, list, set and semver extensions
+	prg, err := types.NewWhenExpressionCELProgram(expression)
+	if err != nil {
+		return false, err
+	}
+	// Evaluate the CEL expression
+	out, _, err := prg.Eval(map[string]interface{}{})
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("The CEL expression %s is not evaluated to a boolean", expression)
+	}
+	return b, nil
+}
+
+// evaluateMatrixCEL leaves out the entries of Matrix.Include and Matrix.Exclude whose CEL expression
+// doesn't evaluate to true, once the variables have been substituted.
+func (t *ResolvedPipelineTask) evaluateMatrixCEL() error {
+	m := t.PipelineTask.Matrix
+	if m == nil {
+		return nil
+	}
+	hasCEL := false
+	for _, include := range m.Include {
+		hasCEL = hasCEL || include.CEL != ""
+	}
+	for _, exclude := range m.Exclude {
+		hasCEL = hasCEL || exclude.CEL != ""
+	}
+	if !hasCEL {
+		return nil
+	}
+
+	pipelineTask := t.PipelineTask.DeepCopy()
+	pipelineTask.Matrix.Include, pipelineTask.Matrix.Exclude = nil, nil
+	for _, include := range m.Include {
+		if include.CEL != "" {
+			b, err := evaluateCEL(include.CEL)
+			if err != nil {
+				return fmt.Errorf("error evaluating the CEL of matrix include %q: %w", include.Name, err)
+			}
+			if !b {
+				continue
+			}
+		}
+		pipelineTask.Matrix.Include = append(pipelineTask.Matrix.Include, include)
+	}
+	for i, exclude := range m.Exclude {
+		if exclude.CEL != "" {
+			b, err := evaluateCEL(exclude.CEL)
+			if err != nil {
+				return fmt.Errorf("error evaluating the CEL of matrix exclude %d: %w", i, err)
+			}
+			if !b {
+				continue
 			}
 		}
+		pipelineTask.Matrix.Exclude = append(pipelineTask.Matrix.Exclude, exclude)
 	}
+	t.PipelineTask = pipelineTask
 	return nil
 }
 
 // isDone returns true only if the task is skipped, succeeded or failed
 func (t ResolvedPipelineTask) isDone(facts *PipelineRunFacts) bool {
-	return t.Skip(facts).IsSkipped || t.isSuccessful() || t.isFailure() || t.isValidationFailed(facts.ValidationFailedTask)
+	return t.Skip(facts).IsSkipped || t.isSuccessful() || t.isFailure() || t.isValidationFailed(facts.ValidationFailedTask) ||
+		t.isMatrixFanOutCancelled(facts)
 }
 
 // IsRunning returns true only if the task is neither succeeded, cancelled nor failed
@@ -159,52 +210,87 @@ func (t ResolvedPipelineTask) IsChildPipeline() bool {
 // If the PipelineTask has a Matrix, getReason returns the failure reason for any failure
 // otherwise, it returns an empty string
 func (t ResolvedPipelineTask) getReason() string {
+	if c := t.getCondition(); c != nil {
+		return c.Reason
+	}
+	return ""
+}
+
+// getMessage returns the message of the condition getReason returns the reason of, truncated
+// to maxStatusMessageLength bytes. It returns an empty string if the run has no condition.
+func (t ResolvedPipelineTask) getMessage() string {
+	c := t.getCondition()
+	if c == nil {
+		return ""
+	}
+	return truncateStatusMessage(c.Message)
+}
+
+// getCondition returns the condition of the first run that has not completed successfully, or
+// else of the first run. It returns nil if there is no run or the run has no condition yet.
+func (t ResolvedPipelineTask) getCondition() *apis.Condition {
 	if t.IsChildPipeline() {
 		if len(t.ChildPipelineRuns) == 0 {
-			return ""
+			return nil
 		}
 		for _, childPipelineRun := range t.ChildPipelineRuns {
 			if !childPipelineRun.IsSuccessful() && len(childPipelineRun.Status.Conditions) >= 1 {
-				return childPipelineRun.Status.Conditions[0].Reason
+				return &childPipelineRun.Status.Conditions[0]
 			}
 		}
 		if len(t.ChildPipelineRuns) >= 1 && len(t.ChildPipelineRuns[0].Status.Conditions) >= 1 {
-			return t.ChildPipelineRuns[0].Status.Conditions[0].Reason
+			return &t.ChildPipelineRuns[0].Status.Conditions[0]
 		}
 	}
 
 	if t.IsCustomTask() {
 		if len(t.CustomRuns) == 0 {
-			return ""
+			return nil
 		}
 		for _, run := range t.CustomRuns {
 			if !run.IsSuccessful() && len(run.Status.Conditions) >= 1 {
-				return run.Status.Conditions[0].Reason
+				return &run.Status.Conditions[0]
 			}
 		}
 		if len(t.CustomRuns) >= 1 && len(t.CustomRuns[0].Status.Conditions) >= 1 {
-			return t.CustomRuns[0].Status.Conditions[0].Reason
+			return &t.CustomRuns[0].Status.Conditions[0]
 		}
 	}
 
 	if len(t.TaskRuns) == 0 {
-		return ""
+		return nil
 	}
 	for _, taskRun := range t.TaskRuns {
 		if !taskRun.IsSuccessful() && len(taskRun.Status.Conditions) >= 1 {
-			return taskRun.Status.Conditions[0].Reason
+			return &taskRun.Status.Conditions[0]
 		}
 	}
 	if len(t.TaskRuns) >= 1 && len(t.TaskRuns[0].Status.Conditions) >= 1 {
-		return t.TaskRuns[0].Status.Conditions[0].Reason
+		return &t.TaskRuns[0].Status.Conditions[0]
 	}
 
-	return ""
+	return nil
+}
+
+// truncateStatusMessage keeps the last maxStatusMessageLength bytes of a message, where the
+// cause of a failure is usually reported, without splitting a multi-byte character.
+func truncateStatusMessage(message string) string {
+	if len(message) <= maxStatusMessageLength {
+		return message
+	}
+	i := len(message) - maxStatusMessageLength + len(truncatedStatusMessagePrefix)
+	for i < len(message) && !utf8.RuneStart(message[i]) {
+		i++
+	}
+	return truncatedStatusMessagePrefix + message[i:]
 }
 
 // isSuccessful returns true only if the run has completed successfully
 // If the PipelineTask has a Matrix, isSuccessful returns true if all runs have completed successfully
 func (t ResolvedPipelineTask) isSuccessful() bool {
+	if t.hasUnstartedMatrixRuns() {
+		return false
+	}
 	if t.IsChildPipeline() {
 		if len(t.ChildPipelineRuns) == 0 {
 			return false
@@ -363,6 +449,43 @@ func (t ResolvedPipelineTask) isScheduled() bool {
 	return len(t.TaskRuns) > 0
 }
 
+// hasUnstartedMatrixRuns returns true if the PipelineTask has a Matrix limited by maxParallel
+// and some of its combinations are waiting for earlier ones to finish before they start.
+func (t ResolvedPipelineTask) hasUnstartedMatrixRuns() bool {
+	if !t.PipelineTask.IsMatrixed() || t.PipelineTask.Matrix.MaxParallel == 0 || t.IsChildPipeline() {
+		return false
+	}
+	if t.IsCustomTask() {
+		return len(t.CustomRuns) < len(t.CustomRunNames)
+	}
+	return len(t.TaskRuns) < len(t.TaskRunNames)
+}
+
+// canStartMatrixRuns returns true if the PipelineTask has a Matrix limited by maxParallel whose
+// remaining combinations can start, i.e. none of the combinations which already started has failed.
+func (t ResolvedPipelineTask) canStartMatrixRuns() bool {
+	return t.hasUnstartedMatrixRuns() && !t.haveAnyRunsFailed()
+}
+
+// isMatrixFanOutCancelled returns true if the PipelineRun was gracefully cancelled while the remaining
+// combinations of a Matrix limited by maxParallel were waiting to start, and none of its runs is running.
+func (t ResolvedPipelineTask) isMatrixFanOutCancelled(facts *PipelineRunFacts) bool {
+	if !facts.IsGracefullyCancelled() || facts.isFinalTask(t.PipelineTask.Name) || !t.hasUnstartedMatrixRuns() {
+		return false
+	}
+	for _, taskRun := range t.TaskRuns {
+		if !taskRun.IsDone() {
+			return false
+		}
+	}
+	for _, run := range t.CustomRuns {
+		if !run.IsDone() {
+			return false
+		}
+	}
+	return true
+}
+
 // haveAnyRunsFailed returns true when any of the child PipelineRuns/TaskRuns/CustomRuns have succeeded condition with status set to false
 func (t ResolvedPipelineTask) haveAnyRunsFailed() bool {
 	if t.IsChildPipeline() {
@@ -444,6 +567,8 @@ func (t *ResolvedPipelineTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 		skippingReason = v1.TasksTimedOutSkip
 	case t.skipBecauseEmptyArrayInMatrixParams():
 		skippingReason = v1.EmptyArrayInMatrixParams
+	case t.skipBecauseAllMatrixCombinationsExcluded():
+		skippingReason = v1.AllMatrixCombinationsExcluded
 	default:
 		skippingReason = v1.None
 	}
@@ -474,6 +599,7 @@ func (t *ResolvedPipelineTask) Skip(facts *PipelineRunFacts) TaskSkipStatus {
 // it returns true if any of the when expressions evaluate to false
 func (t *ResolvedPipelineTask) skipBecauseWhenExpressionsEvaluatedToFalse(facts *PipelineRunFacts) bool {
 	if t.checkParentsDone(facts) {
+		t.applyWhenFiles(facts)
 		if !t.PipelineTask.When.AllowsExecution(t.EvaluatedCEL) {
 			return true
 		}
@@ -481,6 +607,29 @@ func (t *ResolvedPipelineTask) skipBecauseWhenExpressionsEvaluatedToFalse(facts
 	return false
 }
 
+// applyWhenFiles sets the Input of the when expressions testing a file to the content of the file, which
+// the TaskRun of the PipelineTask writing it recorded. The content of a file which doesn't exist is empty.
+func (t *ResolvedPipelineTask) applyWhenFiles(facts *PipelineRunFacts) {
+	stateMap := facts.State.ToMap()
+	for i, we := range t.PipelineTask.When {
+		if we.File == nil {
+			continue
+		}
+		t.PipelineTask.When[i].Input = ""
+		rpt := stateMap[we.File.Task]
+		if rpt == nil {
+			continue
+		}
+		for _, tr := range rpt.TaskRuns {
+			for _, wf := range tr.Status.WhenFiles {
+				if wf.Workspace == we.File.Workspace && wf.Path == we.File.Path {
+					t.PipelineTask.When[i].Input = strings.TrimSpace(wf.Content)
+				}
+			}
+		}
+	}
+}
+
 // skipBecauseParentTaskWasSkipped loops through the parent tasks and checks if the parent task skipped:
 //
 //	if yes, is it because of when expressions?
@@ -505,7 +654,9 @@ func (t *ResolvedPipelineTask) skipBecauseParentTaskWasSkipped(facts *PipelineRu
 }
 
 // skipBecauseResultReferencesAreMissing checks if the task references results that cannot be resolved, which is a
-// reason for skipping the task, and applies result references if found
+// reason for skipping the task, and applies result references if found. When enable-completed-with-errors is set,
+// the results a task failed to produce before its failure was ignored because of its onError are unavailable, and
+// the tasks consuming them are skipped.
 func (t *ResolvedPipelineTask) skipBecauseResultReferencesAreMissing(facts *PipelineRunFacts) bool {
 	if t.checkParentsDone(facts) && t.hasResultReferences() {
 		resolvedResultRefs, pt, err := ResolveResultRefs(facts.State, PipelineRunState{t})
@@ -513,7 +664,8 @@ func (t *ResolvedPipelineTask) skipBecauseResultReferencesAreMissing(facts *Pipe
 		if rpt != nil {
 			if err != nil &&
 				(t.PipelineTask.OnError == v1.PipelineTaskContinue ||
-					(t.IsFinalTask(facts) || rpt.Skip(facts).SkippingReason == v1.WhenExpressionsSkip)) {
+					(t.IsFinalTask(facts) || rpt.Skip(facts).SkippingReason == v1.WhenExpressionsSkip) ||
+					(facts.EnableCompletedWithErrors && rpt.PipelineTask.OnError == v1.PipelineTaskContinue && rpt.isFailure())) {
 				return true
 			}
 		}
@@ -575,6 +727,11 @@ func (t *ResolvedPipelineTask) skipBecauseEmptyArrayInMatrixParams() bool {
 	return false
 }
 
+// skipBecauseAllMatrixCombinationsExcluded returns true if Matrix.Exclude removed all the combinations of the Matrix
+func (t *ResolvedPipelineTask) skipBecauseAllMatrixCombinationsExcluded() bool {
+	return t.PipelineTask.IsMatrixed() && t.PipelineTask.Matrix.HasExclude() && t.PipelineTask.Matrix.CountCombinations() == 0
+}
+
 // IsFinalTask returns true if a task is a finally task
 func (t *ResolvedPipelineTask) IsFinalTask(facts *PipelineRunFacts) bool {
 	return facts.isFinalTask(t.PipelineTask.Name)
@@ -599,6 +756,8 @@ func (t *ResolvedPipelineTask) IsFinallySkipped(facts *PipelineRunFacts) TaskSki
 			skippingReason = v1.FinallyTimedOutSkip
 		case t.skipBecauseEmptyArrayInMatrixParams():
 			skippingReason = v1.EmptyArrayInMatrixParams
+		case t.skipBecauseAllMatrixCombinationsExcluded():
+			skippingReason = v1.AllMatrixCombinationsExcluded
 		default:
 			skippingReason = v1.None
 		}
@@ -691,6 +850,9 @@ func ResolvePipelineTask(
 	ApplyTaskResults(PipelineRunState{&rpt}, resolvedResultRefs)
 
 	if rpt.PipelineTask.IsMatrixed() {
+		if err := rpt.evaluateMatrixCEL(); err != nil {
+			return nil, err
+		}
 		numCombinations = rpt.PipelineTask.Matrix.CountCombinations()
 	}
 
@@ -858,7 +1020,8 @@ func GetTaskRunName(childRefs []v1.ChildStatusReference, ptName, prName string)
 
 // GetNamesOfTaskRuns should return unique names for `TaskRuns` if one has not already been defined, and the existing one otherwise.
 func GetNamesOfTaskRuns(childRefs []v1.ChildStatusReference, ptName, prName string, numberOfTaskRuns int) []string {
-	if taskRunNames := getTaskRunNamesFromChildRefs(childRefs, ptName); taskRunNames != nil {
+	// A Matrix limited by maxParallel only has the TaskRuns of its started combinations in childRefs
+	if taskRunNames := getTaskRunNamesFromChildRefs(childRefs, ptName); len(taskRunNames) >= numberOfTaskRuns {
 		return taskRunNames
 	}
 	return getNewRunNames(ptName, prName, numberOfTaskRuns)
@@ -927,7 +1090,8 @@ func getCustomRunName(childRefs []v1.ChildStatusReference, ptName, prName string
 // getNamesOfCustomRuns should return a unique names for `CustomRuns` if they have not already been defined,
 // and the existing ones otherwise.
 func getNamesOfCustomRuns(childRefs []v1.ChildStatusReference, ptName, prName string, numberOfRuns int) []string {
-	if customRunNames := getRunNamesFromChildRefs(childRefs, ptName); customRunNames != nil {
+	// A Matrix limited by maxParallel only has the CustomRuns of its started combinations in childRefs
+	if customRunNames := getRunNamesFromChildRefs(childRefs, ptName); len(customRunNames) >= numberOfRuns {
 		return customRunNames
 	}
 	return getNewRunNames(ptName, prName, numberOfRuns)
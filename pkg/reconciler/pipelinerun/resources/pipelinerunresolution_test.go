@@ -328,6 +328,12 @@ var matrixedPipelineTask = &v1.PipelineTask{
 	},
 }
 
+var matrixedPipelineTaskWithMaxParallel = func() *v1.PipelineTask {
+	pt := matrixedPipelineTask.DeepCopy()
+	pt.Matrix.MaxParallel = 1
+	return pt
+}()
+
 func makeScheduled(tr v1.TaskRun) *v1.TaskRun {
 	newTr := newTaskRun(tr)
 	newTr.Status = v1.TaskRunStatus{ /* explicitly empty */ }
@@ -2563,6 +2569,66 @@ func TestSkipBecauseParentTaskWasSkipped(t *testing.T) {
 	}
 }
 
+func TestSkipBecauseWhenExpressionsEvaluatedToFalse_WhenFiles(t *testing.T) {
+	whenFile := func(path string, operator selection.Operator, values ...string) v1.WhenExpressions {
+		return v1.WhenExpressions{{
+			File:     &v1.WhenFile{Task: "diff", Workspace: "source", Path: path},
+			Operator: operator,
+			Values:   values,
+		}}
+	}
+	tr := makeSucceeded(v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-diff"}})
+	tr.Status.WhenFiles = []v1.TaskRunWhenFile{{
+		Workspace: "source",
+		Path:      "summary.txt",
+		Content:   "changed\n",
+	}}
+	state := PipelineRunState{{
+		PipelineTask: &v1.PipelineTask{Name: "diff", TaskRef: &v1.TaskRef{Name: "task"}},
+		TaskRunNames: []string{"pipelinerun-diff"},
+		TaskRuns:     []*v1.TaskRun{tr},
+		ResolvedTask: &resources.ResolvedTask{TaskSpec: &task.Spec},
+	}, {
+		PipelineTask: &v1.PipelineTask{Name: "deploy", TaskRef: &v1.TaskRef{Name: "task"}, When: whenFile("summary.txt", selection.NotIn, "")},
+		ResolvedTask: &resources.ResolvedTask{TaskSpec: &task.Spec},
+	}, {
+		PipelineTask: &v1.PipelineTask{Name: "report", TaskRef: &v1.TaskRef{Name: "task"}, When: whenFile("summary.txt", selection.In, "unchanged")},
+		ResolvedTask: &resources.ResolvedTask{TaskSpec: &task.Spec},
+	}, {
+		PipelineTask: &v1.PipelineTask{Name: "publish", TaskRef: &v1.TaskRef{Name: "task"}, When: whenFile("missing.txt", selection.NotIn, "")},
+		ResolvedTask: &resources.ResolvedTask{TaskSpec: &task.Spec},
+	}}
+	d, err := dagFromState(state)
+	if err != nil {
+		t.Fatalf("Could not get a dag from the state %#v: %v", state, err)
+	}
+	facts := PipelineRunFacts{
+		State:           state,
+		TasksGraph:      d,
+		FinalTasksGraph: &dag.Graph{},
+		TimeoutsState: PipelineRunTimeoutsState{
+			Clock: testClock,
+		},
+	}
+	stateMap := state.ToMap()
+	for taskName, expected := range map[string]struct {
+		skipped bool
+		input   string
+	}{
+		"deploy":  {skipped: false, input: "changed"},
+		"report":  {skipped: true, input: "changed"},
+		"publish": {skipped: true, input: ""},
+	} {
+		rpt := stateMap[taskName]
+		if d := cmp.Diff(expected.skipped, rpt.skipBecauseWhenExpressionsEvaluatedToFalse(&facts)); d != "" {
+			t.Errorf("Didn't get expected isSkipped from task %s: %s", taskName, diff.PrintWantGot(d))
+		}
+		if d := cmp.Diff(expected.input, rpt.PipelineTask.When[0].Input); d != "" {
+			t.Errorf("Didn't get expected input of the when expression of task %s: %s", taskName, diff.PrintWantGot(d))
+		}
+	}
+}
+
 func getExpectedMessage(runName string, specStatus v1.PipelineRunSpecStatus, status corev1.ConditionStatus,
 	successful, incomplete, skipped, failed, cancelled int,
 ) string {
@@ -3764,6 +3830,10 @@ func TestGetNamesOfTaskRuns(t *testing.T) {
 		TypeMeta:         runtime.TypeMeta{Kind: "TaskRun"},
 		Name:             "mypipelinerun-mytask-1",
 		PipelineTaskName: "mytask",
+	}, {
+		TypeMeta:         runtime.TypeMeta{Kind: "TaskRun"},
+		Name:             "mypipelinerun-mythrottledtask-0",
+		PipelineTaskName: "mythrottledtask",
 	}}
 
 	for _, tc := range []struct {
@@ -3775,6 +3845,10 @@ func TestGetNamesOfTaskRuns(t *testing.T) {
 		name:        "existing taskruns",
 		ptName:      "mytask",
 		wantTrNames: []string{"mypipelinerun-mytask-0", "mypipelinerun-mytask-1"},
+	}, {
+		name:        "taskruns partially started by a matrix limited by maxParallel",
+		ptName:      "mythrottledtask",
+		wantTrNames: []string{"mypipelinerun-mythrottledtask-0", "mypipelinerun-mythrottledtask-1"},
 	}, {
 		name:        "new taskruns",
 		ptName:      "mynewtask",
@@ -4617,6 +4691,22 @@ func TestIsSuccessful(t *testing.T) {
 			TaskRuns:     []*v1.TaskRun{makeSucceeded(trs[0]), makeSucceeded(trs[1])},
 		},
 		want: true,
+	}, {
+		name: "matrixed taskruns limited by maxParallel succeeded with combinations left to start",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: matrixedPipelineTaskWithMaxParallel,
+			TaskRunNames: []string{"task-0", "task-1"},
+			TaskRuns:     []*v1.TaskRun{makeSucceeded(trs[0])},
+		},
+		want: false,
+	}, {
+		name: "matrixed taskruns limited by maxParallel all succeeded",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: matrixedPipelineTaskWithMaxParallel,
+			TaskRunNames: []string{"task-0", "task-1"},
+			TaskRuns:     []*v1.TaskRun{makeSucceeded(trs[0]), makeSucceeded(trs[1])},
+		},
+		want: true,
 	}, {
 		name: "matrixed runs succeeded",
 		rpt: ResolvedPipelineTask{
@@ -5465,6 +5555,69 @@ func TestGetReason(t *testing.T) {
 	}
 }
 
+func TestGetMessage(t *testing.T) {
+	failedTaskRun := func(name, message string) *v1.TaskRun {
+		return &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: v1.TaskRunStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1.TaskRunReasonFailed.String(),
+				Message: message,
+			}}}},
+		}
+	}
+	longMessage := strings.Repeat("a", maxStatusMessageLength) + "exited with code 1"
+	for _, tc := range []struct {
+		name string
+		rpt  ResolvedPipelineTask
+		want string
+	}{{
+		name: "taskrun not started",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1.PipelineTask{Name: "task"},
+		},
+	}, {
+		name: "taskrun failed",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1.PipelineTask{Name: "task"},
+			TaskRuns:     []*v1.TaskRun{failedTaskRun("taskRun", `"step-build" exited with code 1`)},
+		},
+		want: `"step-build" exited with code 1`,
+	}, {
+		name: "matrixed taskrun failed",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: matrixedPipelineTask,
+			TaskRuns:     []*v1.TaskRun{makeSucceeded(trs[0]), failedTaskRun("taskRun-1", `"step-test" exited with code 2`)},
+		},
+		want: `"step-test" exited with code 2`,
+	}, {
+		name: "message truncated",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1.PipelineTask{Name: "task"},
+			TaskRuns:     []*v1.TaskRun{failedTaskRun("taskRun", longMessage)},
+		},
+		want: truncatedStatusMessagePrefix + longMessage[len(longMessage)-maxStatusMessageLength+len(truncatedStatusMessagePrefix):],
+	}, {
+		name: "message truncated without splitting a character",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1.PipelineTask{Name: "task"},
+			TaskRuns:     []*v1.TaskRun{failedTaskRun("taskRun", strings.Repeat("é", maxStatusMessageLength))},
+		},
+		want: truncatedStatusMessagePrefix + strings.Repeat("é", (maxStatusMessageLength-len(truncatedStatusMessagePrefix))/2),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.rpt.getMessage()
+			if got != tc.want {
+				t.Errorf("expected getMessage: %s but got %s", tc.want, got)
+			}
+			if len(got) > maxStatusMessageLength {
+				t.Errorf("expected getMessage to be at most %d bytes but got %d", maxStatusMessageLength, len(got))
+			}
+		})
+	}
+}
+
 func TestCreateResultsCacheMatrixedTaskRuns(t *testing.T) {
 	for _, tc := range []struct {
 		name string
@@ -5656,6 +5809,18 @@ func TestEvaluateCEL_valid(t *testing.T) {
 		want: map[string]bool{
 			"'release/v1'.matches('release/.*')": true,
 		},
+	}, {
+		name: "extension functions",
+		rpt: &ResolvedPipelineTask{
+			PipelineTask: &v1.PipelineTask{
+				When: v1.WhenExpressions{{
+					CEL: "'v1.10.0'.trim() != '' && semver.compare('v1.10.0', 'v1.9.0') > 0",
+				}},
+			},
+		},
+		want: map[string]bool{
+			"'v1.10.0'.trim() != '' && semver.compare('v1.10.0', 'v1.9.0') > 0": true,
+		},
 	}, {
 		name: "multiple CEL when expressions",
 		rpt: &ResolvedPipelineTask{
@@ -5721,6 +5886,160 @@ func TestEvaluateCEL_invalid(t *testing.T) {
 	}
 }
 
+func TestEvaluateMatrixCEL(t *testing.T) {
+	matrixParams := v1.Params{{
+		Name:  "platform",
+		Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+	}, {
+		Name:  "browser",
+		Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{"chrome", "safari"}},
+	}}
+	for _, tc := range []struct {
+		name   string
+		matrix *v1.Matrix
+		want   *v1.Matrix
+	}{{
+		name:   "matrix without cel",
+		matrix: &v1.Matrix{Params: matrixParams},
+		want:   &v1.Matrix{Params: matrixParams},
+	}, {
+		name: "include and exclude with cel evaluating to true are kept",
+		matrix: &v1.Matrix{
+			Params: matrixParams,
+			Include: v1.IncludeParamsList{{
+				Name:   "debug",
+				CEL:    "'true'=='true'",
+				Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("linux")}, {Name: "debug", Value: *v1.NewStructuredValues("on")}},
+			}},
+			Exclude: v1.ExcludeParamsList{{
+				CEL:    "'mac'=='mac'",
+				Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("mac")}, {Name: "browser", Value: *v1.NewStructuredValues("chrome")}},
+			}},
+		},
+		want: &v1.Matrix{
+			Params: matrixParams,
+			Include: v1.IncludeParamsList{{
+				Name:   "debug",
+				CEL:    "'true'=='true'",
+				Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("linux")}, {Name: "debug", Value: *v1.NewStructuredValues("on")}},
+			}},
+			Exclude: v1.ExcludeParamsList{{
+				CEL:    "'mac'=='mac'",
+				Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("mac")}, {Name: "browser", Value: *v1.NewStructuredValues("chrome")}},
+			}},
+		},
+	}, {
+		name: "include and exclude with cel evaluating to false are left out",
+		matrix: &v1.Matrix{
+			Params: matrixParams,
+			Include: v1.IncludeParamsList{{
+				Name:   "debug",
+				CEL:    "'false'=='true'",
+				Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("linux")}, {Name: "debug", Value: *v1.NewStructuredValues("on")}},
+			}},
+			Exclude: v1.ExcludeParamsList{{
+				CEL:    "'linux'=='mac'",
+				Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("mac")}, {Name: "browser", Value: *v1.NewStructuredValues("chrome")}},
+			}, {
+				Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("linux")}, {Name: "browser", Value: *v1.NewStructuredValues("safari")}},
+			}},
+		},
+		want: &v1.Matrix{
+			Params: matrixParams,
+			Exclude: v1.ExcludeParamsList{{
+				Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("linux")}, {Name: "browser", Value: *v1.NewStructuredValues("safari")}},
+			}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rpt := &ResolvedPipelineTask{
+				PipelineTask: &v1.PipelineTask{Name: "pipelinetask", Matrix: tc.matrix},
+			}
+			if err := rpt.evaluateMatrixCEL(); err != nil {
+				t.Fatalf("Got unexpected err: %v", err)
+			}
+			if d := cmp.Diff(tc.want, rpt.PipelineTask.Matrix); d != "" {
+				t.Errorf("Did not get the expected Matrix %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestEvaluateMatrixCEL_invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		matrix *v1.Matrix
+	}{{
+		name: "include cel does not compile",
+		matrix: &v1.Matrix{
+			Include: v1.IncludeParamsList{{
+				Name:   "debug",
+				CEL:    "$(params.foo)=='foo'",
+				Params: v1.Params{{Name: "debug", Value: *v1.NewStructuredValues("on")}},
+			}},
+		},
+	}, {
+		name: "exclude cel is not true or false",
+		matrix: &v1.Matrix{
+			Exclude: v1.ExcludeParamsList{{
+				CEL:    "{'blue': '0x000080', 'red': '0xFF0000'}['red']",
+				Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("mac")}},
+			}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rpt := &ResolvedPipelineTask{
+				PipelineTask: &v1.PipelineTask{Name: "pipelinetask", Matrix: tc.matrix},
+			}
+			if err := rpt.evaluateMatrixCEL(); err == nil {
+				t.Fatalf("Expected err but got nil")
+			}
+		})
+	}
+}
+
+func TestSkipBecauseAllMatrixCombinationsExcluded(t *testing.T) {
+	matrixParams := v1.Params{{
+		Name:  "platform",
+		Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+	}}
+	for _, tc := range []struct {
+		name   string
+		matrix *v1.Matrix
+		want   bool
+	}{{
+		name:   "matrix without exclude",
+		matrix: &v1.Matrix{Params: matrixParams},
+		want:   false,
+	}, {
+		name: "exclude removing some combinations",
+		matrix: &v1.Matrix{
+			Params:  matrixParams,
+			Exclude: v1.ExcludeParamsList{{Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("mac")}}}},
+		},
+		want: false,
+	}, {
+		name: "exclude removing all combinations",
+		matrix: &v1.Matrix{
+			Params: matrixParams,
+			Exclude: v1.ExcludeParamsList{
+				{Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("linux")}}},
+				{Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("mac")}}},
+			},
+		},
+		want: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rpt := &ResolvedPipelineTask{
+				PipelineTask: &v1.PipelineTask{Name: "pipelinetask", Matrix: tc.matrix},
+			}
+			if got := rpt.skipBecauseAllMatrixCombinationsExcluded(); got != tc.want {
+				t.Errorf("Expected skipBecauseAllMatrixCombinationsExcluded to be %t but got %t", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestValidateParamEnumSubset_Valid(t *testing.T) {
 	tcs := []struct {
 		name       string
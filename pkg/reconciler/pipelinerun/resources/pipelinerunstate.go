@@ -19,6 +19,7 @@ package resources
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -44,6 +45,16 @@ const (
 	// PipelineTaskStatusSuffix is a suffix of the param representing execution state of pipelineTask
 	PipelineTaskStatusSuffix = ".status"
 	PipelineTaskReasonSuffix = ".reason"
+	// PipelineTaskStatusReasonSuffix is a suffix of the param representing the reason of the execution state of pipelineTask
+	PipelineTaskStatusReasonSuffix = ".status.reason"
+	// PipelineTaskStatusMessageSuffix is a suffix of the param representing the message of the execution state of pipelineTask
+	PipelineTaskStatusMessageSuffix = ".status.message"
+
+	// maxStatusMessageLength is the maximum length in bytes of the message of the execution state of a pipelineTask
+	// substituted in the params of finally tasks
+	maxStatusMessageLength = 1024
+	// truncatedStatusMessagePrefix marks a message of the execution state of a pipelineTask that has been truncated
+	truncatedStatusMessagePrefix = "..."
 )
 
 // PipelineRunState is a slice of ResolvedPipelineRunTasks the represents the current execution
@@ -76,6 +87,10 @@ type PipelineRunFacts struct {
 	// the case of failing at the validation is during CheckMissingResultReferences method
 	// Tasks in ValidationFailedTask is added in method runNextSchedulableTask
 	ValidationFailedTask []*ResolvedPipelineTask
+
+	// EnableCompletedWithErrors reports the CompletedWithErrors reason for PipelineRuns with ignored
+	// task failures, and skips the tasks consuming the results those failed tasks did not produce.
+	EnableCompletedWithErrors bool
 }
 
 // PipelineRunTimeoutsState records information about start times and timeouts for the PipelineRun, so that the PipelineRunFacts
@@ -224,6 +239,20 @@ func (state PipelineRunState) GetTaskRunsArtifacts() map[string]*v1.Artifacts {
 	return results
 }
 
+// GetWhenFiles returns the files written by the PipelineTask with the given name, which the when
+// expressions of the PipelineTasks in the state test.
+func (state PipelineRunState) GetWhenFiles(pipelineTaskName string) []v1.WhenFile {
+	var files []v1.WhenFile
+	for _, rpt := range state {
+		for _, we := range rpt.PipelineTask.When {
+			if we.File != nil && we.File.Task == pipelineTaskName && !slices.Contains(files, *we.File) {
+				files = append(files, *we.File)
+			}
+		}
+	}
+	return files
+}
+
 // ConvertResultsMapToTaskRunResults converts the map of results from Matrixed PipelineTasks to a list
 // of TaskRunResults to standard the format
 func ConvertResultsMapToTaskRunResults(resultsMap map[string][]string) []v1.TaskRunResult {
@@ -394,13 +423,14 @@ func (t *ResolvedPipelineTask) getChildRefForTaskRun(taskRun *v1.TaskRun) v1.Chi
 }
 
 // getNextTasks returns a list of pipeline tasks which should be executed next i.e.
-// a list of tasks from candidateTasks which aren't yet indicated in state to be running and
-// a list of cancelled/failed tasks from candidateTasks which haven't exhausted their retries
+// a list of tasks from candidateTasks which aren't yet indicated in state to be running,
+// a list of cancelled/failed tasks from candidateTasks which haven't exhausted their retries and
+// a list of tasks from candidateTasks whose Matrix, limited by maxParallel, has combinations left to start
 func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*ResolvedPipelineTask {
 	tasks := []*ResolvedPipelineTask{}
 	for _, t := range state {
 		if _, ok := candidateTasks[t.PipelineTask.Name]; ok {
-			if len(t.TaskRuns) == 0 && len(t.CustomRuns) == 0 && len(t.ChildPipelineRuns) == 0 {
+			if len(t.TaskRuns) == 0 && len(t.CustomRuns) == 0 && len(t.ChildPipelineRuns) == 0 || t.canStartMatrixRuns() {
 				tasks = append(tasks, t)
 			}
 		}
@@ -408,6 +438,18 @@ func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*Resolv
 	return tasks
 }
 
+// getStartedMatrixTasks returns a list of pipeline tasks from candidateTasks whose Matrix, limited by
+// maxParallel, has started and has combinations left to start
+func (state PipelineRunState) getStartedMatrixTasks(candidateTasks sets.String) []*ResolvedPipelineTask {
+	var tasks []*ResolvedPipelineTask
+	for _, t := range state {
+		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.canStartMatrixRuns() {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
 // IsStopping returns true if the PipelineRun won't be scheduling any new Task because
 // at least one task already failed (with onError: stopAndFail) or was cancelled in the specified dag
 func (facts *PipelineRunFacts) IsStopping() bool {
@@ -425,7 +467,7 @@ func (facts *PipelineRunFacts) IsStopping() bool {
 func (facts *PipelineRunFacts) IsRunning() bool {
 	for _, t := range facts.State {
 		if facts.isDAGTask(t.PipelineTask.Name) {
-			if t.IsRunning() {
+			if t.IsRunning() && !t.isMatrixFanOutCancelled(facts) {
 				return true
 			}
 		}
@@ -464,6 +506,10 @@ func (facts *PipelineRunFacts) DAGExecutionQueue() (PipelineRunState, error) {
 	}
 	if !facts.IsStopping() && !facts.IsGracefullyStopped() {
 		tasks = facts.State.getNextTasks(candidateTasks)
+	} else {
+		// the Matrices limited by maxParallel which have started are allowed to finish,
+		// the same way as the tasks which are running
+		tasks = facts.State.getStartedMatrixTasks(candidateTasks)
 	}
 	return tasks, nil
 }
@@ -534,6 +580,8 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 	// 1. Timed out -> Failed
 	// 2. All tasks are done and at least one has failed or has been cancelled -> Failed
 	// 3. All tasks are done or are skipped (i.e. condition check failed).-> Success
+	//    If the failure of some tasks was ignored because of their onError and enable-completed-with-errors is set
+	//    -> Success with reason CompletedWithErrors
 	// 4. A Task or Condition is running right now or there are things left to run -> Running
 	if pr.HasTimedOut(ctx, c) {
 		return &apis.Condition{
@@ -585,6 +633,10 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 		if s.Skipped > 0 {
 			reason = v1.PipelineRunReasonCompleted.String()
 		}
+		// Set reason to ReasonCompletedWithErrors - At least one failure is ignored
+		if facts.EnableCompletedWithErrors && s.IgnoredFailed > 0 {
+			reason = v1.PipelineRunReasonCompletedWithErrors.String()
+		}
 
 		switch {
 		case s.ValidationFailed > 0:
@@ -688,6 +740,8 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 			}
 			tStatus[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskStatusSuffix] = s
 			tStatus[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskReasonSuffix] = t.getReason()
+			tStatus[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskStatusReasonSuffix] = t.getReason()
+			tStatus[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskStatusMessageSuffix] = t.getMessage()
 		}
 	}
 
@@ -834,6 +888,9 @@ func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 		// checking if any finally tasks were referring to invalid/missing task results
 		case t.IsFinallySkipped(facts).IsSkipped:
 			s.Skipped++
+		// increment cancelled counter since the pipeline was gracefully cancelled before the matrix combinations all started
+		case t.isMatrixFanOutCancelled(facts):
+			s.Cancelled++
 		// increment incomplete counter since the task is pending and not executed yet
 		default:
 			s.Incomplete++
@@ -1109,6 +1109,74 @@ func TestDAGExecutionQueue(t *testing.T) {
 	}
 }
 
+// TestDAGExecutionQueueMatrixMaxParallel tests the DAGExecutionQueue function for a Matrix limited by maxParallel
+func TestDAGExecutionQueueMatrixMaxParallel(t *testing.T) {
+	throttledTask := func(taskRuns ...*v1.TaskRun) *ResolvedPipelineTask {
+		return &ResolvedPipelineTask{
+			PipelineTask: matrixedPipelineTaskWithMaxParallel,
+			TaskRunNames: []string{"task-0", "task-1"},
+			TaskRuns:     taskRuns,
+			ResolvedTask: &resources.ResolvedTask{
+				TaskSpec: &task.Spec,
+			},
+		}
+	}
+	tcs := []struct {
+		name       string
+		rpt        *ResolvedPipelineTask
+		specStatus v1.PipelineRunSpecStatus
+		want       bool
+	}{{
+		name: "not started",
+		rpt:  throttledTask(),
+		want: true,
+	}, {
+		name: "combinations left to start",
+		rpt:  throttledTask(makeSucceeded(trs[0])),
+		want: true,
+	}, {
+		name:       "combinations left to start, gracefully stopped",
+		rpt:        throttledTask(makeSucceeded(trs[0])),
+		specStatus: v1.PipelineRunSpecStatusStoppedRunFinally,
+		want:       true,
+	}, {
+		name:       "combinations left to start, gracefully cancelled",
+		rpt:        throttledTask(makeSucceeded(trs[0])),
+		specStatus: v1.PipelineRunSpecStatusCancelledRunFinally,
+	}, {
+		name: "combinations left to start, a combination failed",
+		rpt:  throttledTask(makeFailed(trs[0])),
+	}, {
+		name: "all combinations started",
+		rpt:  throttledTask(makeSucceeded(trs[0]), newTaskRun(trs[1])),
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			state := PipelineRunState{tc.rpt}
+			d, err := dagFromState(state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           state,
+				SpecStatus:      tc.specStatus,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState: PipelineRunTimeoutsState{
+					Clock: testClock,
+				},
+			}
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Errorf("unexpected error getting DAG execution queue: %s", err)
+			}
+			if got := len(queue) == 1; got != tc.want {
+				t.Errorf("expected the task to be in the execution queue to be %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
 // TestDAGExecutionQueueSequentialTasks tests the DAGExecutionQueue function for sequential TaskRuns
 // in different states for a running or stopping PipelineRun.
 func TestDAGExecutionQueueSequentialTasks(t *testing.T) {
@@ -2415,20 +2483,39 @@ func TestGetPipelineConditionStatus_OnError(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-onError-continue"},
 		Spec:       v1.PipelineRunSpec{},
 	}
-	facts := PipelineRunFacts{
-		State:           oneFailedStateOnError,
-		TasksGraph:      d,
-		FinalTasksGraph: &dag.Graph{},
-		TimeoutsState: PipelineRunTimeoutsState{
-			Clock: testClock,
-		},
-	}
-	c := facts.GetPipelineConditionStatus(t.Context(), pr, zap.NewNop().Sugar(), testClock)
-	if c.Status != corev1.ConditionTrue {
-		t.Fatalf("Expected to get status %s but got %s", corev1.ConditionTrue, c.Status)
-	}
-	if c.Message != "Tasks Completed: 2 (Failed: 1 (Ignored: 1), Cancelled 0), Skipped: 0" {
-		t.Errorf("Unexpected Error Msg: %s", c.Message)
+	for _, tc := range []struct {
+		name                      string
+		enableCompletedWithErrors bool
+		wantReason                v1.PipelineRunReason
+	}{{
+		name:       "completed with errors disabled",
+		wantReason: v1.PipelineRunReasonSuccessful,
+	}, {
+		name:                      "completed with errors enabled",
+		enableCompletedWithErrors: true,
+		wantReason:                v1.PipelineRunReasonCompletedWithErrors,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			facts := PipelineRunFacts{
+				State:           oneFailedStateOnError,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState: PipelineRunTimeoutsState{
+					Clock: testClock,
+				},
+				EnableCompletedWithErrors: tc.enableCompletedWithErrors,
+			}
+			c := facts.GetPipelineConditionStatus(t.Context(), pr, zap.NewNop().Sugar(), testClock)
+			if c.Status != corev1.ConditionTrue {
+				t.Fatalf("Expected to get status %s but got %s", corev1.ConditionTrue, c.Status)
+			}
+			if c.Reason != tc.wantReason.String() {
+				t.Errorf("Expected to get reason %s but got %s", tc.wantReason, c.Reason)
+			}
+			if c.Message != "Tasks Completed: 2 (Failed: 1 (Ignored: 1), Cancelled 0), Skipped: 0" {
+				t.Errorf("Unexpected Error Msg: %s", c.Message)
+			}
+		})
 	}
 }
 
@@ -2643,55 +2730,75 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		state:    noneStartedState,
 		dagTasks: []v1.PipelineTask{pts[0], pts[1]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix: "",
-			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                   PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusMessageSuffix: "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                          PipelineTaskStateNone,
 		},
 	}, {
 		name:     "one-task-started",
 		state:    oneStartedState,
 		dagTasks: []v1.PipelineTask{pts[0], pts[1]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix: "",
-			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                   PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusMessageSuffix: "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                          PipelineTaskStateNone,
 		},
 	}, {
 		name:     "one-task-finished",
 		state:    oneFinishedState,
 		dagTasks: []v1.PipelineTask{pts[0], pts[1]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: v1.TaskRunReasonSuccessful.String(),
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix: "Succeeded",
-			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                   PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:        v1.TaskRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:        "Succeeded",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusReasonSuffix:  "Succeeded",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusMessageSuffix: "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                          PipelineTaskStateNone,
 		},
 	}, {
 		name:     "one-task-failed",
 		state:    oneFailedState,
 		dagTasks: []v1.PipelineTask{pts[0], pts[1]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: v1.TaskRunReasonFailed.String(),
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix: "Failed",
-			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                   v1.PipelineRunReasonFailed.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:        v1.TaskRunReasonFailed.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:        "Failed",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusReasonSuffix:  "Failed",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusMessageSuffix: "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                          v1.PipelineRunReasonFailed.String(),
 		},
 	}, {
 		name:     "all-finished",
 		state:    allFinishedState,
 		dagTasks: []v1.PipelineTask{pts[0], pts[1]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: v1.TaskRunReasonSuccessful.String(),
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix: "Succeeded",
-			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: v1.TaskRunReasonSuccessful.String(),
-			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix: "Succeeded",
-			v1.PipelineTasksAggregateStatus:                                   v1.PipelineRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:        v1.TaskRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:        "Succeeded",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusReasonSuffix:  "Succeeded",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusMessageSuffix: "",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix:        v1.TaskRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix:        "Succeeded",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusReasonSuffix:  "Succeeded",
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                          v1.PipelineRunReasonSuccessful.String(),
 		},
 	}, {
 		name: "task-with-when-expressions-passed",
@@ -2705,9 +2812,11 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		}},
 		dagTasks: []v1.PipelineTask{pts[9]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[9].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[9].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                   PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[9].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[9].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[9].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[9].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                          PipelineTaskStateNone,
 		},
 	}, {
 		name: "tasks-when-expression-failed-and-task-skipped",
@@ -2720,9 +2829,11 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		}},
 		dagTasks: []v1.PipelineTask{pts[10]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                    v1.PipelineRunReasonCompleted.String(),
+			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                           v1.PipelineRunReasonCompleted.String(),
 		},
 	}, {
 		name: "when-expression-task-with-parent-started",
@@ -2741,20 +2852,26 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		}},
 		dagTasks: []v1.PipelineTask{pts[0], pts[11]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:  PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:  "",
-			PipelineTaskStatusPrefix + pts[11].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[11].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                    PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:         PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:         "",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusReasonSuffix:   "",
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusMessageSuffix:  "",
+			PipelineTaskStatusPrefix + pts[11].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[11].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[11].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[11].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                           PipelineTaskStateNone,
 		},
 	}, {
 		name:     "task-cancelled",
 		state:    taskCancelled,
 		dagTasks: []v1.PipelineTask{pts[4]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[4].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[4].Name + PipelineTaskReasonSuffix: v1.TaskRunReasonCancelled.String(),
-			v1.PipelineTasksAggregateStatus:                                   PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[4].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[4].Name + PipelineTaskReasonSuffix:        v1.TaskRunReasonCancelled.String(),
+			PipelineTaskStatusPrefix + pts[4].Name + PipelineTaskStatusReasonSuffix:  v1.TaskRunReasonCancelled.String(),
+			PipelineTaskStatusPrefix + pts[4].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                          PipelineTaskStateNone,
 		},
 	}, {
 		name: "one-skipped-one-failed-aggregate-status-must-be-failed",
@@ -2774,66 +2891,90 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		}},
 		dagTasks: []v1.PipelineTask{pts[0], pts[10]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:  v1.PipelineRunReasonFailed.String(),
-			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:  v1.PipelineRunReasonFailed.String(),
-			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                    v1.PipelineRunReasonFailed.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:         v1.PipelineRunReasonFailed.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:         v1.PipelineRunReasonFailed.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusReasonSuffix:   v1.PipelineRunReasonFailed.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusMessageSuffix:  "",
+			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                           v1.PipelineRunReasonFailed.String(),
 		},
 	}, {
 		name:     "no-child-pipelines-started",
 		state:    noneStartedChildPipelineRunState,
 		dagTasks: []v1.PipelineTask{pts[21], pts[22]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskReasonSuffix: "",
-			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                    PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusMessageSuffix: "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                           PipelineTaskStateNone,
 		},
 	}, {
 		name:     "one-child-pipeline-started",
 		state:    oneChildPipelineRunStartedState,
 		dagTasks: []v1.PipelineTask{pts[21], pts[22]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskReasonSuffix: "",
-			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                    PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusMessageSuffix: "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                           PipelineTaskStateNone,
 		},
 	}, {
 		name:     "one-child-pipeline-finished",
 		state:    oneChildPipelineRunFinishedState,
 		dagTasks: []v1.PipelineTask{pts[21], pts[22]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusSuffix: v1.PipelineRunReasonSuccessful.String(),
-			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskReasonSuffix: "Succeeded",
-			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                    PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusSuffix:        v1.PipelineRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskReasonSuffix:        "Succeeded",
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusReasonSuffix:  "Succeeded",
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusMessageSuffix: "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                           PipelineTaskStateNone,
 		},
 	}, {
 		name:     "one-child-pipeline-failed",
 		state:    oneChildPipelineRunFailedState,
 		dagTasks: []v1.PipelineTask{pts[21], pts[22]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusSuffix: v1.PipelineRunReasonFailed.String(),
-			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskReasonSuffix: "Failed",
-			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
-			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskReasonSuffix: "",
-			v1.PipelineTasksAggregateStatus:                                    v1.PipelineRunReasonFailed.String(),
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusSuffix:        v1.PipelineRunReasonFailed.String(),
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskReasonSuffix:        "Failed",
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusReasonSuffix:  "Failed",
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusMessageSuffix: "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusSuffix:        PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskReasonSuffix:        "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusReasonSuffix:  "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                           v1.PipelineRunReasonFailed.String(),
 		},
 	}, {
 		name:     "all-child-pipelines-finished",
 		state:    allChildPipelineRunsFinishedState,
 		dagTasks: []v1.PipelineTask{pts[21], pts[22]},
 		expectedStatus: map[string]string{
-			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusSuffix: v1.PipelineRunReasonSuccessful.String(),
-			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskReasonSuffix: "Succeeded",
-			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusSuffix: v1.PipelineRunReasonSuccessful.String(),
-			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskReasonSuffix: "Succeeded",
-			v1.PipelineTasksAggregateStatus:                                    v1.PipelineRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusSuffix:        v1.PipelineRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskReasonSuffix:        "Succeeded",
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusReasonSuffix:  "Succeeded",
+			PipelineTaskStatusPrefix + pts[21].Name + PipelineTaskStatusMessageSuffix: "",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusSuffix:        v1.PipelineRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskReasonSuffix:        "Succeeded",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusReasonSuffix:  "Succeeded",
+			PipelineTaskStatusPrefix + pts[22].Name + PipelineTaskStatusMessageSuffix: "",
+			v1.PipelineTasksAggregateStatus:                                           v1.PipelineRunReasonSuccessful.String(),
 		},
 	}}
 	for _, tc := range tcs {
@@ -4940,6 +5081,30 @@ func TestPipelineRunState_GetChildReferences(t *testing.T) {
 	}
 }
 
+func TestPipelineRunState_GetWhenFiles(t *testing.T) {
+	summary := v1.WhenFile{Task: "diff", Workspace: "source", Path: "summary.txt"}
+	lint := v1.WhenFile{Task: "lint", Workspace: "source", Path: "lint.txt"}
+	state := PipelineRunState{{
+		PipelineTask: &v1.PipelineTask{Name: "diff"},
+	}, {
+		PipelineTask: &v1.PipelineTask{Name: "deploy", When: v1.WhenExpressions{{
+			File: &summary, Operator: selection.NotIn, Values: []string{""},
+		}, {
+			File: &lint, Operator: selection.In, Values: []string{"0 issues"},
+		}}},
+	}, {
+		PipelineTask: &v1.PipelineTask{Name: "notify", When: v1.WhenExpressions{{
+			File: &summary, Operator: selection.In, Values: []string{"changed"},
+		}}},
+	}}
+	if d := cmp.Diff([]v1.WhenFile{summary}, state.GetWhenFiles("diff")); d != "" {
+		t.Errorf("Unexpected files written by diff: %s", diff.PrintWantGot(d))
+	}
+	if got := state.GetWhenFiles("deploy"); got != nil {
+		t.Errorf("Expected no files written by deploy, got %v", got)
+	}
+}
+
 func TestConvertResultsMapToTaskRunResults(t *testing.T) {
 	for _, tc := range []struct {
 		name       string
@@ -21,8 +21,10 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 
 	pipelineErrors "github.com/tektoncd/pipeline/pkg/apis/pipeline/errors"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 )
@@ -114,8 +116,35 @@ func removeDup(refs ResolvedResultRefs) ResolvedResultRefs {
 // references are returned. If an error is encountered due to an invalid result reference
 // then a nil list and error is returned instead.
 func convertToResultRefs(pipelineRunState PipelineRunState, target *ResolvedPipelineTask) (ResolvedResultRefs, string, error) {
+	return resolveResultRefs(pipelineRunState, v1.PipelineTaskResultRefs(target.PipelineTask))
+}
+
+// ResolvePodTemplateResultRefs resolves the references to results found in the env of a Pod template,
+// e.g. $(tasks.build.results.digest), by searching pipelineRunState. The referenced PipelineTasks must
+// have finished, so the PipelineTask using the Pod template should run after them.
+func ResolvePodTemplateResultRefs(pipelineRunState PipelineRunState, podTemplate *pod.Template) (ResolvedResultRefs, string, error) {
+	if podTemplate == nil {
+		return nil, "", nil
+	}
+	var refs []*v1.ResultRef
+	for _, env := range podTemplate.Env {
+		var expressions []string
+		for _, expression := range v1.VariableSubstitutionRegex.FindAllString(env.Value, -1) {
+			expressions = append(expressions, strings.TrimSuffix(strings.TrimPrefix(expression, "$("), ")"))
+		}
+		refs = append(refs, v1.NewResultRefs(expressions)...)
+	}
+	resolvedResultRefs, pt, err := resolveResultRefs(pipelineRunState, refs)
+	if err != nil {
+		return nil, pt, err
+	}
+	return removeDup(resolvedResultRefs), "", nil
+}
+
+// resolveResultRefs resolves the given result references to a value by searching pipelineRunState.
+func resolveResultRefs(pipelineRunState PipelineRunState, resultRefs []*v1.ResultRef) (ResolvedResultRefs, string, error) {
 	var resolvedResultRefs ResolvedResultRefs
-	for _, resultRef := range v1.PipelineTaskResultRefs(target.PipelineTask) {
+	for _, resultRef := range resultRefs {
 		referencedPipelineTask := pipelineRunState.ToMap()[resultRef.PipelineTask]
 		if referencedPipelineTask == nil {
 			return nil, resultRef.PipelineTask, fmt.Errorf("could not find task %q referenced by result", resultRef.PipelineTask)
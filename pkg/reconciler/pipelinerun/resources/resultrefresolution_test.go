@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -705,6 +706,61 @@ func TestResolveResultRef(t *testing.T) {
 	}
 }
 
+func TestResolvePodTemplateResultRefs(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		podTemplate *pod.Template
+		want        ResolvedResultRefs
+		wantErr     bool
+		wantPt      string
+	}{{
+		name: "nil pod template",
+	}, {
+		name: "result reference in env",
+		podTemplate: &pod.Template{
+			Env: []corev1.EnvVar{{
+				Name:  "A_RESULT",
+				Value: "--value=$(tasks.aTask.results.aResult)",
+			}, {
+				Name:  "NOT_A_RESULT",
+				Value: "$(params.foo)",
+			}},
+		},
+		want: ResolvedResultRefs{{
+			Value: *v1.NewStructuredValues("aResultValue"),
+			ResultReference: v1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "aResult",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+	}, {
+		name: "reference to the result of an unfinished task",
+		podTemplate: &pod.Template{
+			Env: []corev1.EnvVar{{
+				Name:  "B_RESULT",
+				Value: "$(tasks.bTask.results.bResult)",
+			}},
+		},
+		wantErr: true,
+		wantPt:  "bTask",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pt, err := ResolvePodTemplateResultRefs(pipelineRunState, tt.podTemplate)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResolvePodTemplateResultRefs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Fatalf("ResolvePodTemplateResultRefs %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tt.wantPt, pt); d != "" {
+				t.Fatalf("ResolvedPipelineTask %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func lessResolvedResultRefs(i, j *ResolvedResultRef) bool {
 	fromI := i.FromTaskRun
 	if fromI == "" {
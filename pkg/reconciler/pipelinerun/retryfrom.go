@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"errors"
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// ReasonCouldntRetryFrom indicates that the PipelineRun could not reuse the TaskRuns of
+	// the previous PipelineRun it is retried from.
+	ReasonCouldntRetryFrom = "CouldntRetryFrom"
+)
+
+// ErrCouldntRetryFrom indicates that the previous PipelineRun a PipelineRun is retried from can't be reused.
+var ErrCouldntRetryFrom = errors.New("couldn't retry from previous PipelineRun")
+
+// reuseRetriedTaskRuns adds to the child references of a PipelineRun which is retried from a
+// previous PipelineRun the TaskRuns of the previous PipelineRun for the pipeline tasks which
+// succeeded and which don't need to run again, so that their results are consumed instead.
+// The pipeline task the PipelineRun is retried from, the pipeline tasks which depend on it and
+// the finally tasks are always run again.
+func (c *Reconciler) reuseRetriedTaskRuns(pr *v1.PipelineRun, d *dag.Graph) error {
+	retryFrom := pr.Spec.RetryFrom
+	previous, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(retryFrom.PipelineRun)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrCouldntRetryFrom, retryFrom.PipelineRun, err)
+	}
+	if !previous.IsDone() {
+		return fmt.Errorf("%w %q: it has not completed yet", ErrCouldntRetryFrom, retryFrom.PipelineRun)
+	}
+
+	rerun := sets.Set[string]{}
+	if retryFrom.Task != "" {
+		node, ok := d.Nodes[retryFrom.Task]
+		if !ok {
+			return fmt.Errorf("%w %q: %q is not a task of the pipeline", ErrCouldntRetryFrom, retryFrom.PipelineRun, retryFrom.Task)
+		}
+		insertDownstream(rerun, node)
+	}
+
+	for _, name := range sets.List(sets.KeySet(d.Nodes)) {
+		if rerun.Has(name) {
+			continue
+		}
+		var childRefs []v1.ChildStatusReference
+		succeeded := true
+		for _, cr := range previous.Status.ChildReferences {
+			if cr.PipelineTaskName != name {
+				continue
+			}
+			if cr.Kind != taskRun {
+				succeeded = false
+				break
+			}
+			tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(cr.Name)
+			if err != nil || !tr.IsSuccessful() {
+				succeeded = false
+				break
+			}
+			childRefs = append(childRefs, cr)
+		}
+		if succeeded {
+			pr.Status.ChildReferences = append(pr.Status.ChildReferences, childRefs...)
+		}
+	}
+	return nil
+}
+
+// insertDownstream inserts the key of the node and of all the nodes which depend on it in the set.
+func insertDownstream(keys sets.Set[string], node *dag.Node) {
+	if keys.Has(node.Key) {
+		return
+	}
+	keys.Insert(node.Key)
+	for _, next := range node.Next {
+		insertDownstream(keys, next)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// ReasonCouldntCreatePipelineSidecar indicates that the Pod of a sidecar of the PipelineRun couldn't be created.
+	ReasonCouldntCreatePipelineSidecar = "CouldntCreatePipelineSidecar"
+	// ReasonPipelineSidecarFailed indicates that the Pod of a sidecar of the PipelineRun failed, e.g. because it terminated or was evicted.
+	ReasonPipelineSidecarFailed = "PipelineSidecarFailed"
+)
+
+var (
+	// ErrPipelineSidecarCreationFailed is returned when the Pod of a sidecar of a PipelineRun couldn't be created.
+	ErrPipelineSidecarCreationFailed = errors.New("pipeline sidecar creation error")
+	// ErrPipelineSidecarFailed is returned when the Pod of a sidecar of a PipelineRun has terminated.
+	ErrPipelineSidecarFailed = errors.New("pipeline sidecar failed")
+)
+
+// pipelineSidecarPodName returns the name of the Pod running the given sidecar of a PipelineRun.
+func pipelineSidecarPodName(prName, sidecarName string) string {
+	return kmeta.ChildName(prName, "-sidecar-"+sidecarName)
+}
+
+// reconcilePipelineSidecars checks the Pods of the sidecars of the PipelineRun and returns whether all
+// of them are ready. Missing Pods are created when start is true, i.e. before the first TaskRun of the
+// PipelineRun is created; afterwards a missing, deleted or terminated Pod means that the sidecar failed.
+func (c *Reconciler) reconcilePipelineSidecars(ctx context.Context, pr *v1.PipelineRun, sidecars []v1.PipelineSidecar, start bool) (bool, error) {
+	ready := true
+	for _, s := range sidecars {
+		name := pipelineSidecarPodName(pr.Name, s.Name)
+		p, err := c.podLister.Pods(pr.Namespace).Get(name)
+		switch {
+		case apierrors.IsNotFound(err) && start:
+			if _, err := c.KubeClientSet.CoreV1().Pods(pr.Namespace).Create(ctx, newPipelineSidecarPod(ctx, pr, s), metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+				return false, fmt.Errorf("%w: failed to create Pod %s for sidecar %q: %w", ErrPipelineSidecarCreationFailed, name, s.Name, err)
+			}
+			ready = false
+			continue
+		case apierrors.IsNotFound(err):
+			return false, fmt.Errorf("%w: Pod %s of sidecar %q no longer exists", ErrPipelineSidecarFailed, name, s.Name)
+		case err != nil:
+			return false, fmt.Errorf("failed to get Pod %s for sidecar %q: %w", name, s.Name, err)
+		}
+		if err := checkPipelineSidecarPod(p, s.Name); err != nil {
+			return false, err
+		}
+		if !isPodReady(p) {
+			ready = false
+		}
+	}
+	return ready, nil
+}
+
+// checkPipelineSidecarPod returns an error if the Pod of the sidecar has terminated, e.g. because it
+// failed or was evicted, or is being deleted.
+func checkPipelineSidecarPod(p *corev1.Pod, sidecarName string) error {
+	switch {
+	case p.DeletionTimestamp != nil:
+		return fmt.Errorf("%w: Pod %s of sidecar %q is being deleted", ErrPipelineSidecarFailed, p.Name, sidecarName)
+	case p.Status.Phase == corev1.PodFailed && p.Status.Reason != "":
+		return fmt.Errorf("%w: Pod %s of sidecar %q has terminated with phase %s: %s", ErrPipelineSidecarFailed, p.Name, sidecarName, p.Status.Phase, p.Status.Reason)
+	case p.Status.Phase == corev1.PodFailed || p.Status.Phase == corev1.PodSucceeded:
+		return fmt.Errorf("%w: Pod %s of sidecar %q has terminated with phase %s", ErrPipelineSidecarFailed, p.Name, sidecarName, p.Status.Phase)
+	}
+	return nil
+}
+
+// newPipelineSidecarPod returns the Pod running the given sidecar of the PipelineRun. It uses the
+// ServiceAccount and the scheduling constraints of the PipelineRun's TaskRun template.
+func newPipelineSidecarPod(ctx context.Context, pr *v1.PipelineRun, s v1.PipelineSidecar) *corev1.Pod {
+	cfg := config.FromContextOrDefaults(ctx)
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            pipelineSidecarPodName(pr.Name, s.Name),
+			Namespace:       pr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+			Labels: map[string]string{
+				v1.ManagedByLabelKey:             cfg.Defaults.DefaultManagedByLabelValue,
+				pipeline.PipelineRunLabelKey:     pr.Name,
+				pipeline.PipelineSidecarLabelKey: s.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: pr.Spec.TaskRunTemplate.ServiceAccountName,
+			RestartPolicy:      corev1.RestartPolicyAlways,
+			Containers: []corev1.Container{{
+				Name:           s.Name,
+				Image:          s.Image,
+				Command:        s.Command,
+				Args:           s.Args,
+				Env:            s.Env,
+				Ports:          s.Ports,
+				ReadinessProbe: s.ReadinessProbe,
+				Resources:      s.ComputeResources,
+			}},
+		},
+	}
+	if tpl := pr.Spec.TaskRunTemplate.PodTemplate; tpl != nil {
+		p.Spec.NodeSelector = tpl.NodeSelector
+		p.Spec.Tolerations = tpl.Tolerations
+		p.Spec.ImagePullSecrets = tpl.ImagePullSecrets
+	}
+	return p
+}
+
+// isPodReady returns whether the Pod has the Ready condition.
+func isPodReady(p *corev1.Pod) bool {
+	for _, c := range p.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// pipelineSidecarEnv returns the environment variables holding the address of each sidecar of the
+// PipelineRun, which are added to the Steps of its TaskRuns. It returns a permanent error if the Pod
+// of a sidecar no longer exists or has no IP, as its TaskRuns couldn't reach it.
+func (c *Reconciler) pipelineSidecarEnv(pr *v1.PipelineRun, sidecars []v1.PipelineSidecar) ([]corev1.EnvVar, error) {
+	var env []corev1.EnvVar
+	for _, s := range sidecars {
+		name := pipelineSidecarPodName(pr.Name, s.Name)
+		p, err := c.podLister.Pods(pr.Namespace).Get(name)
+		switch {
+		case apierrors.IsNotFound(err):
+			return nil, controller.NewPermanentError(fmt.Errorf("%w: Pod %s of sidecar %q no longer exists", ErrPipelineSidecarFailed, name, s.Name))
+		case err != nil:
+			return nil, fmt.Errorf("failed to get the Pod of sidecar %q: %w", s.Name, err)
+		case p.Status.PodIP == "":
+			return nil, controller.NewPermanentError(fmt.Errorf("%w: Pod %s of sidecar %q has no IP", ErrPipelineSidecarFailed, name, s.Name))
+		}
+		prefix := pipelineSidecarEnvPrefix(s.Name)
+		env = append(env, corev1.EnvVar{Name: prefix + "_HOST", Value: p.Status.PodIP})
+		if len(s.Ports) > 0 {
+			env = append(env, corev1.EnvVar{Name: prefix + "_PORT", Value: strconv.Itoa(int(s.Ports[0].ContainerPort))})
+		}
+	}
+	return env, nil
+}
+
+// pipelineSidecarEnvPrefix returns the prefix of the environment variables holding the address of
+// the sidecar with the given name, e.g. TEKTON_SIDECAR_TEST_DB for test-db.
+func pipelineSidecarEnvPrefix(name string) string {
+	return "TEKTON_SIDECAR_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// cleanupPipelineSidecars deletes the Pods of the sidecars of the PipelineRun.
+func (c *Reconciler) cleanupPipelineSidecars(ctx context.Context, pr *v1.PipelineRun) error {
+	if pr.Status.PipelineSpec == nil {
+		return nil
+	}
+	var errs []error
+	for _, s := range pr.Status.PipelineSpec.Sidecars {
+		name := pipelineSidecarPodName(pr.Name, s.Name)
+		if err := c.KubeClientSet.CoreV1().Pods(pr.Namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete Pod %s: %w", name, err))
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
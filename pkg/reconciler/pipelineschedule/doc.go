@@ -0,0 +1,24 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package pipelineschedule provides a reconciler for PipelineSchedule objects.
+The reconciler creates PipelineRuns from the PipelineSchedule's template when
+they are due according to its cron schedule, applies its concurrency policy to
+the PipelineRuns which are still running, and deletes the completed PipelineRuns
+which exceed its history limits.
+*/
+package pipelineschedule
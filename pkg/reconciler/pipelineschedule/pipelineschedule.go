@@ -0,0 +1,326 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelineschedule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	pipelineschedulereconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/pipelineschedule"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
+	jsonpatch "gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+)
+
+const (
+	// ReasonScheduled indicates that the PipelineSchedule creates PipelineRuns on its schedule.
+	ReasonScheduled = "Scheduled"
+	// ReasonSuspended indicates that the PipelineSchedule is suspended.
+	ReasonSuspended = "Suspended"
+	// ReasonInvalidSchedule indicates that the schedule of the PipelineSchedule cannot be parsed.
+	ReasonInvalidSchedule = "InvalidSchedule"
+	// ReasonPipelineRunCreated indicates that a PipelineRun was created for a scheduled time.
+	ReasonPipelineRunCreated = "PipelineRunCreated"
+	// ReasonPipelineRunSkipped indicates that the PipelineRun for a scheduled time was skipped
+	// because of the Forbid concurrency policy.
+	ReasonPipelineRunSkipped = "PipelineRunSkipped"
+	// ReasonTooManyMissedSchedules indicates that more than maxMissedSchedules scheduled times
+	// were missed and that they were skipped.
+	ReasonTooManyMissedSchedules = "TooManyMissedSchedules"
+
+	// maxMissedSchedules is the number of missed scheduled times after which they are all
+	// skipped rather than going through them to find the latest one, like the CronJob controller.
+	maxMissedSchedules = 100
+)
+
+var cancelPipelineRunPatchBytes []byte
+
+func init() {
+	var err error
+	cancelPipelineRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{{
+		Operation: "add",
+		Path:      "/spec/status",
+		Value:     v1.PipelineRunSpecStatusCancelled,
+	}})
+	if err != nil {
+		log.Fatalf("failed to marshal PipelineRun cancel patch bytes: %v", err)
+	}
+}
+
+// Reconciler implements controller.Reconciler for PipelineSchedule resources.
+type Reconciler struct {
+	PipelineClientSet clientset.Interface
+	Clock             clock.PassiveClock
+
+	pipelineRunLister listers.PipelineRunLister
+}
+
+var _ pipelineschedulereconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind creates the PipelineRun which is due for the PipelineSchedule, if any, updates
+// its status with the PipelineRuns it created, and requeues it for the next scheduled time.
+func (c *Reconciler) ReconcileKind(ctx context.Context, ps *v1alpha1.PipelineSchedule) reconciler.Event {
+	logger := logging.FromContext(ctx)
+	if ps.Status.GetCondition(apis.ConditionReady) == nil {
+		ps.Status.InitializeConditions()
+	}
+
+	schedule, err := ps.Spec.GetSchedule()
+	if err != nil {
+		ps.Status.MarkFailed(ReasonInvalidSchedule, "Failed to parse schedule %q: %v", ps.Spec.Schedule, err)
+		return controller.NewPermanentError(err)
+	}
+
+	active, successful, failed, err := c.getPipelineRuns(ps)
+	if err != nil {
+		return err
+	}
+	updateLastSuccessfulTime(ps, successful)
+	if err := c.deleteHistory(ctx, successful, ps.Spec.GetSuccessfulRunsHistoryLimit()); err != nil {
+		return err
+	}
+	if err := c.deleteHistory(ctx, failed, ps.Spec.GetFailedRunsHistoryLimit()); err != nil {
+		return err
+	}
+
+	if ps.Spec.Suspend {
+		ps.Status.Active = pipelineRunNames(active)
+		ps.Status.NextScheduleTime = nil
+		ps.Status.MarkReady(ReasonSuspended, "PipelineSchedule %q is suspended", ps.Name)
+		return nil
+	}
+
+	now := c.Clock.Now()
+	due, next, tooManyMissed := scheduledTimes(ps, schedule, now)
+	if tooManyMissed {
+		logger.Warnf("PipelineSchedule %q missed more than %d scheduled times, skipping them", ps.Name, maxMissedSchedules)
+		controller.GetEventRecorder(ctx).Eventf(ps, corev1.EventTypeWarning, ReasonTooManyMissedSchedules, "Skipped the PipelineRuns of more than %d missed scheduled times", maxMissedSchedules)
+		ps.Status.LastScheduleTime = &metav1.Time{Time: now}
+	}
+	if !due.IsZero() {
+		logger.Infof("PipelineRun of PipelineSchedule %q scheduled at %s is due", ps.Name, due)
+		if active, err = c.runScheduled(ctx, ps, due, active); err != nil {
+			return err
+		}
+		ps.Status.LastScheduleTime = &metav1.Time{Time: due}
+	}
+	ps.Status.Active = pipelineRunNames(active)
+
+	if next.IsZero() {
+		ps.Status.NextScheduleTime = nil
+		ps.Status.MarkReady(ReasonScheduled, "Schedule %q has no upcoming time", ps.Spec.Schedule)
+		return nil
+	}
+	ps.Status.NextScheduleTime = &metav1.Time{Time: next}
+	ps.Status.MarkReady(ReasonScheduled, "Next PipelineRun is due at %s", next.Format(time.RFC3339))
+	return controller.NewRequeueAfter(next.Sub(now))
+}
+
+// getPipelineRuns returns the PipelineRuns created by the PipelineSchedule which are
+// still running, which succeeded and which failed.
+func (c *Reconciler) getPipelineRuns(ps *v1alpha1.PipelineSchedule) (active, successful, failed []*v1.PipelineRun, err error) {
+	prs, err := c.pipelineRunLister.PipelineRuns(ps.Namespace).List(labels.SelectorFromSet(labels.Set{pipeline.PipelineScheduleLabelKey: ps.Name}))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to list PipelineRuns of PipelineSchedule %s: %w", ps.Name, err)
+	}
+	for _, pr := range prs {
+		if !metav1.IsControlledBy(pr, ps) {
+			continue
+		}
+		switch {
+		case !pr.IsDone():
+			active = append(active, pr)
+		case pr.Status.GetCondition(apis.ConditionSucceeded).IsTrue():
+			successful = append(successful, pr)
+		default:
+			failed = append(failed, pr)
+		}
+	}
+	return active, successful, failed, nil
+}
+
+// runScheduled creates the PipelineRun due at the given scheduled time, applying the concurrency
+// policy of the PipelineSchedule to the active PipelineRuns, and returns the PipelineRuns which
+// are active afterwards.
+func (c *Reconciler) runScheduled(ctx context.Context, ps *v1alpha1.PipelineSchedule, due time.Time, active []*v1.PipelineRun) ([]*v1.PipelineRun, error) {
+	recorder := controller.GetEventRecorder(ctx)
+	if len(active) > 0 {
+		switch ps.Spec.GetConcurrencyPolicy() {
+		case v1alpha1.ForbidConcurrent:
+			recorder.Eventf(ps, corev1.EventTypeNormal, ReasonPipelineRunSkipped, "Skipped the PipelineRun scheduled at %s because %d PipelineRuns are still running", due.Format(time.RFC3339), len(active))
+			return active, nil
+		case v1alpha1.ReplaceConcurrent:
+			for _, pr := range active {
+				if pr.IsCancelled() {
+					continue
+				}
+				if _, err := c.PipelineClientSet.TektonV1().PipelineRuns(pr.Namespace).Patch(ctx, pr.Name, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil && !errors.IsNotFound(err) {
+					return nil, fmt.Errorf("failed to cancel PipelineRun %s: %w", pr.Name, err)
+				}
+			}
+			active = nil
+		case v1alpha1.AllowConcurrent:
+		}
+	}
+
+	pr := newPipelineRun(ps, due)
+	created, err := c.PipelineClientSet.TektonV1().PipelineRuns(ps.Namespace).Create(ctx, pr, metav1.CreateOptions{})
+	switch {
+	case errors.IsAlreadyExists(err):
+		// The PipelineRun was created by an earlier reconciliation whose status update failed.
+		return active, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to create PipelineRun %s: %w", pr.Name, err)
+	}
+	recorder.Eventf(ps, corev1.EventTypeNormal, ReasonPipelineRunCreated, "Created PipelineRun %q scheduled at %s", created.Name, due.Format(time.RFC3339))
+	return append(active, created), nil
+}
+
+// newPipelineRun returns the PipelineRun of the PipelineSchedule for the given scheduled time.
+// Its name is derived from the scheduled time so that it is only created once.
+func newPipelineRun(ps *v1alpha1.PipelineSchedule, due time.Time) *v1.PipelineRun {
+	template := ps.Spec.PipelineRunTemplate
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            kmeta.ChildName(ps.Name, fmt.Sprintf("-%d", due.Unix()/60)),
+			Namespace:       ps.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(ps)},
+			Labels:          make(map[string]string, len(template.Metadata.Labels)+1),
+			Annotations:     make(map[string]string, len(template.Metadata.Annotations)+1),
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	for key, value := range template.Metadata.Labels {
+		pr.Labels[key] = value
+	}
+	pr.Labels[pipeline.PipelineScheduleLabelKey] = ps.Name
+	for key, value := range template.Metadata.Annotations {
+		pr.Annotations[key] = value
+	}
+	pr.Annotations[v1alpha1.PipelineScheduleTimeAnnotation] = due.UTC().Format(time.RFC3339)
+	return pr
+}
+
+// scheduledTimes returns the latest scheduled time which is due at the given time and for
+// which no PipelineRun was created yet, or the zero time if there is none, and the time at
+// which the next PipelineRun is due, or the zero time if the schedule has no upcoming time.
+// When the controller misses several scheduled times, e.g. because it was down, only the
+// latest one is run. When it misses more than maxMissedSchedules of them, none is run and
+// tooManyMissed is true.
+func scheduledTimes(ps *v1alpha1.PipelineSchedule, schedule cron.Schedule, now time.Time) (due, next time.Time, tooManyMissed bool) {
+	from := ps.CreationTimestamp.Time
+	if ps.Status.LastScheduleTime != nil {
+		from = ps.Status.LastScheduleTime.Time
+	}
+	if from.IsZero() {
+		from = now
+	}
+	t := schedule.Next(from)
+	for missed := 0; !t.IsZero() && !t.Add(jitter(ps, t)).After(now); missed++ {
+		if missed == maxMissedSchedules {
+			due, tooManyMissed = time.Time{}, true
+			t = schedule.Next(now)
+			break
+		}
+		due = t
+		t = schedule.Next(t)
+	}
+	if t.IsZero() {
+		return due, t, tooManyMissed
+	}
+	return due, t.Add(jitter(ps, t)), tooManyMissed
+}
+
+// jitter returns the delay added to the given scheduled time of the PipelineSchedule. It is
+// derived from the PipelineSchedule's UID and the scheduled time, so that it is the same on
+// every reconciliation but differs between PipelineSchedules sharing the same schedule.
+func jitter(ps *v1alpha1.PipelineSchedule, t time.Time) time.Duration {
+	if ps.Spec.Jitter == nil || ps.Spec.Jitter.Duration <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(ps.UID))
+	h.Write([]byte(t.UTC().Format(time.RFC3339)))
+	return time.Duration(h.Sum64() % uint64(ps.Spec.Jitter.Duration))
+}
+
+// deleteHistory deletes the oldest of the given completed PipelineRuns so that at most limit of them are kept.
+func (c *Reconciler) deleteHistory(ctx context.Context, prs []*v1.PipelineRun, limit int32) error {
+	if len(prs) <= int(limit) {
+		return nil
+	}
+	sort.Slice(prs, func(i, j int) bool {
+		return completionTime(prs[i]).Before(completionTime(prs[j]))
+	})
+	for _, pr := range prs[:len(prs)-int(limit)] {
+		if err := c.PipelineClientSet.TektonV1().PipelineRuns(pr.Namespace).Delete(ctx, pr.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete PipelineRun %s: %w", pr.Name, err)
+		}
+	}
+	return nil
+}
+
+// updateLastSuccessfulTime records the completion time of the latest successful PipelineRun.
+func updateLastSuccessfulTime(ps *v1alpha1.PipelineSchedule, successful []*v1.PipelineRun) {
+	for _, pr := range successful {
+		t := completionTime(pr)
+		if ps.Status.LastSuccessfulTime == nil || ps.Status.LastSuccessfulTime.Time.Before(t) {
+			ps.Status.LastSuccessfulTime = &metav1.Time{Time: t}
+		}
+	}
+}
+
+// completionTime returns the completion time of the PipelineRun, or its creation time if it is not set.
+func completionTime(pr *v1.PipelineRun) time.Time {
+	if pr.Status.CompletionTime != nil {
+		return pr.Status.CompletionTime.Time
+	}
+	return pr.CreationTimestamp.Time
+}
+
+// pipelineRunNames returns the sorted names of the PipelineRuns.
+func pipelineRunNames(prs []*v1.PipelineRun) []string {
+	if len(prs) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		names = append(names, pr.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,393 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelineschedule
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	clock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	cminformer "knative.dev/pkg/configmap/informer"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+	_ "knative.dev/pkg/system/testing" // Setup system.Namespace()
+)
+
+var (
+	now                      = time.Date(2026, time.January, 1, 0, 12, 0, 0, time.UTC)
+	testClock                = clock.NewFakePassiveClock(now)
+	ignoreLastTransitionTime = cmpopts.IgnoreFields(apis.Condition{}, "LastTransitionTime.Inner.Time")
+)
+
+func initializePipelineScheduleControllerAssets(t *testing.T, d test.Data) (test.Assets, func()) {
+	t.Helper()
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	test.EnsureConfigurationConfigMapsExist(&d)
+	c, informers := test.SeedTestData(t, ctx, d)
+	configMapWatcher := cminformer.NewInformedWatcher(c.Kube, system.Namespace())
+	ctl := NewController(testClock)(ctx, configMapWatcher)
+	if err := configMapWatcher.Start(ctx.Done()); err != nil {
+		t.Fatalf("error starting configmap watcher: %v", err)
+	}
+
+	if la, ok := ctl.Reconciler.(pkgreconciler.LeaderAware); ok {
+		la.Promote(pkgreconciler.UniversalBucket(), func(pkgreconciler.Bucket, types.NamespacedName) {})
+	}
+
+	return test.Assets{
+		Logger:     logging.FromContext(ctx),
+		Controller: ctl,
+		Clients:    c,
+		Informers:  informers,
+		Recorder:   controller.GetEventRecorder(ctx).(*record.FakeRecorder),
+		Ctx:        ctx,
+	}, cancel
+}
+
+func newPipelineSchedule(policy v1alpha1.ConcurrencyPolicy, suspend bool) *v1alpha1.PipelineSchedule {
+	return &v1alpha1.PipelineSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "nightly",
+			Namespace:         "foo",
+			UID:               "nightly-uid",
+			CreationTimestamp: metav1.Time{Time: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		Spec: v1alpha1.PipelineScheduleSpec{
+			Schedule:          "*/5 * * * *",
+			ConcurrencyPolicy: policy,
+			Suspend:           suspend,
+			PipelineRunTemplate: v1alpha1.PipelineRunTemplate{
+				Metadata: v1.PipelineTaskMetadata{
+					Labels: map[string]string{"team": "build"},
+				},
+				Spec: v1.PipelineRunSpec{
+					PipelineRef: &v1.PipelineRef{Name: "release"},
+				},
+			},
+		},
+	}
+}
+
+func scheduledPipelineRun(ps *v1alpha1.PipelineSchedule, due time.Time, succeeded corev1.ConditionStatus, completed time.Time) *v1.PipelineRun {
+	pr := newPipelineRun(ps, due)
+	pr.CreationTimestamp = metav1.Time{Time: due}
+	pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: succeeded})
+	if !completed.IsZero() {
+		pr.Status.CompletionTime = &metav1.Time{Time: completed}
+	}
+	return pr
+}
+
+func TestReconcile(t *testing.T) {
+	at := func(minute int) time.Time { return time.Date(2026, time.January, 1, 0, minute, 0, 0, time.UTC) }
+	allow := newPipelineSchedule(v1alpha1.AllowConcurrent, false)
+	forbid := newPipelineSchedule(v1alpha1.ForbidConcurrent, false)
+	replace := newPipelineSchedule(v1alpha1.ReplaceConcurrent, false)
+	suspended := newPipelineSchedule(v1alpha1.AllowConcurrent, true)
+	longMissed := newPipelineSchedule(v1alpha1.AllowConcurrent, false)
+	longMissed.Status.LastScheduleTime = &metav1.Time{Time: at(0).Add(-24 * time.Hour)}
+	// The PipelineRun due at 00:10 is the latest one; the one due at 00:05 was missed and is skipped.
+	dueName := kmeta.ChildName("nightly", "-29453770")
+	runningName := kmeta.ChildName("nightly", "-29453765")
+
+	for _, tc := range []struct {
+		name         string
+		ps           *v1alpha1.PipelineSchedule
+		pipelineRuns []*v1.PipelineRun
+		wantCreated  bool
+		wantPatched  []string
+		wantDeleted  []string
+		wantStatus   v1alpha1.PipelineScheduleStatus
+	}{{
+		name:        "creates the PipelineRun which is due",
+		ps:          allow,
+		wantCreated: true,
+		wantStatus: v1alpha1.PipelineScheduleStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type: apis.ConditionReady, Status: corev1.ConditionTrue, Reason: ReasonScheduled, Message: "Next PipelineRun is due at 2026-01-01T00:15:00Z",
+			}}},
+			PipelineScheduleStatusFields: v1alpha1.PipelineScheduleStatusFields{
+				Active:           []string{dueName},
+				LastScheduleTime: &metav1.Time{Time: at(10)},
+				NextScheduleTime: &metav1.Time{Time: at(15)},
+			},
+		},
+	}, {
+		name:         "allows concurrent PipelineRuns",
+		ps:           allow,
+		pipelineRuns: []*v1.PipelineRun{scheduledPipelineRun(allow, at(5), corev1.ConditionUnknown, time.Time{})},
+		wantCreated:  true,
+		wantStatus: v1alpha1.PipelineScheduleStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type: apis.ConditionReady, Status: corev1.ConditionTrue, Reason: ReasonScheduled, Message: "Next PipelineRun is due at 2026-01-01T00:15:00Z",
+			}}},
+			PipelineScheduleStatusFields: v1alpha1.PipelineScheduleStatusFields{
+				Active:           []string{runningName, dueName},
+				LastScheduleTime: &metav1.Time{Time: at(10)},
+				NextScheduleTime: &metav1.Time{Time: at(15)},
+			},
+		},
+	}, {
+		name:         "forbids concurrent PipelineRuns",
+		ps:           forbid,
+		pipelineRuns: []*v1.PipelineRun{scheduledPipelineRun(forbid, at(5), corev1.ConditionUnknown, time.Time{})},
+		wantStatus: v1alpha1.PipelineScheduleStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type: apis.ConditionReady, Status: corev1.ConditionTrue, Reason: ReasonScheduled, Message: "Next PipelineRun is due at 2026-01-01T00:15:00Z",
+			}}},
+			PipelineScheduleStatusFields: v1alpha1.PipelineScheduleStatusFields{
+				Active:           []string{runningName},
+				LastScheduleTime: &metav1.Time{Time: at(10)},
+				NextScheduleTime: &metav1.Time{Time: at(15)},
+			},
+		},
+	}, {
+		name:         "replaces concurrent PipelineRuns",
+		ps:           replace,
+		pipelineRuns: []*v1.PipelineRun{scheduledPipelineRun(replace, at(5), corev1.ConditionUnknown, time.Time{})},
+		wantCreated:  true,
+		wantPatched:  []string{runningName},
+		wantStatus: v1alpha1.PipelineScheduleStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type: apis.ConditionReady, Status: corev1.ConditionTrue, Reason: ReasonScheduled, Message: "Next PipelineRun is due at 2026-01-01T00:15:00Z",
+			}}},
+			PipelineScheduleStatusFields: v1alpha1.PipelineScheduleStatusFields{
+				Active:           []string{dueName},
+				LastScheduleTime: &metav1.Time{Time: at(10)},
+				NextScheduleTime: &metav1.Time{Time: at(15)},
+			},
+		},
+	}, {
+		name: "skips too many missed scheduled times",
+		ps:   longMissed,
+		wantStatus: v1alpha1.PipelineScheduleStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type: apis.ConditionReady, Status: corev1.ConditionTrue, Reason: ReasonScheduled, Message: "Next PipelineRun is due at 2026-01-01T00:15:00Z",
+			}}},
+			PipelineScheduleStatusFields: v1alpha1.PipelineScheduleStatusFields{
+				LastScheduleTime: &metav1.Time{Time: now},
+				NextScheduleTime: &metav1.Time{Time: at(15)},
+			},
+		},
+	}, {
+		name: "suspended",
+		ps:   suspended,
+		wantStatus: v1alpha1.PipelineScheduleStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type: apis.ConditionReady, Status: corev1.ConditionTrue, Reason: ReasonSuspended, Message: `PipelineSchedule "nightly" is suspended`,
+			}}},
+		},
+	}, {
+		name: "deletes the PipelineRuns exceeding the history limits",
+		ps:   suspended,
+		pipelineRuns: []*v1.PipelineRun{
+			scheduledPipelineRun(suspended, at(0).Add(-4*time.Hour), corev1.ConditionTrue, at(0).Add(-4*time.Hour+time.Minute)),
+			scheduledPipelineRun(suspended, at(0).Add(-3*time.Hour), corev1.ConditionTrue, at(0).Add(-3*time.Hour+time.Minute)),
+			scheduledPipelineRun(suspended, at(0).Add(-2*time.Hour), corev1.ConditionTrue, at(0).Add(-2*time.Hour+time.Minute)),
+			scheduledPipelineRun(suspended, at(0).Add(-1*time.Hour), corev1.ConditionTrue, at(0).Add(-1*time.Hour+time.Minute)),
+			scheduledPipelineRun(suspended, at(0).Add(-2*time.Hour-5*time.Minute), corev1.ConditionFalse, at(0).Add(-2*time.Hour)),
+			scheduledPipelineRun(suspended, at(0).Add(-1*time.Hour-5*time.Minute), corev1.ConditionFalse, at(0).Add(-1*time.Hour)),
+		},
+		wantDeleted: []string{
+			kmeta.ChildName("nightly", "-29453520"),
+			kmeta.ChildName("nightly", "-29453635"),
+		},
+		wantStatus: v1alpha1.PipelineScheduleStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type: apis.ConditionReady, Status: corev1.ConditionTrue, Reason: ReasonSuspended, Message: `PipelineSchedule "nightly" is suspended`,
+			}}},
+			PipelineScheduleStatusFields: v1alpha1.PipelineScheduleStatusFields{
+				LastSuccessfulTime: &metav1.Time{Time: at(0).Add(-1*time.Hour + time.Minute)},
+			},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				PipelineSchedules: []*v1alpha1.PipelineSchedule{tc.ps},
+				PipelineRuns:      tc.pipelineRuns,
+			}
+			testAssets, cancel := initializePipelineScheduleControllerAssets(t, d)
+			defer cancel()
+			c := testAssets.Clients.Pipeline
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, strings.Join([]string{tc.ps.Namespace, tc.ps.Name}, "/"))
+			if ok, _ := controller.IsRequeueKey(err); err != nil && !ok {
+				t.Fatalf("did not expect an error, but got %v", err)
+			}
+
+			var created, patched, deleted []string
+			for _, action := range c.Actions() {
+				if action.GetResource().Resource != "pipelineruns" {
+					continue
+				}
+				switch action.GetVerb() {
+				case "create":
+					created = append(created, action.(ktesting.CreateAction).GetObject().(*v1.PipelineRun).Name)
+				case "patch":
+					patched = append(patched, action.(ktesting.PatchAction).GetName())
+				case "delete":
+					deleted = append(deleted, action.(ktesting.DeleteAction).GetName())
+				}
+			}
+			if tc.wantCreated {
+				if d := cmp.Diff([]string{dueName}, created); d != "" {
+					t.Errorf("created PipelineRuns %s", diff.PrintWantGot(d))
+				}
+				pr, err := c.TektonV1().PipelineRuns(tc.ps.Namespace).Get(testAssets.Ctx, dueName, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("getting created PipelineRun: %v", err)
+				}
+				wantLabels := map[string]string{"team": "build", pipeline.PipelineScheduleLabelKey: "nightly"}
+				if d := cmp.Diff(wantLabels, pr.Labels); d != "" {
+					t.Errorf("PipelineRun labels %s", diff.PrintWantGot(d))
+				}
+				if got := pr.Annotations[v1alpha1.PipelineScheduleTimeAnnotation]; got != "2026-01-01T00:10:00Z" {
+					t.Errorf("expected scheduled time annotation 2026-01-01T00:10:00Z, got %q", got)
+				}
+				if !metav1.IsControlledBy(pr, tc.ps) {
+					t.Errorf("expected PipelineRun to be controlled by the PipelineSchedule, got owner references %v", pr.OwnerReferences)
+				}
+			} else if len(created) != 0 {
+				t.Errorf("expected no PipelineRun to be created, got %v", created)
+			}
+			if d := cmp.Diff(tc.wantPatched, patched, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("cancelled PipelineRuns %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantDeleted, deleted, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+				t.Errorf("deleted PipelineRuns %s", diff.PrintWantGot(d))
+			}
+
+			reconciled, err := c.TektonV1alpha1().PipelineSchedules(tc.ps.Namespace).Get(testAssets.Ctx, tc.ps.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated PipelineSchedule: %v", err)
+			}
+			if d := cmp.Diff(tc.wantStatus, reconciled.Status, ignoreLastTransitionTime, cmpopts.IgnoreFields(apis.Condition{}, "Severity")); d != "" {
+				t.Errorf("PipelineSchedule status doesn't match %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestScheduledTimes(t *testing.T) {
+	ps := newPipelineSchedule(v1alpha1.AllowConcurrent, false)
+	schedule, err := ps.Spec.GetSchedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(minute int) time.Time { return time.Date(2026, time.January, 1, 0, minute, 0, 0, time.UTC) }
+
+	for _, tc := range []struct {
+		name             string
+		lastScheduleTime *time.Time
+		now              time.Time
+		wantDue          time.Time
+		wantNext         time.Time
+		wantTooMany      bool
+	}{{
+		name:     "not due yet",
+		now:      at(3),
+		wantNext: at(5),
+	}, {
+		name:     "due",
+		now:      at(5),
+		wantDue:  at(5),
+		wantNext: at(10),
+	}, {
+		name:     "latest of missed times",
+		now:      at(17),
+		wantDue:  at(15),
+		wantNext: at(20),
+	}, {
+		name:             "already run",
+		lastScheduleTime: &[]time.Time{at(15)}[0],
+		now:              at(17),
+		wantNext:         at(20),
+	}, {
+		name:             "at most the maximum of missed times",
+		lastScheduleTime: &[]time.Time{at(15 - 5*maxMissedSchedules)}[0],
+		now:              at(17),
+		wantDue:          at(15),
+		wantNext:         at(20),
+	}, {
+		name:             "too many missed times",
+		lastScheduleTime: &[]time.Time{at(10 - 5*maxMissedSchedules)}[0],
+		now:              at(17),
+		wantNext:         at(20),
+		wantTooMany:      true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := ps.DeepCopy()
+			if tc.lastScheduleTime != nil {
+				ps.Status.LastScheduleTime = &metav1.Time{Time: *tc.lastScheduleTime}
+			}
+			due, next, tooMany := scheduledTimes(ps, schedule, tc.now)
+			if !due.Equal(tc.wantDue) || !next.Equal(tc.wantNext) || tooMany != tc.wantTooMany {
+				t.Errorf("expected due %s, next %s and too many missed times %t, got due %s, next %s and %t", tc.wantDue, tc.wantNext, tc.wantTooMany, due, next, tooMany)
+			}
+		})
+	}
+}
+
+func TestJitter(t *testing.T) {
+	ps := newPipelineSchedule(v1alpha1.AllowConcurrent, false)
+	scheduled := time.Date(2026, time.January, 1, 0, 5, 0, 0, time.UTC)
+	if got := jitter(ps, scheduled); got != 0 {
+		t.Errorf("expected no jitter without spec.jitter, got %s", got)
+	}
+
+	ps.Spec.Jitter = &metav1.Duration{Duration: time.Minute}
+	first := jitter(ps, scheduled)
+	if first < 0 || first >= time.Minute {
+		t.Errorf("expected jitter within [0, 1m), got %s", first)
+	}
+	if got := jitter(ps, scheduled); got != first {
+		t.Errorf("expected the jitter of a scheduled time to be stable, got %s and %s", first, got)
+	}
+
+	other := ps.DeepCopy()
+	other.UID = "other-uid"
+	different := false
+	for i := range 10 {
+		if jitter(ps, scheduled.Add(time.Duration(i)*time.Hour)) != jitter(other, scheduled.Add(time.Duration(i)*time.Hour)) {
+			different = true
+		}
+	}
+	if !different {
+		t.Error("expected the jitter to differ between PipelineSchedules")
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
 	limitrangeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/limitrange"
 	filteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
 	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
@@ -72,6 +73,7 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		taskRunInformer := taskruninformer.Get(ctx)
 		podInformer := filteredpodinformer.Get(ctx, v1.ManagedByLabelKey)
 		limitrangeInformer := limitrangeinformer.Get(ctx)
+		configMapInformer := configmapinformer.Get(ctx)
 		verificationpolicyInformer := verificationpolicyinformer.Get(ctx)
 		resolutionInformer := resolutioninformer.Get(ctx)
 		secretinformer := secretinformer.Get(ctx)
@@ -99,6 +101,7 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			spireClient:              spireClient,
 			taskRunLister:            taskRunInformer.Lister(),
 			limitrangeLister:         limitrangeInformer.Lister(),
+			configMapLister:          configMapInformer.Lister(),
 			verificationPolicyLister: verificationpolicyInformer.Lister(),
 			cloudEventClient:         cloudeventclient.Get(ctx),
 			metrics:                  taskrunmetricsRecorder,
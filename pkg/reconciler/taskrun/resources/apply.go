@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"sort"
 	"strconv"
@@ -100,6 +101,12 @@ func applyStepActionParameters(step *v1.Step, spec *v1.TaskSpec, tr *v1.TaskRun,
 	for _, p := range defaults {
 		// 2. step provided parameters
 		if value, exists := stepProvidedParams[p.Name]; exists {
+			if p.Type == v1.ParamTypeObject {
+				var err error
+				if value, err = mergeStepActionObjectParam(p, value); err != nil {
+					return nil, err
+				}
+			}
 			// parameter provided by step, add it to processed
 			processedParams = append(processedParams, v1.Param{
 				Name:  p.Name,
@@ -251,6 +258,42 @@ func applyStepActionParameters(step *v1.Step, spec *v1.TaskSpec, tr *v1.TaskRun,
 	return step, nil
 }
 
+// mergeStepActionObjectParam merges the keys of the default value of an object StepAction param into the
+// object value provided by the Step, e.g. a whole object param of the Task passed with $(params.<name>[*]).
+// Keys provided by the Step take precedence. It returns an error if the provided value is an array, or if
+// keys declared in the properties of the StepAction param are neither provided nor defaulted.
+func mergeStepActionObjectParam(p v1.ParamSpec, value v1.ParamValue) (v1.ParamValue, error) {
+	if value.Type == v1.ParamTypeArray {
+		return value, fmt.Errorf("object param %q of StepAction was passed an array value", p.Name)
+	}
+	if value.Type != v1.ParamTypeObject {
+		// a string value can still be a reference resolved later, e.g. to a step result
+		return value, nil
+	}
+
+	merged := map[string]string{}
+	if p.Default != nil {
+		for k, v := range p.Default.ObjectVal {
+			merged[k] = v
+		}
+	}
+	for k, v := range value.ObjectVal {
+		merged[k] = v
+	}
+
+	var missing []string
+	for key := range p.Properties {
+		if _, ok := merged[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return value, fmt.Errorf("object param %q of StepAction is missing keys: %v", p.Name, missing)
+	}
+	return *v1.NewObject(merged), nil
+}
+
 // checkForDuplicateKeys checks if there are duplicate keys in the replacements
 func checkForDuplicateKeys(stringReplacements map[string]string, arrayReplacements map[string][]string) error {
 	keys := make([]string, 0, len(stringReplacements))
@@ -393,9 +436,54 @@ func getTaskParameters(spec *v1.TaskSpec, tr *v1.TaskRun, defaults ...v1.ParamSp
 // ApplyParameters applies the params from a TaskRun.Parameters to a TaskSpec
 func ApplyParameters(spec *v1.TaskSpec, tr *v1.TaskRun, defaults ...v1.ParamSpec) *v1.TaskSpec {
 	stringReplacements, arrayReplacements, objectReplacements := getTaskParameters(spec, tr, defaults...)
+	for k, v := range getParamFallbackReplacements(spec, stringReplacements) {
+		stringReplacements[k] = v
+	}
 	return ApplyReplacements(spec, stringReplacements, arrayReplacements, objectReplacements)
 }
 
+// getParamFallbackReplacements returns the replacements for all references to params with a fallback value,
+// e.g. $(params.foo | default "bar"), found in the TaskSpec. Each reference resolves to the value of the param
+// in stringReplacements, or to its fallback value if the param is empty.
+func getParamFallbackReplacements(spec *v1.TaskSpec, stringReplacements map[string]string) map[string]string {
+	values := collectParamFallbackStrings(reflect.ValueOf(spec), nil)
+	if len(values) == 0 {
+		return nil
+	}
+	return substitution.ParamFallbackReplacements(values, stringReplacements)
+}
+
+// collectParamFallbackStrings appends the strings found in v which may contain a reference to a param with a
+// fallback value to values. It walks the exported fields of v in place, so that the TaskSpec is not copied.
+func collectParamFallbackStrings(v reflect.Value, values []string) []string {
+	switch v.Kind() {
+	case reflect.String:
+		if s := v.String(); strings.Contains(s, "$(params") && strings.Contains(s, "default") {
+			values = append(values, s)
+		}
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			values = collectParamFallbackStrings(v.Elem(), values)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				values = collectParamFallbackStrings(v.Field(i), values)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			values = collectParamFallbackStrings(v.Index(i), values)
+		}
+	case reflect.Map:
+		for iter := v.MapRange(); iter.Next(); {
+			values = collectParamFallbackStrings(iter.Value(), values)
+		}
+	default:
+	}
+	return values
+}
+
 func replacementsFromDefaultParams(defaults v1.ParamSpecs) (map[string]string, map[string][]string, map[string]map[string]string) {
 	stringReplacements := map[string]string{}
 	arrayReplacements := map[string][]string{}
@@ -493,25 +581,39 @@ func replacementsFromParams(params v1.Params) (map[string]string, map[string][]s
 }
 
 func getContextReplacements(taskName string, tr *v1.TaskRun) map[string]string {
-	return map[string]string{
+	replacements := map[string]string{
 		"context.taskRun.name":      tr.Name,
 		"context.task.name":         taskName,
 		"context.taskRun.namespace": tr.Namespace,
 		"context.taskRun.uid":       string(tr.ObjectMeta.UID),
 		"context.task.retry-count":  strconv.Itoa(len(tr.Status.RetriesStatus)),
 	}
+	addMetadataReplacements(replacements, "context.taskRun.labels", tr.Labels)
+	addMetadataReplacements(replacements, "context.taskRun.annotations", tr.Annotations)
+	return replacements
+}
+
+// addMetadataReplacements adds the replacements for the given labels or annotations, referenced
+// with the dot notation or the bracket notation, e.g. $(context.taskRun.labels['tekton.dev/task']).
+func addMetadataReplacements(replacements map[string]string, prefix string, metadata map[string]string) {
+	for k, v := range metadata {
+		replacements[fmt.Sprintf("%s.%s", prefix, k)] = v
+		replacements[fmt.Sprintf("%s['%s']", prefix, k)] = v
+		replacements[fmt.Sprintf("%s[%q]", prefix, k)] = v
+	}
 }
 
 // ApplyContexts applies the substitution from $(context.(taskRun|task).*) with the specified values.
-// Uses "" as a default if a value is not available.
+// Uses "" as a default if a value is not available, except for labels and annotations which are left unchanged.
 func ApplyContexts(spec *v1.TaskSpec, taskName string, tr *v1.TaskRun) *v1.TaskSpec {
 	return ApplyReplacements(spec, getContextReplacements(taskName, tr), map[string][]string{}, map[string]map[string]string{})
 }
 
 // ApplyWorkspaces applies the substitution from paths that the workspaces in declarations mounted to, the
-// volumes that bindings are realized with in the task spec and the PersistentVolumeClaim names for the
-// workspaces.
-func ApplyWorkspaces(ctx context.Context, spec *v1.TaskSpec, declarations []v1.WorkspaceDeclaration, bindings []v1.WorkspaceBinding, vols map[string]corev1.Volume) *v1.TaskSpec {
+// volumes that bindings are realized with in the task spec and the PersistentVolumeClaim names and sizes for
+// the workspaces. pvcSizes holds the storage size of the PersistentVolumeClaims bound to workspaces, keyed by
+// workspace name; workspaces without a known size are substituted with "0".
+func ApplyWorkspaces(ctx context.Context, spec *v1.TaskSpec, declarations []v1.WorkspaceDeclaration, bindings []v1.WorkspaceBinding, vols map[string]corev1.Volume, pvcSizes map[string]string) *v1.TaskSpec {
 	stringReplacements := map[string]string{}
 
 	bindNames := sets.NewString()
@@ -524,6 +626,7 @@ func ApplyWorkspaces(ctx context.Context, spec *v1.TaskSpec, declarations []v1.W
 		if declaration.Optional && !bindNames.Has(declaration.Name) {
 			stringReplacements[prefix+"bound"] = "false"
 			stringReplacements[prefix+"path"] = ""
+			stringReplacements[prefix+"size"] = "0"
 		} else {
 			stringReplacements[prefix+"bound"] = "true"
 			spec = applyWorkspaceMountPath(prefix+"path", spec, declaration)
@@ -539,10 +642,26 @@ func ApplyWorkspaces(ctx context.Context, spec *v1.TaskSpec, declarations []v1.W
 		} else {
 			stringReplacements[fmt.Sprintf("workspaces.%s.claim", binding.Name)] = ""
 		}
+		stringReplacements[fmt.Sprintf("workspaces.%s.size", binding.Name)] = getWorkspaceSize(binding, pvcSizes)
 	}
 	return ApplyReplacements(spec, stringReplacements, map[string][]string{}, map[string]map[string]string{})
 }
 
+// getWorkspaceSize returns the storage size of the volume bound to the workspace. Sizes of
+// PersistentVolumeClaims are taken from pvcSizes, while claims created from a volumeClaimTemplate
+// fall back to the storage requested in the template. "0" is returned for any other binding.
+func getWorkspaceSize(binding v1.WorkspaceBinding, pvcSizes map[string]string) string {
+	if size, ok := pvcSizes[binding.Name]; ok {
+		return size
+	}
+	if binding.VolumeClaimTemplate != nil {
+		if size, ok := binding.VolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			return size.String()
+		}
+	}
+	return "0"
+}
+
 // ApplyParametersToWorkspaceBindings applies parameters to the WorkspaceBindings of a TaskRun. It takes a TaskSpec and a TaskRun as input and returns the modified TaskRun.
 func ApplyParametersToWorkspaceBindings(ts *v1.TaskSpec, tr *v1.TaskRun) *v1.TaskRun {
 	tsCopy := ts.DeepCopy()
@@ -747,15 +866,24 @@ func ApplyReplacements(spec *v1.TaskSpec, stringReplacements map[string]string,
 	return spec
 }
 
-// ApplyPodTemplateParameters applies parameter substitution to a PodTemplate
+// ApplyPodTemplateReplacements applies parameter substitution to a PodTemplate
 func ApplyPodTemplateReplacements(podTemplate *podtpl.Template, tr *v1.TaskRun, defaults ...v1.ParamSpec) *podtpl.Template {
 	if podTemplate == nil {
 		return nil
 	}
 
-	result := podTemplate.DeepCopy()
-
 	stringReplacements, _, _ := getTaskParameters(nil, tr, defaults...)
+	return ApplyPodTemplateStringReplacements(podTemplate, stringReplacements)
+}
+
+// ApplyPodTemplateStringReplacements returns a copy of the PodTemplate with the given string
+// replacements applied to all of its string fields.
+func ApplyPodTemplateStringReplacements(podTemplate *podtpl.Template, stringReplacements map[string]string) *podtpl.Template {
+	if podTemplate == nil {
+		return nil
+	}
+
+	result := podTemplate.DeepCopy()
 
 	// Apply substitution to NodeSelector
 	if result.NodeSelector != nil {
@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	"github.com/tektoncd/pipeline/test/names"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
@@ -1323,6 +1324,55 @@ func TestApplyParameters(t *testing.T) {
 	}
 }
 
+func TestApplyParameters_Fallbacks(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{
+			Name: "provided",
+			Type: v1.ParamTypeString,
+		}, {
+			Name:    "empty",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues(""),
+		}, {
+			Name:       "config",
+			Type:       v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{"host": {Type: v1.ParamTypeString}},
+			Default:    v1.NewObject(map[string]string{"host": "default-host"}),
+		}},
+		Steps: []v1.Step{{
+			Name:  "step",
+			Image: `$(params.provided | default "alpine")`,
+			Args:  []string{`$(params.empty | default "fallback")`, `$(params.config.host | default "localhost")`},
+			Env: []corev1.EnvVar{{
+				Name:  "MIXED",
+				Value: `$(params.empty)-$(params["empty"] | default "x")`,
+			}},
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:  "sidecar",
+			Image: `$(params.empty | default "busybox")`,
+		}},
+	}
+	tr := &v1.TaskRun{
+		Spec: v1.TaskRunSpec{
+			Params: v1.Params{{
+				Name:  "provided",
+				Value: *v1.NewStructuredValues("ubuntu"),
+			}},
+		},
+	}
+	want := applyMutation(ts, func(spec *v1.TaskSpec) {
+		spec.Steps[0].Image = "ubuntu"
+		spec.Steps[0].Args = []string{"fallback", "default-host"}
+		spec.Steps[0].Env[0].Value = "-x"
+		spec.Sidecars[0].Image = "busybox"
+	})
+	got := resources.ApplyParameters(ts, tr, ts.Params...)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyParameters() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyParameters_ArrayIndexing(t *testing.T) {
 	tr := &v1.TaskRun{
 		Spec: v1.TaskRunSpec{
@@ -2387,11 +2437,12 @@ func TestApplyWorkspaces(t *testing.T) {
 		}},
 	}
 	for _, tc := range []struct {
-		name  string
-		spec  *v1.TaskSpec
-		decls []v1.WorkspaceDeclaration
-		binds []v1.WorkspaceBinding
-		want  *v1.TaskSpec
+		name     string
+		spec     *v1.TaskSpec
+		decls    []v1.WorkspaceDeclaration
+		binds    []v1.WorkspaceBinding
+		pvcSizes map[string]string
+		want     *v1.TaskSpec
 	}{{
 		name: "workspace-variable-replacement",
 		spec: ts.DeepCopy(),
@@ -2435,6 +2486,65 @@ func TestApplyWorkspaces(t *testing.T) {
 			spec.Volumes[1].VolumeSource.Secret.SecretName = "ws-b31db"
 			spec.Volumes[2].VolumeSource.PersistentVolumeClaim.ClaimName = "ws-b31db"
 		}),
+	}, {
+		name: "sidecar-workspace-variable-replacement",
+		spec: &v1.TaskSpec{Sidecars: []v1.Sidecar{{
+			Name:  "foo",
+			Image: "bar",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "$(workspaces.myws.volume)",
+				MountPath: "$(workspaces.myws.path)",
+				SubPath:   "$(workspaces.myws.claim)",
+			}},
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "$(workspaces.myws.claim)"},
+				},
+			}},
+			ReadinessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					Exec: &corev1.ExecAction{Command: []string{"test", "-f", "$(workspaces.myws.path)/ready"}},
+				},
+			},
+			Lifecycle: &corev1.Lifecycle{
+				PreStop: &corev1.LifecycleHandler{
+					Exec: &corev1.ExecAction{Command: []string{"rm", "$(workspaces.myws.path)/ready"}},
+				},
+			},
+		}}},
+		decls: []v1.WorkspaceDeclaration{{
+			Name: "myws",
+		}},
+		binds: []v1.WorkspaceBinding{{
+			Name: "myws",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "foo",
+			},
+		}},
+		want: &v1.TaskSpec{Sidecars: []v1.Sidecar{{
+			Name:  "foo",
+			Image: "bar",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "ws-b31db",
+				MountPath: "/workspace/myws",
+				SubPath:   "foo",
+			}},
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "foo"},
+				},
+			}},
+			ReadinessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					Exec: &corev1.ExecAction{Command: []string{"test", "-f", "/workspace/myws/ready"}},
+				},
+			},
+			Lifecycle: &corev1.Lifecycle{
+				PreStop: &corev1.LifecycleHandler{
+					Exec: &corev1.ExecAction{Command: []string{"rm", "/workspace/myws/ready"}},
+				},
+			},
+		}}},
 	}, {
 		name: "optional-workspace-provided-variable-replacement",
 		spec: &v1.TaskSpec{Steps: []v1.Step{{
@@ -2464,10 +2574,49 @@ func TestApplyWorkspaces(t *testing.T) {
 		want: &v1.TaskSpec{Steps: []v1.Step{{
 			Script: `test "false" = "true" && echo ""`,
 		}}},
+	}, {
+		name: "workspace-size-replacement",
+		spec: &v1.TaskSpec{Steps: []v1.Step{{
+			Script: `echo "$(workspaces.pvc.size) $(workspaces.vct.size) $(workspaces.empty.size) $(workspaces.ows.size)"`,
+		}}},
+		decls: []v1.WorkspaceDeclaration{{
+			Name: "pvc",
+		}, {
+			Name: "vct",
+		}, {
+			Name: "empty",
+		}, {
+			Name:     "ows",
+			Optional: true,
+		}},
+		binds: []v1.WorkspaceBinding{{
+			Name: "pvc",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "foo",
+			},
+		}, {
+			Name: "vct",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("500Mi"),
+						},
+					},
+				},
+			},
+		}, {
+			Name:     "empty",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+		pvcSizes: map[string]string{"pvc": "10Gi"},
+		want: &v1.TaskSpec{Steps: []v1.Step{{
+			Script: `echo "10Gi 500Mi 0 0"`,
+		}}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			vols := workspace.CreateVolumes(tc.binds)
-			got := resources.ApplyWorkspaces(t.Context(), tc.spec, tc.decls, tc.binds, vols)
+			got := resources.ApplyWorkspaces(t.Context(), tc.spec, tc.decls, tc.binds, vols, tc.pvcSizes)
 			if d := cmp.Diff(tc.want, got); d != "" {
 				t.Errorf("TestApplyWorkspaces() got diff %s", diff.PrintWantGot(d))
 			}
@@ -2536,7 +2685,7 @@ func TestApplyWorkspaces_IsolatedWorkspaces(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := t.Context()
 			vols := workspace.CreateVolumes(tc.binds)
-			got := resources.ApplyWorkspaces(ctx, tc.spec, tc.decls, tc.binds, vols)
+			got := resources.ApplyWorkspaces(ctx, tc.spec, tc.decls, tc.binds, vols, nil)
 			if d := cmp.Diff(tc.want, got); d != "" {
 				t.Errorf("TestApplyWorkspaces() got diff %s", diff.PrintWantGot(d))
 			}
@@ -2695,6 +2844,37 @@ func TestContext(t *testing.T) {
 				Image: "UID-1",
 			}},
 		},
+	}, {
+		description: "context labels and annotations replacement",
+		taskName:    "Task1",
+		tr: v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"app": "my-app", "tekton.dev/pipeline": "my-pipeline"},
+				Annotations: map[string]string{"example.com/commit-sha": "abc123"},
+			},
+		},
+		spec: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:  "ImageName",
+				Image: "$(context.taskRun.labels.app)",
+				Args: []string{
+					"$(context.taskRun.labels['tekton.dev/pipeline'])",
+					`$(context.taskRun.annotations["example.com/commit-sha"])`,
+					"$(context.taskRun.labels.missing)",
+				},
+			}},
+		},
+		want: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:  "ImageName",
+				Image: "my-app",
+				Args: []string{
+					"my-pipeline",
+					"abc123",
+					"$(context.taskRun.labels.missing)",
+				},
+			}},
+		},
 	}, {
 		description: "context retry count replacement",
 		tr: v1.TaskRun{
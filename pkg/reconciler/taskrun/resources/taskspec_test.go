@@ -1548,6 +1548,56 @@ func TestGetStepActionsData(t *testing.T) {
 			Image: "myimage",
 			Args:  []string{"taskrun string param", "taskspec", "array", "taskspec", "array", "param", "taskrun key", "taskspec key2", "step action key3"},
 		}},
+	}, {
+		name: "whole object param passed to stepaction merged with stepaction default",
+		tr: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mytaskrun",
+				Namespace: "default",
+			},
+			Spec: v1.TaskRunSpec{
+				Params: v1.Params{{
+					Name:  "gitrepo",
+					Value: *v1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+				}},
+				TaskSpec: &v1.TaskSpec{
+					Params: v1.ParamSpecs{{
+						Name:       "gitrepo",
+						Type:       v1.ParamTypeObject,
+						Properties: map[string]v1.PropertySpec{"url": {Type: "string"}},
+					}},
+					Steps: []v1.Step{{
+						Ref: &v1.Ref{
+							Name: "stepAction",
+						},
+						Params: v1.Params{{
+							Name:  "repo",
+							Value: *v1.NewStructuredValues("$(params.gitrepo)"),
+						}},
+					}},
+				},
+			},
+		},
+		stepActions: []*v1beta1.StepAction{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "stepAction",
+				Namespace: "default",
+			},
+			Spec: v1beta1.StepActionSpec{
+				Image: "myimage",
+				Args:  []string{"$(params.repo.url)", "$(params.repo.revision)"},
+				Params: v1.ParamSpecs{{
+					Name:       "repo",
+					Type:       v1.ParamTypeObject,
+					Properties: map[string]v1.PropertySpec{"url": {Type: "string"}, "revision": {Type: "string"}},
+					Default:    v1.NewObject(map[string]string{"revision": "main"}),
+				}},
+			},
+		}},
+		want: []v1.Step{{
+			Image: "myimage",
+			Args:  []string{"https://github.com/tektoncd/pipeline", "main"},
+		}},
 	}, {
 		name: "params in step propagated to stepaction only",
 		tr: &v1.TaskRun{
@@ -2058,6 +2108,89 @@ func TestGetStepActionsData_Error(t *testing.T) {
 			},
 		},
 		expectedError: errors.New(`failed to resolve step ref for step "" (index 0): parameter "param1" references non-existent parameter "nonexistent"`),
+	}, {
+		name: "whole object param passed to stepaction with missing keys",
+		tr: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mytaskrun",
+				Namespace: "default",
+			},
+			Spec: v1.TaskRunSpec{
+				Params: v1.Params{{
+					Name:  "gitrepo",
+					Value: *v1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+				}},
+				TaskSpec: &v1.TaskSpec{
+					Params: v1.ParamSpecs{{
+						Name:       "gitrepo",
+						Type:       v1.ParamTypeObject,
+						Properties: map[string]v1.PropertySpec{"url": {Type: "string"}},
+					}},
+					Steps: []v1.Step{{
+						Ref: &v1.Ref{
+							Name: "stepAction",
+						},
+						Params: v1.Params{{
+							Name:  "repo",
+							Value: *v1.NewStructuredValues("$(params.gitrepo[*])"),
+						}},
+					}},
+				},
+			},
+		},
+		stepAction: &v1beta1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "stepAction",
+				Namespace: "default",
+			},
+			Spec: v1beta1.StepActionSpec{
+				Image: "myimage",
+				Args:  []string{"$(params.repo.url)", "$(params.repo.revision)"},
+				Params: v1.ParamSpecs{{
+					Name:       "repo",
+					Type:       v1.ParamTypeObject,
+					Properties: map[string]v1.PropertySpec{"url": {Type: "string"}, "revision": {Type: "string"}},
+				}},
+			},
+		},
+		expectedError: errors.New(`failed to resolve step ref for step "" (index 0): object param "repo" of StepAction is missing keys: [revision]`),
+	}, {
+		name: "array param passed to object stepaction param",
+		tr: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mytaskrun",
+				Namespace: "default",
+			},
+			Spec: v1.TaskRunSpec{
+				TaskSpec: &v1.TaskSpec{
+					Steps: []v1.Step{{
+						Ref: &v1.Ref{
+							Name: "stepAction",
+						},
+						Params: v1.Params{{
+							Name:  "repo",
+							Value: *v1.NewStructuredValues("a", "b"),
+						}},
+					}},
+				},
+			},
+		},
+		stepAction: &v1beta1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "stepAction",
+				Namespace: "default",
+			},
+			Spec: v1beta1.StepActionSpec{
+				Image: "myimage",
+				Args:  []string{"$(params.repo.url)"},
+				Params: v1.ParamSpecs{{
+					Name:       "repo",
+					Type:       v1.ParamTypeObject,
+					Properties: map[string]v1.PropertySpec{"url": {Type: "string"}},
+				}},
+			},
+		},
+		expectedError: errors.New(`failed to resolve step ref for step "" (index 0): object param "repo" of StepAction was passed an array value`),
 	}, {
 		name: "circular dependency in params",
 		tr: &v1.TaskRun{
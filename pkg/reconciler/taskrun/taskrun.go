@@ -18,10 +18,14 @@ package taskrun
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand/v2"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,6 +44,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/internal/computeresources"
 	"github.com/tektoncd/pipeline/pkg/internal/defaultresourcerequirements"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
+	"github.com/tektoncd/pipeline/pkg/internal/topologyaffinity"
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/apiserver"
@@ -60,6 +65,7 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -87,6 +93,7 @@ type Reconciler struct {
 	spireClient              spire.ControllerAPIClient
 	taskRunLister            listers.TaskRunLister
 	limitrangeLister         corev1Listers.LimitRangeLister
+	configMapLister          corev1Listers.ConfigMapLister
 	podLister                corev1Listers.PodLister
 	verificationPolicyLister alphalisters.VerificationPolicyLister
 	cloudEventClient         cloudevent.CEClient
@@ -137,6 +144,21 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 	// Record the duration and count after the reconcile cycle.
 	defer c.durationAndCountMetrics(ctx, tr, before)
 
+	// If the TaskRun has completed and has been annotated to be restarted, archive
+	// its status so that it runs again from scratch below.
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableTaskRunRestart &&
+		tr.IsDone() && !tr.IsCancelled() && tr.Annotations[v1.TaskRunRestartAnnotation] == "true" {
+		logger.Infof("Restarting TaskRun %s", tr.GetNamespacedName())
+		restartTaskRun(tr)
+	}
+
+	// If the TaskRun is waiting for the backoff of its RetryStrategy before its
+	// next retry attempt, requeue it until then, so that the backoff does not
+	// count against the timeout of the attempt.
+	if wait := retryWait(tr, c.Clock.Now()); wait > 0 && !tr.IsCancelled() {
+		return controller.NewRequeueAfter(wait)
+	}
+
 	// If the TaskRun is just starting, this will also set the starttime,
 	// from which the timeout will immediately begin counting down.
 	if !tr.HasStarted() {
@@ -210,6 +232,13 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
 	}
 
+	// Check whether the Pod has been waiting to be scheduled for longer than the scheduling timeout
+	unschedulable, message, schedulingWaitTime := c.checkPodUnschedulable(tr)
+	if unschedulable {
+		err := c.failTaskRun(ctx, tr, v1.TaskRunReasonPodUnschedulable, message)
+		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
+	}
+
 	// prepare fetches all required resources, validates them together with the
 	// taskrun, runs API conversions. In case of error we update, emit events and return.
 	_, rtr, err := c.prepare(ctx, tr)
@@ -253,14 +282,53 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 		// In both cases, we should not requeue based on timeout. The reconciler will
 		// still be triggered appropriately by pod watch events when the TaskRun changes.
 		if timeout == config.NoTimeoutDuration {
+			if schedulingWaitTime > 0 {
+				return controller.NewRequeueAfter(schedulingWaitTime)
+			}
 			return nil
 		}
 		waitTime := timeout - elapsed
+		if schedulingWaitTime > 0 && schedulingWaitTime < waitTime {
+			waitTime = schedulingWaitTime
+		}
 		return controller.NewRequeueAfter(waitTime)
 	}
 	return nil
 }
 
+// checkPodUnschedulable reports whether the Pod of the TaskRun has not been scheduled to
+// a node within the TaskRun's scheduling timeout. If the Pod is still waiting to be
+// scheduled and the timeout has not elapsed yet, the remaining time is returned so the
+// TaskRun can be requeued for when it expires.
+func (c *Reconciler) checkPodUnschedulable(tr *v1.TaskRun) (bool, string, time.Duration) {
+	if tr.Spec.SchedulingTimeout == nil || tr.Status.PodName == "" {
+		return false, "", 0
+	}
+	pod, err := c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName)
+	if err != nil || pod.Status.Phase != corev1.PodPending || pod.Spec.NodeName != "" {
+		return false, "", 0
+	}
+	var scheduledCondition *corev1.PodCondition
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == corev1.PodScheduled {
+			scheduledCondition = &pod.Status.Conditions[i]
+			break
+		}
+	}
+	if scheduledCondition != nil && scheduledCondition.Status == corev1.ConditionTrue {
+		return false, "", 0
+	}
+	remaining := tr.Spec.SchedulingTimeout.Duration - c.Clock.Since(pod.CreationTimestamp.Time)
+	if remaining > 0 {
+		return false, "", remaining
+	}
+	message := fmt.Sprintf("the pod %q of TaskRun %q was not scheduled within %q", pod.Name, tr.Name, tr.Spec.SchedulingTimeout.Duration)
+	if scheduledCondition != nil && scheduledCondition.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, scheduledCondition.Message)
+	}
+	return true, message, 0
+}
+
 func (c *Reconciler) checkPodFailed(ctx context.Context, tr *v1.TaskRun) (bool, v1.TaskRunReason, string) {
 	for _, step := range tr.Status.Steps {
 		if step.Waiting == nil {
@@ -420,7 +488,7 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1
 
 	afterCondition := tr.Status.GetCondition(apis.ConditionSucceeded)
 	if afterCondition.IsFalse() && !tr.IsCancelled() && tr.IsRetriable() {
-		retryTaskRun(tr, afterCondition.Message)
+		retryTaskRun(tr, afterCondition.Message, c.Clock.Now())
 		afterCondition = tr.Status.GetCondition(apis.ConditionSucceeded)
 	}
 	// Send k8s events and cloud events (when configured)
@@ -519,6 +587,14 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 	}
 
 	if taskMeta.VerificationResult != nil {
+		if config.FromContextOrDefaults(ctx).FeatureFlags.EnableProvenanceInStatus {
+			if rv := taskMeta.VerificationResult.ResourceVerification(taskMeta.Name, string(resources.GetTaskKind(tr)), ""); rv != nil {
+				if tr.Status.Provenance == nil {
+					tr.Status.Provenance = &v1.Provenance{}
+				}
+				tr.Status.Provenance.SetVerification(*rv)
+			}
+		}
 		switch taskMeta.VerificationResult.VerificationResultType {
 		case trustedresources.VerificationError:
 			logger.Errorf("TaskRun %s/%s referred task failed signature verification", tr.Namespace, tr.Name)
@@ -668,8 +744,23 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 		}
 	}
 
+	// If the Pod was disrupted, e.g. evicted, recreate it rather than failing the TaskRun, as long
+	// as the disruption retry budget of this attempt allows.
+	if pod != nil && config.FromContextOrDefaults(ctx).FeatureFlags.EnablePodDisruptionRescheduling &&
+		len(tr.Status.PodDisruptions) < config.FromContextOrDefaults(ctx).Defaults.DefaultMaxPodDisruptionRetries {
+		if disrupted, reason, message := podconvert.IsPodDisrupted(pod); disrupted {
+			if err := c.reschedulePod(ctx, tr, pod, reason, message); err != nil {
+				logger.Errorf("Error rescheduling disrupted pod %q: %v", pod.Name, err)
+				return err
+			}
+			pod = nil
+		}
+	}
+
 	// Please note that this block is required to run before `applyParamsContextsResultsAndWorkspaces` is called the first time,
 	// and that `applyParamsContextsResultsAndWorkspaces` _must_ be called on every reconcile.
+	// This is used by the volumeClaimTemplates and createPod below. Changes to the Spec are not updated.
+	tr.Spec.Workspaces = applySnapshotWorkspaces(tr.Spec.Workspaces)
 	if pod == nil && tr.HasVolumeClaimTemplate() {
 		for _, ws := range tr.Spec.Workspaces {
 			if err := c.pvcHandler.CreatePVCFromVolumeClaimTemplate(ctx, ws, *kmeta.NewControllerRef(tr), tr.Namespace); err != nil {
@@ -687,10 +778,38 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 	}
 
 	resources.ApplyParametersToWorkspaceBindings(rtr.TaskSpec, tr)
+	if err := workspace.ValidateEphemeralBindings(tr.Spec.Workspaces); err != nil {
+		logger.Errorf("TaskRun %q workspaces are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(v1.TaskRunReasonFailedValidation, err)
+		return controller.NewPermanentError(err)
+	}
+	// The cache keys can only be resolved once the parameters have been applied to the workspace bindings.
+	if pod == nil {
+		for _, ws := range tr.Spec.Workspaces {
+			if ws.Cache == nil {
+				continue
+			}
+			if err := c.pvcHandler.EnsureCachePVC(ctx, ws, tr.Namespace); err != nil {
+				logger.Errorf("Failed to create cache PVC for TaskRun %s: %v", tr.Name, err)
+				tr.Status.MarkResourceFailed(volumeclaim.ReasonCouldntCreateWorkspacePVC,
+					fmt.Errorf("failed to create cache PVC for TaskRun %s workspaces correctly: %w",
+						fmt.Sprintf("%s/%s", tr.Namespace, tr.Name), err))
+				return controller.NewPermanentError(err)
+			}
+		}
+	}
+	// This is used by createPod below. Changes to the Spec are not updated.
+	tr.Spec.Workspaces = applyCacheWorkspaces(tr.Spec.Workspaces)
 	// Get the randomized volume names assigned to workspace bindings
 	workspaceVolumes := workspace.CreateVolumes(tr.Spec.Workspaces)
 
-	ts, err := applyParamsContextsResultsAndWorkspaces(ctx, tr, rtr, workspaceVolumes)
+	workspacePVCSizes, err := c.getWorkspacePVCSizes(ctx, rtr.TaskSpec, tr)
+	if err != nil {
+		logger.Errorf("Error getting the size of the workspace PersistentVolumeClaims: %v", err)
+		return err
+	}
+
+	ts, err := applyParamsContextsResultsAndWorkspaces(ctx, tr, rtr, workspaceVolumes, workspacePVCSizes)
 	if err != nil {
 		logger.Errorf("Error updating task spec parameters, contexts, results and workspaces: %s", err)
 		return err
@@ -702,6 +821,10 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 	}
 
 	if pod == nil {
+		if err := c.createHermeticNetworkPolicy(ctx, tr); err != nil {
+			logger.Errorf("Failed to create the NetworkPolicy of hermetic taskrun %q: %v", tr.Name, err)
+			return err
+		}
 		pod, err = c.createPod(ctx, ts, tr, rtr, workspaceVolumes)
 		if err != nil {
 			newErr := c.handlePodCreationError(tr, err)
@@ -724,10 +847,16 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 	}
 
 	// Convert the Pod's status to the equivalent TaskRun Status.
+	beforeSteps := make([]v1.StepState, len(tr.Status.Steps))
+	for i := range tr.Status.Steps {
+		tr.Status.Steps[i].DeepCopyInto(&beforeSteps[i])
+	}
 	tr.Status, err = podconvert.MakeTaskRunStatus(ctx, logger, *tr, pod, c.KubeClientSet, rtr.TaskSpec)
 	if err != nil {
 		return err
 	}
+	cloudevent.EmitStepCloudEvents(ctx, beforeSteps, tr)
+	c.updateWorkspaceConditions(ctx, tr, pod)
 
 	if err := validateTaskRunResults(tr, rtr.TaskSpec); err != nil {
 		tr.Status.MarkResourceFailed(v1.TaskRunReasonFailedValidation, err)
@@ -738,6 +867,128 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 	return nil
 }
 
+// updateWorkspaceConditions explains why the pod of the TaskRun is pending, if it waits for its Affinity Assistant
+// or for the PersistentVolumeClaims of its workspaces, in the AffinityAssistantReady and WorkspacePVCBound
+// conditions. The conditions are only set once the pod waits, and marked as true when it no longer does.
+func (c *Reconciler) updateWorkspaceConditions(ctx context.Context, tr *v1.TaskRun, pod *corev1.Pod) {
+	pending := pod.Status.Phase == corev1.PodPending
+	if aaName := tr.Annotations[workspace.AnnotationAffinityAssistantName]; aaName != "" {
+		message := ""
+		if pending {
+			message = c.affinityAssistantNotReadyMessage(ctx, tr.Namespace, aaName)
+		}
+		if message != "" {
+			setWorkspaceCondition(ctx, tr, v1.TaskRunConditionAffinityAssistantReady, corev1.ConditionFalse, v1.TaskRunReasonAffinityAssistantNotReady, message)
+		} else if cond := tr.Status.GetCondition(apis.ConditionType(v1.TaskRunConditionAffinityAssistantReady.String())); cond.IsFalse() {
+			setWorkspaceCondition(ctx, tr, v1.TaskRunConditionAffinityAssistantReady, corev1.ConditionTrue, v1.TaskRunReasonAffinityAssistantReady,
+				fmt.Sprintf("Affinity Assistant %q is ready", aaName))
+		}
+	}
+
+	var messages []string
+	if pending {
+		messages = c.workspacePVCsNotBoundMessages(ctx, tr)
+	}
+	if len(messages) > 0 {
+		setWorkspaceCondition(ctx, tr, v1.TaskRunConditionWorkspacePVCBound, corev1.ConditionFalse, v1.TaskRunReasonWorkspacePVCNotBound, strings.Join(messages, "; "))
+	} else if cond := tr.Status.GetCondition(apis.ConditionType(v1.TaskRunConditionWorkspacePVCBound.String())); cond.IsFalse() {
+		setWorkspaceCondition(ctx, tr, v1.TaskRunConditionWorkspacePVCBound, corev1.ConditionTrue, v1.TaskRunReasonWorkspacePVCBound,
+			"The PersistentVolumeClaims of the workspaces are bound")
+	}
+}
+
+// affinityAssistantNotReadyMessage returns why the pod of the Affinity Assistant aaName is not ready,
+// or an empty string if it is ready or its state could not be retrieved.
+func (c *Reconciler) affinityAssistantNotReadyMessage(ctx context.Context, namespace, aaName string) string {
+	podName := aaName + "-0"
+	aaPod, err := c.KubeClientSet.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+		return fmt.Sprintf("Affinity Assistant pod %q does not exist", podName)
+	case err != nil:
+		logging.FromContext(ctx).Warnf("Failed to get Affinity Assistant pod %q: %v", podName, err)
+		return ""
+	}
+	for _, cond := range aaPod.Status.Conditions {
+		if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+			return ""
+		}
+	}
+	for _, cond := range aaPod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse {
+			return fmt.Sprintf("Affinity Assistant pod %q is not scheduled: %s", podName, cond.Message)
+		}
+	}
+	return fmt.Sprintf("Affinity Assistant pod %q is not ready, its phase is %q", podName, aaPod.Status.Phase)
+}
+
+// workspacePVCsNotBoundMessages returns why each of the PersistentVolumeClaims of the workspaces
+// of the TaskRun that is not bound yet is not.
+func (c *Reconciler) workspacePVCsNotBoundMessages(ctx context.Context, tr *v1.TaskRun) []string {
+	var messages []string
+	for _, binding := range tr.Spec.Workspaces {
+		if binding.PersistentVolumeClaim == nil {
+			continue
+		}
+		claimName := binding.PersistentVolumeClaim.ClaimName
+		pvc, err := c.KubeClientSet.CoreV1().PersistentVolumeClaims(tr.Namespace).Get(ctx, claimName, metav1.GetOptions{})
+		switch {
+		case k8serrors.IsNotFound(err):
+			messages = append(messages, fmt.Sprintf("PersistentVolumeClaim %q of workspace %q does not exist", claimName, binding.Name))
+		case err != nil:
+			logging.FromContext(ctx).Warnf("Failed to get PersistentVolumeClaim %q of workspace %q: %v", claimName, binding.Name, err)
+		case pvc.Status.Phase != corev1.ClaimBound:
+			messages = append(messages, fmt.Sprintf("PersistentVolumeClaim %q of workspace %q is %s", claimName, binding.Name, pvc.Status.Phase))
+		}
+	}
+	return messages
+}
+
+// setWorkspaceCondition sets the condition of the TaskRun, and emits an event if it changed.
+func setWorkspaceCondition(ctx context.Context, tr *v1.TaskRun, conditionType v1.TaskRunConditionType, status corev1.ConditionStatus, reason v1.TaskRunReason, message string) {
+	t := apis.ConditionType(conditionType.String())
+	if before := tr.Status.GetCondition(t); before != nil && before.Status == status && before.Message == message {
+		return
+	}
+	tr.Status.SetCondition(&apis.Condition{
+		Type:    t,
+		Status:  status,
+		Reason:  reason.String(),
+		Message: message,
+	})
+	eventType := corev1.EventTypeNormal
+	if status == corev1.ConditionFalse {
+		eventType = corev1.EventTypeWarning
+	}
+	controller.GetEventRecorder(ctx).Event(tr, eventType, reason.String(), message)
+}
+
+// reschedulePod records the disruption of the Pod of the TaskRun and deletes the Pod, so that a
+// new one is created for the TaskRun. The PersistentVolumeClaims bound to its workspaces are kept,
+// so only the content of the ephemeral ones, e.g. emptyDir, is lost.
+func (c *Reconciler) reschedulePod(ctx context.Context, tr *v1.TaskRun, pod *corev1.Pod, reason, message string) error {
+	logger := logging.FromContext(ctx)
+	logger.Warnf("pod %q of TaskRun %q was disrupted (%s), recreating it: %s", pod.Name, tr.Name, reason, message)
+	if err := c.KubeClientSet.CoreV1().Pods(tr.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	tr.Status.PodDisruptions = append(tr.Status.PodDisruptions, v1.TaskRunPodDisruption{
+		PodName:  pod.Name,
+		NodeName: pod.Spec.NodeName,
+		Reason:   reason,
+		Message:  message,
+		Time:     metav1.Time{Time: c.Clock.Now()},
+	})
+	tr.Status.PodName = ""
+	tr.Status.Steps = nil
+	tr.Status.Sidecars = nil
+	tr.Status.Results = nil
+	tr.Status.Artifacts = nil
+	controller.GetEventRecorder(ctx).Eventf(tr, corev1.EventTypeWarning, podconvert.ReasonPodDisrupted,
+		"Pod %q was disrupted (%s) and is recreated: %s", pod.Name, reason, message)
+	return nil
+}
+
 func (c *Reconciler) updateTaskRunWithDefaultWorkspaces(ctx context.Context, tr *v1.TaskRun, taskSpec *v1.TaskSpec) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "updateTaskRunWithDefaultWorkspaces")
 	defer span.End()
@@ -794,7 +1045,8 @@ func (c *Reconciler) updateLabelsAndAnnotations(ctx context.Context, tr *v1.Task
 		// to deal with Patch (setting resourceVersion, and optimistic concurrency checks).
 		newTr = newTr.DeepCopy()
 		newTr.Labels = kmap.Union(newTr.Labels, tr.Labels)
-		newTr.Annotations = kmap.Union(kmap.ExcludeKeys(newTr.Annotations, tknreconciler.KubectlLastAppliedAnnotationKey), tr.Annotations)
+		// The restart annotation is removed from the TaskRun when it is restarted.
+		newTr.Annotations = kmap.Union(kmap.ExcludeKeys(newTr.Annotations, tknreconciler.KubectlLastAppliedAnnotationKey, v1.TaskRunRestartAnnotation), tr.Annotations)
 		return c.PipelineClientSet.TektonV1().TaskRuns(tr.Namespace).Update(ctx, newTr, metav1.UpdateOptions{})
 	}
 	return newTr, nil
@@ -818,6 +1070,13 @@ func (c *Reconciler) handlePodCreationError(tr *v1.TaskRun, err error) error {
 		tr.Status.MarkResourceOngoing(podconvert.ReasonPodPending, "tried to create pod, but it already exists")
 	case isPodAdmissionFailed(err):
 		tr.Status.MarkResourceFailed(podconvert.ReasonPodAdmissionFailed, err)
+	case errors.Is(err, podconvert.ErrUnsupportedImagePlatform):
+		err = controller.NewPermanentError(err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonUnsupportedImagePlatform, err)
+	case errors.Is(err, defaultresourcerequirements.ErrStepResourcesConfigMapUnavailable):
+		// The default step resources of the namespace couldn't be read, retry later.
+		tr.Status.StartTime = nil
+		tr.Status.MarkResourceOngoing(podconvert.ReasonPodPending, fmt.Sprint("failed to read the default step resources of the namespace: ", err))
 	default:
 		// The pod creation failed with unknown reason. The most likely
 		// reason is that something is wrong with the spec of the Task, that we could
@@ -941,6 +1200,20 @@ func terminateStepsInPod(tr *v1.TaskRun, taskRunReason v1.TaskRunReason) {
 	}
 }
 
+// createHermeticNetworkPolicy creates the NetworkPolicy isolating the pod of a hermetic TaskRun from the network,
+// if the "enforce-hermetic-network-policy" feature flag is enabled.
+func (c *Reconciler) createHermeticNetworkPolicy(ctx context.Context, tr *v1.TaskRun) error {
+	featureFlags := config.FromContextOrDefaults(ctx).FeatureFlags
+	if !featureFlags.EnforceHermeticNetworkPolicy || featureFlags.EnableAPIFields != config.AlphaAPIFields || !podconvert.IsHermetic(tr) {
+		return nil
+	}
+	np := podconvert.MakeHermeticNetworkPolicy(tr)
+	if _, err := c.KubeClientSet.NetworkingV1().NetworkPolicies(tr.Namespace).Create(ctx, np, metav1.CreateOptions{}); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
 // createPod creates a Pod based on the Task's configuration, with pvcName as a volumeMount
 // TODO(dibyom): Refactor resource setup/substitution logic to its own function in the resources package
 func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.TaskRun, rtr *resources.ResolvedTask, workspaceVolumes map[string]corev1.Volume) (*corev1.Pod, error) {
@@ -997,9 +1270,11 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.Task
 		EntrypointCache: c.entrypointCache,
 	}
 	pod, err := podbuilder.Build(ctx, tr, *ts,
+		defaultresourcerequirements.NewNamespaceTransformer(ctx, tr.Namespace, c.configMapLister),
 		defaultresourcerequirements.NewTransformer(ctx),
 		computeresources.NewTransformer(ctx, tr.Namespace, c.limitrangeLister),
 		affinityassistant.NewTransformer(ctx, tr.Annotations),
+		topologyaffinity.NewTransformer(ctx),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("translating TaskSpec to Pod: %w", err)
@@ -1053,8 +1328,37 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.Task
 	return pod, nil
 }
 
+// getWorkspacePVCSizes returns the storage size of the PersistentVolumeClaims bound to the TaskRun's workspaces,
+// keyed by workspace name. A claim is only looked up if the TaskSpec references its workspace's
+// $(workspaces.<name>.size) variable. The capacity of a bound claim is preferred over its requested storage.
+func (c *Reconciler) getWorkspacePVCSizes(ctx context.Context, ts *v1.TaskSpec, tr *v1.TaskRun) (map[string]string, error) {
+	sizes := map[string]string{}
+	if ts == nil {
+		return sizes, nil
+	}
+	spec, err := json.Marshal(ts)
+	if err != nil {
+		return nil, err
+	}
+	for _, binding := range tr.Spec.Workspaces {
+		if binding.PersistentVolumeClaim == nil || !strings.Contains(string(spec), fmt.Sprintf("workspaces.%s.size", binding.Name)) {
+			continue
+		}
+		pvc, err := c.KubeClientSet.CoreV1().PersistentVolumeClaims(tr.Namespace).Get(ctx, binding.PersistentVolumeClaim.ClaimName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PersistentVolumeClaim %q bound to workspace %q: %w", binding.PersistentVolumeClaim.ClaimName, binding.Name, err)
+		}
+		size := pvc.Status.Capacity[corev1.ResourceStorage]
+		if size.IsZero() {
+			size = pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		}
+		sizes[binding.Name] = size.String()
+	}
+	return sizes, nil
+}
+
 // applyParamsContextsResultsAndWorkspaces applies paramater, context, results and workspace substitutions to the TaskSpec.
-func applyParamsContextsResultsAndWorkspaces(ctx context.Context, tr *v1.TaskRun, rtr *resources.ResolvedTask, workspaceVolumes map[string]corev1.Volume) (*v1.TaskSpec, error) {
+func applyParamsContextsResultsAndWorkspaces(ctx context.Context, tr *v1.TaskRun, rtr *resources.ResolvedTask, workspaceVolumes map[string]corev1.Volume, workspacePVCSizes map[string]string) (*v1.TaskSpec, error) {
 	ts := rtr.TaskSpec.DeepCopy()
 	var defaults []v1.ParamSpec
 	if len(ts.Params) > 0 {
@@ -1093,7 +1397,7 @@ func applyParamsContextsResultsAndWorkspaces(ctx context.Context, tr *v1.TaskRun
 			ts.Workspaces = append(ts.Workspaces, v1.WorkspaceDeclaration{Name: trw.Name})
 		}
 	}
-	ts = resources.ApplyWorkspaces(ctx, ts, ts.Workspaces, tr.Spec.Workspaces, workspaceVolumes)
+	ts = resources.ApplyWorkspaces(ctx, ts, ts.Workspaces, tr.Spec.Workspaces, workspaceVolumes, workspacePVCSizes)
 
 	return ts, nil
 }
@@ -1169,6 +1473,62 @@ func applyVolumeClaimTemplates(workspaceBindings []v1.WorkspaceBinding, owner me
 	return taskRunWorkspaceBindings
 }
 
+// applySnapshotWorkspaces returns the WorkspaceBindings with the EmptyDirs to snapshot translated to
+// VolumeClaimTemplates. Their PersistentVolumeClaims are named after the TaskRun, so every retry of the
+// TaskRun mounts the same volume and finds the contents left by the previous attempt.
+func applySnapshotWorkspaces(workspaceBindings []v1.WorkspaceBinding) []v1.WorkspaceBinding {
+	taskRunWorkspaceBindings := make([]v1.WorkspaceBinding, 0, len(workspaceBindings))
+	for _, wb := range workspaceBindings {
+		if wb.Snapshot == nil || wb.EmptyDir == nil {
+			taskRunWorkspaceBindings = append(taskRunWorkspaceBindings, wb)
+			continue
+		}
+		size, err := resource.ParseQuantity(wb.Snapshot.Size)
+		if err != nil {
+			// The size is checked by the validation, keep the EmptyDir if it was bypassed.
+			taskRunWorkspaceBindings = append(taskRunWorkspaceBindings, wb)
+			continue
+		}
+		claim := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "snapshot"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+				},
+			},
+		}
+		if wb.Snapshot.StorageClassName != "" {
+			claim.Spec.StorageClassName = &wb.Snapshot.StorageClassName
+		}
+		taskRunWorkspaceBindings = append(taskRunWorkspaceBindings, v1.WorkspaceBinding{
+			Name:                wb.Name,
+			SubPath:             wb.SubPath,
+			VolumeClaimTemplate: claim,
+		})
+	}
+	return taskRunWorkspaceBindings
+}
+
+// applyCacheWorkspaces returns the WorkspaceBindings with the caches translated to their PersistentVolumeClaims
+func applyCacheWorkspaces(workspaceBindings []v1.WorkspaceBinding) []v1.WorkspaceBinding {
+	taskRunWorkspaceBindings := make([]v1.WorkspaceBinding, 0, len(workspaceBindings))
+	for _, wb := range workspaceBindings {
+		if wb.Cache == nil {
+			taskRunWorkspaceBindings = append(taskRunWorkspaceBindings, wb)
+			continue
+		}
+		taskRunWorkspaceBindings = append(taskRunWorkspaceBindings, v1.WorkspaceBinding{
+			Name:    wb.Name,
+			SubPath: wb.SubPath,
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: volumeclaim.CachePVCName(wb.Cache.Key),
+			},
+		})
+	}
+	return taskRunWorkspaceBindings
+}
+
 func storeTaskSpecAndMergeMeta(ctx context.Context, tr *v1.TaskRun, ts *v1.TaskSpec, meta *resolutionutil.ResolvedObjectMeta) error {
 	// Only store the TaskSpec once, if it has never been set before.
 	if tr.Status.TaskSpec == nil {
@@ -1251,8 +1611,38 @@ func isConcurrentModificationError(err error) bool {
 }
 
 // retryTaskRun archives taskRun.Status to taskRun.Status.RetriesStatus, and set
-// taskRun status to Unknown with Reason v1.TaskRunReasonToBeRetried.
-func retryTaskRun(tr *v1.TaskRun, message string) {
+// taskRun status to Unknown with Reason v1.TaskRunReasonToBeRetried. If the TaskRun
+// has a RetryStrategy, the time of the next retry attempt is set after its backoff.
+func retryTaskRun(tr *v1.TaskRun, message string, now time.Time) {
+	archiveTaskRunStatus(tr)
+	if tr.Spec.RetryStrategy != nil {
+		tr.Status.RetryTime = &metav1.Time{Time: now.Add(retryBackoff(tr.Spec.RetryStrategy, len(tr.Status.RetriesStatus)))}
+	}
+	taskRunCondSet := apis.NewBatchConditionSet()
+	taskRunCondSet.Manage(&tr.Status).MarkUnknown(apis.ConditionSucceeded, v1.TaskRunReasonToBeRetried.String(), message)
+}
+
+// restartTaskRun archives the status of a completed TaskRun to taskRun.Status.RetriesStatus,
+// so that it runs again in a pod named after its new attempt, removes its restart annotation
+// and bumps its restart generation label.
+func restartTaskRun(tr *v1.TaskRun) {
+	archiveTaskRunStatus(tr)
+	tr.Status.Conditions = nil
+	tr.Status.Steps = nil
+	tr.Status.Sidecars = nil
+	tr.Status.Artifacts = nil
+
+	delete(tr.Annotations, v1.TaskRunRestartAnnotation)
+	generation, _ := strconv.Atoi(tr.Labels[v1.TaskRunRestartGenerationLabel])
+	if tr.Labels == nil {
+		tr.Labels = map[string]string{}
+	}
+	tr.Labels[v1.TaskRunRestartGenerationLabel] = strconv.Itoa(generation + 1)
+}
+
+// archiveTaskRunStatus appends the status of the current attempt of the TaskRun to
+// taskRun.Status.RetriesStatus and unsets the fields which identify that attempt.
+func archiveTaskRunStatus(tr *v1.TaskRun) {
 	newStatus := tr.Status.DeepCopy()
 	newStatus.RetriesStatus = nil
 	tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, *newStatus)
@@ -1260,6 +1650,35 @@ func retryTaskRun(tr *v1.TaskRun, message string) {
 	tr.Status.CompletionTime = nil
 	tr.Status.PodName = ""
 	tr.Status.Results = nil
-	taskRunCondSet := apis.NewBatchConditionSet()
-	taskRunCondSet.Manage(&tr.Status).MarkUnknown(apis.ConditionSucceeded, v1.TaskRunReasonToBeRetried.String(), message)
+	tr.Status.RetryTime = nil
+	tr.Status.PodDisruptions = nil
+}
+
+// retryBackoff returns the delay before the given retry attempt, starting at 1: the backoff
+// of the RetryStrategy doubled for each previous attempt, capped to its max backoff and
+// randomized to between half of it and all of it if jitter is requested.
+func retryBackoff(rs *v1.RetryStrategy, attempt int) time.Duration {
+	backoff := rs.Backoff.Duration
+	for i := 1; i < attempt && backoff < math.MaxInt64/2; i++ {
+		if rs.MaxBackoff != nil && backoff >= rs.MaxBackoff.Duration {
+			break
+		}
+		backoff *= 2
+	}
+	if rs.MaxBackoff != nil && backoff > rs.MaxBackoff.Duration {
+		backoff = rs.MaxBackoff.Duration
+	}
+	if rs.Jitter && backoff > 1 {
+		backoff = backoff/2 + rand.N(backoff/2+1) //nolint:gosec // the jitter does not need to be cryptographically secure
+	}
+	return backoff
+}
+
+// retryWait returns how long the TaskRun still has to wait before its next retry attempt
+// starts, according to the RetryTime set when it was retried.
+func retryWait(tr *v1.TaskRun, now time.Time) time.Duration {
+	if tr.Status.StartTime != nil || tr.Status.RetryTime == nil {
+		return 0
+	}
+	return tr.Status.RetryTime.Sub(now)
 }
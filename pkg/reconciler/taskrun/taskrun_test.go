@@ -46,6 +46,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/internal/defaultresourcerequirements"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
@@ -1680,6 +1681,8 @@ status:
         enableProvenanceInStatus: true
         resultExtractionMethod: "termination-message"
         maxResultSize: 4096
+        maxFileResultSize: 104857600
+        entrypointLogFormat: "text"
         coschedule: "workspaces"
         disableInlineSpec: ""
   provenance:
@@ -1692,6 +1695,8 @@ status:
       enableProvenanceInStatus: true
       resultExtractionMethod: "termination-message"
       maxResultSize: 4096
+      maxFileResultSize: 104857600
+      entrypointLogFormat: "text"
       coschedule: "workspaces"
       disableInlineSpec: ""
 `, pipelineErrors.UserErrorLabel, pipelineErrors.UserErrorLabel))
@@ -1746,6 +1751,8 @@ status:
       enableProvenanceInStatus: true
       resultExtractionMethod: "termination-message"
       maxResultSize: 4096
+      maxFileResultSize: 104857600
+      entrypointLogFormat: "text"
       coschedule: "workspaces"
       disableInlineSpec: ""
 `)
@@ -3086,6 +3093,444 @@ status:
 	}
 }
 
+func TestReconcilePodUnschedulable(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		podAge        time.Duration
+		scheduled     corev1.ConditionStatus
+		wantFailed    bool
+		wantRequeueAt time.Duration
+	}{{
+		name:       "pod unschedulable beyond the scheduling timeout",
+		podAge:     10 * time.Minute,
+		scheduled:  corev1.ConditionFalse,
+		wantFailed: true,
+	}, {
+		name:          "pod unschedulable within the scheduling timeout",
+		podAge:        2 * time.Minute,
+		scheduled:     corev1.ConditionFalse,
+		wantRequeueAt: 3 * time.Minute,
+	}, {
+		name:          "pod scheduled",
+		podAge:        10 * time.Minute,
+		scheduled:     corev1.ConditionTrue,
+		wantRequeueAt: 60 * time.Minute,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-unschedulable
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+  schedulingTimeout: 5m
+status:
+  podName: test-taskrun-unschedulable-pod
+  conditions:
+  - status: Unknown
+    type: Succeeded
+`)
+			start := metav1.NewTime(now)
+			taskRun.Status.StartTime = &start
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-taskrun-unschedulable-pod",
+					Namespace:         "foo",
+					CreationTimestamp: metav1.NewTime(now.Add(-tc.podAge)),
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					Conditions: []corev1.PodCondition{{
+						Type:    corev1.PodScheduled,
+						Status:  tc.scheduled,
+						Reason:  corev1.PodReasonUnschedulable,
+						Message: "0/3 nodes are available: 3 Insufficient cpu.",
+					}},
+				},
+			}
+			d := test.Data{
+				TaskRuns: []*v1.TaskRun{taskRun},
+				Tasks:    []*v1.Task{simpleTask},
+				Pods:     []*corev1.Pod{pod},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data: map[string]string{
+						"enable-api-fields": config.AlphaAPIFields,
+					},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			reconciledTr, getErr := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if getErr != nil {
+				t.Fatalf("Failed to get reconciled TaskRun: %v", getErr)
+			}
+			condition := reconciledTr.Status.GetCondition(apis.ConditionSucceeded)
+
+			if tc.wantFailed {
+				if err != nil {
+					t.Fatalf("Unexpected error reconciling TaskRun: %v", err)
+				}
+				if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != v1.TaskRunReasonPodUnschedulable.String() {
+					t.Fatalf("Expected TaskRun to fail with reason %q, got condition %v", v1.TaskRunReasonPodUnschedulable, condition)
+				}
+				if !strings.Contains(condition.Message, "Insufficient cpu") {
+					t.Errorf("Expected message to contain the scheduler message, got: %q", condition.Message)
+				}
+				if _, err := testAssets.Clients.Kube.CoreV1().Pods(pod.Namespace).Get(testAssets.Ctx, pod.Name, metav1.GetOptions{}); !k8sapierrors.IsNotFound(err) {
+					t.Errorf("Expected pod %s to be deleted, got error: %v", pod.Name, err)
+				}
+				return
+			}
+
+			if condition != nil && condition.Status == corev1.ConditionFalse {
+				t.Fatalf("Expected TaskRun not to fail, got condition %v", condition)
+			}
+			if isRequeue, requeueDuration := controller.IsRequeueKey(err); !isRequeue {
+				t.Errorf("Expected requeue error, but got: %v", err)
+			} else if requeueDuration != tc.wantRequeueAt {
+				t.Errorf("Expected requeue after %s, got %s", tc.wantRequeueAt, requeueDuration)
+			}
+		})
+	}
+}
+
+func TestReconcileRetryBackoff(t *testing.T) {
+	toBeTimedOutTaskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-retry-backoff-timedout
+  namespace: foo
+spec:
+  retries: 2
+  retryStrategy:
+    backoff: 10s
+  timeout: "10s"
+  taskRef:
+    name: test-task
+status:
+  startTime: "2021-12-31T00:00:00Z"
+  conditions:
+  - reason: Running
+    status: Unknown
+    type: Succeeded
+`)
+	waitingTaskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-retry-backoff-waiting
+  namespace: foo
+spec:
+  retries: 2
+  retryStrategy:
+    backoff: 10s
+  taskRef:
+    name: test-task
+status:
+  retryTime: "2022-01-01T00:00:20Z"
+  conditions:
+  - reason: ToBeRetried
+    status: Unknown
+    type: Succeeded
+  retriesStatus:
+  - conditions:
+    - reason: TaskRunTimeout
+      status: "False"
+      type: Succeeded
+    startTime: "2021-12-31T00:00:00Z"
+    completionTime: "2022-01-01T00:00:00Z"
+`)
+	d := test.Data{
+		TaskRuns: []*v1.TaskRun{toBeTimedOutTaskRun, waitingTaskRun},
+		Tasks:    []*v1.Task{simpleTask},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+			Data: map[string]string{
+				"enable-api-fields": config.AlphaAPIFields,
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, "default", "foo")
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(toBeTimedOutTaskRun)); err != nil {
+		if ok, _ := controller.IsRequeueKey(err); !ok {
+			t.Fatalf("Unexpected error reconciling TaskRun: %v", err)
+		}
+	}
+	retried, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns("foo").Get(testAssets.Ctx, toBeTimedOutTaskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get reconciled TaskRun: %v", err)
+	}
+	if c := retried.Status.GetCondition(apis.ConditionSucceeded); c.Reason != v1.TaskRunReasonToBeRetried.String() {
+		t.Errorf("Expected TaskRun to be retried, got condition %v", c)
+	}
+	if want := now.Add(10 * time.Second); retried.Status.RetryTime == nil || !retried.Status.RetryTime.Time.Equal(want) {
+		t.Errorf("Expected retry time %s, got %v", want, retried.Status.RetryTime)
+	}
+
+	err = testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(waitingTaskRun))
+	if isRequeue, requeueDuration := controller.IsRequeueKey(err); !isRequeue {
+		t.Errorf("Expected requeue error, but got: %v", err)
+	} else if requeueDuration != 20*time.Second {
+		t.Errorf("Expected requeue after 20s, got %s", requeueDuration)
+	}
+	waiting, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns("foo").Get(testAssets.Ctx, waitingTaskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get reconciled TaskRun: %v", err)
+	}
+	if waiting.Status.StartTime != nil || waiting.Status.PodName != "" {
+		t.Errorf("Expected TaskRun not to start before its retry time, got start time %v and pod %q", waiting.Status.StartTime, waiting.Status.PodName)
+	}
+}
+
+func TestReconcileRestart(t *testing.T) {
+	taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-restart
+  namespace: foo
+  annotations:
+    experimental.tekton.dev/restart: "true"
+  labels:
+    experimental.tekton.dev/restart-generation: "1"
+spec:
+  taskRef:
+    name: test-task
+status:
+  startTime: "2021-12-31T23:58:59Z"
+  completionTime: "2021-12-31T23:59:59Z"
+  podName: test-taskrun-restart-pod
+  conditions:
+  - reason: Succeeded
+    status: "True"
+    type: Succeeded
+`)
+	d := test.Data{
+		TaskRuns: []*v1.TaskRun{taskRun},
+		Tasks:    []*v1.Task{simpleTask},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+			Data: map[string]string{
+				"enable-taskrun-restart": "true",
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, "default", taskRun.Namespace)
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		if ok, _ := controller.IsRequeueKey(err); !ok {
+			t.Fatalf("Unexpected error reconciling TaskRun: %v", err)
+		}
+	}
+	restarted, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get reconciled TaskRun: %v", err)
+	}
+	if c := restarted.Status.GetCondition(apis.ConditionSucceeded); !c.IsUnknown() {
+		t.Errorf("Expected restarted TaskRun to be running, got condition %v", c)
+	}
+	if len(restarted.Status.RetriesStatus) != 1 || !restarted.Status.RetriesStatus[0].GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Errorf("Expected the previous status to be archived, got %v", restarted.Status.RetriesStatus)
+	}
+	if want := "test-taskrun-restart-pod-retry1"; restarted.Status.PodName != want {
+		t.Errorf("Expected pod %q, got %q", want, restarted.Status.PodName)
+	}
+	if _, ok := restarted.Annotations[v1.TaskRunRestartAnnotation]; ok {
+		t.Errorf("Expected annotation %s to be removed, got %v", v1.TaskRunRestartAnnotation, restarted.Annotations)
+	}
+	if got := restarted.Labels[v1.TaskRunRestartGenerationLabel]; got != "2" {
+		t.Errorf("Expected restart generation 2, got %q", got)
+	}
+}
+
+func TestReconcileRestartDisabled(t *testing.T) {
+	taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-restart
+  namespace: foo
+  annotations:
+    experimental.tekton.dev/restart: "true"
+spec:
+  taskRef:
+    name: test-task
+status:
+  startTime: "2021-12-31T23:58:59Z"
+  completionTime: "2021-12-31T23:59:59Z"
+  conditions:
+  - reason: Succeeded
+    status: "True"
+    type: Succeeded
+`)
+	d := test.Data{
+		TaskRuns: []*v1.TaskRun{taskRun},
+		Tasks:    []*v1.Task{simpleTask},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, "default", taskRun.Namespace)
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error reconciling TaskRun: %v", err)
+	}
+	reconciled, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get reconciled TaskRun: %v", err)
+	}
+	if c := reconciled.Status.GetCondition(apis.ConditionSucceeded); !c.IsTrue() {
+		t.Errorf("Expected TaskRun not to be restarted, got condition %v", c)
+	}
+	if len(reconciled.Status.RetriesStatus) != 0 {
+		t.Errorf("Expected no archived status, got %v", reconciled.Status.RetriesStatus)
+	}
+}
+
+func TestReconcilePodDisrupted(t *testing.T) {
+	taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-disrupted
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+status:
+  startTime: "2021-12-31T23:59:59Z"
+  podName: test-taskrun-disrupted-pod
+  conditions:
+  - reason: Running
+    status: Unknown
+    type: Succeeded
+`)
+	evictedPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-disrupted-pod", Namespace: "foo"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		maxRetries      string
+		wantDisruptions int
+		wantPodName     string
+		wantFailed      bool
+	}{{
+		name:            "disrupted pod is recreated",
+		maxRetries:      "3",
+		wantDisruptions: 1,
+		wantPodName:     "test-taskrun-disrupted-pod-disruption1",
+	}, {
+		name:        "disruption retries exhausted",
+		maxRetries:  "0",
+		wantPodName: "test-taskrun-disrupted-pod",
+		wantFailed:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				TaskRuns: []*v1.TaskRun{taskRun},
+				Tasks:    []*v1.Task{simpleTask},
+				Pods:     []*corev1.Pod{evictedPod},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data: map[string]string{
+						"enable-pod-disruption-rescheduling": "true",
+					},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetDefaultsConfigName()},
+					Data: map[string]string{
+						"default-max-pod-disruption-retries": tc.maxRetries,
+					},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, "default", taskRun.Namespace)
+
+			if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+				if ok, _ := controller.IsRequeueKey(err); !ok {
+					t.Fatalf("Unexpected error reconciling TaskRun: %v", err)
+				}
+			}
+			reconciled, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get reconciled TaskRun: %v", err)
+			}
+			if c := reconciled.Status.GetCondition(apis.ConditionSucceeded); c.IsFalse() != tc.wantFailed {
+				t.Errorf("Expected TaskRun failed to be %t, got condition %v", tc.wantFailed, c)
+			}
+			if len(reconciled.Status.PodDisruptions) != tc.wantDisruptions {
+				t.Fatalf("Expected %d pod disruptions, got %v", tc.wantDisruptions, reconciled.Status.PodDisruptions)
+			}
+			if reconciled.Status.PodName != tc.wantPodName {
+				t.Errorf("Expected pod %q, got %q", tc.wantPodName, reconciled.Status.PodName)
+			}
+			if tc.wantDisruptions == 0 {
+				return
+			}
+			want := v1.TaskRunPodDisruption{
+				PodName:  "test-taskrun-disrupted-pod",
+				NodeName: "node-1",
+				Reason:   "Evicted",
+				Message:  "The node was low on resource: memory.",
+			}
+			if d := cmp.Diff(want, reconciled.Status.PodDisruptions[0], cmpopts.IgnoreFields(v1.TaskRunPodDisruption{}, "Time")); d != "" {
+				t.Errorf("Unexpected pod disruption: %s", diff.PrintWantGot(d))
+			}
+			if _, err := testAssets.Clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, evictedPod.Name, metav1.GetOptions{}); !k8sapierrors.IsNotFound(err) {
+				t.Errorf("Expected disrupted pod to be deleted, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		rs      v1.RetryStrategy
+		attempt int
+		want    time.Duration
+	}{{
+		name:    "first attempt",
+		rs:      v1.RetryStrategy{Backoff: metav1.Duration{Duration: 10 * time.Second}},
+		attempt: 1,
+		want:    10 * time.Second,
+	}, {
+		name:    "doubles for each attempt",
+		rs:      v1.RetryStrategy{Backoff: metav1.Duration{Duration: 10 * time.Second}},
+		attempt: 4,
+		want:    80 * time.Second,
+	}, {
+		name: "capped to max backoff",
+		rs: v1.RetryStrategy{
+			Backoff:    metav1.Duration{Duration: 10 * time.Second},
+			MaxBackoff: &metav1.Duration{Duration: time.Minute},
+		},
+		attempt: 4,
+		want:    time.Minute,
+	}, {
+		name:    "does not overflow",
+		rs:      v1.RetryStrategy{Backoff: metav1.Duration{Duration: time.Hour}},
+		attempt: 100,
+		want:    time.Hour << 21,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := retryBackoff(&tc.rs, tc.attempt); got != tc.want {
+				t.Errorf("retryBackoff() = %s, want %s", got, tc.want)
+			}
+			tc.rs.Jitter = true
+			if got := retryBackoff(&tc.rs, tc.attempt); got < tc.want/2 || got > tc.want {
+				t.Errorf("retryBackoff() with jitter = %s, want between %s and %s", got, tc.want/2, tc.want)
+			}
+		})
+	}
+}
+
 func TestReconcileTimeouts(t *testing.T) {
 	type testCase struct {
 		name           string
@@ -4111,6 +4556,7 @@ spec:
 		Clock:             testClock,
 		taskRunLister:     testAssets.Informers.TaskRun.Lister(),
 		limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
+		configMapLister:   testAssets.Informers.ConfigMap.Lister(),
 		cloudEventClient:  testAssets.Clients.CloudEvents,
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
@@ -4125,7 +4571,7 @@ spec:
 	}
 	ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
 	workspaceVolumes := workspace.CreateVolumes(taskRun.Spec.Workspaces)
-	taskSpec, err := applyParamsContextsResultsAndWorkspaces(ctx, taskRun, rtr, workspaceVolumes)
+	taskSpec, err := applyParamsContextsResultsAndWorkspaces(ctx, taskRun, rtr, workspaceVolumes, nil)
 	if err != nil {
 		t.Fatalf("update task spec threw error %v", err)
 	}
@@ -4219,6 +4665,7 @@ spec:
 		Clock:             testClock,
 		taskRunLister:     testAssets.Informers.TaskRun.Lister(),
 		limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
+		configMapLister:   testAssets.Informers.ConfigMap.Lister(),
 		cloudEventClient:  testAssets.Clients.CloudEvents,
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
@@ -4234,7 +4681,7 @@ spec:
 
 	workspaceVolumes := workspace.CreateVolumes(taskRun.Spec.Workspaces)
 	ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
-	taskSpec, err := applyParamsContextsResultsAndWorkspaces(ctx, taskRun, rtr, workspaceVolumes)
+	taskSpec, err := applyParamsContextsResultsAndWorkspaces(ctx, taskRun, rtr, workspaceVolumes, nil)
 	if err != nil {
 		t.Errorf("update task spec threw an error: %v", err)
 	}
@@ -4272,6 +4719,7 @@ status:
 		Clock:             testClock,
 		taskRunLister:     testAssets.Informers.TaskRun.Lister(),
 		limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
+		configMapLister:   testAssets.Informers.ConfigMap.Lister(),
 		cloudEventClient:  testAssets.Clients.CloudEvents,
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
@@ -4310,6 +4758,18 @@ status:
 			expectedType:   apis.ConditionSucceeded,
 			expectedStatus: corev1.ConditionFalse,
 			expectedReason: podconvert.ReasonPodCreationFailed,
+		}, {
+			description:    "unavailable default step resources do not fail the taskrun",
+			err:            fmt.Errorf("translating TaskSpec to Pod: %w: failed to get custom-ns/tekton-step-defaults: timeout", defaultresourcerequirements.ErrStepResourcesConfigMapUnavailable),
+			expectedType:   apis.ConditionSucceeded,
+			expectedStatus: corev1.ConditionUnknown,
+			expectedReason: podconvert.ReasonPodPending,
+		}, {
+			description:    "images not providing the targeted platform fail the taskrun",
+			err:            fmt.Errorf("translating TaskSpec to Pod: %w: image \"busybox\" only provides [linux/amd64], not \"arm64\"", podconvert.ErrUnsupportedImagePlatform),
+			expectedType:   apis.ConditionSucceeded,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: podconvert.ReasonUnsupportedImagePlatform,
 		}, {
 			description: "errors violating PodSecurity fail the taskrun",
 			err: k8sapierrors.NewForbidden(k8sruntimeschema.GroupResource{Group: "foo", Resource: "bar"}, "baz",
@@ -4455,6 +4915,7 @@ spec:
 				Clock:             testClock,
 				taskRunLister:     testAssets.Informers.TaskRun.Lister(),
 				limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
+				configMapLister:   testAssets.Informers.ConfigMap.Lister(),
 				cloudEventClient:  testAssets.Clients.CloudEvents,
 				metrics:           nil,
 				entrypointCache:   nil,
@@ -4482,7 +4943,7 @@ spec:
 			}
 
 			workspaceVolumes := workspace.CreateVolumes(tr.Spec.Workspaces)
-			taskSpec, err := applyParamsContextsResultsAndWorkspaces(testAssets.Ctx, tr, rtr, workspaceVolumes)
+			taskSpec, err := applyParamsContextsResultsAndWorkspaces(testAssets.Ctx, tr, rtr, workspaceVolumes, nil)
 			if err != nil {
 				t.Fatalf("update task spec threw error %v", err)
 			}
@@ -5109,6 +5570,397 @@ spec:
 	}
 }
 
+func TestReconcileWorkspaceWithSnapshot(t *testing.T) {
+	taskWithWorkspace := parse.MustParseV1Task(t, `
+metadata:
+  name: test-task-with-workspace
+  namespace: foo
+spec:
+  steps:
+  - command:
+    - /mycmd
+    image: foo
+    name: simple-step
+  workspaces:
+  - description: a test task workspace
+    name: ws1
+`)
+	taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-snapshot-workspace
+  namespace: foo
+spec:
+  retries: 2
+  taskRef:
+    apiVersion: v1
+    name: test-task-with-workspace
+  workspaces:
+  - name: ws1
+    emptyDir: {}
+    snapshot:
+      size: 1Gi
+      storageClassName: fast
+`)
+	d := test.Data{
+		Tasks:    []*v1.Task{taskWithWorkspace},
+		TaskRuns: []*v1.TaskRun{taskRun},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+			Data: map[string]string{
+				"enable-api-fields": config.AlphaAPIFields,
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+	createServiceAccount(t, testAssets, "default", "foo")
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err == nil {
+		t.Error("Wanted a wrapped requeue error, but got nil.")
+	} else if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+	}
+
+	ttt, err := clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	expectedPVCName := volumeclaim.GeneratePVCNameFromWorkspaceBinding("snapshot", ttt.Spec.Workspaces[0], *kmeta.NewControllerRef(ttt))
+	pvc, err := clients.Kube.CoreV1().PersistentVolumeClaims(taskRun.Namespace).Get(testAssets.Ctx, expectedPVCName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected snapshot PVC %s to exist but instead got error when getting it: %v", expectedPVCName, err)
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != "fast" {
+		t.Errorf("expected the snapshot PVC to use the storage class fast, got %v", pvc.Spec.StorageClassName)
+	}
+	pod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, ttt.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected Pod %s to exist but instead got error when getting it: %v", ttt.Status.PodName, err)
+	}
+	found := false
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == expectedPVCName {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the Pod to mount the snapshot PVC %s, got volumes %v", expectedPVCName, pod.Spec.Volumes)
+	}
+}
+
+func TestReconcileWorkspaceWithCache(t *testing.T) {
+	taskWithWorkspace := parse.MustParseV1Task(t, `
+metadata:
+  name: test-task-with-workspace
+  namespace: foo
+spec:
+  params:
+  - name: go-version
+    type: string
+  steps:
+  - command:
+    - /mycmd
+    image: foo
+    name: simple-step
+  workspaces:
+  - description: a test task workspace
+    name: ws1
+`)
+	taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-cache-workspace
+  namespace: foo
+spec:
+  params:
+  - name: go-version
+    value: "1.22"
+  taskRef:
+    apiVersion: v1
+    name: test-task-with-workspace
+  workspaces:
+  - name: ws1
+    cache:
+      key: go-$(params.go-version)
+      size: 1Gi
+`)
+	d := test.Data{
+		Tasks:    []*v1.Task{taskWithWorkspace},
+		TaskRuns: []*v1.TaskRun{taskRun},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+			Data: map[string]string{
+				"enable-api-fields": config.AlphaAPIFields,
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+	createServiceAccount(t, testAssets, "default", "foo")
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err == nil {
+		t.Error("Wanted a wrapped requeue error, but got nil.")
+	} else if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+	}
+
+	expectedPVCName := volumeclaim.CachePVCName("go-1.22")
+	if _, err := clients.Kube.CoreV1().PersistentVolumeClaims(taskRun.Namespace).Get(testAssets.Ctx, expectedPVCName, metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected cache PVC %s to exist but instead got error when getting it: %v", expectedPVCName, err)
+	}
+
+	ttt, err := clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	pod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, ttt.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected Pod %s to exist but instead got error when getting it: %v", ttt.Status.PodName, err)
+	}
+	found := false
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == expectedPVCName {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the Pod to mount the cache PVC %s, got volumes %v", expectedPVCName, pod.Spec.Volumes)
+	}
+}
+
+func TestReconcileHermeticNetworkPolicy(t *testing.T) {
+	task := parse.MustParseV1Task(t, `
+metadata:
+  name: test-task
+  namespace: foo
+spec:
+  steps:
+  - command:
+    - /mycmd
+    image: foo
+    name: simple-step
+`)
+	for _, tc := range []struct {
+		name      string
+		enforce   string
+		execution string
+		want      bool
+	}{{
+		name:      "hermetic TaskRun with the policy enforced",
+		enforce:   "true",
+		execution: "hermetic",
+		want:      true,
+	}, {
+		name:      "hermetic TaskRun without the policy enforced",
+		enforce:   "false",
+		execution: "hermetic",
+	}, {
+		name:    "non hermetic TaskRun with the policy enforced",
+		enforce: "true",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-hermetic
+  namespace: foo
+  uid: test-taskrun-hermetic-uid
+spec:
+  taskRef:
+    name: test-task
+`)
+			if tc.execution != "" {
+				taskRun.Annotations = map[string]string{podconvert.ExecutionModeAnnotation: tc.execution}
+			}
+			d := test.Data{
+				Tasks:    []*v1.Task{task},
+				TaskRuns: []*v1.TaskRun{taskRun},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data: map[string]string{
+						"enable-api-fields":               config.AlphaAPIFields,
+						"enforce-hermetic-network-policy": tc.enforce,
+					},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			clients := testAssets.Clients
+			createServiceAccount(t, testAssets, "default", "foo")
+
+			if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err == nil {
+				t.Error("Wanted a wrapped requeue error, but got nil.")
+			} else if ok, _ := controller.IsRequeueKey(err); !ok {
+				t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+			}
+
+			nps, err := clients.Kube.NetworkingV1().NetworkPolicies(taskRun.Namespace).List(testAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list the NetworkPolicies: %v", err)
+			}
+			if !tc.want {
+				if len(nps.Items) != 0 {
+					t.Errorf("expected no NetworkPolicy, got %v", nps.Items)
+				}
+				return
+			}
+			if len(nps.Items) != 1 {
+				t.Fatalf("expected 1 NetworkPolicy, got %v", nps.Items)
+			}
+			if d := cmp.Diff(podconvert.MakeHermeticNetworkPolicy(taskRun).Spec, nps.Items[0].Spec); d != "" {
+				t.Errorf("NetworkPolicy spec mismatch %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetWorkspacePVCSizes(t *testing.T) {
+	boundPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "bound-pvc", Namespace: "foo"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")},
+		},
+	}
+	pendingPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending-pvc", Namespace: "foo"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+	}
+	taskRun := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "foo"},
+		Spec: v1.TaskRunSpec{
+			Workspaces: []v1.WorkspaceBinding{{
+				Name:                  "bound",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "bound-pvc"},
+			}, {
+				Name:                  "pending",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pending-pvc"},
+			}, {
+				Name:                  "unreferenced",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "missing-pvc"},
+			}, {
+				Name:     "empty",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+	}
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Image:  "foo",
+			Script: "echo $(workspaces.bound.size) $(workspaces.pending.size) $(workspaces.empty.size)",
+		}},
+	}
+
+	c := &Reconciler{KubeClientSet: fakekubeclientset.NewSimpleClientset(boundPVC, pendingPVC)}
+	got, err := c.getWorkspacePVCSizes(t.Context(), ts, taskRun)
+	if err != nil {
+		t.Fatalf("getWorkspacePVCSizes() unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"bound":   "2Gi",
+		"pending": "5Gi",
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("getWorkspacePVCSizes() %s", diff.PrintWantGot(d))
+	}
+
+	ts.Steps[0].Script = "echo $(workspaces.unreferenced.size)"
+	if _, err := c.getWorkspacePVCSizes(t.Context(), ts, taskRun); err == nil {
+		t.Error("getWorkspacePVCSizes() expected an error for a missing PersistentVolumeClaim, got nil")
+	}
+}
+
+func TestUpdateWorkspaceConditions(t *testing.T) {
+	aaPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "affinity-assistant-0", Namespace: "foo"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Message: "0/3 nodes are available",
+			}},
+		},
+	}
+	pendingPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending-pvc", Namespace: "foo"},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+	}
+	taskRun := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tr",
+			Namespace:   "foo",
+			Annotations: map[string]string{workspace.AnnotationAffinityAssistantName: "affinity-assistant"},
+		},
+		Spec: v1.TaskRunSpec{
+			Workspaces: []v1.WorkspaceBinding{{
+				Name:                  "source",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pending-pvc"},
+			}, {
+				Name:     "empty",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+	}
+	pod := &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodPending}}
+
+	kubeClient := fakekubeclientset.NewSimpleClientset(aaPod, pendingPVC)
+	c := &Reconciler{KubeClientSet: kubeClient}
+	recorder := record.NewFakeRecorder(10)
+	ctx := controller.WithEventRecorder(t.Context(), recorder)
+
+	c.updateWorkspaceConditions(ctx, taskRun, pod)
+	for _, want := range []apis.Condition{{
+		Type:    apis.ConditionType(v1.TaskRunConditionAffinityAssistantReady.String()),
+		Status:  corev1.ConditionFalse,
+		Reason:  v1.TaskRunReasonAffinityAssistantNotReady.String(),
+		Message: `Affinity Assistant pod "affinity-assistant-0" is not scheduled: 0/3 nodes are available`,
+	}, {
+		Type:    apis.ConditionType(v1.TaskRunConditionWorkspacePVCBound.String()),
+		Status:  corev1.ConditionFalse,
+		Reason:  v1.TaskRunReasonWorkspacePVCNotBound.String(),
+		Message: `PersistentVolumeClaim "pending-pvc" of workspace "source" is Pending`,
+	}} {
+		if d := cmp.Diff(&want, taskRun.Status.GetCondition(want.Type), ignoreLastTransitionTime); d != "" {
+			t.Errorf("Unexpected %s condition %s", want.Type, diff.PrintWantGot(d))
+		}
+	}
+	if err := k8sevent.CheckEventsOrdered(t, recorder.Events, "pending", []string{
+		`Warning AffinityAssistantNotReady Affinity Assistant pod "affinity-assistant-0" is not scheduled: 0/3 nodes are available`,
+		`Warning WorkspacePVCNotBound PersistentVolumeClaim "pending-pvc" of workspace "source" is Pending`,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	// Reconciling again without any change must not emit the events again.
+	c.updateWorkspaceConditions(ctx, taskRun, pod)
+	if err := k8sevent.CheckEventsOrdered(t, recorder.Events, "unchanged", []string{}); err != nil {
+		t.Error(err)
+	}
+
+	pod.Status.Phase = corev1.PodRunning
+	c.updateWorkspaceConditions(ctx, taskRun, pod)
+	for _, conditionType := range []v1.TaskRunConditionType{v1.TaskRunConditionAffinityAssistantReady, v1.TaskRunConditionWorkspacePVCBound} {
+		if cond := taskRun.Status.GetCondition(apis.ConditionType(conditionType.String())); !cond.IsTrue() {
+			t.Errorf("Expected the %s condition to be true, got %v", conditionType, cond)
+		}
+	}
+	if err := k8sevent.CheckEventsOrdered(t, recorder.Events, "running", []string{
+		`Normal AffinityAssistantReady Affinity Assistant "affinity-assistant" is ready`,
+		"Normal WorkspacePVCBound The PersistentVolumeClaims of the workspaces are bound",
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestFailTaskRun(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -5527,6 +6379,7 @@ status:
 				Clock:             testClock,
 				taskRunLister:     testAssets.Informers.TaskRun.Lister(),
 				limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
+				configMapLister:   testAssets.Informers.ConfigMap.Lister(),
 				cloudEventClient:  testAssets.Clients.CloudEvents,
 				metrics:           nil, // Not used
 				entrypointCache:   nil, // Not used
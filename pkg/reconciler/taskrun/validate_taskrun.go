@@ -290,9 +290,59 @@ func validateTaskRunResults(tr *v1.TaskRun, resolvedTaskSpec *v1.TaskSpec) error
 	if missingKeysObjectNames := missingKeysofObjectResults(tr, specResults); len(missingKeysObjectNames) != 0 {
 		return pipelineErrors.WrapUserError(fmt.Errorf("missing keys for these results which are required in TaskResult's properties %v", missingKeysObjectNames))
 	}
+
+	// When get the results, check if they match the schemas of the results which declare one.
+	if err := validateResultsAgainstSchemas(tr, specResults, resolvedTaskSpec); err != nil {
+		return pipelineErrors.WrapUserError(fmt.Errorf("results don't match their schema: %w", err))
+	}
 	return nil
 }
 
+// validateResultsAgainstSchemas checks the emitted TaskRun and Step results against the schemas of the
+// results declared in spec.
+func validateResultsAgainstSchemas(tr *v1.TaskRun, specResults []v1.TaskResult, resolvedTaskSpec *v1.TaskSpec) error {
+	var errs []string
+	for _, trr := range tr.Status.Results {
+		for _, r := range specResults {
+			if r.Name != trr.Name {
+				continue
+			}
+			if err := r.ValidateValueAgainstSchema(trr.Value); err != nil {
+				errs = append(errs, err.Error())
+			}
+			break
+		}
+	}
+
+	taskSpec := resolvedTaskSpec
+	if taskSpec == nil {
+		taskSpec = tr.Spec.TaskSpec
+	}
+	if taskSpec != nil {
+		stepResults := make(map[string][]v1.StepResult)
+		for _, step := range taskSpec.Steps {
+			stepResults[step.Name] = step.Results
+		}
+		for _, step := range tr.Status.Steps {
+			for _, sr := range step.Results {
+				for _, r := range stepResults[step.Name] {
+					if r.Name != sr.Name {
+						continue
+					}
+					if err := r.ValidateValueAgainstSchema(sr.Value); err != nil {
+						errs = append(errs, fmt.Sprintf("step %q: %v", step.Name, err))
+					}
+					break
+				}
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(errs, "; "))
+}
+
 // mismatchedTypesResults checks and returns all the mismatched types of emitted results against specified results.
 func mismatchedTypesResults(tr *v1.TaskRun, specResults []v1.TaskResult) map[string]string {
 	neededTypes := make(map[string]string)
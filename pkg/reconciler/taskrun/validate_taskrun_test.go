@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -820,6 +821,90 @@ func TestValidateResult(t *testing.T) {
 			Results: []v1.TaskResult{},
 		},
 		wantErr: true,
+	}, {
+		name: "results matching their schema",
+		tr: &v1.TaskRun{
+			Status: v1.TaskRunStatus{
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					Results: []v1.TaskRunResult{{
+						Name:  "digest",
+						Type:  v1.ResultsTypeString,
+						Value: *v1.NewStructuredValues("sha256:abc"),
+					}},
+					Steps: []v1.StepState{{
+						Name: "build",
+						Results: []v1.TaskRunStepResult{{
+							Name:  "tags",
+							Type:  v1.ResultsTypeArray,
+							Value: *v1.NewStructuredValues("1.0", "latest"),
+						}},
+					}},
+				},
+			},
+		},
+		rtr: &v1.TaskSpec{
+			Results: []v1.TaskResult{{
+				Name:   "digest",
+				Type:   v1.ResultsTypeString,
+				Schema: &runtime.RawExtension{Raw: []byte(`{"pattern": "^sha256:"}`)},
+			}},
+			Steps: []v1.Step{{
+				Name: "build",
+				Results: []v1.StepResult{{
+					Name:   "tags",
+					Type:   v1.ResultsTypeArray,
+					Schema: &runtime.RawExtension{Raw: []byte(`{"maxItems": 2}`)},
+				}},
+			}},
+		},
+	}, {
+		name: "task result not matching its schema",
+		tr: &v1.TaskRun{
+			Status: v1.TaskRunStatus{
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					Results: []v1.TaskRunResult{{
+						Name:  "digest",
+						Type:  v1.ResultsTypeString,
+						Value: *v1.NewStructuredValues("md5:abc"),
+					}},
+				},
+			},
+		},
+		rtr: &v1.TaskSpec{
+			Results: []v1.TaskResult{{
+				Name:   "digest",
+				Type:   v1.ResultsTypeString,
+				Schema: &runtime.RawExtension{Raw: []byte(`{"pattern": "^sha256:"}`)},
+			}},
+		},
+		wantErr: true,
+	}, {
+		name: "step result not matching its schema",
+		tr: &v1.TaskRun{
+			Status: v1.TaskRunStatus{
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					Steps: []v1.StepState{{
+						Name: "build",
+						Results: []v1.TaskRunStepResult{{
+							Name:  "tags",
+							Type:  v1.ResultsTypeArray,
+							Value: *v1.NewStructuredValues("1.0", "1", "latest"),
+						}},
+					}},
+				},
+			},
+		},
+		rtr: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name: "build",
+				Results: []v1.StepResult{{
+					Name:   "tags",
+					Type:   v1.ResultsTypeArray,
+					Schema: &runtime.RawExtension{Raw: []byte(`{"maxItems": 2}`)},
+				}},
+			}},
+		},
+		wantErr: true,
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
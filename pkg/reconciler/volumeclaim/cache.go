@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumeclaim
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// CacheLabelKey is the label set on the PersistentVolumeClaims backing cache workspaces.
+	CacheLabelKey = "tekton.dev/cache"
+	// CacheKeyAnnotationKey is the annotation recording the cache key of a cache PersistentVolumeClaim.
+	CacheKeyAnnotationKey = "tekton.dev/cache-key"
+	// CacheLastUsedAnnotationKey is the annotation recording when a cache PersistentVolumeClaim was last used.
+	CacheLastUsedAnnotationKey = "tekton.dev/cache-last-used"
+	// CacheTTLAnnotationKey is the annotation recording how long a cache PersistentVolumeClaim is kept
+	// after it was last used.
+	CacheTTLAnnotationKey = "tekton.dev/cache-ttl"
+
+	// DefaultCacheTTL is how long a cache PersistentVolumeClaim is kept after it was last used,
+	// unless the workspace binding specifies a TTL.
+	DefaultCacheTTL = 7 * 24 * time.Hour
+)
+
+// CachePVCName returns the name of the PersistentVolumeClaim backing the cache with the given key.
+func CachePVCName(key string) string {
+	hashBytes := sha256.Sum256([]byte(key))
+	return "tekton-cache-" + hex.EncodeToString(hashBytes[:])[:16]
+}
+
+// EnsureCachePVC creates the PersistentVolumeClaim backing the cache workspace wb, unless it already
+// exists, and records that it is being used. The cache PersistentVolumeClaims of the namespace which
+// have not been used for their TTL are then deleted.
+func (c *defaultPVCHandler) EnsureCachePVC(ctx context.Context, wb v1.WorkspaceBinding, namespace string) error {
+	if wb.Cache == nil {
+		return nil
+	}
+	now := c.now()
+	claim, err := getPVCFromCache(*wb.Cache, namespace, now)
+	if err != nil {
+		return fmt.Errorf("%w for cache workspace %s: %v", ErrPvcCreationFailed, wb.Name, err.Error())
+	}
+
+	_, err = c.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claim.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if _, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, claim, metav1.CreateOptions{}); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				if isRetryableError(err) {
+					return fmt.Errorf("%w for %s: %v", ErrPvcCreationFailedRetryable, claim.Name, err.Error())
+				}
+				return fmt.Errorf("%w for %s: %v", ErrPvcCreationFailed, claim.Name, err.Error())
+			}
+		} else {
+			c.logger.Infof("Created cache PersistentVolumeClaim %s in namespace %s", claim.Name, namespace)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to retrieve PVC %s: %w", claim.Name, err)
+	default:
+		patch, err := json.Marshal(map[string]any{
+			"metadata": map[string]any{
+				"annotations": map[string]string{CacheLastUsedAnnotationKey: now.Format(time.RFC3339)},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Patch(ctx, claim.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			return fmt.Errorf("failed to patch the PVC %s: %w", claim.Name, err)
+		}
+	}
+
+	// Eviction is best effort, the next run using a cache of the namespace tries again.
+	if err := c.deleteExpiredCachePVCs(ctx, namespace, claim.Name, now); err != nil {
+		c.logger.Warnf("Failed to delete the expired cache PersistentVolumeClaims in namespace %s: %v", namespace, err)
+	}
+	return nil
+}
+
+// deleteExpiredCachePVCs deletes the cache PersistentVolumeClaims of the namespace, other than inUse, which
+// have not been used for their TTL. Those still mounted by a Pod are only removed once it is done with them.
+func (c *defaultPVCHandler) deleteExpiredCachePVCs(ctx context.Context, namespace, inUse string, now time.Time) error {
+	selector := labels.Set{CacheLabelKey: "true"}.AsSelector().String()
+	pvcs, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, pvc := range pvcs.Items {
+		if pvc.Name == inUse || pvc.DeletionTimestamp != nil {
+			continue
+		}
+		lastUsed, err := time.Parse(time.RFC3339, pvc.Annotations[CacheLastUsedAnnotationKey])
+		if err != nil {
+			continue
+		}
+		ttl, err := time.ParseDuration(pvc.Annotations[CacheTTLAnnotationKey])
+		if err != nil {
+			ttl = DefaultCacheTTL
+		}
+		if now.Sub(lastUsed) < ttl {
+			continue
+		}
+		if err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the PVC %s: %w", pvc.Name, err)
+		}
+		c.logger.Infof("Deleted cache PersistentVolumeClaim %s in namespace %s, unused since %s", pvc.Name, namespace, lastUsed)
+	}
+	return nil
+}
+
+// getPVCFromCache returns the PersistentVolumeClaim backing the cache workspace source.
+func getPVCFromCache(cache v1.CacheWorkspaceSource, namespace string, now time.Time) (*corev1.PersistentVolumeClaim, error) {
+	size, err := resource.ParseQuantity(cache.Size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid size %q: %w", cache.Size, err)
+	}
+	if size.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid size %q: must be a positive quantity", cache.Size)
+	}
+	accessModes := cache.AccessModes
+	if len(accessModes) == 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+	ttl := DefaultCacheTTL
+	if cache.TTL != nil {
+		ttl = cache.TTL.Duration
+	}
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CachePVCName(cache.Key),
+			Namespace: namespace,
+			Labels:    map[string]string{CacheLabelKey: "true"},
+			Annotations: map[string]string{
+				CacheKeyAnnotationKey:      cache.Key,
+				CacheLastUsedAnnotationKey: now.Format(time.RFC3339),
+				CacheTTLAnnotationKey:      ttl.String(),
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: accessModes,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+	if cache.StorageClassName != "" {
+		storageClassName := cache.StorageClassName
+		claim.Spec.StorageClassName = &storageClassName
+	}
+	return claim, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumeclaim
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEnsureCachePVC_Create(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	fakekubeclient := fakek8s.NewSimpleClientset()
+	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar(), func() time.Time { return now }}
+	wb := v1.WorkspaceBinding{
+		Name: "cache",
+		Cache: &v1.CacheWorkspaceSource{
+			Key:              "go-1.22",
+			Size:             "5Gi",
+			StorageClassName: "fast",
+			TTL:              &metav1.Duration{Duration: time.Hour},
+		},
+	}
+
+	if err := pvcHandler.EnsureCachePVC(t.Context(), wb, "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(t.Context(), CachePVCName("go-1.22"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the cache PVC to be created: %v", err)
+	}
+	storageClassName := "fast"
+	want := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CachePVCName("go-1.22"),
+			Namespace: "ns",
+			Labels:    map[string]string{CacheLabelKey: "true"},
+			Annotations: map[string]string{
+				CacheKeyAnnotationKey:      "go-1.22",
+				CacheLastUsedAnnotationKey: "2026-10-16T12:00:00Z",
+				CacheTTLAnnotationKey:      "1h0m0s",
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &storageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("cache PVC %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEnsureCachePVC_ReuseAndEvict(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cachePVC := func(key, lastUsed, ttl string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      CachePVCName(key),
+				Namespace: "ns",
+				Labels:    map[string]string{CacheLabelKey: "true"},
+				Annotations: map[string]string{
+					CacheKeyAnnotationKey:      key,
+					CacheLastUsedAnnotationKey: lastUsed,
+					CacheTTLAnnotationKey:      ttl,
+				},
+			},
+		}
+	}
+	fakekubeclient := fakek8s.NewSimpleClientset(
+		cachePVC("reused", "2026-10-01T12:00:00Z", "1h0m0s"),
+		cachePVC("expired", "2026-10-16T10:00:00Z", "1h0m0s"),
+		cachePVC("fresh", "2026-10-16T11:30:00Z", "1h0m0s"),
+	)
+	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar(), func() time.Time { return now }}
+	wb := v1.WorkspaceBinding{
+		Name:  "cache",
+		Cache: &v1.CacheWorkspaceSource{Key: "reused", Size: "1Gi"},
+	}
+
+	if err := pvcHandler.EnsureCachePVC(t.Context(), wb, "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reused, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(t.Context(), CachePVCName("reused"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the reused cache PVC to be kept: %v", err)
+	}
+	if got := reused.Annotations[CacheLastUsedAnnotationKey]; got != "2026-10-16T12:00:00Z" {
+		t.Errorf("expected the last used time of the reused cache PVC to be updated, got %q", got)
+	}
+	if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(t.Context(), CachePVCName("expired"), metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the expired cache PVC to be deleted, got %v", err)
+	}
+	if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(t.Context(), CachePVCName("fresh"), metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the fresh cache PVC to be kept: %v", err)
+	}
+}
+
+func TestEnsureCachePVC_InvalidSize(t *testing.T) {
+	pvcHandler := defaultPVCHandler{fakek8s.NewSimpleClientset(), zap.NewExample().Sugar(), time.Now}
+	wb := v1.WorkspaceBinding{
+		Name:  "cache",
+		Cache: &v1.CacheWorkspaceSource{Key: "key", Size: "lots"},
+	}
+	if err := pvcHandler.EnsureCachePVC(t.Context(), wb, "ns"); !errors.Is(err, ErrPvcCreationFailed) {
+		t.Errorf("expected a PVC creation error, got %v", err)
+	}
+}
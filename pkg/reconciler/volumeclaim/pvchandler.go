@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"go.uber.org/zap"
@@ -50,16 +51,18 @@ var (
 type PvcHandler interface {
 	CreatePVCFromVolumeClaimTemplate(ctx context.Context, wb v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error
 	PurgeFinalizerAndDeletePVCForWorkspace(ctx context.Context, pvcName, namespace string) error
+	EnsureCachePVC(ctx context.Context, wb v1.WorkspaceBinding, namespace string) error
 }
 
 type defaultPVCHandler struct {
 	clientset clientset.Interface
 	logger    *zap.SugaredLogger
+	now       func() time.Time
 }
 
 // NewPVCHandler returns a new defaultPVCHandler
 func NewPVCHandler(clientset clientset.Interface, logger *zap.SugaredLogger) PvcHandler {
-	return &defaultPVCHandler{clientset, logger}
+	return &defaultPVCHandler{clientset, logger, time.Now}
 }
 
 // CreatePVCFromVolumeClaimTemplate checks if a PVC named <claim-name>-<workspace-name>-<owner-name> exists;
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"go.uber.org/zap"
@@ -82,7 +83,7 @@ func TestCreatePersistentVolumeClaimsForWorkspaces(t *testing.T) {
 	ownerRef := metav1.OwnerReference{UID: types.UID(ownerName)}
 	namespace := "ns"
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar(), time.Now}
 
 	// when
 
@@ -148,7 +149,7 @@ func TestCreatePersistentVolumeClaimsForWorkspacesWithoutMetadata(t *testing.T)
 	ownerRef := metav1.OwnerReference{UID: types.UID(ownerName)}
 	namespace := "ns"
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar(), time.Now}
 
 	// when
 
@@ -191,7 +192,7 @@ func TestCreateExistPersistentVolumeClaims(t *testing.T) {
 	ownerRef := metav1.OwnerReference{UID: types.UID(ownerName)}
 	namespace := "ns"
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar(), time.Now}
 
 	for _, ws := range workspaces {
 		claim := pvcHandler.getPVCFromVolumeClaimTemplate(ws, ownerRef, namespace)
@@ -279,7 +280,7 @@ func TestPurgeFinalizerAndDeletePVCForWorkspace(t *testing.T) {
 	// call PurgeFinalizerAndDeletePVCForWorkspace to delete pvc
 	// note that the pvcs are not actually deleted in the unit test due to the mock limitation of fakek8s.NewSimpleClientset();
 	// full pvc lifecycle is tested in TestAffinityAssistant_PerPipelineRun integration test
-	pvcHandler := defaultPVCHandler{kubeClientSet, zap.NewExample().Sugar()}
+	pvcHandler := defaultPVCHandler{kubeClientSet, zap.NewExample().Sugar(), time.Now}
 	if err := pvcHandler.PurgeFinalizerAndDeletePVCForWorkspace(ctx, pvcName, namespace); err != nil {
 		t.Fatalf("unexpected error when calling PurgeFinalizerAndDeletePVCForWorkspace: %v", err)
 	}
@@ -312,7 +313,7 @@ func TestCreatePVCFromVolumeClaimTemplate_GetError(t *testing.T) {
 	}
 
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar(), time.Now}
 
 	// Mock Get to return an error that's not NotFound
 	fakekubeclient.Fake.PrependReactor("get", "persistentvolumeclaims",
@@ -345,7 +346,7 @@ func TestCreatePVCFromVolumeClaimTemplate_CreateRetryableError(t *testing.T) {
 	}
 
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar(), time.Now}
 
 	// Mock Get to return NotFound, then Create to return quota exceeded error
 	fakekubeclient.Fake.PrependReactor("get", "persistentvolumeclaims",
@@ -383,7 +384,7 @@ func TestCreatePVCFromVolumeClaimTemplate_CreateNonRetryableError(t *testing.T)
 	}
 
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar(), time.Now}
 
 	// Mock Get to return NotFound, then Create to return non-retryable error
 	fakekubeclient.Fake.PrependReactor("get", "persistentvolumeclaims",
@@ -421,7 +422,7 @@ func TestCreatePVCFromVolumeClaimTemplate_ConflictError(t *testing.T) {
 	}
 
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar(), time.Now}
 
 	// Mock Get to return NotFound, then Create to return conflict error
 	fakekubeclient.Fake.PrependReactor("get", "persistentvolumeclaims",
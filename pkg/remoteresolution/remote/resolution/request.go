@@ -14,13 +14,17 @@ limitations under the License.
 package resolution
 
 import (
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	resolution "github.com/tektoncd/pipeline/pkg/remoteresolution/resource"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/kmeta"
 )
 
 var _ resolution.Request = &resolutionRequest{}
 var _ resolution.OwnedRequest = &resolutionRequest{}
+var _ resolution.CacheBypassingRequest = &resolutionRequest{}
+var _ resolution.ServiceAccountRequest = &resolutionRequest{}
 
 type resolutionRequest struct {
 	resolution.Request
@@ -30,3 +34,20 @@ type resolutionRequest struct {
 func (req *resolutionRequest) OwnerRef() metav1.OwnerReference {
 	return *kmeta.NewControllerRef(req.owner)
 }
+
+// BypassCache returns whether the owner of the request asks resolvers not to use their cache.
+func (req *resolutionRequest) BypassCache() bool {
+	return req.owner.GetObjectMeta().GetAnnotations()[common.AnnotationKeyCacheBypass] == "true"
+}
+
+// ServiceAccountName returns the service account of the TaskRun or PipelineRun
+// owning the request, or an empty string for any other owner.
+func (req *resolutionRequest) ServiceAccountName() string {
+	switch owner := req.owner.(type) {
+	case *v1.TaskRun:
+		return owner.Spec.ServiceAccountName
+	case *v1.PipelineRun:
+		return owner.Spec.TaskRunTemplate.ServiceAccountName
+	}
+	return ""
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 
@@ -187,3 +188,77 @@ func TestBuildRequestV2(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildRequestBypassCache(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{{
+		name: "no annotation",
+	}, {
+		name:        "bypass annotation",
+		annotations: map[string]string{common.AnnotationKeyCacheBypass: "true"},
+		want:        true,
+	}, {
+		name:        "bypass annotation not true",
+		annotations: map[string]string{common.AnnotationKeyCacheBypass: "false"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "foo",
+					Namespace:   "bar",
+					Annotations: tc.annotations,
+				},
+			}
+			rr := &remoteresource.ResolverPayload{ResolutionSpec: &resv1beta1.ResolutionRequestSpec{}}
+			req, err := buildRequest("git", owner, rr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := req.BypassCache(); got != tc.want {
+				t.Errorf("expected BypassCache() to be %t, but was %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildRequestServiceAccountName(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{Name: "foo", Namespace: "bar"}
+	for _, tc := range []struct {
+		name  string
+		owner kmeta.OwnerRefable
+		want  string
+	}{{
+		name: "taskrun",
+		owner: &v1.TaskRun{
+			ObjectMeta: objectMeta,
+			Spec:       v1.TaskRunSpec{ServiceAccountName: "tr-sa"},
+		},
+		want: "tr-sa",
+	}, {
+		name: "pipelinerun",
+		owner: &v1.PipelineRun{
+			ObjectMeta: objectMeta,
+			Spec: v1.PipelineRunSpec{
+				TaskRunTemplate: v1.PipelineTaskRunTemplate{ServiceAccountName: "pr-sa"},
+			},
+		},
+		want: "pr-sa",
+	}, {
+		name:  "other owner",
+		owner: &v1beta1.PipelineRun{ObjectMeta: objectMeta},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := &remoteresource.ResolverPayload{ResolutionSpec: &resv1beta1.ResolutionRequestSpec{}}
+			req, err := buildRequest("bundles", tc.owner, rr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := req.ServiceAccountName(); got != tc.want {
+				t.Errorf("expected ServiceAccountName() to be %q, but was %q", tc.want, got)
+			}
+		})
+	}
+}
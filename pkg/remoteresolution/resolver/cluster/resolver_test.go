@@ -633,7 +633,7 @@ func TestResolveWithCacheHit(t *testing.T) {
 	}
 
 	// add the mock resource to the cache
-	cache.Get(ctx).Add(cluster.LabelValueClusterResolverType, params, mockResource)
+	cache.Get(ctx).Add(ctx, cluster.LabelValueClusterResolverType, params, mockResource)
 
 	// create request with same parameters
 	req := &v1beta1.ResolutionRequestSpec{Params: params}
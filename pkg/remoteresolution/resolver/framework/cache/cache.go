@@ -24,7 +24,9 @@ import (
 	"time"
 
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 	utilcache "k8s.io/apimachinery/pkg/util/cache"
 )
@@ -32,13 +34,24 @@ import (
 var _ resolutionframework.ConfigWatcher = (*resolverCache)(nil)
 
 // resolverCache is a wrapper around utilcache.LRUExpireCache that provides
-// type-safe methods for caching resolver results.
+// type-safe methods for caching resolver results. If persistenceDir is set,
+// the entries are also persisted to it and loaded back when they are not in
+// memory, e.g. after a restart.
+//
+// Entries are keyed by the resolver type, the request scope and the resolution
+// parameters rather than by the digest of the resolved revision, which is only
+// known once the resource has been fetched. Which references are cached, e.g.
+// only the immutable ones, is set by the cache mode, see ShouldUse.
 type resolverCache struct {
-	cache   *utilcache.LRUExpireCache
-	logger  *zap.SugaredLogger
-	ttl     time.Duration
-	maxSize int
-	clock   utilcache.Clock
+	cache            *utilcache.LRUExpireCache
+	logger           *zap.SugaredLogger
+	ttl              time.Duration
+	maxSize          int
+	clock            utilcache.Clock
+	persistenceDir   string
+	sweeper          *persistenceSweeper
+	defaultCacheMode string
+	requests         metric.Int64Counter
 }
 
 func newResolverCache(maxSize int, ttl time.Duration) *resolverCache {
@@ -47,10 +60,12 @@ func newResolverCache(maxSize int, ttl time.Duration) *resolverCache {
 
 func newResolverCacheWithClock(maxSize int, ttl time.Duration, clock utilcache.Clock) *resolverCache {
 	return &resolverCache{
-		cache:   utilcache.NewLRUExpireCacheWithClock(maxSize, clock),
-		ttl:     ttl,
-		maxSize: maxSize,
-		clock:   clock,
+		cache:    utilcache.NewLRUExpireCacheWithClock(maxSize, clock),
+		ttl:      ttl,
+		maxSize:  maxSize,
+		clock:    clock,
+		sweeper:  &persistenceSweeper{},
+		requests: newRequestsCounter(),
 	}
 }
 
@@ -62,7 +77,17 @@ func (c *resolverCache) GetConfigName(_ context.Context) string {
 // withLogger returns a new ResolverCache instance with the provided logger.
 // This prevents state leak by not storing logger in the global singleton.
 func (c *resolverCache) withLogger(logger *zap.SugaredLogger) *resolverCache {
-	return &resolverCache{logger: logger, cache: c.cache, ttl: c.ttl, maxSize: c.maxSize, clock: c.clock}
+	return &resolverCache{
+		logger:           logger,
+		cache:            c.cache,
+		ttl:              c.ttl,
+		maxSize:          c.maxSize,
+		clock:            c.clock,
+		persistenceDir:   c.persistenceDir,
+		sweeper:          c.sweeper,
+		defaultCacheMode: c.defaultCacheMode,
+		requests:         c.requests,
+	}
 }
 
 // TTL returns the time-to-live duration for cache entries.
@@ -76,10 +101,19 @@ func (c *resolverCache) MaxSize() int {
 }
 
 // Get retrieves a cached resource by resolver type and parameters, returning
-// the resource and whether it was found.
-func (c *resolverCache) Get(resolverType string, params []pipelinev1.Param) (resolutionframework.ResolvedResource, bool) {
-	key := generateCacheKey(resolverType, params)
+// the resource and whether it was found. Only the resources cached for the
+// same request namespace and service account are returned, see requestScope.
+func (c *resolverCache) Get(ctx context.Context, resolverType string, params []pipelinev1.Param) (resolutionframework.ResolvedResource, bool) {
+	scope := requestScope(ctx)
+	key := generateCacheKey(resolverType, scope, params)
 	value, found := c.cache.Get(key)
+	if !found && c.persistenceDir != "" {
+		if resource, expiration, ok := c.loadPersisted(key, scope); ok {
+			c.infow("Loaded persisted cache entry", "key", key)
+			c.cache.Add(key, resource, expiration.Sub(c.clock.Now()))
+			value, found = resource, true
+		}
+	}
 	if !found {
 		c.infow("Cache miss", "key", key)
 		return nil, found
@@ -102,30 +136,45 @@ func (c *resolverCache) infow(msg string, keysAndValues ...any) {
 	}
 }
 
+func (c *resolverCache) warnw(msg string, keysAndValues ...any) {
+	if c.logger != nil {
+		c.logger.Warnw(msg, keysAndValues...)
+	}
+}
+
 // Add stores a resource in the cache with the configured TTL and returns an
 // annotated version of the resource.
 func (c *resolverCache) Add(
+	ctx context.Context,
 	resolverType string,
 	params []pipelinev1.Param,
 	resource resolutionframework.ResolvedResource,
 ) resolutionframework.ResolvedResource {
-	key := generateCacheKey(resolverType, params)
+	scope := requestScope(ctx)
+	key := generateCacheKey(resolverType, scope, params)
 	c.infow("Adding to cache", "key", key, "expiration", c.ttl)
 
 	timestamp := c.clock.Now().Format(time.RFC3339)
 	annotatedResource := newAnnotatedResource(resource, resolverType, cacheOperationStore, timestamp)
 
 	c.cache.Add(key, annotatedResource, c.ttl)
+	if c.persistenceDir != "" {
+		c.persist(key, scope, annotatedResource, c.clock.Now().Add(c.ttl))
+		c.maybeSweepPersisted()
+	}
 
 	return annotatedResource
 }
 
 // Remove deletes a cached resource identified by resolver type and parameters.
-func (c *resolverCache) Remove(resolverType string, params []pipelinev1.Param) {
-	key := generateCacheKey(resolverType, params)
+func (c *resolverCache) Remove(ctx context.Context, resolverType string, params []pipelinev1.Param) {
+	key := generateCacheKey(resolverType, requestScope(ctx), params)
 	c.infow("Removing from cache", "key", key)
 
 	c.cache.Remove(key)
+	if c.persistenceDir != "" {
+		c.removePersisted(key)
+	}
 }
 
 // Clear removes all entries from the cache.
@@ -133,11 +182,29 @@ func (c *resolverCache) Clear() {
 	c.infow("Clearing all cache entries")
 	// predicate that returns true clears all entries
 	c.cache.RemoveAll(func(_ any) bool { return true })
+	if c.persistenceDir != "" {
+		c.clearPersisted()
+	}
 }
 
-func generateCacheKey(resolverType string, params []pipelinev1.Param) string {
+// requestScope returns the namespace and service account of the resolution
+// request being processed. Resolvers fetch resources with credentials from
+// them, e.g. the imagePullSecrets of the service account for bundles, so a
+// resource is only served from the cache to requests with the same scope.
+func requestScope(ctx context.Context) string {
+	namespace := resolutioncommon.RequestNamespace(ctx)
+	if namespace == "" {
+		return ""
+	}
+	return namespace + "/" + resolutioncommon.RequestServiceAccount(ctx)
+}
+
+func generateCacheKey(resolverType, scope string, params []pipelinev1.Param) string {
 	// Create a deterministic string representation of the parameters
 	paramStr := resolverType + ":"
+	if scope != "" {
+		paramStr += scope + ":"
+	}
 
 	// Filter out the 'cache' parameter and sort remaining params by name for determinism
 	filteredParams := make([]pipelinev1.Param, 0, len(params))
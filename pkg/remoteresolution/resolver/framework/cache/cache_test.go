@@ -78,7 +78,7 @@ func TestGenerateCacheKey(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actualKey := generateCacheKey(tt.resolverType, tt.params)
+			actualKey := generateCacheKey(tt.resolverType, "", tt.params)
 			if tt.expectedKey != actualKey {
 				t.Errorf("want %s, got %s", tt.expectedKey, actualKey)
 			}
@@ -164,7 +164,7 @@ func TestGenerateCacheKey_IndependentOfCacheParam(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedSame {
 				// Generate key with cache param
-				keyWithCache := generateCacheKey(tt.resolverType, tt.params)
+				keyWithCache := generateCacheKey(tt.resolverType, "", tt.params)
 
 				// Generate key without cache param
 				paramsWithoutCache := make([]pipelinev1.Param, 0, len(tt.params))
@@ -173,7 +173,7 @@ func TestGenerateCacheKey_IndependentOfCacheParam(t *testing.T) {
 						paramsWithoutCache = append(paramsWithoutCache, p)
 					}
 				}
-				keyWithoutCache := generateCacheKey(tt.resolverType, paramsWithoutCache)
+				keyWithoutCache := generateCacheKey(tt.resolverType, "", paramsWithoutCache)
 
 				if keyWithCache != keyWithoutCache {
 					t.Errorf("Expected same keys, but got different:\nWith cache: %s\nWithout cache: %s\nDescription: %s",
@@ -191,8 +191,8 @@ func TestGenerateCacheKey_IndependentOfCacheParam(t *testing.T) {
 					}
 				}
 
-				key1 := generateCacheKey(tt.resolverType, tt.params)
-				key2 := generateCacheKey(tt.resolverType, params2)
+				key1 := generateCacheKey(tt.resolverType, "", tt.params)
+				key2 := generateCacheKey(tt.resolverType, "", params2)
 				if key1 == key2 {
 					t.Errorf("Expected different keys, but got same: %s\nDescription: %s",
 						key1, tt.description)
@@ -211,8 +211,8 @@ func TestGenerateCacheKey_Deterministic(t *testing.T) {
 	}
 
 	// Generate the same key multiple times
-	key1 := generateCacheKey(resolverType, params)
-	key2 := generateCacheKey(resolverType, params)
+	key1 := generateCacheKey(resolverType, "", params)
+	key2 := generateCacheKey(resolverType, "", params)
 
 	if key1 != key2 {
 		t.Errorf("Cache key generation is not deterministic. Got different keys: %s vs %s", key1, key2)
@@ -229,7 +229,7 @@ func TestGenerateCacheKey_AllParamTypes(t *testing.T) {
 	}
 
 	// Generate key with cache param
-	keyWithCache := generateCacheKey(resolverType, params)
+	keyWithCache := generateCacheKey(resolverType, "", params)
 
 	// Generate key without cache param
 	paramsWithoutCache := make([]pipelinev1.Param, 0, len(params))
@@ -238,7 +238,7 @@ func TestGenerateCacheKey_AllParamTypes(t *testing.T) {
 			paramsWithoutCache = append(paramsWithoutCache, p)
 		}
 	}
-	keyWithoutCache := generateCacheKey(resolverType, paramsWithoutCache)
+	keyWithoutCache := generateCacheKey(resolverType, "", paramsWithoutCache)
 	if keyWithCache != keyWithoutCache {
 		t.Errorf("Expected same keys for all param types, but got different:\nWith cache: %s\nWithout cache: %s",
 			keyWithCache, keyWithoutCache)
@@ -257,8 +257,8 @@ func TestCacheTTLExpiration(t *testing.T) {
 
 	// WHEN
 	cache := newResolverCacheWithClock(100, ttl, &fc).withLogger(zaptest.NewLogger(t).Sugar())
-	cache.Add(resolverType, params, mockResource)
-	_, ok := cache.Get(resolverType, params)
+	cache.Add(t.Context(), resolverType, params, mockResource)
+	_, ok := cache.Get(t.Context(), resolverType, params)
 
 	// THEN: Verify it's immediately retrievable.
 	if !ok {
@@ -267,7 +267,7 @@ func TestCacheTTLExpiration(t *testing.T) {
 
 	// WHEN
 	fc.Advance(ttl + time.Second)
-	cached, ok := cache.Get(resolverType, params)
+	cached, ok := cache.Get(t.Context(), resolverType, params)
 
 	// THEN: Verify entry is no longer in cache after TTL expiration
 	if ok {
@@ -312,12 +312,12 @@ func TestCacheLRUEviction(t *testing.T) {
 		mockResource := &mockResolvedResource{
 			data: []byte(entry.name),
 		}
-		cache.Add(resolverType, entry.params, mockResource)
+		cache.Add(t.Context(), resolverType, entry.params, mockResource)
 	}
 
 	// Verify all 3 entries are in cache
 	for _, entry := range entries {
-		if _, ok := cache.Get(resolverType, entry.params); !ok {
+		if _, ok := cache.Get(t.Context(), resolverType, entry.params); !ok {
 			t.Errorf("Expected cache hit for %s after adding, but got cache miss", entry.name)
 		}
 	}
@@ -329,23 +329,23 @@ func TestCacheLRUEviction(t *testing.T) {
 	mockResource4 := &mockResolvedResource{
 		data: []byte("entry4"),
 	}
-	cache.Add(resolverType, entry4Params, mockResource4)
+	cache.Add(t.Context(), resolverType, entry4Params, mockResource4)
 
 	// Verify entry1 (LRU) was evicted
-	if _, ok := cache.Get(resolverType, entries[0].params); ok {
+	if _, ok := cache.Get(t.Context(), resolverType, entries[0].params); ok {
 		t.Error("Expected entry1 to be evicted (LRU), but it was still in cache")
 	}
 
 	// Verify entry2 and entry3 are still in cache
-	if _, ok := cache.Get(resolverType, entries[1].params); !ok {
+	if _, ok := cache.Get(t.Context(), resolverType, entries[1].params); !ok {
 		t.Error("Expected entry2 to still be in cache, but got cache miss")
 	}
-	if _, ok := cache.Get(resolverType, entries[2].params); !ok {
+	if _, ok := cache.Get(t.Context(), resolverType, entries[2].params); !ok {
 		t.Error("Expected entry3 to still be in cache, but got cache miss")
 	}
 
 	// Verify entry4 is in cache
-	if _, ok := cache.Get(resolverType, entry4Params); !ok {
+	if _, ok := cache.Get(t.Context(), resolverType, entry4Params); !ok {
 		t.Error("Expected entry4 to be in cache after adding, but got cache miss")
 	}
 }
@@ -387,11 +387,11 @@ func TestCacheLRUEvictionWithAccess(t *testing.T) {
 		mockResource := &mockResolvedResource{
 			data: []byte(entry.name),
 		}
-		cache.Add(resolverType, entry.params, mockResource)
+		cache.Add(t.Context(), resolverType, entry.params, mockResource)
 	}
 
 	// Access entry1 to make it recently used (entry2 becomes LRU)
-	if _, ok := cache.Get(resolverType, entries[0].params); !ok {
+	if _, ok := cache.Get(t.Context(), resolverType, entries[0].params); !ok {
 		t.Error("Expected cache hit for entry1")
 	}
 
@@ -402,25 +402,25 @@ func TestCacheLRUEvictionWithAccess(t *testing.T) {
 	mockResource4 := &mockResolvedResource{
 		data: []byte("entry4"),
 	}
-	cache.Add(resolverType, entry4Params, mockResource4)
+	cache.Add(t.Context(), resolverType, entry4Params, mockResource4)
 
 	// Verify entry2 (LRU after entry1 was accessed) was evicted
-	if _, ok := cache.Get(resolverType, entries[1].params); ok {
+	if _, ok := cache.Get(t.Context(), resolverType, entries[1].params); ok {
 		t.Error("Expected entry2 to be evicted (LRU), but it was still in cache")
 	}
 
 	// Verify entry1 (accessed recently) is still in cache
-	if _, ok := cache.Get(resolverType, entries[0].params); !ok {
+	if _, ok := cache.Get(t.Context(), resolverType, entries[0].params); !ok {
 		t.Error("Expected entry1 to still be in cache after being accessed, but got cache miss")
 	}
 
 	// Verify entry3 is still in cache
-	if _, ok := cache.Get(resolverType, entries[2].params); !ok {
+	if _, ok := cache.Get(t.Context(), resolverType, entries[2].params); !ok {
 		t.Error("Expected entry3 to still be in cache, but got cache miss")
 	}
 
 	// Verify entry4 is in cache
-	if _, ok := cache.Get(resolverType, entry4Params); !ok {
+	if _, ok := cache.Get(t.Context(), resolverType, entry4Params); !ok {
 		t.Error("Expected entry4 to be in cache, but got cache miss")
 	}
 }
@@ -444,7 +444,7 @@ func TestCacheConcurrentReads(t *testing.T) {
 		mockResource := &mockResolvedResource{
 			data: []byte(fmt.Sprintf("data-%d", i)),
 		}
-		cache.Add(resolverType, params, mockResource)
+		cache.Add(t.Context(), resolverType, params, mockResource)
 	}
 
 	// Launch 1000 concurrent readers
@@ -458,7 +458,7 @@ func TestCacheConcurrentReads(t *testing.T) {
 			// Each reader performs 100 reads
 			for j := range 100 {
 				entryIdx := (readerID + j) % numEntries
-				if _, ok := cache.Get(resolverType, entries[entryIdx]); !ok {
+				if _, ok := cache.Get(t.Context(), resolverType, entries[entryIdx]); !ok {
 					t.Errorf("Reader %d: Expected cache hit for entry %d, got miss", readerID, entryIdx)
 				}
 			}
@@ -504,7 +504,7 @@ func TestCacheConcurrentWrites(t *testing.T) {
 				mockResource := &mockResolvedResource{
 					data: []byte(expectedData),
 				}
-				cache.Add(resolverType, params, mockResource)
+				cache.Add(t.Context(), resolverType, params, mockResource)
 
 				// Record this entry for verification
 				allEntries[writerID*entriesPerWriter+j] = entryInfo{
@@ -529,7 +529,7 @@ func TestCacheConcurrentWrites(t *testing.T) {
 	wrongDataCount := 0
 
 	for _, entry := range allEntries {
-		cached, ok := cache.Get(resolverType, entry.params)
+		cached, ok := cache.Get(t.Context(), resolverType, entry.params)
 		if ok {
 			cachedCount++
 			// Verify the data is correct
@@ -574,7 +574,7 @@ func TestCacheConcurrentReadWrite(t *testing.T) {
 		mockResource := &mockResolvedResource{
 			data: []byte(fmt.Sprintf("initial-data-%d", i)),
 		}
-		cache.Add(resolverType, params, mockResource)
+		cache.Add(t.Context(), resolverType, params, mockResource)
 	}
 
 	// Launch 300 readers and 300 writers concurrently
@@ -598,7 +598,7 @@ func TestCacheConcurrentReadWrite(t *testing.T) {
 
 			for j := range 50 {
 				entryIdx := (readerID + j) % numInitialEntries
-				cache.Get(resolverType, initialEntries[entryIdx])
+				cache.Get(t.Context(), resolverType, initialEntries[entryIdx])
 			}
 		}(i)
 	}
@@ -619,7 +619,7 @@ func TestCacheConcurrentReadWrite(t *testing.T) {
 				mockResource := &mockResolvedResource{
 					data: []byte(expectedData),
 				}
-				cache.Add(resolverType, params, mockResource)
+				cache.Add(t.Context(), resolverType, params, mockResource)
 
 				// Record this entry for verification
 				writerEntries[writerID*entriesPerWriter+j] = entryInfo{
@@ -644,7 +644,7 @@ func TestCacheConcurrentReadWrite(t *testing.T) {
 	wrongDataCount := 0
 
 	for _, entry := range writerEntries {
-		cached, ok := cache.Get(resolverType, entry.params)
+		cached, ok := cache.Get(t.Context(), resolverType, entry.params)
 		if ok {
 			cachedCount++
 			// Verify the data is correct
@@ -695,11 +695,11 @@ func TestCacheConcurrentEviction(t *testing.T) {
 				mockResource := &mockResolvedResource{
 					data: []byte(fmt.Sprintf("eviction-data-%d-%d", writerID, j)),
 				}
-				cache.Add(resolverType, params, mockResource)
+				cache.Add(t.Context(), resolverType, params, mockResource)
 
 				// Small random read to simulate real access patterns
 				if j%3 == 0 {
-					cache.Get(resolverType, params)
+					cache.Get(t.Context(), resolverType, params)
 				}
 			}
 		}(i)
@@ -730,7 +730,7 @@ func TestCacheConcurrentEviction(t *testing.T) {
 				}},
 			}
 			totalChecked++
-			if _, ok := cache.Get(resolverType, params); !ok {
+			if _, ok := cache.Get(t.Context(), resolverType, params); !ok {
 				evictedCount++
 			}
 		}
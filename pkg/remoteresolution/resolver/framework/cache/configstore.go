@@ -38,6 +38,11 @@ const (
 	ttlConfigMapKey      = "ttl"
 	defaultCacheSize     = 1000
 	defaultExpiration    = 5 * time.Minute
+
+	// persistenceDirConfigMapKey is the key of the directory, e.g. the mount path of a
+	// PersistentVolumeClaim, the cache entries are persisted to. They are only kept in
+	// memory if it is not set.
+	persistenceDirConfigMapKey = "persistence-dir"
 )
 
 var (
@@ -123,4 +128,8 @@ func onCacheConfigChanged(_ string, value any) {
 	defer cacheMu.Unlock()
 
 	sharedCache = newResolverCache(maxSize, ttl)
+	sharedCache.persistenceDir = conf[persistenceDirConfigMapKey]
+	if mode := conf[defaultCacheModeConfigMapKey]; Validate(mode) == nil {
+		sharedCache.defaultCacheMode = mode
+	}
 }
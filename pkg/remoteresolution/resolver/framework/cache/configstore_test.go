@@ -29,10 +29,12 @@ import (
 
 func TestOnCacheConfigMapChanged(t *testing.T) {
 	tests := []struct {
-		name            string
-		configMap       *corev1.ConfigMap
-		expectedMaxSize int
-		expectedTTL     time.Duration
+		name                   string
+		configMap              *corev1.ConfigMap
+		expectedMaxSize        int
+		expectedTTL            time.Duration
+		expectedPersistenceDir   string
+		expectedDefaultCacheMode string
 	}{
 		{
 			name:            "nil ConfigMap returns defaults",
@@ -179,6 +181,50 @@ func TestOnCacheConfigMapChanged(t *testing.T) {
 			expectedMaxSize: defaultCacheSize,
 			expectedTTL:     defaultExpiration,
 		},
+		{
+			name: "ConfigMap with default-cache-mode",
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-config",
+					Namespace: "test-namespace",
+				},
+				Data: map[string]string{
+					"default-cache-mode": "never",
+				},
+			},
+			expectedMaxSize:          defaultCacheSize,
+			expectedTTL:              defaultExpiration,
+			expectedDefaultCacheMode: "never",
+		},
+		{
+			name: "ConfigMap with invalid default-cache-mode",
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-config",
+					Namespace: "test-namespace",
+				},
+				Data: map[string]string{
+					"default-cache-mode": "sometimes",
+				},
+			},
+			expectedMaxSize: defaultCacheSize,
+			expectedTTL:     defaultExpiration,
+		},
+		{
+			name: "ConfigMap with persistence-dir",
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-config",
+					Namespace: "test-namespace",
+				},
+				Data: map[string]string{
+					"persistence-dir": "/var/cache/tekton-resolvers",
+				},
+			},
+			expectedMaxSize:        defaultCacheSize,
+			expectedTTL:            defaultExpiration,
+			expectedPersistenceDir: "/var/cache/tekton-resolvers",
+		},
 	}
 
 	for _, tt := range tests {
@@ -198,6 +244,14 @@ func TestOnCacheConfigMapChanged(t *testing.T) {
 			if cache.TTL() != tt.expectedTTL {
 				t.Errorf("TTL = %v, want %v", cache.TTL(), tt.expectedTTL)
 			}
+
+			if cache.persistenceDir != tt.expectedPersistenceDir {
+				t.Errorf("persistenceDir = %q, want %q", cache.persistenceDir, tt.expectedPersistenceDir)
+			}
+
+			if cache.defaultCacheMode != tt.expectedDefaultCacheMode {
+				t.Errorf("defaultCacheMode = %q, want %q", cache.defaultCacheMode, tt.expectedDefaultCacheMode)
+			}
 		})
 	}
 }
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+const (
+	// cacheResultHit is the result of a lookup served from the cache.
+	cacheResultHit = "hit"
+	// cacheResultMiss is the result of a lookup not found in the cache.
+	cacheResultMiss = "miss"
+	// cacheResultBypass is the result of a lookup which bypassed the cache on request.
+	cacheResultBypass = "bypass"
+)
+
+// newRequestsCounter returns the counter of the lookups in the resolver cache.
+func newRequestsCounter() metric.Int64Counter {
+	counter, err := otel.GetMeterProvider().Meter("tekton_pipelines_resolvers").Int64Counter(
+		"tekton_pipelines_resolvers_cache_requests_total",
+		metric.WithDescription("Number of lookups in the resolver cache, by resolver type and result"),
+	)
+	if err != nil {
+		return noop.Int64Counter{}
+	}
+	return counter
+}
+
+// recordRequest records a lookup of the given resolver type in the cache, with its result.
+func (c *resolverCache) recordRequest(ctx context.Context, resolverType, result string) {
+	c.requests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("resolver_type", resolverType),
+		attribute.String("result", result),
+	))
+}
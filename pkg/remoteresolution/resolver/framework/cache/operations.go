@@ -41,8 +41,9 @@ type ImmutabilityChecker interface {
 
 // ShouldUse determines whether caching should be used based on:
 // 1. Task/Pipeline cache parameter (highest priority)
-// 2. ConfigMap default-cache-mode (middle priority)
-// 3. System default for resolver type (lowest priority)
+// 2. default-cache-mode of the resolver's ConfigMap
+// 3. default-cache-mode of the cache's ConfigMap
+// 4. System default for resolver type (lowest priority)
 func ShouldUse(
 	ctx context.Context,
 	resolver ImmutabilityChecker,
@@ -69,6 +70,11 @@ func ShouldUse(
 		}
 	}
 
+	// If no resolver default, get the default of all the resolvers from the cache's ConfigMap
+	if cacheMode == "" {
+		cacheMode = Get(ctx).defaultCacheMode
+	}
+
 	// If still no mode, use system default
 	if cacheMode == "" {
 		cacheMode = cacheModeAuto
@@ -102,6 +108,19 @@ func Validate(cacheMode string) error {
 	return fmt.Errorf("invalid cache mode '%s', must be one of: %v (or empty for default)", cacheMode, validCacheModes)
 }
 
+type cacheBypassKey struct{}
+
+// WithBypass returns a context in which GetFromCacheOrResolve resolves the resource
+// again rather than looking it up in the cache, and refreshes the cache with it.
+func WithBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func isBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
+
 type resolveFn = func() (resolutionframework.ResolvedResource, error)
 
 func GetFromCacheOrResolve(
@@ -112,8 +131,13 @@ func GetFromCacheOrResolve(
 ) (resolutionframework.ResolvedResource, error) {
 	cacheInstance := Get(ctx)
 
-	if cached, ok := cacheInstance.Get(resolverType, params); ok {
+	if isBypassed(ctx) {
+		cacheInstance.recordRequest(ctx, resolverType, cacheResultBypass)
+	} else if cached, ok := cacheInstance.Get(ctx, resolverType, params); ok {
+		cacheInstance.recordRequest(ctx, resolverType, cacheResultHit)
 		return cached, nil
+	} else {
+		cacheInstance.recordRequest(ctx, resolverType, cacheResultMiss)
 	}
 
 	// If cache miss, resolve from params
@@ -124,5 +148,5 @@ func GetFromCacheOrResolve(
 
 	// Store annotated resource with store operation and return annotated resource
 	// to indicate it was stored in cache
-	return cacheInstance.Add(resolverType, params, resource), nil
+	return cacheInstance.Add(ctx, resolverType, params, resource), nil
 }
@@ -17,14 +17,23 @@ limitations under the License.
 package cache
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	bundleresolution "github.com/tektoncd/pipeline/pkg/resolution/resolver/bundle"
 	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
 type resolverFake struct{}
@@ -171,6 +180,25 @@ func TestShouldUseCachePrecedence(t *testing.T) {
 	}
 }
 
+func TestShouldUseCacheConfigDefault(t *testing.T) {
+	c := newResolverCache(100, 5*time.Minute)
+	c.defaultCacheMode = cacheModeAlways
+	ctx := context.WithValue(t.Context(), resolverCacheKey{}, c)
+	params := []pipelinev1.Param{{
+		Name:  bundleresolution.ParamBundle,
+		Value: pipelinev1.ParamValue{StringVal: "registry.io/repo:latest"},
+	}}
+
+	if !ShouldUse(ctx, &resolverFake{}, params, bundleresolution.LabelValueBundleResolverType) {
+		t.Error("Expected the default cache mode of the cache ConfigMap to be used when the resolver has none")
+	}
+
+	resolverCtx := resolutionframework.InjectResolverConfigToContext(ctx, map[string]string{"default-cache-mode": "auto"})
+	if ShouldUse(resolverCtx, &resolverFake{}, params, bundleresolution.LabelValueBundleResolverType) {
+		t.Error("Expected the default cache mode of the resolver ConfigMap to take precedence")
+	}
+}
+
 func TestValidateCacheMode(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -284,7 +312,7 @@ func TestGetFromCacheOrResolve(t *testing.T) {
 			// If this is a cache hit test, pre-populate the cache directly
 			if tt.cacheHit {
 				mockResource := &mockResolvedResource{data: []byte("test data")}
-				Get(ctx).Add(tt.resolverType, tt.params, mockResource)
+				Get(ctx).Add(ctx, tt.resolverType, tt.params, mockResource)
 			}
 
 			result, err := GetFromCacheOrResolve(ctx, tt.params, tt.resolverType, resolveFn)
@@ -315,3 +343,110 @@ func TestGetFromCacheOrResolve(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFromCacheOrResolveBypassAndMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	c := newResolverCacheWithClock(100, 5*time.Minute, &fakeClock{time.Now()})
+	ctx := context.WithValue(t.Context(), resolverCacheKey{}, c)
+	resolverType := bundleresolution.LabelValueBundleResolverType
+	params := []pipelinev1.Param{{
+		Name:  bundleresolution.ParamBundle,
+		Value: pipelinev1.ParamValue{StringVal: "registry.io/repo@sha256:abcdef"},
+	}}
+
+	resolveCalls := 0
+	data := "first"
+	resolveFn := func() (resolutionframework.ResolvedResource, error) {
+		resolveCalls++
+		return &mockResolvedResource{data: []byte(data)}, nil
+	}
+
+	// miss, then hit
+	for range 2 {
+		if _, err := GetFromCacheOrResolve(ctx, params, resolverType, resolveFn); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	// bypass resolves again and refreshes the cached resource
+	data = "second"
+	result, err := GetFromCacheOrResolve(WithBypass(ctx), params, resolverType, resolveFn)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resolveCalls != 2 {
+		t.Errorf("Expected resolve to be called twice, got %d", resolveCalls)
+	}
+	if string(result.Data()) != "second" {
+		t.Errorf("Expected bypass to return the resolved data, got %q", string(result.Data()))
+	}
+	if cached, ok := c.Get(ctx, resolverType, params); !ok || string(cached.Data()) != "second" {
+		t.Errorf("Expected bypass to refresh the cached resource")
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+	got := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "tekton_pipelines_resolvers_cache_requests_total" {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				t.Fatalf("Expected an int64 sum, got %T", m.Data)
+			}
+			for _, dp := range sum.DataPoints {
+				if rt, _ := dp.Attributes.Value(attribute.Key("resolver_type")); rt.AsString() != resolverType {
+					t.Errorf("Expected resolver_type %q, got %q", resolverType, rt.AsString())
+				}
+				result, _ := dp.Attributes.Value(attribute.Key("result"))
+				got[result.AsString()] = dp.Value
+			}
+		}
+	}
+	want := map[string]int64{cacheResultHit: 1, cacheResultMiss: 1, cacheResultBypass: 1}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected cache requests %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestGetFromCacheOrResolveRequestScope(t *testing.T) {
+	c := newResolverCacheWithClock(100, 5*time.Minute, &fakeClock{time.Now()})
+	ctx := context.WithValue(t.Context(), resolverCacheKey{}, c)
+	resolverType := bundleresolution.LabelValueBundleResolverType
+	params := []pipelinev1.Param{{
+		Name:  bundleresolution.ParamBundle,
+		Value: pipelinev1.ParamValue{StringVal: "registry.io/private@sha256:abcdef"},
+	}}
+
+	resolveCalls := 0
+	resolveFn := func() (resolutionframework.ResolvedResource, error) {
+		resolveCalls++
+		return &mockResolvedResource{data: []byte("data")}, nil
+	}
+	requestCtx := func(namespace, serviceAccount string) context.Context {
+		ctx := resolutioncommon.InjectRequestNamespace(ctx, namespace)
+		return resolutioncommon.InjectRequestServiceAccount(ctx, serviceAccount)
+	}
+
+	for _, tc := range []struct {
+		namespace, serviceAccount string
+		wantResolveCalls          int
+	}{
+		{namespace: "foo", serviceAccount: "build-bot", wantResolveCalls: 1},
+		{namespace: "foo", serviceAccount: "build-bot", wantResolveCalls: 1},
+		{namespace: "foo", serviceAccount: "default", wantResolveCalls: 2},
+		{namespace: "bar", serviceAccount: "build-bot", wantResolveCalls: 3},
+	} {
+		if _, err := GetFromCacheOrResolve(requestCtx(tc.namespace, tc.serviceAccount), params, resolverType, resolveFn); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if resolveCalls != tc.wantResolveCalls {
+			t.Errorf("Expected %d resolve calls after resolving for %s/%s, got %d", tc.wantResolveCalls, tc.namespace, tc.serviceAccount, resolveCalls)
+		}
+	}
+}
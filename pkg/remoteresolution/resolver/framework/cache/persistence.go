@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+)
+
+const (
+	// persistedEntryExtension is the extension of the files cache entries are persisted to.
+	persistedEntryExtension = ".json"
+
+	// persistenceSweepInterval is the minimum interval between two sweeps of the persistence
+	// directory, see sweepPersisted.
+	persistenceSweepInterval = time.Minute
+)
+
+// persistedEntry is a cache entry persisted to the persistence directory of the cache, e.g. a
+// PersistentVolume, so that it survives restarts of the resolvers. Scope is the request scope
+// the entry was resolved for, see requestScope.
+type persistedEntry struct {
+	Scope       string            `json:"scope"`
+	Content     []byte            `json:"content"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	RefSource   *v1.RefSource     `json:"refSource,omitempty"`
+	Expiration  time.Time         `json:"expiration"`
+}
+
+// persistenceSweeper records when the persistence directory of the cache was last swept. It is
+// shared by the copies of the cache returned by withLogger.
+type persistenceSweeper struct {
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// persistedResource is a ResolvedResource loaded from the persistence directory of the cache.
+type persistedResource struct {
+	entry persistedEntry
+}
+
+var _ resolutionframework.ResolvedResource = (*persistedResource)(nil)
+
+// Data returns the bytes of the resource
+func (p *persistedResource) Data() []byte {
+	return p.entry.Content
+}
+
+// Annotations returns the annotations of the resource
+func (p *persistedResource) Annotations() map[string]string {
+	return p.entry.Annotations
+}
+
+// RefSource returns the source reference of the remote data
+func (p *persistedResource) RefSource() *v1.RefSource {
+	return p.entry.RefSource
+}
+
+func (c *resolverCache) persistedEntryPath(key string) string {
+	return filepath.Join(c.persistenceDir, key+persistedEntryExtension)
+}
+
+// persist writes the resource to the persistence directory of the cache. The file is written
+// to a temporary file first and then renamed, so that a partially written entry is never read.
+func (c *resolverCache) persist(key, scope string, resource resolutionframework.ResolvedResource, expiration time.Time) {
+	b, err := json.Marshal(persistedEntry{
+		Scope:       scope,
+		Content:     resource.Data(),
+		Annotations: resource.Annotations(),
+		RefSource:   resource.RefSource(),
+		Expiration:  expiration,
+	})
+	if err != nil {
+		c.warnw("Failed marshalling cache entry", "key", key, "error", err)
+		return
+	}
+	tmp, err := os.CreateTemp(c.persistenceDir, key+"-*.tmp")
+	if err != nil {
+		c.warnw("Failed persisting cache entry", "key", key, "error", err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		c.warnw("Failed persisting cache entry", "key", key, "error", err)
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		c.warnw("Failed persisting cache entry", "key", key, "error", err)
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.persistedEntryPath(key)); err != nil {
+		c.warnw("Failed persisting cache entry", "key", key, "error", err)
+	}
+}
+
+// loadPersisted reads the resource with the given key from the persistence directory of the
+// cache, returning it with its expiration time if it was found for the given request scope and
+// has not expired yet. Entries persisted for another scope, e.g. before the scope was part of
+// the key, are removed.
+func (c *resolverCache) loadPersisted(key, scope string) (resolutionframework.ResolvedResource, time.Time, bool) {
+	b, err := os.ReadFile(c.persistedEntryPath(key))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			c.warnw("Failed reading persisted cache entry", "key", key, "error", err)
+		}
+		return nil, time.Time{}, false
+	}
+	var entry persistedEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		c.warnw("Failed unmarshalling persisted cache entry", "key", key, "error", err)
+		c.removePersisted(key)
+		return nil, time.Time{}, false
+	}
+	if entry.Scope != scope {
+		c.warnw("Removing persisted cache entry resolved for another request scope", "key", key)
+		c.removePersisted(key)
+		return nil, time.Time{}, false
+	}
+	if !c.clock.Now().Before(entry.Expiration) {
+		c.removePersisted(key)
+		return nil, time.Time{}, false
+	}
+	return &persistedResource{entry: entry}, entry.Expiration, true
+}
+
+// removePersisted deletes the resource with the given key from the persistence directory of the cache.
+func (c *resolverCache) removePersisted(key string) {
+	if err := os.Remove(c.persistedEntryPath(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		c.warnw("Failed removing persisted cache entry", "key", key, "error", err)
+	}
+}
+
+// maybeSweepPersisted sweeps the persistence directory of the cache unless it was swept less than
+// persistenceSweepInterval ago.
+func (c *resolverCache) maybeSweepPersisted() {
+	c.sweeper.mu.Lock()
+	defer c.sweeper.mu.Unlock()
+	now := c.clock.Now()
+	if !c.sweeper.lastSweep.IsZero() && now.Sub(c.sweeper.lastSweep) < persistenceSweepInterval {
+		return
+	}
+	c.sweeper.lastSweep = now
+	c.sweepPersisted()
+}
+
+// sweepPersisted deletes the expired and unreadable entries from the persistence directory of the
+// cache, which would otherwise only be removed when they are looked up again. If more than maxSize
+// entries are left, the ones expiring first are deleted too.
+func (c *resolverCache) sweepPersisted() {
+	entries, err := os.ReadDir(c.persistenceDir)
+	if err != nil {
+		c.warnw("Failed listing persisted cache entries", "error", err)
+		return
+	}
+	type persistedKey struct {
+		key        string
+		expiration time.Time
+	}
+	var kept []persistedKey
+	now := c.clock.Now()
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, persistedEntryExtension) {
+			continue
+		}
+		key := strings.TrimSuffix(name, persistedEntryExtension)
+		b, err := os.ReadFile(c.persistedEntryPath(key))
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				c.warnw("Failed reading persisted cache entry", "key", key, "error", err)
+			}
+			continue
+		}
+		var entry persistedEntry
+		if err := json.Unmarshal(b, &entry); err != nil || !now.Before(entry.Expiration) {
+			c.removePersisted(key)
+			continue
+		}
+		kept = append(kept, persistedKey{key: key, expiration: entry.Expiration})
+	}
+	if len(kept) <= c.maxSize {
+		return
+	}
+	sort.Slice(kept, func(i, j int) bool {
+		return kept[i].expiration.Before(kept[j].expiration)
+	})
+	for _, k := range kept[:len(kept)-c.maxSize] {
+		c.removePersisted(k.key)
+	}
+}
+
+// clearPersisted deletes all the resources from the persistence directory of the cache.
+func (c *resolverCache) clearPersisted() {
+	entries, err := os.ReadDir(c.persistenceDir)
+	if err != nil {
+		c.warnw("Failed listing persisted cache entries", "error", err)
+		return
+	}
+	for _, e := range entries {
+		if name := e.Name(); !e.IsDir() && strings.HasSuffix(name, persistedEntryExtension) {
+			c.removePersisted(strings.TrimSuffix(name, persistedEntryExtension))
+		}
+	}
+}
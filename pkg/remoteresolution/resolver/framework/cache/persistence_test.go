@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap/zaptest"
+)
+
+func newPersistentTestCache(t *testing.T, dir string, clock *fakeClock) *resolverCache {
+	t.Helper()
+	c := newResolverCacheWithClock(100, 5*time.Minute, clock).withLogger(zaptest.NewLogger(t).Sugar())
+	c.persistenceDir = dir
+	return c
+}
+
+func TestCachePersistence(t *testing.T) {
+	// GIVEN
+	dir := t.TempDir()
+	fc := &fakeClock{time.Now()}
+	resolverType := "bundle"
+	params := []pipelinev1.Param{
+		{Name: "bundle", Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeString, StringVal: "registry.io/repo@sha256:abcdef"}},
+	}
+	mockResource := &mockResolvedResource{
+		data:        []byte("test data"),
+		annotations: map[string]string{"existing-key": "existing-value"},
+		refSource:   &pipelinev1.RefSource{URI: "registry.io/repo", Digest: map[string]string{"sha256": "abcdef"}},
+	}
+
+	// WHEN: an entry is added, and the cache is recreated as if the resolvers restarted
+	newPersistentTestCache(t, dir, fc).Add(t.Context(), resolverType, params, mockResource)
+	restarted := newPersistentTestCache(t, dir, fc)
+	cached, ok := restarted.Get(t.Context(), resolverType, params)
+
+	// THEN: the entry is loaded from the persistence directory
+	if !ok {
+		t.Fatal("Expected cache hit from the persisted entry, but got cache miss")
+	}
+	if d := cmp.Diff(mockResource.data, cached.Data()); d != "" {
+		t.Errorf("Unexpected data %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(mockResource.refSource, cached.RefSource()); d != "" {
+		t.Errorf("Unexpected refSource %s", diff.PrintWantGot(d))
+	}
+	if got := cached.Annotations()["existing-key"]; got != "existing-value" {
+		t.Errorf("Expected annotation existing-key to be kept, got %q", got)
+	}
+	if got := cached.Annotations()[cacheOperationKey]; got != cacheOperationRetrieve {
+		t.Errorf("Expected cache operation %q, got %q", cacheOperationRetrieve, got)
+	}
+
+	// WHEN: the entry expires
+	fc.Advance(5*time.Minute + time.Second)
+	_, ok = newPersistentTestCache(t, dir, fc).Get(t.Context(), resolverType, params)
+
+	// THEN: it is not loaded anymore and is removed from the persistence directory
+	if ok {
+		t.Fatal("Expected cache miss for the expired persisted entry, but got cache hit")
+	}
+	if _, err := os.Stat(filepath.Join(dir, generateCacheKey(resolverType, "", params)+persistedEntryExtension)); !os.IsNotExist(err) {
+		t.Errorf("Expected the expired persisted entry to be removed, got %v", err)
+	}
+}
+
+func TestCachePersistenceRemoveAndClear(t *testing.T) {
+	dir := t.TempDir()
+	fc := &fakeClock{time.Now()}
+	c := newPersistentTestCache(t, dir, fc)
+	mockResource := &mockResolvedResource{data: []byte("test data")}
+	paramsA := []pipelinev1.Param{{Name: "bundle", Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeString, StringVal: "a"}}}
+	paramsB := []pipelinev1.Param{{Name: "bundle", Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeString, StringVal: "b"}}}
+	c.Add(t.Context(), "bundle", paramsA, mockResource)
+	c.Add(t.Context(), "bundle", paramsB, mockResource)
+
+	c.Remove(t.Context(), "bundle", paramsA)
+	if _, ok := newPersistentTestCache(t, dir, fc).Get(t.Context(), "bundle", paramsA); ok {
+		t.Error("Expected cache miss for the removed entry, but got cache hit")
+	}
+	if _, ok := newPersistentTestCache(t, dir, fc).Get(t.Context(), "bundle", paramsB); !ok {
+		t.Error("Expected cache hit for the entry which was not removed, but got cache miss")
+	}
+
+	c.Clear()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error reading the persistence directory: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected the persistence directory to be empty after clearing the cache, got %d entries", len(entries))
+	}
+}
+
+func TestCachePersistenceRequestScope(t *testing.T) {
+	dir := t.TempDir()
+	fc := &fakeClock{time.Now()}
+	resolverType := "bundle"
+	params := []pipelinev1.Param{
+		{Name: "bundle", Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeString, StringVal: "registry.io/private@sha256:abcdef"}},
+	}
+	requestCtx := func(namespace, serviceAccount string) context.Context {
+		ctx := resolutioncommon.InjectRequestNamespace(t.Context(), namespace)
+		return resolutioncommon.InjectRequestServiceAccount(ctx, serviceAccount)
+	}
+	newPersistentTestCache(t, dir, fc).Add(requestCtx("foo", "build-bot"), resolverType, params, &mockResolvedResource{data: []byte("test data")})
+
+	// an entry persisted for a request scope is not loaded for another one after a restart
+	if _, ok := newPersistentTestCache(t, dir, fc).Get(requestCtx("bar", "build-bot"), resolverType, params); ok {
+		t.Error("Expected cache miss for another namespace, but got cache hit")
+	}
+	if _, ok := newPersistentTestCache(t, dir, fc).Get(requestCtx("foo", "default"), resolverType, params); ok {
+		t.Error("Expected cache miss for another service account, but got cache hit")
+	}
+	if _, ok := newPersistentTestCache(t, dir, fc).Get(requestCtx("foo", "build-bot"), resolverType, params); !ok {
+		t.Error("Expected cache hit for the same request scope, but got cache miss")
+	}
+
+	// an entry whose persisted scope does not match its key is removed
+	path := filepath.Join(dir, generateCacheKey(resolverType, "foo/build-bot", params)+persistedEntryExtension)
+	b, err := json.Marshal(persistedEntry{Scope: "bar/build-bot", Content: []byte("test data"), Expiration: fc.Now().Add(time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := newPersistentTestCache(t, dir, fc).Get(requestCtx("foo", "build-bot"), resolverType, params); ok {
+		t.Error("Expected cache miss for an entry persisted for another request scope, but got cache hit")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the entry persisted for another request scope to be removed, got %v", err)
+	}
+}
+
+func TestCachePersistenceSweep(t *testing.T) {
+	dir := t.TempDir()
+	fc := &fakeClock{time.Now()}
+	c := newResolverCacheWithClock(2, 90*time.Second, fc).withLogger(zaptest.NewLogger(t).Sugar())
+	c.persistenceDir = dir
+	mockResource := &mockResolvedResource{data: []byte("test data")}
+	params := func(name string) []pipelinev1.Param {
+		return []pipelinev1.Param{{Name: "bundle", Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeString, StringVal: name}}}
+	}
+	persisted := func() []string {
+		t.Helper()
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("Unexpected error reading the persistence directory: %v", err)
+		}
+		var keys []string
+		for _, e := range entries {
+			keys = append(keys, e.Name())
+		}
+		return keys
+	}
+	entryFile := func(name string) string {
+		return generateCacheKey("bundle", "", params(name)) + persistedEntryExtension
+	}
+
+	// An entry which expires before the next sweep, and an unreadable one.
+	c.Add(t.Context(), "bundle", params("expired"), mockResource)
+	if err := os.WriteFile(filepath.Join(dir, "corrupted"+persistedEntryExtension), []byte("not json"), 0o600); err != nil {
+		t.Fatalf("Unexpected error writing the corrupted entry: %v", err)
+	}
+
+	// Entries added within the sweep interval of the previous sweep don't trigger one.
+	fc.Advance(50 * time.Second)
+	c.Add(t.Context(), "bundle", params("a"), mockResource)
+	fc.Advance(time.Second)
+	c.Add(t.Context(), "bundle", params("b"), mockResource)
+	if got := len(persisted()); got != 4 {
+		t.Fatalf("Expected 4 persisted entries before the sweep, got %d", got)
+	}
+
+	// The next sweep removes the expired and corrupted entries, and the entry expiring first
+	// to keep at most max-size entries.
+	fc.Advance(40 * time.Second)
+	c.Add(t.Context(), "bundle", params("c"), mockResource)
+	want := []string{entryFile("b"), entryFile("c")}
+	sort.Strings(want)
+	if d := cmp.Diff(want, persisted()); d != "" {
+		t.Errorf("Unexpected persisted entries after the sweep %s", diff.PrintWantGot(d))
+	}
+}
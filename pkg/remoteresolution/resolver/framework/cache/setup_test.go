@@ -70,10 +70,10 @@ func TestCacheSharing(t *testing.T) {
 	testResource := &mockResolvedResource{data: []byte("test-data")}
 
 	// Add to cache1
-	cache1.Add("test-resolver", testParams, testResource)
+	cache1.Add(t.Context(), "test-resolver", testParams, testResource)
 
 	// Verify it exists in cache2 (proving they share the same underlying storage)
-	retrieved, found := cache2.Get("test-resolver", testParams)
+	retrieved, found := cache2.Get(t.Context(), "test-resolver", testParams)
 	if !found {
 		t.Fatal("Expected to find resource in cache2 that was added to cache1 - caches are not shared")
 	}
@@ -0,0 +1,41 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+const (
+	// TimeoutKey is the configuration field name for controlling
+	// the maximum duration of a resolution request for an object.
+	TimeoutKey = "fetch-timeout"
+
+	// DefaultRegionKey is the configuration field name for the region
+	// used when a request does not set the region param.
+	DefaultRegionKey = "default-region"
+
+	// DefaultEndpointKey is the configuration field name for the endpoint
+	// used when a request does not set the endpoint param, e.g. the URL
+	// of a MinIO server.
+	DefaultEndpointKey = "default-endpoint"
+
+	// AllowedEndpointsKey is the configuration field name for the comma
+	// separated list of endpoints, besides the default one, which requests
+	// may set with the endpoint param.
+	AllowedEndpointsKey = "allowed-endpoints"
+
+	// MaxObjectSizeKey is the configuration field name for the maximum
+	// size in bytes of a fetched object.
+	MaxObjectSizeKey = "max-object-size"
+)
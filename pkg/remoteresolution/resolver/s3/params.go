@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import "github.com/tektoncd/pipeline/pkg/resolution/resource"
+
+const (
+	// URLParam is the s3:// URL of the object to fetch, e.g. s3://bucket/path/to/task.yaml
+	URLParam string = resource.ParamURL
+
+	// RegionParam is the region of the bucket
+	RegionParam string = "region"
+
+	// EndpointParam is the URL of an S3 compatible endpoint, e.g. a MinIO server.
+	// Objects are addressed path-style when it is set.
+	EndpointParam string = "endpoint"
+
+	// SecretParam is the name of a secret in the PipelineRun or TaskRun namespace
+	// holding the credentials to fetch the object with
+	SecretParam string = "secret"
+
+	// AccessKeyIDSecretKey is the key of the access key ID in the secret
+	AccessKeyIDSecretKey string = "accessKeyID"
+
+	// SecretAccessKeySecretKey is the key of the secret access key in the secret
+	SecretAccessKeySecretKey string = "secretAccessKey"
+
+	// SessionTokenSecretKey is the key of the optional session token in the secret
+	SessionTokenSecretKey string = "sessionToken"
+)
@@ -0,0 +1,377 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	resolverconfig "github.com/tektoncd/pipeline/pkg/apis/config/resolver"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// LabelValueS3ResolverType is the value to use for the
+	// resolution.tekton.dev/type label on resource requests
+	LabelValueS3ResolverType = "s3"
+	disabledError            = "cannot handle resolution request, enable-s3-resolver feature flag not true"
+	s3ResolverName           = "S3"
+	configMapName            = "s3-resolver-config"
+	defaultFetchTimeout      = "1m"
+	defaultMaxObjectSize     = 1024 * 1024
+	defaultRegion            = "us-east-1"
+	s3Scheme                 = "s3"
+
+	// emptyPayloadHash is the SHA-256 of the empty body of the GetObject requests.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+var (
+	// bucketRegex matches the S3 bucket naming rules, so that the bucket
+	// cannot change the host the object is fetched from.
+	bucketRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	// regionRegex matches the AWS region names, e.g. eu-west-1.
+	regionRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+var _ framework.Resolver = (*Resolver)(nil)
+var _ resolutionframework.ConfigWatcher = (*Resolver)(nil)
+
+// Resolver implements a framework.Resolver that can fetch files from S3
+// compatible object storage, such as AWS S3 or MinIO.
+type Resolver struct {
+	kubeClient kubernetes.Interface
+	logger     *zap.SugaredLogger
+}
+
+// Initialize sets up any dependencies needed by the resolver.
+func (r *Resolver) Initialize(ctx context.Context) error {
+	r.kubeClient = kubeclient.Get(ctx)
+	r.logger = logging.FromContext(ctx)
+	return nil
+}
+
+// GetName returns a string name to refer to this resolver by.
+func (r *Resolver) GetName(_ context.Context) string {
+	return s3ResolverName
+}
+
+// GetConfigName returns the name of the s3 resolver's configmap.
+func (r *Resolver) GetConfigName(_ context.Context) string {
+	return configMapName
+}
+
+// GetSelector returns a map of labels to match requests to this resolver.
+func (r *Resolver) GetSelector(_ context.Context) map[string]string {
+	return map[string]string{
+		common.LabelKeyResolverType: LabelValueS3ResolverType,
+	}
+}
+
+// Validate ensures parameters from a request are as expected.
+func (r *Resolver) Validate(ctx context.Context, req *v1beta1.ResolutionRequestSpec) error {
+	if isDisabled(ctx) {
+		return errors.New(disabledError)
+	}
+	_, err := objectRequestFromParams(ctx, req.Params)
+	return err
+}
+
+// Resolve uses the given params to resolve the requested file or resource.
+func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSpec) (resolutionframework.ResolvedResource, error) {
+	if isDisabled(ctx) {
+		return nil, errors.New(disabledError)
+	}
+	obj, err := objectRequestFromParams(ctx, req.Params)
+	if err != nil {
+		return nil, err
+	}
+	return r.fetchObject(ctx, obj)
+}
+
+func isDisabled(ctx context.Context) bool {
+	cfg := resolverconfig.FromContextOrDefaults(ctx)
+	return !cfg.FeatureFlags.EnableS3Resolver
+}
+
+// objectRequest holds the location of an object and the credentials to fetch it with.
+type objectRequest struct {
+	bucket   string
+	key      string
+	region   string
+	endpoint string
+	secret   string
+}
+
+func objectRequestFromParams(ctx context.Context, params []pipelinev1.Param) (*objectRequest, error) {
+	conf := resolutionframework.GetResolverConfigFromContext(ctx)
+	paramsMap := make(map[string]string)
+	for _, p := range params {
+		paramsMap[p.Name] = p.Value.StringVal
+	}
+
+	rawURL, ok := paramsMap[URLParam]
+	if !ok {
+		return nil, fmt.Errorf("missing required s3 resolver params: %s", URLParam)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse url %s: %w", rawURL, err)
+	}
+	obj := &objectRequest{
+		bucket: u.Host,
+		key:    strings.TrimPrefix(u.Path, "/"),
+		region: defaultRegion,
+	}
+	if u.Scheme != s3Scheme || u.User != nil || obj.bucket == "" || obj.key == "" {
+		return nil, fmt.Errorf("url %s is not a valid s3://bucket/key url", rawURL)
+	}
+
+	if v := conf[DefaultRegionKey]; v != "" {
+		obj.region = v
+	}
+	if v, ok := paramsMap[RegionParam]; ok {
+		if v == "" {
+			return nil, fmt.Errorf("value %s cannot be empty", RegionParam)
+		}
+		obj.region = v
+	}
+	if !bucketRegex.MatchString(obj.bucket) {
+		return nil, fmt.Errorf("bucket %s of url %s is not a valid s3 bucket name", obj.bucket, rawURL)
+	}
+	if !regionRegex.MatchString(obj.region) {
+		return nil, fmt.Errorf("region %s is not a valid region name", obj.region)
+	}
+
+	obj.endpoint = conf[DefaultEndpointKey]
+	if v, ok := paramsMap[EndpointParam]; ok {
+		obj.endpoint = v
+	}
+	if obj.endpoint != "" {
+		e, err := url.ParseRequestURI(obj.endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse endpoint %s: %w", obj.endpoint, err)
+		}
+		if e.Scheme != "http" && e.Scheme != "https" {
+			return nil, fmt.Errorf("endpoint %s is not a valid http(s) url", obj.endpoint)
+		}
+		if !isAllowedEndpoint(conf, obj.endpoint) {
+			return nil, fmt.Errorf("endpoint %s is not allowed, it must be the %s or one of the %s of the s3 resolver", obj.endpoint, DefaultEndpointKey, AllowedEndpointsKey)
+		}
+	}
+
+	if v, ok := paramsMap[SecretParam]; ok {
+		if v == "" {
+			return nil, fmt.Errorf("value %s cannot be empty", SecretParam)
+		}
+		obj.secret = v
+	}
+
+	return obj, nil
+}
+
+// isAllowedEndpoint returns whether requests may fetch objects from the endpoint,
+// i.e. whether it is the default endpoint or one of the allowed endpoints
+// configured by the operator, so that requests cannot make the resolvers
+// send requests to arbitrary URLs.
+func isAllowedEndpoint(conf map[string]string, endpoint string) bool {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	allowed := []string{conf[DefaultEndpointKey]}
+	if v := conf[AllowedEndpointsKey]; v != "" {
+		allowed = append(allowed, strings.Split(v, ",")...)
+	}
+	return slices.ContainsFunc(allowed, func(e string) bool {
+		e = strings.TrimSuffix(strings.TrimSpace(e), "/")
+		return e != "" && e == endpoint
+	})
+}
+
+// s3URI returns the s3:// URI of the object.
+func (o *objectRequest) s3URI() string {
+	return fmt.Sprintf("%s://%s/%s", s3Scheme, o.bucket, o.key)
+}
+
+// httpURL returns the URL to get the object from. Objects are addressed
+// virtual-hosted-style on AWS, and path-style on a custom endpoint, as
+// expected by MinIO and most other S3 compatible storages.
+func (o *objectRequest) httpURL() string {
+	if o.endpoint == "" {
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", o.bucket, o.region, escapeKey(o.key))
+	}
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(o.endpoint, "/"), escapeKey(o.bucket), escapeKey(o.key))
+}
+
+// escapeKey URI-encodes every byte of the key but the unreserved characters and
+// the path separators, as expected in the canonical request of a signature.
+func escapeKey(key string) string {
+	var b strings.Builder
+	for i := range len(key) {
+		c := key[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func (r *Resolver) fetchObject(ctx context.Context, obj *objectRequest) (resolutionframework.ResolvedResource, error) {
+	conf := resolutionframework.GetResolverConfigFromContext(ctx)
+	timeout, _ := time.ParseDuration(defaultFetchTimeout)
+	if v, ok := conf[TimeoutKey]; ok {
+		var err error
+		timeout, err = time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing timeout value %s: %w", v, err)
+		}
+	}
+	maxObjectSize := int64(defaultMaxObjectSize)
+	if v, ok := conf[MaxObjectSizeKey]; ok {
+		var err error
+		maxObjectSize, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || maxObjectSize <= 0 {
+			return nil, fmt.Errorf("invalid %s value %s, it must be a positive number of bytes", MaxObjectSizeKey, v)
+		}
+	}
+	httpClient := &http.Client{Timeout: timeout}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, obj.httpURL(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("constructing request: %w", err)
+	}
+
+	if obj.secret != "" {
+		creds, err := r.getCredentials(ctx, obj.secret)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+		signer := v4.NewSigner(func(o *v4.SignerOptions) {
+			// The key is already escaped in the URL.
+			o.DisableURIPathEscaping = true
+		})
+		if err := signer.SignHTTP(ctx, creds, req, emptyPayloadHash, "s3", obj.region, time.Now()); err != nil {
+			return nil, fmt.Errorf("error signing request: %w", err)
+		}
+	}
+
+	// #nosec G704 -- URL cannot be constant in this case.
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching object %s: %w", obj.s3URI(), err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching object %s: %s", obj.s3URI(), resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxObjectSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	if int64(len(body)) > maxObjectSize {
+		return nil, fmt.Errorf("object %s is larger than the %s of %d bytes", obj.s3URI(), MaxObjectSizeKey, maxObjectSize)
+	}
+
+	return &resolvedS3Resource{
+		URI:     obj.s3URI(),
+		Content: body,
+	}, nil
+}
+
+func (r *Resolver) getCredentials(ctx context.Context, secretName string) (aws.Credentials, error) {
+	secretNS := common.RequestNamespace(ctx)
+	secret, err := r.kubeClient.CoreV1().Secrets(secretNS).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			notFoundErr := fmt.Errorf("cannot get credentials, secret %s not found in namespace %s", secretName, secretNS)
+			r.logger.Info(notFoundErr)
+			return aws.Credentials{}, notFoundErr
+		}
+		wrappedErr := fmt.Errorf("error reading credentials from secret %s in namespace %s: %w", secretName, secretNS, err)
+		r.logger.Info(wrappedErr)
+		return aws.Credentials{}, wrappedErr
+	}
+	for _, key := range []string{AccessKeyIDSecretKey, SecretAccessKeySecretKey} {
+		if _, ok := secret.Data[key]; !ok {
+			err := fmt.Errorf("cannot get credentials, key %s not found in secret %s in namespace %s", key, secretName, secretNS)
+			r.logger.Info(err)
+			return aws.Credentials{}, err
+		}
+	}
+	return aws.Credentials{
+		AccessKeyID:     string(secret.Data[AccessKeyIDSecretKey]),
+		SecretAccessKey: string(secret.Data[SecretAccessKeySecretKey]),
+		SessionToken:    string(secret.Data[SessionTokenSecretKey]),
+	}, nil
+}
+
+// resolvedS3Resource wraps the data we want to return to Pipelines
+type resolvedS3Resource struct {
+	URI     string
+	Content []byte
+}
+
+var _ resolutionframework.ResolvedResource = &resolvedS3Resource{}
+
+// Data returns the bytes of the fetched object.
+func (rr *resolvedS3Resource) Data() []byte {
+	return rr.Content
+}
+
+// Annotations returns any metadata needed alongside the data. None atm.
+func (*resolvedS3Resource) Annotations() map[string]string {
+	return nil
+}
+
+// RefSource is the source reference of the remote data that records where the remote
+// file came from including the s3:// URI and the digest.
+func (rr *resolvedS3Resource) RefSource() *pipelinev1.RefSource {
+	h := sha256.Sum256(rr.Content)
+	return &pipelinev1.RefSource{
+		URI: rr.URI,
+		Digest: map[string]string{
+			"sha256": hex.EncodeToString(h[:]),
+		},
+	}
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	frameworktesting "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+const sampleTask = `---
+kind: Task
+apiVersion: tekton.dev/v1
+metadata:
+  name: foo
+spec:
+  steps:
+  - name: step1
+    image: scratch`
+
+func TestGetSelector(t *testing.T) {
+	resolver := Resolver{}
+	sel := resolver.GetSelector(t.Context())
+	if typ, has := sel[resolutioncommon.LabelKeyResolverType]; !has {
+		t.Fatalf("unexpected selector: %v", sel)
+	} else if typ != LabelValueS3ResolverType {
+		t.Fatalf("unexpected type: %q", typ)
+	}
+}
+
+func TestGetName(t *testing.T) {
+	resolver := Resolver{}
+	ctx := t.Context()
+
+	if d := cmp.Diff(s3ResolverName, resolver.GetName(ctx)); d != "" {
+		t.Errorf("invalid name: %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(configMapName, resolver.GetConfigName(ctx)); d != "" {
+		t.Errorf("invalid config map name: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		params      map[string]string
+		expectedErr error
+	}{{
+		name:   "valid/url",
+		params: map[string]string{URLParam: "s3://bucket/tasks/task.yaml"},
+	}, {
+		name: "valid/all params",
+		params: map[string]string{
+			URLParam:      "s3://bucket/tasks/task.yaml",
+			RegionParam:   "eu-west-1",
+			EndpointParam: "https://minio.example.com",
+			SecretParam:   "s3-creds",
+		},
+	}, {
+		name:        "missing/url",
+		params:      map[string]string{"foo": "bar"},
+		expectedErr: errors.New(`missing required s3 resolver params: url`),
+	}, {
+		name:        "invalid/url scheme",
+		params:      map[string]string{URLParam: "https://bucket/tasks/task.yaml"},
+		expectedErr: errors.New(`url https://bucket/tasks/task.yaml is not a valid s3://bucket/key url`),
+	}, {
+		name:        "invalid/url without key",
+		params:      map[string]string{URLParam: "s3://bucket/"},
+		expectedErr: errors.New(`url s3://bucket/ is not a valid s3://bucket/key url`),
+	}, {
+		name:        "invalid/empty region",
+		params:      map[string]string{URLParam: "s3://bucket/task.yaml", RegionParam: ""},
+		expectedErr: errors.New(`value region cannot be empty`),
+	}, {
+		name:        "invalid/region changing the host",
+		params:      map[string]string{URLParam: "s3://bucket/task.yaml", RegionParam: "evil.com/x?"},
+		expectedErr: errors.New(`region evil.com/x? is not a valid region name`),
+	}, {
+		name:        "invalid/region with fragment",
+		params:      map[string]string{URLParam: "s3://bucket/task.yaml", RegionParam: "evil.com#"},
+		expectedErr: errors.New(`region evil.com# is not a valid region name`),
+	}, {
+		name:        "invalid/bucket with port",
+		params:      map[string]string{URLParam: "s3://evil.com:443/task.yaml"},
+		expectedErr: errors.New(`bucket evil.com:443 of url s3://evil.com:443/task.yaml is not a valid s3 bucket name`),
+	}, {
+		name:        "invalid/url with credentials",
+		params:      map[string]string{URLParam: "s3://user@bucket/task.yaml"},
+		expectedErr: errors.New(`url s3://user@bucket/task.yaml is not a valid s3://bucket/key url`),
+	}, {
+		name:        "invalid/endpoint",
+		params:      map[string]string{URLParam: "s3://bucket/task.yaml", EndpointParam: "ftp://minio.example.com"},
+		expectedErr: errors.New(`endpoint ftp://minio.example.com is not a valid http(s) url`),
+	}, {
+		name:        "invalid/endpoint not allowed",
+		params:      map[string]string{URLParam: "s3://bucket/task.yaml", EndpointParam: "http://169.254.169.254"},
+		expectedErr: errors.New(`endpoint http://169.254.169.254 is not allowed, it must be the default-endpoint or one of the allowed-endpoints of the s3 resolver`),
+	}, {
+		name:        "invalid/empty secret",
+		params:      map[string]string{URLParam: "s3://bucket/task.yaml", SecretParam: ""},
+		expectedErr: errors.New(`value secret cannot be empty`),
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := Resolver{}
+			ctx := resolutionframework.InjectResolverConfigToContext(t.Context(), map[string]string{
+				AllowedEndpointsKey: "https://minio.example.com/, http://minio:9000",
+			})
+			err := resolver.Validate(ctx, &v1beta1.ResolutionRequestSpec{Params: toParams(tc.params)})
+			if tc.expectedErr != nil {
+				checkExpectedErr(t, tc.expectedErr, err)
+			} else if err != nil {
+				t.Fatalf("unexpected error validating params: %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPURL(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config map[string]string
+		params map[string]string
+		want   string
+	}{{
+		name:   "aws with default region",
+		params: map[string]string{URLParam: "s3://bucket/tasks/task.yaml"},
+		want:   "https://bucket.s3.us-east-1.amazonaws.com/tasks/task.yaml",
+	}, {
+		name:   "aws with region from config",
+		config: map[string]string{DefaultRegionKey: "eu-west-1"},
+		params: map[string]string{URLParam: "s3://bucket/tasks/task.yaml"},
+		want:   "https://bucket.s3.eu-west-1.amazonaws.com/tasks/task.yaml",
+	}, {
+		name:   "aws with region param",
+		config: map[string]string{DefaultRegionKey: "eu-west-1"},
+		params: map[string]string{URLParam: "s3://bucket/tasks/task.yaml", RegionParam: "ap-south-1"},
+		want:   "https://bucket.s3.ap-south-1.amazonaws.com/tasks/task.yaml",
+	}, {
+		name:   "endpoint from config",
+		config: map[string]string{DefaultEndpointKey: "https://minio.example.com/"},
+		params: map[string]string{URLParam: "s3://bucket/tasks/task.yaml"},
+		want:   "https://minio.example.com/bucket/tasks/task.yaml",
+	}, {
+		name:   "endpoint param with escaped key",
+		config: map[string]string{AllowedEndpointsKey: "http://minio:9000"},
+		params: map[string]string{URLParam: "s3://bucket/my tasks/task+v1.yaml", EndpointParam: "http://minio:9000"},
+		want:   "http://minio:9000/bucket/my%20tasks/task%2Bv1.yaml",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := resolutionframework.InjectResolverConfigToContext(t.Context(), tc.config)
+			obj, err := objectRequestFromParams(ctx, toParams(tc.params))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, obj.httpURL()); d != "" {
+				t.Errorf("unexpected url %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-creds", Namespace: "foo"},
+		Data: map[string][]byte{
+			AccessKeyIDSecretKey:     []byte("AKID"),
+			SecretAccessKeySecretKey: []byte("SECRET"),
+			SessionTokenSecretKey:    []byte("TOKEN"),
+		},
+	}
+	incompleteCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "incomplete-creds", Namespace: "foo"},
+		Data:       map[string][]byte{AccessKeyIDSecretKey: []byte("AKID")},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		key           string
+		secret        string
+		status        int
+		maxObjectSize string
+		expectedErr   error
+	}{{
+		name: "anonymous",
+		key:  "tasks/task.yaml",
+	}, {
+		name:   "signed with the secret credentials",
+		key:    "tasks/task.yaml",
+		secret: "s3-creds",
+	}, {
+		name:        "object not found",
+		key:         "tasks/missing.yaml",
+		status:      http.StatusNotFound,
+		expectedErr: errors.New(`error fetching object s3://bucket/tasks/missing.yaml: 404 Not Found`),
+	}, {
+		name:        "secret not found",
+		key:         "tasks/task.yaml",
+		secret:      "missing-creds",
+		expectedErr: errors.New(`cannot get credentials, secret missing-creds not found in namespace foo`),
+	}, {
+		name:        "secret without secret access key",
+		key:         "tasks/task.yaml",
+		secret:      "incomplete-creds",
+		expectedErr: errors.New(`cannot get credentials, key secretAccessKey not found in secret incomplete-creds in namespace foo`),
+	}, {
+		name:          "object too large",
+		key:           "tasks/task.yaml",
+		maxObjectSize: "10",
+		expectedErr:   errors.New(`object s3://bucket/tasks/task.yaml is larger than the max-object-size of 10 bytes`),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/bucket/"+tc.key {
+					t.Errorf("unexpected path %q", r.URL.Path)
+				}
+				auth := r.Header.Get("Authorization")
+				if tc.secret == "" && auth != "" {
+					t.Errorf("expected an anonymous request, got Authorization %q", auth)
+				}
+				if tc.secret != "" {
+					if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/us-east-1/s3/aws4_request") {
+						t.Errorf("unexpected Authorization %q", auth)
+					}
+					if token := r.Header.Get("X-Amz-Security-Token"); token != "TOKEN" {
+						t.Errorf("expected session token TOKEN, got %q", token)
+					}
+				}
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+				}
+				fmt.Fprint(w, sampleTask)
+			}))
+			defer svr.Close()
+
+			resolver := Resolver{
+				kubeClient: fakek8s.NewSimpleClientset(credentials, incompleteCredentials),
+				logger:     zaptest.NewLogger(t).Sugar(),
+			}
+			conf := map[string]string{AllowedEndpointsKey: svr.URL}
+			if tc.maxObjectSize != "" {
+				conf[MaxObjectSizeKey] = tc.maxObjectSize
+			}
+			ctx := resolutionframework.InjectResolverConfigToContext(t.Context(), conf)
+			ctx = resolutioncommon.InjectRequestNamespace(ctx, "foo")
+			params := map[string]string{
+				URLParam:      "s3://bucket/" + tc.key,
+				EndpointParam: svr.URL,
+			}
+			if tc.secret != "" {
+				params[SecretParam] = tc.secret
+			}
+
+			output, err := resolver.Resolve(ctx, &v1beta1.ResolutionRequestSpec{Params: toParams(params)})
+			if tc.expectedErr != nil {
+				checkExpectedErr(t, tc.expectedErr, err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving: %v", err)
+			}
+			if d := cmp.Diff(sampleTask, string(output.Data())); d != "" {
+				t.Errorf("unexpected data %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff("s3://bucket/"+tc.key, output.RefSource().URI); d != "" {
+				t.Errorf("unexpected refSource URI %s", diff.PrintWantGot(d))
+			}
+			if output.RefSource().Digest["sha256"] == "" {
+				t.Errorf("expected the sha256 digest of the object in the refSource")
+			}
+		})
+	}
+}
+
+func TestResolveNotEnabled(t *testing.T) {
+	resolver := Resolver{}
+	ctx := frameworktesting.ContextWithS3ResolverDisabled(context.Background())
+	req := &v1beta1.ResolutionRequestSpec{Params: toParams(map[string]string{URLParam: "s3://bucket/task.yaml"})}
+
+	_, err := resolver.Resolve(ctx, req)
+	checkExpectedErr(t, errors.New(disabledError), err)
+	err = resolver.Validate(ctx, req)
+	checkExpectedErr(t, errors.New(disabledError), err)
+}
+
+func toParams(m map[string]string) []pipelinev1.Param {
+	var params []pipelinev1.Param
+
+	for k, v := range m {
+		params = append(params, pipelinev1.Param{
+			Name:  k,
+			Value: *pipelinev1.NewStructuredValues(v),
+		})
+	}
+
+	return params
+}
+
+func checkExpectedErr(t *testing.T, expectedErr, actualErr error) {
+	t.Helper()
+	if actualErr == nil {
+		t.Fatalf("expected err '%v' but didn't get one", expectedErr)
+	}
+	if d := cmp.Diff(expectedErr.Error(), actualErr.Error()); d != "" {
+		t.Fatalf("expected err '%v' but got '%v'", expectedErr, actualErr)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	rrclient "github.com/tektoncd/pipeline/pkg/client/resolution/clientset/versioned"
 	rrlisters "github.com/tektoncd/pipeline/pkg/client/resolution/listers/resolution/v1beta1"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
@@ -88,6 +89,19 @@ func (r *CRDRequester) createResolutionRequest(ctx context.Context, resolver Res
 	}
 	rr := resolutionresource.CreateResolutionRequest(ctx, resolver, req.ResolverPayload().Name, req.ResolverPayload().Namespace, req.ResolverPayload().ResolutionSpec.Params, owner)
 	rr.Spec.URL = req.ResolverPayload().ResolutionSpec.URL
+	if bypassingReq, ok := req.(CacheBypassingRequest); ok && bypassingReq.BypassCache() {
+		setAnnotation(rr, resolutioncommon.AnnotationKeyCacheBypass, "true")
+	}
+	if saReq, ok := req.(ServiceAccountRequest); ok && saReq.ServiceAccountName() != "" {
+		setAnnotation(rr, resolutioncommon.AnnotationKeyServiceAccount, saReq.ServiceAccountName())
+	}
 	_, err := r.clientset.ResolutionV1beta1().ResolutionRequests(rr.Namespace).Create(ctx, rr, metav1.CreateOptions{})
 	return err
 }
+
+func setAnnotation(rr *v1beta1.ResolutionRequest, key, value string) {
+	if rr.Annotations == nil {
+		rr.Annotations = map[string]string{}
+	}
+	rr.Annotations[key] = value
+}
@@ -107,6 +107,11 @@ spec:
 `)
 	createdRR := baseRR.DeepCopy()
 	//
+	createdBypassingCacheRR := baseRR.DeepCopy()
+	createdBypassingCacheRR.Annotations = map[string]string{resolutioncommon.AnnotationKeyCacheBypass: "true"}
+	createdWithServiceAccountRR := baseRR.DeepCopy()
+	createdWithServiceAccountRR.Annotations = map[string]string{resolutioncommon.AnnotationKeyServiceAccount: "run-sa"}
+	//
 	unknownRR := baseRR.DeepCopy()
 	unknownRR.Status = *mustParseResolutionRequestStatus(t, `
 conditions:
@@ -151,6 +156,8 @@ conditions:
 		name                      string
 		inputRequest              *resolution.RawRequest
 		inputResolutionRequest    *v1beta1.ResolutionRequest
+		bypassCache               bool
+		serviceAccountName        string
 		expectedResolutionRequest *v1beta1.ResolutionRequest
 		expectedResolvedResource  *v1beta1.ResolutionRequest
 		expectedErr               error
@@ -163,6 +170,24 @@ conditions:
 			expectedResolvedResource:  nil,
 			expectedErr:               resolutioncommon.ErrRequestInProgress,
 		},
+		{
+			name:                      "resolution request bypassing the cache is created with the bypass annotation",
+			inputRequest:              request,
+			inputResolutionRequest:    nil,
+			bypassCache:               true,
+			expectedResolutionRequest: createdBypassingCacheRR.DeepCopy(),
+			expectedResolvedResource:  nil,
+			expectedErr:               resolutioncommon.ErrRequestInProgress,
+		},
+		{
+			name:                      "resolution request of a run with a service account is created with the service account annotation",
+			inputRequest:              request,
+			inputResolutionRequest:    nil,
+			serviceAccountName:        "run-sa",
+			expectedResolutionRequest: createdWithServiceAccountRR.DeepCopy(),
+			expectedResolvedResource:  nil,
+			expectedErr:               resolutioncommon.ErrRequestInProgress,
+		},
 		{
 			name:                      "resolution request exist and status is unknown",
 			inputRequest:              request,
@@ -212,8 +237,10 @@ conditions:
 			resolver := resolutioncommon.ResolverName("git")
 			crdRequester := resource.NewCRDRequester(clients.ResolutionRequests, testAssets.Informers.ResolutionRequest.Lister())
 			requestWithOwner := &ownerRequest{
-				Request:  tc.inputRequest.Request(),
-				ownerRef: *ownerRef,
+				Request:            tc.inputRequest.Request(),
+				ownerRef:           *ownerRef,
+				bypassCache:        tc.bypassCache,
+				serviceAccountName: tc.serviceAccountName,
 			}
 			resolvedResource, err := crdRequester.Submit(ctx, resolver, requestWithOwner)
 
@@ -261,13 +288,23 @@ conditions:
 
 type ownerRequest struct {
 	resource.Request
-	ownerRef metav1.OwnerReference
+	ownerRef           metav1.OwnerReference
+	bypassCache        bool
+	serviceAccountName string
 }
 
 func (r *ownerRequest) OwnerRef() metav1.OwnerReference {
 	return r.ownerRef
 }
 
+func (r *ownerRequest) BypassCache() bool {
+	return r.bypassCache
+}
+
+func (r *ownerRequest) ServiceAccountName() string {
+	return r.serviceAccountName
+}
+
 func mustParseRawRequest(t *testing.T, yamlStr string) *resolution.RawRequest {
 	t.Helper()
 	output := &resolution.RawRequest{}
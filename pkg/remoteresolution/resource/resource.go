@@ -32,6 +32,16 @@ type ResolverName = common.ResolverName
 // made.
 type OwnedRequest = common.OwnedRequest
 
+// CacheBypassingRequest is implemented by any type implementing Request that
+// can ask resolvers to fetch the remote resource again instead of using their
+// cache.
+type CacheBypassingRequest = common.CacheBypassingRequest
+
+// ServiceAccountRequest is implemented by any type implementing Request that
+// is made on behalf of a run with a service account resolvers can
+// authenticate to remote sources as.
+type ServiceAccountRequest = common.ServiceAccountRequest
+
 // ResolvedResource is implemented by any type that offers a read-only
 // view of the data and metadata of a resolved remote resource.
 type ResolvedResource = common.ResolvedResource
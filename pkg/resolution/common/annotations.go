@@ -22,4 +22,14 @@ const (
 	// AnnotationKeyContentType is the annotation key passed back
 	// with a resolved resource's content type.
 	AnnotationKeyContentType = resolution.GroupName + "/content-type"
+
+	// AnnotationKeyCacheBypass is the annotation key which, set to "true" on a TaskRun
+	// or a PipelineRun, is passed on to its ResolutionRequests so that resolvers fetch
+	// the remote resources again instead of using their cache.
+	AnnotationKeyCacheBypass = resolution.GroupName + "/cache-bypass"
+
+	// AnnotationKeyServiceAccount is the annotation key set on a ResolutionRequest to
+	// the service account of the TaskRun or PipelineRun it was created for, so that
+	// resolvers can authenticate to remote sources as that service account.
+	AnnotationKeyServiceAccount = resolution.GroupName + "/service-account"
 )
@@ -74,3 +74,23 @@ func RequestName(ctx context.Context) string {
 	}
 	return ""
 }
+
+// requestServiceAccountContextKey is the key stored in a context alongside
+// the string service account a resolution request is made on behalf of.
+type requestServiceAccountContextKey struct{}
+
+// InjectRequestServiceAccount returns a new context with the request-scoped
+// service account of the run the request is made on behalf of.
+func InjectRequestServiceAccount(ctx context.Context, serviceAccount string) context.Context {
+	return context.WithValue(ctx, requestServiceAccountContextKey{}, serviceAccount)
+}
+
+// RequestServiceAccount returns the service account of the run the
+// resolution request currently being processed is made on behalf of, or an
+// empty string if none was registered.
+func RequestServiceAccount(ctx context.Context) string {
+	if str, ok := ctx.Value(requestServiceAccountContextKey{}).(string); ok {
+		return str
+	}
+	return ""
+}
@@ -63,3 +63,17 @@ func TestRequestName(t *testing.T) {
 		t.Fatalf("expected empty namespace returned if no value was previously injected")
 	}
 }
+
+func TestRequestServiceAccount(t *testing.T) {
+	ctx := common.InjectRequestServiceAccount(t.Context(), "foo")
+	if common.RequestServiceAccount(ctx) != "foo" {
+		t.Fatalf("expected service account to be stored as part of context")
+	}
+	if common.RequestNamespace(ctx) != "" {
+		t.Fatalf("expected service account not to be stored as the namespace")
+	}
+
+	if common.RequestServiceAccount(t.Context()) != "" {
+		t.Fatalf("expected empty service account returned if no value was previously injected")
+	}
+}
@@ -58,6 +58,20 @@ type OwnedRequest interface {
 	OwnerRef() metav1.OwnerReference
 }
 
+// CacheBypassingRequest is implemented by any type implementing Request that
+// can ask resolvers to fetch the remote resource again instead of using their
+// cache.
+type CacheBypassingRequest interface {
+	BypassCache() bool
+}
+
+// ServiceAccountRequest is implemented by any type implementing Request that
+// is made on behalf of a run with a service account resolvers can
+// authenticate to remote sources as.
+type ServiceAccountRequest interface {
+	ServiceAccountName() string
+}
+
 // ResolvedResource is implemented by any type that offers a read-only
 // view of the data and metadata of a resolved remote resource.
 type ResolvedResource interface {
@@ -20,6 +20,7 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/name"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	"github.com/tektoncd/pipeline/pkg/resolution/resource"
 )
@@ -60,7 +61,11 @@ func OptionsFromParams(ctx context.Context, params []pipelinev1.Param) (RequestO
 	saVal, ok := paramsMap[ParamServiceAccount]
 	sa := ""
 	if !ok || saVal.StringVal == "" {
-		if saString, ok := conf[ConfigServiceAccount]; ok {
+		// Fall back to the service account of the run the request is made on
+		// behalf of, so that its imagePullSecrets and workload identity are used.
+		if runSA := common.RequestServiceAccount(ctx); runSA != "" {
+			sa = runSA
+		} else if saString, ok := conf[ConfigServiceAccount]; ok {
 			sa = saString
 		} else {
 			return opts, errors.New("default Service Account was not set during installation of the bundle resolver")
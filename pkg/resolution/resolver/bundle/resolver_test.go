@@ -147,6 +147,60 @@ func TestValidateParamsServiceAccount(t *testing.T) {
 	}
 }
 
+func TestOptionsFromParamsServiceAccount(t *testing.T) {
+	params := []pipelinev1.Param{{
+		Name:  bundle.ParamKind,
+		Value: *pipelinev1.NewStructuredValues("task"),
+	}, {
+		Name:  bundle.ParamName,
+		Value: *pipelinev1.NewStructuredValues("foo"),
+	}, {
+		Name:  bundle.ParamBundle,
+		Value: *pipelinev1.NewStructuredValues("bar"),
+	}}
+	paramsWithServiceAccount := append(params, pipelinev1.Param{
+		Name:  bundle.ParamServiceAccount,
+		Value: *pipelinev1.NewStructuredValues("param-sa"),
+	})
+
+	for _, tc := range []struct {
+		name   string
+		params []pipelinev1.Param
+		runSA  string
+		want   string
+	}{{
+		name:   "serviceAccount param takes precedence",
+		params: paramsWithServiceAccount,
+		runSA:  "run-sa",
+		want:   "param-sa",
+	}, {
+		name:   "run service account is used without serviceAccount param",
+		params: params,
+		runSA:  "run-sa",
+		want:   "run-sa",
+	}, {
+		name:   "default service account is used without run service account",
+		params: params,
+		want:   "default",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{
+				bundle.ConfigServiceAccount: "default",
+			})
+			if tc.runSA != "" {
+				ctx = common.InjectRequestServiceAccount(ctx, tc.runSA)
+			}
+			opts, err := bundle.OptionsFromParams(ctx, tc.params)
+			if err != nil {
+				t.Fatalf("unexpected error getting options from params: %v", err)
+			}
+			if opts.ServiceAccount != tc.want {
+				t.Errorf("expected service account %q, got %q", tc.want, opts.ServiceAccount)
+			}
+		})
+	}
+}
+
 func TestValidateParamsDisabled(t *testing.T) {
 	resolver := bundle.Resolver{}
 
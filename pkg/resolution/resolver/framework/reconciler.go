@@ -98,6 +98,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 	// configuration from the configmap this resolver is watching.
 	ctx = resolutioncommon.InjectRequestNamespace(ctx, namespace)
 	ctx = resolutioncommon.InjectRequestName(ctx, name)
+	if sa := rr.Annotations[resolutioncommon.AnnotationKeyServiceAccount]; sa != "" {
+		ctx = resolutioncommon.InjectRequestServiceAccount(ctx, sa)
+	}
 	if r.configStore != nil {
 		ctx = r.configStore.ToContext(ctx)
 	}
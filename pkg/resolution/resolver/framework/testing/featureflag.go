@@ -48,6 +48,11 @@ func ContextWithHttpResolverDisabled(ctx context.Context) context.Context {
 	return contextWithResolverDisabled(ctx, "enable-http-resolver")
 }
 
+// ContextWithS3ResolverDisabled returns a context containing a Config with the enable-s3-resolver feature flag disabled.
+func ContextWithS3ResolverDisabled(ctx context.Context) context.Context {
+	return contextWithResolverDisabled(ctx, "enable-s3-resolver")
+}
+
 func contextWithResolverDisabled(ctx context.Context, resolverFlag string) context.Context {
 	featureFlags, _ := resolverconfig.NewFeatureFlagsFromMap(map[string]string{
 		resolverFlag: "false",
@@ -45,4 +45,8 @@ const (
 	ServerURLParam string = "serverURL"
 	// ConfigKeyParam is an optional string to provid which scm configuration to use from git resolver configmap
 	ConfigKeyParam string = "configKey"
+	// SparseCheckoutParam is an optional boolean to only fetch and check out the pathInRepo file when cloning
+	SparseCheckoutParam string = "sparseCheckout"
+	// SubmodulesParam is an optional boolean to check out the submodules of the repository when cloning
+	SubmodulesParam string = "submodules"
 )
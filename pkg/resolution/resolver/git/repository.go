@@ -21,8 +21,10 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"strings"
 )
@@ -30,10 +32,11 @@ import (
 type cmdExecutor = func(context.Context, string, ...string) *exec.Cmd
 
 type remote struct {
-	url         string
-	username    string
-	password    string
-	cmdExecutor cmdExecutor
+	url            string
+	username       string
+	password       string
+	sparseCheckout bool
+	cmdExecutor    cmdExecutor
 }
 
 func (r remote) clone(ctx context.Context) (*repository, func(), error) {
@@ -55,7 +58,12 @@ func (r remote) clone(ctx context.Context) (*repository, func(), error) {
 		executor:  r.cmdExecutor,
 	}
 
-	_, err = repo.execGit(ctx, "clone", repo.url, tmpDir, "--depth=1", "--no-checkout")
+	args := []string{repo.url, tmpDir, "--depth=1", "--no-checkout"}
+	if r.sparseCheckout {
+		// Only fetch the blobs of the files which are checked out.
+		args = append(args, "--filter=blob:none", "--sparse")
+	}
+	_, err = repo.execGit(ctx, "clone", args...)
 	if err != nil {
 		if strings.Contains(err.Error(), "could not read Username") {
 			err = errors.New("clone error: authentication required")
@@ -71,6 +79,8 @@ type repository struct {
 	password  string
 	directory string
 	executor  cmdExecutor
+	// authURL restricts the credentials to the URLs it prefixes when set.
+	authURL string
 }
 
 func (repo *repository) currentRevision(ctx context.Context) (string, error) {
@@ -95,6 +105,39 @@ func (repo *repository) checkout(ctx context.Context, revision string) error {
 	return nil
 }
 
+// setSparseCheckout restricts the files checked out to the given paths. It must be
+// called before checkout on a repository cloned with sparseCheckout.
+func (repo *repository) setSparseCheckout(ctx context.Context, paths ...string) error {
+	args := []string{"set", "--no-cone"}
+	for _, p := range paths {
+		args = append(args, "/"+path.Clean(strings.TrimPrefix(p, "/")))
+	}
+	_, err := repo.execGit(ctx, "sparse-checkout", args...)
+	return err
+}
+
+// updateSubmodules checks out the submodules of the checked out revision, recursively.
+func (repo *repository) updateSubmodules(ctx context.Context) error {
+	// The credentials are only sent to the submodules hosted alongside the repository.
+	submodulesRepo := *repo
+	submodulesRepo.authURL = hostURL(repo.url)
+	if submodulesRepo.authURL == "" {
+		submodulesRepo.username, submodulesRepo.password = "", ""
+	}
+	_, err := submodulesRepo.execGit(ctx, "submodule", "update", "--init", "--recursive", "--depth=1")
+	return err
+}
+
+// hostURL returns the scheme and host of an http(s) repository URL, or an empty
+// string for any other URL.
+func hostURL(repoURL string) string {
+	u, err := url.Parse(repoURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host + "/"
+}
+
 func (repo *repository) execGit(ctx context.Context, subCmd string, args ...string) ([]byte, error) {
 	if repo.executor == nil {
 		repo.executor = exec.CommandContext
@@ -116,7 +159,11 @@ func (repo *repository) execGit(ctx context.Context, subCmd string, args ...stri
 			env,
 			"GIT_AUTH_HEADER=Authorization: Basic "+token,
 		)
-		configArgs = append(configArgs, "--config-env", "http.extraHeader=GIT_AUTH_HEADER")
+		extraHeaderKey := "http.extraHeader"
+		if repo.authURL != "" {
+			extraHeaderKey = "http." + repo.authURL + ".extraHeader"
+		}
+		configArgs = append(configArgs, "--config-env", extraHeaderKey+"=GIT_AUTH_HEADER")
 	}
 
 	cmd := repo.executor(ctx, "git", append(configArgs, args...)...)
@@ -28,10 +28,11 @@ import (
 
 func TestClone(t *testing.T) {
 	type testCase struct {
-		url       string
-		username  string
-		password  string
-		expectErr string
+		url            string
+		username       string
+		password       string
+		sparseCheckout bool
+		expectErr      string
 	}
 
 	testCases := map[string]testCase{
@@ -39,6 +40,7 @@ func TestClone(t *testing.T) {
 		"normal usage with .git": {url: "https://github.com/tektoncd/pipeline.git"},
 		"private repository":     {url: "https://github.com/tektoncd/not-a-repository.git"},
 		"with crendentials":      {url: "https://github.com/tektoncd/not-a-repository.git", username: "fake", password: "fake"},
+		"sparse checkout":        {url: "https://github.com/tektoncd/pipeline", sparseCheckout: true},
 	}
 
 	for name, test := range testCases {
@@ -53,7 +55,7 @@ func TestClone(t *testing.T) {
 				return cmd
 			}
 
-			mockCmdRemote := remote{url: test.url, username: test.username, password: test.password, cmdExecutor: executor}
+			mockCmdRemote := remote{url: test.url, username: test.username, password: test.password, sparseCheckout: test.sparseCheckout, cmdExecutor: executor}
 			repo, cleanup, err := mockCmdRemote.clone(t.Context())
 			defer cleanup()
 			if test.expectErr != "" {
@@ -74,6 +76,9 @@ func TestClone(t *testing.T) {
 				expectedEnv = append(expectedEnv, "GIT_AUTH_HEADER=Authorization: Basic "+token)
 			}
 			expectedCmd = append(expectedCmd, "clone", test.url, repo.directory, "--depth=1", "--no-checkout")
+			if test.sparseCheckout {
+				expectedCmd = append(expectedCmd, "--filter=blob:none", "--sparse")
+			}
 
 			if len(executions) != 1 {
 				t.Fatalf("Expected 1 command execution during cloning, got %d: %v", len(executions), executions)
@@ -170,3 +175,114 @@ func TestCheckout(t *testing.T) {
 		})
 	}
 }
+
+func TestSparseCheckoutAndSubmodules(t *testing.T) {
+	submodulePath, _ := createTestRepo(t, []commitForRepo{{
+		Dir:      "tasks/",
+		Filename: "task.yaml",
+		Content:  "task in submodule",
+	}})
+	repoPath, _ := createTestRepo(t, []commitForRepo{{
+		Dir:      "pipelines/",
+		Filename: "pipeline.yaml",
+		Content:  "pipeline",
+	}, {
+		Dir:      "other/",
+		Filename: "other.yaml",
+		Content:  "other",
+	}})
+	gitCmd := getGitCmd(t, repoPath)
+	// Allow the local submodule to be cloned, see CVE-2022-39253
+	if out, err := gitCmd("config", "--global", "protocol.file.allow", "always").CombinedOutput(); err != nil {
+		t.Fatalf("couldn't allow the file protocol: %v: %s", err, out)
+	}
+	if out, err := gitCmd("submodule", "add", submodulePath, "modules/sub").CombinedOutput(); err != nil {
+		t.Fatalf("couldn't add submodule: %v: %s", err, out)
+	}
+	if out, err := gitCmd("commit", "-m", "add submodule").CombinedOutput(); err != nil {
+		t.Fatalf("couldn't commit submodule: %v: %s", err, out)
+	}
+
+	ctx := t.Context()
+	testCases := map[string]struct {
+		path           string
+		sparseCheckout bool
+		submodules     bool
+		expectedFile   string
+		missingFile    string
+	}{
+		"sparse checkout": {
+			path:           "./pipelines/pipeline.yaml",
+			sparseCheckout: true,
+			expectedFile:   "pipeline",
+			missingFile:    "other/other.yaml",
+		},
+		"submodules": {
+			path:         "modules/sub/tasks/task.yaml",
+			submodules:   true,
+			expectedFile: "task in submodule",
+		},
+		"sparse checkout in a submodule": {
+			path:           "modules/sub/tasks/task.yaml",
+			sparseCheckout: true,
+			submodules:     true,
+			expectedFile:   "task in submodule",
+			missingFile:    "pipelines/pipeline.yaml",
+		},
+		"submodules not checked out": {
+			path:        "modules/sub/tasks/task.yaml",
+			missingFile: "modules/sub/tasks/task.yaml",
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			repo, cleanup, err := remote{url: repoPath, sparseCheckout: test.sparseCheckout}.clone(ctx)
+			defer cleanup()
+			if err != nil {
+				t.Fatalf("Error cloning repository %v", err)
+			}
+			if test.sparseCheckout {
+				if err := repo.setSparseCheckout(ctx, test.path); err != nil {
+					t.Fatalf("Error setting sparse checkout: %v", err)
+				}
+			}
+			if err := repo.checkout(ctx, "main"); err != nil {
+				t.Fatalf("Error checking out revision: %v", err)
+			}
+			if test.submodules {
+				if err := repo.updateSubmodules(ctx); err != nil {
+					t.Fatalf("Error updating submodules: %v", err)
+				}
+			}
+
+			if test.expectedFile != "" {
+				content, err := repo.getFileContent(test.path)
+				if err != nil {
+					t.Fatalf("Error getting file content: %v", err)
+				}
+				if string(content) != test.expectedFile {
+					t.Errorf("Expected file content %q but got %q", test.expectedFile, content)
+				}
+			}
+			if test.missingFile != "" {
+				if _, err := repo.getFileContent(test.missingFile); err == nil {
+					t.Errorf("Expected %s not to be checked out", test.missingFile)
+				}
+			}
+		})
+	}
+}
+
+func TestHostURL(t *testing.T) {
+	for repoURL, expected := range map[string]string{
+		"https://github.com/tektoncd/pipeline.git": "https://github.com/",
+		"http://gitea.local:3000/org/repo":         "http://gitea.local:3000/",
+		"git@github.com:tektoncd/pipeline.git":     "",
+		"/tmp/repo":                                "",
+	} {
+		if got := hostURL(repoURL); got != expected {
+			t.Errorf("hostURL(%q) = %q, expected %q", repoURL, got, expected)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -219,18 +220,32 @@ func (g *GitResolver) ResolveGitClone(ctx context.Context) (framework.ResolvedRe
 	}
 
 	path := g.Params[PathParam]
+	sparseCheckout, _ := strconv.ParseBool(g.Params[SparseCheckoutParam])
+	submodules, _ := strconv.ParseBool(g.Params[SubmodulesParam])
 
-	repo, cleanupFunc, err := remote{url: repoURL, username: username, password: password}.clone(ctx)
+	repo, cleanupFunc, err := remote{url: repoURL, username: username, password: password, sparseCheckout: sparseCheckout}.clone(ctx)
 	defer cleanupFunc()
 	if err != nil {
 		return nil, fmt.Errorf("error resolving repository: %w", err)
 	}
 
+	if sparseCheckout {
+		if err := repo.setSparseCheckout(ctx, path); err != nil {
+			return nil, err
+		}
+	}
+
 	err = repo.checkout(ctx, revision)
 	if err != nil {
 		return nil, err
 	}
 
+	if submodules {
+		if err := repo.updateSubmodules(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	fullRevision, err := repo.currentRevision(ctx)
 	if err != nil {
 		return nil, err
@@ -399,6 +414,20 @@ func PopulateDefaultParams(ctx context.Context, params []pipelinev1.Param) (map[
 		return nil, fmt.Errorf("invalid git repository url: %s", paramsMap[UrlParam])
 	}
 
+	// the sparse checkout and submodules options only apply to cloning
+	for _, cloneParam := range []string{SparseCheckoutParam, SubmodulesParam} {
+		v, ok := paramsMap[cloneParam]
+		if !ok {
+			continue
+		}
+		if _, err := strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("invalid value %q for param '%s', must be true or false", v, cloneParam)
+		}
+		if paramsMap[RepoParam] != "" {
+			return nil, fmt.Errorf("'%s' is only supported when cloning with '%s'", cloneParam, UrlParam)
+		}
+	}
+
 	// TODO(sbwsg): validate pathInRepo is valid relative pathInRepo
 	return paramsMap, nil
 }
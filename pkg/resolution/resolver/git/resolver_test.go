@@ -110,6 +110,16 @@ func TestValidateParams(t *testing.T) {
 				RevisionParam: "baz",
 			},
 		},
+		{
+			name: "sparse checkout and submodules",
+			params: map[string]string{
+				UrlParam:            "https://foo/bar/hello/moto",
+				PathParam:           "bar",
+				RevisionParam:       "baz",
+				SparseCheckoutParam: "true",
+				SubmodulesParam:     "false",
+			},
+		},
 		{
 			name: "bad url",
 			params: map[string]string{
@@ -193,6 +203,25 @@ func TestValidateParams_Failure(t *testing.T) {
 				RepoParam:     "foo",
 			},
 			expectedErr: "'org' is required when 'repo' is specified",
+		}, {
+			name: "invalid sparse checkout",
+			params: map[string]string{
+				RevisionParam:       "abcd1234",
+				PathParam:           "/foo/bar",
+				UrlParam:            "http://foo",
+				SparseCheckoutParam: "yes please",
+			},
+			expectedErr: `invalid value "yes please" for param 'sparseCheckout', must be true or false`,
+		}, {
+			name: "submodules with repo",
+			params: map[string]string{
+				RevisionParam:   "abcd1234",
+				PathParam:       "/foo/bar",
+				OrgParam:        "abcd1234",
+				RepoParam:       "foo",
+				SubmodulesParam: "true",
+			},
+			expectedErr: "'submodules' is only supported when cloning with 'url'",
 		},
 	}
 
@@ -40,6 +40,9 @@ const (
 
 	// TaskRunArtifactsResultType default taskRun artifacts result value
 	TaskRunArtifactsResultType ResultType = 6
+
+	// WhenFileResultType is the content of a file tested by a when expression
+	WhenFileResultType ResultType = 7
 )
 
 // RunResult is used to write key/value pairs to TaskRun pod termination messages.
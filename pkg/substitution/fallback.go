@@ -0,0 +1,110 @@
+//go:build !disable_tls
+
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package substitution
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// paramFallbackRegex matches references to a parameter with a fallback value, e.g. `$(params.foo | default "bar")`.
+// The referenced parameter can use the dot or bracket notation and refer to an object key or an array index.
+var paramFallbackRegex = regexp.MustCompile(`\$\((params(?:\.[_a-zA-Z0-9.-]+|\['[_a-zA-Z0-9./-]+'\]|\["[_a-zA-Z0-9./-]+"\])(?:\[[0-9]+\])?)\s*\|\s*default\s+("(?:[^"\\]|\\.)*")\)`)
+
+// ParamFallback is a reference to a parameter with a fallback value, e.g. `$(params.foo | default "bar")`.
+type ParamFallback struct {
+	// Expression is the whole reference without the surrounding "$(" and ")", e.g. `params.foo | default "bar"`.
+	Expression string
+	// Reference is the referenced variable, e.g. "params.foo".
+	Reference string
+	// Default is the unquoted fallback value, e.g. "bar".
+	Default string
+}
+
+// ExtractParamFallbacks returns the references to parameters with a fallback value found in s.
+func ExtractParamFallbacks(s string) ([]ParamFallback, error) {
+	var fallbacks []ParamFallback
+	for _, match := range paramFallbackRegex.FindAllStringSubmatch(s, -1) {
+		value, err := strconv.Unquote(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid fallback value %s in %q: %w", match[2], match[0], err)
+		}
+		fallbacks = append(fallbacks, ParamFallback{
+			Expression: match[0][2 : len(match[0])-1],
+			Reference:  match[1],
+			Default:    value,
+		})
+	}
+	return fallbacks, nil
+}
+
+// ParamFallbackReplacements returns the replacements for the references to parameters with a fallback value found
+// in values. A reference resolves to the value of the parameter in stringReplacements, or to its fallback value if
+// the parameter has no value or its value is empty. Invalid fallback values are left unresolved.
+func ParamFallbackReplacements(values []string, stringReplacements map[string]string) map[string]string {
+	replacements := map[string]string{}
+	for _, value := range values {
+		fallbacks, err := ExtractParamFallbacks(value)
+		if err != nil {
+			continue
+		}
+		for _, fallback := range fallbacks {
+			if v := stringReplacements[fallback.Reference]; v != "" {
+				replacements[fallback.Expression] = v
+			} else {
+				replacements[fallback.Expression] = fallback.Default
+			}
+		}
+	}
+	return replacements
+}
+
+// StripParamFallbacks returns s with the fallback values removed from references to parameters,
+// e.g. `$(params.foo | default "bar")` becomes `$(params.foo)`.
+func StripParamFallbacks(s string) string {
+	return paramFallbackRegex.ReplaceAllString(s, "$$($1)")
+}
+
+// ValidateParamFallbacks returns an error if the input string contains a reference with a fallback value
+// to the whole of any variable in vars, or if a fallback value is not a valid quoted string.
+// Fallback values are only supported for references resolving to a string, so vars are typically the names
+// of array and object parameters, whose individual elements and keys can still have fallback values.
+func ValidateParamFallbacks(value string, vars sets.String) *apis.FieldError {
+	fallbacks, err := ExtractParamFallbacks(value)
+	if err != nil {
+		return &apis.FieldError{
+			Message: err.Error(),
+			// Empty path is required to make the `ViaField`, … work
+			Paths: []string{""},
+		}
+	}
+	for _, fallback := range fallbacks {
+		if referencesParam(fallback.Reference, vars) {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("fallback values are only supported for references to strings in %q", value),
+				Paths:   []string{""},
+			}
+		}
+	}
+	return nil
+}
@@ -18,9 +18,14 @@ package substitution
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
+// arrayJoinRegex matches references joining the elements of an array parameter with a separator,
+// e.g. `$(params.list[*]:,)`. The separator is captured as is and may be empty, which validation rejects.
+var arrayJoinRegex = regexp.MustCompile(`\$\((params(?:\.[_a-zA-Z0-9.-]+|\['[_a-zA-Z0-9./-]+'\]|\["[_a-zA-Z0-9./-]+"\]))\[\*\]:([^()$]*)\)`)
+
 // ApplyReplacements returns a string with references to parameters replaced,
 // based on the mapping provided in replacements.
 // For example, if the input string is "foo: $(params.foo)", and replacements maps "params.foo" to "bar",
@@ -59,3 +64,18 @@ func ApplyArrayReplacements(in string, stringReplacements map[string]string, arr
 	// Otherwise return a size-1 array containing the input string with standard stringReplacements applied.
 	return []string{ApplyReplacements(in, stringReplacements)}
 }
+
+// ApplyArrayJoinReplacements returns a string with references joining the elements of an array, e.g. `$(params.list[*]:,)`,
+// replaced by the elements of the corresponding array in arrayReplacements joined with the separator.
+// For example, if the input string is "$(params.list[*]:,)", and arrayReplacements maps "params.list" to ["a", "b"],
+// the output would be "a,b". References to unknown arrays are left as is.
+func ApplyArrayJoinReplacements(in string, arrayReplacements map[string][]string) string {
+	return arrayJoinRegex.ReplaceAllStringFunc(in, func(ref string) string {
+		match := arrayJoinRegex.FindStringSubmatch(ref)
+		v, ok := arrayReplacements[match[1]]
+		if !ok {
+			return ref
+		}
+		return strings.Join(v, match[2])
+	})
+}
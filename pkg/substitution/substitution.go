@@ -50,6 +50,9 @@ var paramIndexingRegex = regexp.MustCompile(paramIndexing)
 // intIndexRegex will match all `[int]` for param expression
 var intIndexRegex = regexp.MustCompile(intIndex)
 
+// bracketedKeyRegex matches a variable followed by a key in bracket notation, e.g. `labels['tekton.dev/pipeline']`
+var bracketedKeyRegex = regexp.MustCompile(`^([^.\[\]]+)\[(?:'[^']*'|"[^"]*")\]$`)
+
 // ValidateNoReferencesToUnknownVariables returns an error if the input string contains references to unknown variables
 // Inputs:
 // - value: a string containing a reference to a variable that can be substituted, e.g. "echo $(params.foo)"
@@ -160,6 +163,7 @@ func ValidateNoReferencesToEntireProhibitedVariables(value, prefix string, vars
 // - vars: names of known variables
 func ValidateVariableReferenceIsIsolated(value, prefix string, vars sets.String) *apis.FieldError {
 	paths := []string{""} // Empty path is required to make the `ViaField`, … work
+	value = StripParamFallbacks(value)
 	if vs, present, errString := ExtractVariablesFromString(value, prefix); present {
 		if errString != "" {
 			return &apis.FieldError{
@@ -231,7 +235,9 @@ func extractExpressionFromString(s, prefix string) (string, error) {
 // It returns a slice of strings which contains the extracted variables, a bool flag to indicate if matches were found
 // and the error string if the referencing of parameters is invalid.
 // If the string does not contain the input prefix then the output will contain a slice of strings with length 0.
+// Fallback values of parameter references, e.g. `$(params.foo | default "bar")`, are ignored.
 func ExtractVariablesFromString(s, prefix string) ([]string, bool, string) {
+	s = StripParamFallbacks(s)
 	pattern := fmt.Sprintf(braceMatchingRegex, prefix, parameterSubstitution, parameterSubstitution, parameterSubstitution)
 	re, err := regexp.Compile(pattern)
 	if err != nil {
@@ -252,9 +258,14 @@ func ExtractVariablesFromString(s, prefix string) ([]string, bool, string) {
 			// Valid Examples:
 			//  - extract "aString" from <prefix>.aString
 			//  - extract "anObject" from <prefix>.anObject.key
+			//  - extract "labels" from <prefix>.labels['tekton.dev/pipeline']
 			// Invalid Examples:
 			//  - <prefix>.foo.bar.baz....
 			if j == 0 && strings.Contains(val, ".") {
+				if match := bracketedKeyRegex.FindStringSubmatch(val); match != nil {
+					vars[i] = match[1]
+					break
+				}
 				if len(strings.Split(val, ".")) > 2 {
 					errString = fmt.Sprintf(`Invalid referencing of parameters in "%s"! Only two dot-separated components after the prefix "%s" are allowed.`, s, prefix)
 					return vars, true, errString
@@ -273,6 +284,7 @@ func ExtractVariablesFromString(s, prefix string) ([]string, bool, string) {
 
 // extractEntireVariablesFromString returns any references to entire array or object params in s with the given prefix
 func extractEntireVariablesFromString(s, prefix string) ([]string, error) {
+	s = StripParamFallbacks(s)
 	pattern := fmt.Sprintf(braceMatchingRegex, prefix, parameterSubstitution, parameterSubstitution, parameterSubstitution)
 	re, err := regexp.Compile(pattern)
 	if err != nil {
@@ -346,3 +358,41 @@ func ExtractIndex(s string) (int, error) {
 func StripStarVarSubExpression(s string) string {
 	return strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(s, "$("), ")"), "[*]")
 }
+
+// StripArrayJoins returns s with the references joining the elements of an array parameter removed,
+// e.g. `FOO=$(params.list[*]:,)` becomes `FOO=`.
+func StripArrayJoins(s string) string {
+	return arrayJoinRegex.ReplaceAllString(s, "")
+}
+
+// ValidateArrayJoins returns an error if the input string contains a reference joining the elements of a
+// variable which is not in vars, or if the separator of such a reference is empty.
+// vars are typically the names of the array parameters.
+func ValidateArrayJoins(value string, vars sets.String) *apis.FieldError {
+	for _, match := range arrayJoinRegex.FindAllStringSubmatch(value, -1) {
+		if match[2] == "" {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("empty separator in %q", value),
+				Paths:   []string{""},
+			}
+		}
+		if !referencesParam(match[1], vars) {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("only array parameters can be joined in %q", value),
+				Paths:   []string{""},
+			}
+		}
+	}
+	return nil
+}
+
+// referencesParam returns true if ref, e.g. "params.foo" or `params["foo"]`, is a reference to the whole of
+// any of the parameters in names.
+func referencesParam(ref string, names sets.String) bool {
+	for _, name := range names.List() {
+		if ref == "params."+name || ref == fmt.Sprintf("params[%q]", name) || ref == fmt.Sprintf("params['%s']", name) {
+			return true
+		}
+	}
+	return false
+}
@@ -785,6 +785,13 @@ func TestExtractVariablesFromString(t *testing.T) {
 		want:      []string{""},
 		extracted: true,
 		err:       `Invalid referencing of parameters in "--flag=$(inputs.params.foo.baz.bar)"! Only two dot-separated components after the prefix "inputs.params" are allowed.`,
+	}, {
+		name:      "key with dots in bracket notation",
+		s:         "--flag=$(context.taskRun.labels['tekton.dev/pipeline']) $(context.taskRun.annotations[\"example.com/sha\"])",
+		prefix:    "context.taskRun",
+		want:      []string{"labels", "annotations"},
+		extracted: true,
+		err:       "",
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -801,3 +808,196 @@ func TestExtractVariablesFromString(t *testing.T) {
 		})
 	}
 }
+
+func TestParamFallbackReplacements(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		values             []string
+		stringReplacements map[string]string
+		want               map[string]string
+	}{{
+		name:   "no fallbacks",
+		values: []string{"echo $(params.foo)"},
+		want:   map[string]string{},
+	}, {
+		name:               "param has a value",
+		values:             []string{`echo $(params.foo | default "bar")`},
+		stringReplacements: map[string]string{"params.foo": "value"},
+		want:               map[string]string{`params.foo | default "bar"`: "value"},
+	}, {
+		name:               "param is empty",
+		values:             []string{`echo $(params.foo | default "bar")`},
+		stringReplacements: map[string]string{"params.foo": ""},
+		want:               map[string]string{`params.foo | default "bar"`: "bar"},
+	}, {
+		name:   "param is missing",
+		values: []string{`echo $(params.foo|default "bar")`},
+		want:   map[string]string{`params.foo|default "bar"`: "bar"},
+	}, {
+		name:   "bracket notation, object key and array index",
+		values: []string{`$(params["foo"] | default "a") $(params.obj.key | default "b") $(params.arr[1] | default "c")`},
+		stringReplacements: map[string]string{
+			`params["foo"]`:  "foo-value",
+			"params.obj.key": "key-value",
+		},
+		want: map[string]string{
+			`params["foo"] | default "a"`:  "foo-value",
+			`params.obj.key | default "b"`: "key-value",
+			`params.arr[1] | default "c"`:  "c",
+		},
+	}, {
+		name:   "escaped quotes in fallback value",
+		values: []string{`$(params.foo | default "say \"hi\"")`},
+		want:   map[string]string{`params.foo | default "say \"hi\""`: `say "hi"`},
+	}, {
+		name:   "invalid fallback value is left unresolved",
+		values: []string{`$(params.foo | default "\q")`},
+		want:   map[string]string{},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := substitution.ParamFallbackReplacements(tc.values, tc.stringReplacements)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStripParamFallbacks(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		want  string
+	}{{
+		input: "echo $(params.foo)",
+		want:  "echo $(params.foo)",
+	}, {
+		input: `echo $(params.foo | default "bar") $(params['baz'] | default "") $(params.obj.key | default "x")`,
+		want:  "echo $(params.foo) $(params['baz']) $(params.obj.key)",
+	}} {
+		t.Run(tc.input, func(t *testing.T) {
+			if got := substitution.StripParamFallbacks(tc.input); got != tc.want {
+				t.Errorf("StripParamFallbacks() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateParamFallbacks(t *testing.T) {
+	vars := sets.NewString("arr", "obj")
+	for _, tc := range []struct {
+		name          string
+		input         string
+		expectedError *apis.FieldError
+	}{{
+		name:  "string param",
+		input: `$(params.foo | default "bar")`,
+	}, {
+		name:  "array index and object key",
+		input: `$(params.arr[0] | default "bar") $(params.obj.key | default "baz")`,
+	}, {
+		name:  "whole array param",
+		input: `$(params.arr | default "bar")`,
+		expectedError: &apis.FieldError{
+			Message: `fallback values are only supported for references to strings in "$(params.arr | default \"bar\")"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name:  "whole object param in bracket notation",
+		input: `$(params["obj"] | default "bar")`,
+		expectedError: &apis.FieldError{
+			Message: `fallback values are only supported for references to strings in "$(params[\"obj\"] | default \"bar\")"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name:  "invalid fallback value",
+		input: `$(params.foo | default "\q")`,
+		expectedError: &apis.FieldError{
+			Message: `invalid fallback value "\q" in "$(params.foo | default \"\\q\")": invalid syntax`,
+			Paths:   []string{""},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := substitution.ValidateParamFallbacks(tc.input, vars)
+			if d := cmp.Diff(tc.expectedError, got, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestApplyArrayJoinReplacements(t *testing.T) {
+	arrayReplacements := map[string][]string{
+		"params.list":     {"a", "b", "c"},
+		`params["list"]`:  {"a", "b", "c"},
+		"params.empty":    {},
+		"params.singular": {"x"},
+	}
+	for _, tc := range []struct {
+		name     string
+		input    string
+		expected string
+	}{{
+		name:     "comma separator",
+		input:    "$(params.list[*]:,)",
+		expected: "a,b,c",
+	}, {
+		name:     "multi-character separator within a string",
+		input:    `--items=$(params["list"][*]: | )`,
+		expected: "--items=a | b | c",
+	}, {
+		name:     "empty and single element arrays",
+		input:    "[$(params.empty[*]:,)] [$(params.singular[*]:,)]",
+		expected: "[] [x]",
+	}, {
+		name:     "unknown array",
+		input:    "$(params.unknown[*]:,)",
+		expected: "$(params.unknown[*]:,)",
+	}, {
+		name:     "no join",
+		input:    "$(params.list[*])",
+		expected: "$(params.list[*])",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := substitution.ApplyArrayJoinReplacements(tc.input, arrayReplacements)
+			if d := cmp.Diff(tc.expected, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateArrayJoins(t *testing.T) {
+	vars := sets.NewString("list")
+	for _, tc := range []struct {
+		name          string
+		input         string
+		expectedError *apis.FieldError
+	}{{
+		name:  "array param",
+		input: "FOO=$(params.list[*]:,)",
+	}, {
+		name:  "array param in bracket notation",
+		input: "$(params['list'][*]:;)",
+	}, {
+		name:  "empty separator",
+		input: "$(params.list[*]:)",
+		expectedError: &apis.FieldError{
+			Message: `empty separator in "$(params.list[*]:)"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name:  "not an array param",
+		input: "$(params.foo[*]:,)",
+		expectedError: &apis.FieldError{
+			Message: `only array parameters can be joined in "$(params.foo[*]:,)"`,
+			Paths:   []string{""},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := substitution.ValidateArrayJoins(tc.input, vars)
+			if d := cmp.Diff(tc.expectedError, got, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
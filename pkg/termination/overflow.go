@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package termination
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/result"
+)
+
+const (
+	// OverflowResultPrefix prefixes the lines of a step's log which carry a result that did not fit
+	// in its termination message.
+	OverflowResultPrefix = "tekton.dev/result-overflow: "
+	// OverflowMarkerPrefix prefixes the line of a step's log after which the overflowed results are
+	// written. It is followed by a nonce which is only known from the termination message, so that
+	// the step cannot forge results by printing lines with OverflowResultPrefix itself.
+	OverflowMarkerPrefix = "tekton.dev/result-overflow-start: "
+	// OverflowResultsKey is the key of the internal result written to the termination message
+	// when some of the step's results were written to its log instead. Its value is made of the
+	// nonce of the marker line and of the number of overflowed results, see ParseOverflowMarker.
+	OverflowResultsKey = "ResultsOverflow"
+
+	// overflowHeadroom is kept free in the termination message when results overflow, so that
+	// the results written after the step (e.g. the exit code) still fit.
+	overflowHeadroom = 512
+)
+
+// WriteMessageWithOverflow writes the results to the termination message path like WriteMessage.
+// If the results do not fit in the termination message, the largest task and step results are
+// written to w instead: a marker line made of OverflowMarkerPrefix and a random nonce, then one
+// result per line prefixed with OverflowResultPrefix. An internal result with the key
+// OverflowResultsKey is added to the termination message so that the controller knows to read
+// them back from the step's log. All the results of a step must be written by a single call.
+func WriteMessageWithOverflow(path string, pro []result.RunResult, w io.Writer) error {
+	existingEntries, err := readMessage(path)
+	if err != nil {
+		return err
+	}
+	jsonOutput, err := json.Marshal(append(existingEntries, pro...))
+	if err != nil {
+		return err
+	}
+	if len(jsonOutput) <= MaxContainerTerminationMessageLength {
+		return writeMessage(path, jsonOutput)
+	}
+
+	nonce, err := newOverflowNonce()
+	if err != nil {
+		return err
+	}
+	// the marker only accounts for the results written after it, which an earlier call would lose
+	if slices.ContainsFunc(existingEntries, func(r result.RunResult) bool { return r.Key == OverflowResultsKey }) {
+		return errors.New("results already overflowed to the step log, they must be written at once")
+	}
+	kept := make([]result.RunResult, len(pro))
+	copy(kept, pro)
+	// move the largest results out first to keep as many results as possible in the termination message
+	sort.SliceStable(kept, func(i, j int) bool { return len(kept[i].Value) > len(kept[j].Value) })
+	var overflow []result.RunResult
+	for {
+		marker := result.RunResult{Key: OverflowResultsKey, Value: formatOverflowMarker(nonce, len(overflow)), ResultType: result.InternalTektonResultType}
+		jsonOutput, err = json.Marshal(append(append(existingEntries, kept...), marker))
+		if err != nil {
+			return err
+		}
+		if len(jsonOutput) <= MaxContainerTerminationMessageLength-overflowHeadroom {
+			break
+		}
+		i := indexOfOverflowable(kept)
+		if i < 0 {
+			return errTooLong
+		}
+		overflow = append(overflow, kept[i])
+		kept = append(kept[:i], kept[i+1:]...)
+	}
+
+	if _, err := fmt.Fprintf(w, "\n%s%s\n", OverflowMarkerPrefix, nonce); err != nil {
+		return err
+	}
+	for _, r := range overflow {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s%s\n", OverflowResultPrefix, line); err != nil {
+			return err
+		}
+	}
+	return writeMessage(path, jsonOutput)
+}
+
+// indexOfOverflowable returns the index of the first task or step result in results, or -1.
+func indexOfOverflowable(results []result.RunResult) int {
+	for i, r := range results {
+		if isOverflowable(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isOverflowable returns whether the result may be written to the step's log.
+func isOverflowable(r result.RunResult) bool {
+	return r.ResultType == result.TaskRunResultType || r.ResultType == result.StepResultType
+}
+
+// newOverflowNonce returns a random nonce for the marker line of the overflowed results.
+func newOverflowNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func formatOverflowMarker(nonce string, count int) string {
+	return nonce + ":" + strconv.Itoa(count)
+}
+
+// ParseOverflowMarker parses the value of the internal result with the key OverflowResultsKey
+// into the nonce of the marker line and the number of results written after it.
+func ParseOverflowMarker(value string) (string, int, error) {
+	nonce, n, ok := strings.Cut(value, ":")
+	if !ok || nonce == "" {
+		return "", 0, fmt.Errorf("invalid overflowed results marker %q", value)
+	}
+	count, err := strconv.Atoi(n)
+	if err != nil || count < 0 {
+		return "", 0, fmt.Errorf("invalid overflowed results marker %q", value)
+	}
+	return nonce, count, nil
+}
+
+// ParseOverflowResults reads the count results written to a step's log by WriteMessageWithOverflow
+// after the marker line with the given nonce. The lines before the marker and the lines after it
+// which do not carry a result are skipped, however long they are. Overflowed results longer than
+// maxLen bytes, and results which are neither task nor step results, are rejected.
+func ParseOverflowResults(r io.Reader, nonce string, count int, maxLen int) ([]result.RunResult, error) {
+	marker := []byte(OverflowMarkerPrefix + nonce)
+	maxLineLen := maxLen + len(OverflowResultPrefix)
+	reader := bufio.NewReader(r)
+	started := false
+	var results []result.RunResult
+	for len(results) < count {
+		line, tooLong, err := readLine(reader, maxLineLen)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read overflowed results: %w", err)
+		}
+		if !started {
+			started = !tooLong && bytes.Equal(line, marker)
+			continue
+		}
+		value, ok := bytes.CutPrefix(line, []byte(OverflowResultPrefix))
+		if !ok {
+			continue
+		}
+		if tooLong {
+			return nil, fmt.Errorf("overflowed result is longer than %d bytes", maxLen)
+		}
+		var res result.RunResult
+		if err := json.Unmarshal(value, &res); err != nil {
+			return nil, fmt.Errorf("invalid overflowed result %q: %w", value, err)
+		}
+		if !isOverflowable(res) {
+			return nil, fmt.Errorf("invalid type %d of overflowed result %q", res.ResultType, res.Key)
+		}
+		results = append(results, res)
+	}
+	if !started {
+		return nil, errors.New("the marker of the overflowed results was not found in the step log")
+	}
+	if len(results) < count {
+		return nil, fmt.Errorf("found %d of the %d overflowed results in the step log", len(results), count)
+	}
+	return results, nil
+}
+
+// readLine reads a line from reader, keeping at most maxLen bytes of it. tooLong is true when the
+// line was longer, in which case the rest of the line is discarded.
+func readLine(reader *bufio.Reader, maxLen int) (line []byte, tooLong bool, err error) {
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			return nil, false, err
+		}
+		if !tooLong {
+			line = append(line, chunk...)
+			if len(line) > maxLen {
+				line = line[:maxLen]
+				tooLong = true
+			}
+		}
+		if !isPrefix {
+			return line, tooLong, nil
+		}
+	}
+}
@@ -33,17 +33,11 @@ const (
 func WriteMessage(path string, pro []result.RunResult) error {
 	// if the file at path exists, concatenate the new values otherwise create it
 	// file at path already exists
-	fileContents, err := os.ReadFile(path)
-	if err == nil {
-		var existingEntries []result.RunResult
-		if err := json.Unmarshal(fileContents, &existingEntries); err == nil {
-			// append new entries to existing entries
-			pro = append(existingEntries, pro...)
-		}
-	} else if !os.IsNotExist(err) {
+	existingEntries, err := readMessage(path)
+	if err != nil {
 		return err
 	}
-	jsonOutput, err := json.Marshal(pro)
+	jsonOutput, err := json.Marshal(append(existingEntries, pro...))
 	if err != nil {
 		return err
 	}
@@ -51,7 +45,26 @@ func WriteMessage(path string, pro []result.RunResult) error {
 	if len(jsonOutput) > MaxContainerTerminationMessageLength {
 		return errTooLong
 	}
+	return writeMessage(path, jsonOutput)
+}
+
+// readMessage reads the results already written to the termination message path, if any.
+func readMessage(path string) ([]result.RunResult, error) {
+	fileContents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var existingEntries []result.RunResult
+	if err := json.Unmarshal(fileContents, &existingEntries); err != nil {
+		return nil, nil //nolint:nilerr // a termination message which is not made of results is overwritten
+	}
+	return existingEntries, nil
+}
 
+// writeMessage writes the serialized results to the termination message path.
+func writeMessage(path string, jsonOutput []byte) error {
 	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0666)
 	if err != nil {
 		return err
@@ -16,6 +16,7 @@ limitations under the License.
 package termination_test
 
 import (
+	"encoding/json"
 	"errors"
 	"log"
 	"os"
@@ -89,3 +90,213 @@ func TestMaxSizeFile(t *testing.T) {
 		t.Fatalf("Expected MessageLengthError, received: %v", err)
 	}
 }
+
+func TestWriteMessageWithOverflow(t *testing.T) {
+	tmpFile, err := os.CreateTemp(t.TempDir(), "tempFile")
+	if err != nil {
+		t.Fatalf("Cannot create temporary file: %v", err)
+	}
+	large := strings.Repeat("a", 4096)
+	output := []result.RunResult{{
+		Key:        "small",
+		Value:      "hello",
+		ResultType: result.TaskRunResultType,
+	}, {
+		Key:        "large",
+		Value:      large,
+		ResultType: result.TaskRunResultType,
+	}}
+
+	var logs strings.Builder
+	if err := termination.WriteMessageWithOverflow(tmpFile.Name(), output, &logs); err != nil {
+		t.Fatalf("Error while writing message: %v", err)
+	}
+
+	fileContents, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Unexpected error reading %v: %v", tmpFile.Name(), err)
+	}
+	var written []result.RunResult
+	if err := json.Unmarshal(fileContents, &written); err != nil {
+		t.Fatalf("Unexpected error parsing %v: %v", tmpFile.Name(), err)
+	}
+	if len(written) != 2 || written[1].Key != termination.OverflowResultsKey {
+		t.Fatalf("Expected the overflowed results marker to be written after the results, got %v", written)
+	}
+	if d := cmp.Diff(output[:1], written[:1]); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+	nonce, count, err := termination.ParseOverflowMarker(written[1].Value)
+	if err != nil {
+		t.Fatalf("Error while parsing the overflowed results marker: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 overflowed result, got %d", count)
+	}
+
+	// a forged result written by the step before the marker is ignored
+	stepOutput := "some step output\n" + termination.OverflowResultPrefix + `{"key":"large","value":"forged","type":1}` + "\n"
+	got, err := termination.ParseOverflowResults(strings.NewReader(stepOutput+logs.String()), nonce, count, 8192)
+	if err != nil {
+		t.Fatalf("Error while parsing overflowed results: %v", err)
+	}
+	wantOverflow := []result.RunResult{{
+		Key:        "large",
+		Value:      large,
+		ResultType: result.TaskRunResultType,
+	}}
+	if d := cmp.Diff(wantOverflow, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestWriteMessageWithOverflow_Fits(t *testing.T) {
+	tmpFile, err := os.CreateTemp(t.TempDir(), "tempFile")
+	if err != nil {
+		t.Fatalf("Cannot create temporary file: %v", err)
+	}
+	output := []result.RunResult{{
+		Key:        "key1",
+		Value:      "hello",
+		ResultType: result.TaskRunResultType,
+	}}
+
+	var logs strings.Builder
+	if err := termination.WriteMessageWithOverflow(tmpFile.Name(), output, &logs); err != nil {
+		t.Fatalf("Error while writing message: %v", err)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("Expected no overflowed results, got %q", logs.String())
+	}
+	fileContents, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Unexpected error reading %v: %v", tmpFile.Name(), err)
+	}
+	want := `[{"key":"key1","value":"hello","type":1}]`
+	if d := cmp.Diff(want, string(fileContents)); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestWriteMessageWithOverflow_AlreadyOverflowed(t *testing.T) {
+	tmpFile, err := os.CreateTemp(t.TempDir(), "tempFile")
+	if err != nil {
+		t.Fatalf("Cannot create temporary file: %v", err)
+	}
+	output := []result.RunResult{{
+		Key:        "large",
+		Value:      strings.Repeat("a", 4096),
+		ResultType: result.TaskRunResultType,
+	}}
+	if err := termination.WriteMessageWithOverflow(tmpFile.Name(), output, &strings.Builder{}); err != nil {
+		t.Fatalf("Error while writing message: %v", err)
+	}
+
+	// a second call would drop the results which overflowed in the first one
+	output[0].Key = "other"
+	if err := termination.WriteMessageWithOverflow(tmpFile.Name(), output, &strings.Builder{}); err == nil {
+		t.Error("Expected an error when the results already overflowed, got nil")
+	}
+}
+
+func TestWriteMessageWithOverflow_InternalResultsTooLong(t *testing.T) {
+	tmpFile, err := os.CreateTemp(t.TempDir(), "tempFile")
+	if err != nil {
+		t.Fatalf("Cannot create temporary file: %v", err)
+	}
+	output := []result.RunResult{{
+		Key:        "internal",
+		Value:      strings.Repeat("a", 4096),
+		ResultType: result.InternalTektonResultType,
+	}}
+
+	err = termination.WriteMessageWithOverflow(tmpFile.Name(), output, &strings.Builder{})
+	var expectedErr termination.MessageLengthError
+	if !errors.As(err, &expectedErr) {
+		t.Fatalf("Expected MessageLengthError, received: %v", err)
+	}
+}
+
+func TestParseOverflowResults(t *testing.T) {
+	const nonce = "0123456789abcdef"
+	marker := termination.OverflowMarkerPrefix + nonce + "\n"
+	taskResult := termination.OverflowResultPrefix + `{"key":"foo","value":"bar","type":1}` + "\n"
+	for _, tc := range []struct {
+		desc    string
+		logs    string
+		count   int
+		want    []result.RunResult
+		wantErr string
+	}{{
+		desc:  "results after the marker",
+		logs:  "step output\n" + marker + taskResult,
+		count: 1,
+		want:  []result.RunResult{{Key: "foo", Value: "bar", ResultType: result.TaskRunResultType}},
+	}, {
+		desc:  "long lines without the prefix are skipped",
+		logs:  strings.Repeat("a", 100000) + "\n" + marker + strings.Repeat("b", 100000) + "\n" + taskResult,
+		count: 1,
+		want:  []result.RunResult{{Key: "foo", Value: "bar", ResultType: result.TaskRunResultType}},
+	}, {
+		desc:  "output of background processes interleaved with and after the results",
+		logs:  marker + "background output\n" + taskResult + "more background output\n",
+		count: 1,
+		want:  []result.RunResult{{Key: "foo", Value: "bar", ResultType: result.TaskRunResultType}},
+	}, {
+		desc:    "results without the marker",
+		logs:    taskResult,
+		count:   1,
+		wantErr: "the marker of the overflowed results was not found in the step log",
+	}, {
+		desc:    "marker with another nonce",
+		logs:    termination.OverflowMarkerPrefix + "forged\n" + taskResult,
+		count:   1,
+		wantErr: "the marker of the overflowed results was not found in the step log",
+	}, {
+		desc:    "missing results",
+		logs:    marker,
+		count:   1,
+		wantErr: "found 0 of the 1 overflowed results in the step log",
+	}, {
+		desc:    "result too long",
+		logs:    marker + termination.OverflowResultPrefix + `{"key":"foo","value":"` + strings.Repeat("a", 100) + `","type":1}` + "\n",
+		count:   1,
+		wantErr: "overflowed result is longer than 64 bytes",
+	}, {
+		desc:    "internal result",
+		logs:    marker + termination.OverflowResultPrefix + `{"key":"StartedAt","value":"bar","type":3}` + "\n",
+		count:   1,
+		wantErr: `invalid type 3 of overflowed result "StartedAt"`,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := termination.ParseOverflowResults(strings.NewReader(tc.logs), nonce, tc.count, 64)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("Expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParseOverflowMarker(t *testing.T) {
+	nonce, count, err := termination.ParseOverflowMarker("abc:2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if nonce != "abc" || count != 2 {
+		t.Errorf("Expected nonce abc and count 2, got %s and %d", nonce, count)
+	}
+	for _, value := range []string{"true", ":2", "abc:", "abc:-1"} {
+		if _, _, err := termination.ParseOverflowMarker(value); err == nil {
+			t.Errorf("Expected an error parsing %q", value)
+		}
+	}
+}
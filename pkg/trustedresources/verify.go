@@ -67,6 +67,33 @@ type VerificationResult struct {
 	VerificationResultType VerificationResultType
 	// Err contains the error message when there is a warning logged or error returned.
 	Err error
+	// Policies contains the outcome of each matched policy that was evaluated.
+	Policies []v1.PolicyVerification
+}
+
+// ResourceVerification returns the record of the verification result to store in the run provenance.
+// It returns nil when the verification was skipped.
+func (vr *VerificationResult) ResourceVerification(name, kind, pipelineTask string) *v1.ResourceVerification {
+	rv := &v1.ResourceVerification{
+		Name:         name,
+		Kind:         kind,
+		PipelineTask: pipelineTask,
+		Policies:     vr.Policies,
+	}
+	switch vr.VerificationResultType {
+	case VerificationPass:
+		rv.Outcome = v1.VerificationOutcomePassed
+	case VerificationWarn:
+		rv.Outcome = v1.VerificationOutcomeWarned
+	case VerificationError:
+		rv.Outcome = v1.VerificationOutcomeFailed
+	default:
+		return nil
+	}
+	if vr.Err != nil {
+		rv.Message = vr.Err.Error()
+	}
+	return rv
 }
 
 // VerifyResource verifies the signature and public key against resource (v1beta1 and v1 task and pipeline).
@@ -162,14 +189,17 @@ func verifyResource(ctx context.Context, resource metav1.Object, k8s kubernetes.
 	}
 
 	// first evaluate all enforce policies. Return VerificationError type of VerificationResult if any policy fails.
+	var policyResults []v1.PolicyVerification
 	for _, p := range enforcePolicies {
 		verifiers, err := verifier.FromPolicy(ctx, k8s, p)
 		if err != nil {
-			return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("failed to get verifiers from policy: %w", err)}
+			policyResults = append(policyResults, policyVerification(p, -1))
+			return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("failed to get verifiers from policy: %w", err), Policies: policyResults}
 		}
-		passVerification := doesAnyVerifierPass(ctx, checksumBytes, signature, verifiers)
-		if !passVerification {
-			return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("%w: resource %s in namespace %s fails verification", ErrResourceVerificationFailed, resource.GetName(), resource.GetNamespace())}
+		passed := doesAnyVerifierPass(ctx, checksumBytes, signature, verifiers)
+		policyResults = append(policyResults, policyVerification(p, passed))
+		if passed < 0 {
+			return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("%w: resource %s in namespace %s fails verification", ErrResourceVerificationFailed, resource.GetName(), resource.GetNamespace()), Policies: policyResults}
 		}
 	}
 
@@ -179,35 +209,54 @@ func verifyResource(ctx context.Context, resource metav1.Object, k8s kubernetes.
 		if err != nil {
 			warn := fmt.Errorf("failed to get verifiers for resource %s from namespace %s: %w", resource.GetName(), resource.GetNamespace(), err)
 			logger.Warnf(warn.Error())
-			return VerificationResult{VerificationResultType: VerificationWarn, Err: warn}
+			policyResults = append(policyResults, policyVerification(p, -1))
+			return VerificationResult{VerificationResultType: VerificationWarn, Err: warn, Policies: policyResults}
 		}
-		passVerification := doesAnyVerifierPass(ctx, checksumBytes, signature, verifiers)
-		if !passVerification {
+		passed := doesAnyVerifierPass(ctx, checksumBytes, signature, verifiers)
+		policyResults = append(policyResults, policyVerification(p, passed))
+		if passed < 0 {
 			warn := fmt.Errorf("%w: resource %s in namespace %s fails verification", ErrResourceVerificationFailed, resource.GetName(), resource.GetNamespace())
 			logger.Warnf(warn.Error())
-			return VerificationResult{VerificationResultType: VerificationWarn, Err: warn}
+			return VerificationResult{VerificationResultType: VerificationWarn, Err: warn, Policies: policyResults}
 		}
 	}
 
-	return VerificationResult{VerificationResultType: VerificationPass}
+	return VerificationResult{VerificationResultType: VerificationPass, Policies: policyResults}
+}
+
+// policyVerification records the outcome of policy p, authorityIndex is the index of the
+// authority whose key verified the signature or -1 if none did.
+func policyVerification(p *v1alpha1.VerificationPolicy, authorityIndex int) v1.PolicyVerification {
+	pv := v1.PolicyVerification{
+		Name: p.Namespace + "/" + p.Name,
+		Mode: string(p.Spec.Mode),
+	}
+	if pv.Mode == "" {
+		pv.Mode = string(v1alpha1.ModeEnforce)
+	}
+	// verifier.FromPolicy returns one verifier per authority in the same order.
+	if authorityIndex >= 0 && authorityIndex < len(p.Spec.Authorities) {
+		pv.Authority = p.Spec.Authorities[authorityIndex].Name
+		pv.Passed = true
+	}
+	return pv
 }
 
-// doesAnyVerifierPass loop over verifiers to verify the checksum and the signature, return true if any verifier pass verification.
-func doesAnyVerifierPass(ctx context.Context, checksumBytes []byte, signature []byte, verifiers []signature.Verifier) bool {
+// doesAnyVerifierPass loop over verifiers to verify the checksum and the signature, return the index of the first verifier
+// that passes verification or -1 if none does.
+func doesAnyVerifierPass(ctx context.Context, checksumBytes []byte, signature []byte, verifiers []signature.Verifier) int {
 	logger := logging.FromContext(ctx)
-	passVerification := false
-	for _, verifier := range verifiers {
-		if err := verifier.VerifySignature(bytes.NewReader(signature), bytes.NewReader(checksumBytes)); err == nil {
+	for i, verifier := range verifiers {
+		err := verifier.VerifySignature(bytes.NewReader(signature), bytes.NewReader(checksumBytes))
+		if err == nil {
 			// if one of the verifier passes verification, then this policy passes verification
-			passVerification = true
-			break
-		} else {
-			// FixMe: changing %v to %w breaks integration tests.
-			warn := fmt.Errorf("%w:%v", ErrResourceVerificationFailed, err.Error())
-			logger.Warnf(warn.Error())
+			return i
 		}
+		// FixMe: changing %v to %w breaks integration tests.
+		warn := fmt.Errorf("%w:%v", ErrResourceVerificationFailed, err.Error())
+		logger.Warnf(warn.Error())
 	}
-	return passVerification
+	return -1
 }
 
 // extractSignature extracts the signature if it is present in the metadata.
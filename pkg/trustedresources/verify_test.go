@@ -26,6 +26,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -543,6 +544,96 @@ func TestVerifyResource_V1Pipeline_Error(t *testing.T) {
 	}
 }
 
+func TestVerifyResource_PolicyResults(t *testing.T) {
+	_, _, pubOther, err := test.GenerateKeys(elliptic.P256(), crypto.SHA256)
+	if err != nil {
+		t.Fatalf("failed to generate keys %v", err)
+	}
+	signer, _, pub, err := test.GenerateKeys(elliptic.P256(), crypto.SHA256)
+	if err != nil {
+		t.Fatalf("failed to generate keys %v", err)
+	}
+	signed, err := getSignedV1Task(unsignedV1Task.DeepCopy(), signer, "signed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modified := signed.DeepCopy()
+	modified.Annotations["foo"] = "modified"
+
+	policy := func(name string, mode v1alpha1.ModeType) *v1alpha1.VerificationPolicy {
+		return &v1alpha1.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha1.VerificationPolicySpec{
+				Resources: []v1alpha1.ResourcePattern{{Pattern: "https://github.com/tektoncd/catalog.git"}},
+				Authorities: []v1alpha1.Authority{
+					{Name: "other-key", Key: &v1alpha1.KeyRef{Data: string(pubOther)}},
+					{Name: "signing-key", Key: &v1alpha1.KeyRef{Data: string(pub)}},
+				},
+				Mode: mode,
+			},
+		}
+	}
+	source := &v1.RefSource{URI: "git+https://github.com/tektoncd/catalog.git"}
+
+	tcs := []struct {
+		name     string
+		task     *v1.Task
+		policies []*v1alpha1.VerificationPolicy
+		want     *v1.ResourceVerification
+	}{{
+		name:     "passing policies record the verifying authority",
+		task:     signed,
+		policies: []*v1alpha1.VerificationPolicy{policy("enforced", ""), policy("warned", v1alpha1.ModeWarn)},
+		want: &v1.ResourceVerification{
+			Name:    "signed",
+			Kind:    "Task",
+			Outcome: v1.VerificationOutcomePassed,
+			Policies: []v1.PolicyVerification{
+				{Name: namespace + "/enforced", Mode: "enforce", Authority: "signing-key", Passed: true},
+				{Name: namespace + "/warned", Mode: "warn", Authority: "signing-key", Passed: true},
+			},
+		},
+	}, {
+		name:     "failing enforce policy",
+		task:     modified,
+		policies: []*v1alpha1.VerificationPolicy{policy("enforced", v1alpha1.ModeEnforce)},
+		want: &v1.ResourceVerification{
+			Name:     "signed",
+			Kind:     "Task",
+			Outcome:  v1.VerificationOutcomeFailed,
+			Policies: []v1.PolicyVerification{{Name: namespace + "/enforced", Mode: "enforce"}},
+			Message:  "resource verification failed: resource signed in namespace  fails verification",
+		},
+	}, {
+		name:     "failing warn policy",
+		task:     modified,
+		policies: []*v1alpha1.VerificationPolicy{policy("warned", v1alpha1.ModeWarn)},
+		want: &v1.ResourceVerification{
+			Name:     "signed",
+			Kind:     "Task",
+			Outcome:  v1.VerificationOutcomeWarned,
+			Policies: []v1.PolicyVerification{{Name: namespace + "/warned", Mode: "warn"}},
+			Message:  "resource verification failed: resource signed in namespace  fails verification",
+		},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			vr := VerifyResource(t.Context(), tc.task, nil, source, tc.policies)
+			got := vr.ResourceVerification(tc.task.Name, "Task", "")
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("ResourceVerification mismatch (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestVerificationResult_ResourceVerificationSkip(t *testing.T) {
+	vr := VerificationResult{VerificationResultType: VerificationSkip}
+	if got := vr.ResourceVerification("task", "Task", ""); got != nil {
+		t.Errorf("expected no record for a skipped verification, got %v", got)
+	}
+}
+
 func signInterface(signer signature.Signer, i interface{}) ([]byte, error) {
 	if signer == nil {
 		return nil, errors.New("signer is nil")
@@ -19,11 +19,13 @@ package workspace
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	pkgnames "github.com/tektoncd/pipeline/pkg/names"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -93,11 +95,48 @@ func CreateVolumes(wb []v1.WorkspaceBinding) map[string]corev1.Volume {
 		case w.CSI != nil:
 			csi := *w.CSI
 			v.setVolumeSource(w.Name, name, corev1.VolumeSource{CSI: &csi})
+		case w.ObjectStorage != nil:
+			// The contents of the object storage are fetched into and uploaded from an emptyDir
+			v.setVolumeSource(w.Name, name, corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}})
+		case w.Ephemeral != nil:
+			// The resolved size was checked by ValidateEphemeralBindings before the volumes are created
+			if ev, err := ephemeralVolumeSource(*w.Ephemeral); err == nil {
+				v.setVolumeSource(w.Name, name, corev1.VolumeSource{Ephemeral: ev})
+			}
 		}
 	}
 	return v
 }
 
+// ephemeralVolumeSource returns the generic ephemeral volume source for an ephemeral workspace, or an
+// error if its size is not a valid quantity.
+func ephemeralVolumeSource(source v1.EphemeralWorkspaceSource) (*corev1.EphemeralVolumeSource, error) {
+	size, err := resource.ParseQuantity(source.Size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid size %q: %w", source.Size, err)
+	}
+	if size.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid size %q: must be a positive quantity", source.Size)
+	}
+	accessModes := source.AccessModes
+	if len(accessModes) == 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+	spec := corev1.PersistentVolumeClaimSpec{
+		AccessModes: accessModes,
+		Resources: corev1.VolumeResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+		},
+	}
+	if source.StorageClassName != "" {
+		storageClassName := source.StorageClassName
+		spec.StorageClassName = &storageClassName
+	}
+	return &corev1.EphemeralVolumeSource{
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{Spec: spec},
+	}, nil
+}
+
 func getDeclaredWorkspace(name string, w []v1.WorkspaceDeclaration) (*v1.WorkspaceDeclaration, error) {
 	for _, workspace := range w {
 		if workspace.Name == name {
@@ -169,6 +208,14 @@ func Apply(ctx context.Context, ts v1.TaskSpec, wb []v1.WorkspaceBinding, v map[
 			mountAsSharedWorkspace(ts, volumeMount)
 		}
 
+		if wb[i].ObjectStorage != nil {
+			fetch, upload := objectStorageSteps(*w, *wb[i].ObjectStorage, volumeMount, isolatedWorkspaces.Has(w.Name))
+			ts.Steps = append([]v1.Step{fetch}, ts.Steps...)
+			if upload != nil {
+				ts.Steps = append(ts.Steps, *upload)
+			}
+		}
+
 		// Only add this volume if it hasn't already been added
 		if !addedVolumes.Has(vv.Name) {
 			ts.Volumes = append(ts.Volumes, vv)
@@ -178,6 +225,46 @@ func Apply(ctx context.Context, ts v1.TaskSpec, wb []v1.WorkspaceBinding, v map[
 	return &ts, nil
 }
 
+// objectStorageSteps returns the Steps fetching the contents of an object storage workspace before the
+// Task's Steps run and uploading them once they have completed. No upload Step is returned for read only
+// workspaces. Shared workspaces are mounted through the StepTemplate, so the volumeMount is only added to
+// the returned Steps for isolated workspaces.
+func objectStorageSteps(w v1.WorkspaceDeclaration, source v1.ObjectStorageWorkspaceSource, volumeMount corev1.VolumeMount, isolated bool) (v1.Step, *v1.Step) {
+	path := w.GetMountPath()
+	newStep := func(name string, command []string) v1.Step {
+		step := v1.Step{
+			Name:    fmt.Sprintf("%s-workspace-%s", name, w.Name),
+			Image:   source.Image,
+			Command: command,
+		}
+		if source.SecretName != "" {
+			step.EnvFrom = []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: source.SecretName}},
+			}}
+		}
+		if isolated {
+			step.VolumeMounts = []corev1.VolumeMount{volumeMount}
+		}
+		return step
+	}
+
+	fetch := newStep("fetch", objectStorageSyncCommand(source.URL, source.URL, path))
+	if w.ReadOnly {
+		return fetch, nil
+	}
+	upload := newStep("upload", objectStorageSyncCommand(source.URL, path, source.URL))
+	return fetch, &upload
+}
+
+// objectStorageSyncCommand returns the command copying the contents of src to dst, one of which is the
+// object storage url and the other a local path.
+func objectStorageSyncCommand(url, src, dst string) []string {
+	if strings.HasPrefix(url, "gs://") {
+		return []string{"gsutil", "-m", "rsync", "-r", src, dst}
+	}
+	return []string{"aws", "s3", "sync", src, dst}
+}
+
 // mountAsSharedWorkspace takes a volumeMount and adds it to all the steps and sidecars in
 // a TaskSpec.
 func mountAsSharedWorkspace(ts v1.TaskSpec, volumeMount corev1.VolumeMount) {
@@ -349,6 +436,19 @@ func replaceWorkspaceBindingVars(wb *v1.WorkspaceBinding, replacements map[strin
 	if wb.CSI != nil {
 		wb.CSI = applyCSIVolumeSource(wb.CSI, replacements)
 	}
+	if wb.ObjectStorage != nil {
+		wb.ObjectStorage.URL = substitution.ApplyReplacements(wb.ObjectStorage.URL, replacements)
+		wb.ObjectStorage.SecretName = substitution.ApplyReplacements(wb.ObjectStorage.SecretName, replacements)
+	}
+	if wb.Ephemeral != nil {
+		wb.Ephemeral.Size = substitution.ApplyReplacements(wb.Ephemeral.Size, replacements)
+		wb.Ephemeral.StorageClassName = substitution.ApplyReplacements(wb.Ephemeral.StorageClassName, replacements)
+	}
+	if wb.Cache != nil {
+		wb.Cache.Key = substitution.ApplyReplacements(wb.Cache.Key, replacements)
+		wb.Cache.Size = substitution.ApplyReplacements(wb.Cache.Size, replacements)
+		wb.Cache.StorageClassName = substitution.ApplyReplacements(wb.Cache.StorageClassName, replacements)
+	}
 	return wb
 }
 
@@ -26,10 +26,12 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	"github.com/tektoncd/pipeline/test/names"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func TestCreateVolumes(t *testing.T) {
+	storageClassName := "fast"
 	names.TestingSeed()
 	for _, tc := range []struct {
 		name            string
@@ -260,6 +262,59 @@ func TestCreateVolumes(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "binding an ephemeral volume",
+		workspaces: []v1.WorkspaceBinding{{
+			Name: "custom",
+			Ephemeral: &v1.EphemeralWorkspaceSource{
+				Size:             "1Gi",
+				StorageClassName: "fast",
+			},
+		}},
+		expectedVolumes: map[string]corev1.Volume{
+			"custom": {
+				Name: "ws-20573",
+				VolumeSource: corev1.VolumeSource{
+					Ephemeral: &corev1.EphemeralVolumeSource{
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+								StorageClassName: &storageClassName,
+								Resources: corev1.VolumeResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, {
+		name: "binding an ephemeral volume with access modes and no storage class",
+		workspaces: []v1.WorkspaceBinding{{
+			Name: "custom",
+			Ephemeral: &v1.EphemeralWorkspaceSource{
+				Size:        "500Mi",
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod},
+			},
+		}},
+		expectedVolumes: map[string]corev1.Volume{
+			"custom": {
+				Name: "ws-20573",
+				VolumeSource: corev1.VolumeSource{
+					Ephemeral: &corev1.EphemeralVolumeSource{
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod},
+								Resources: corev1.VolumeResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("500Mi")},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}, {
 		name: "consistent hashed name for different inputs",
 		workspaces: []v1.WorkspaceBinding{{
@@ -738,6 +793,112 @@ func TestApply(t *testing.T) {
 				ReadOnly:  true,
 			}},
 		},
+	}, {
+		name: "binding a single workspace with objectStorage",
+		ts: v1.TaskSpec{
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "custom",
+			}},
+			Steps: []v1.Step{{
+				Name:  "build",
+				Image: "golang",
+			}},
+		},
+		workspaces: []v1.WorkspaceBinding{{
+			Name: "custom",
+			ObjectStorage: &v1.ObjectStorageWorkspaceSource{
+				URL:        "s3://my-bucket/path",
+				Image:      "amazon/aws-cli",
+				SecretName: "aws-credentials",
+			},
+		}},
+		expectedTaskSpec: v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "ws-20573",
+					MountPath: "/workspace/custom",
+				}},
+			},
+			Steps: []v1.Step{{
+				Name:    "fetch-workspace-custom",
+				Image:   "amazon/aws-cli",
+				Command: []string{"aws", "s3", "sync", "s3://my-bucket/path", "/workspace/custom"},
+				EnvFrom: []corev1.EnvFromSource{{
+					SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "aws-credentials"}},
+				}},
+			}, {
+				Name:  "build",
+				Image: "golang",
+			}, {
+				Name:    "upload-workspace-custom",
+				Image:   "amazon/aws-cli",
+				Command: []string{"aws", "s3", "sync", "/workspace/custom", "s3://my-bucket/path"},
+				EnvFrom: []corev1.EnvFromSource{{
+					SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "aws-credentials"}},
+				}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "ws-20573",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "custom",
+			}},
+		},
+	}, {
+		name: "binding a read only isolated workspace with objectStorage",
+		ts: v1.TaskSpec{
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:     "custom",
+				ReadOnly: true,
+			}},
+			Steps: []v1.Step{{
+				Name:       "build",
+				Image:      "golang",
+				Workspaces: []v1.WorkspaceUsage{{Name: "custom"}},
+			}},
+		},
+		workspaces: []v1.WorkspaceBinding{{
+			Name: "custom",
+			ObjectStorage: &v1.ObjectStorageWorkspaceSource{
+				URL:   "gs://my-bucket/path",
+				Image: "google/cloud-sdk",
+			},
+		}},
+		expectedTaskSpec: v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{},
+			Steps: []v1.Step{{
+				Name:    "fetch-workspace-custom",
+				Image:   "google/cloud-sdk",
+				Command: []string{"gsutil", "-m", "rsync", "-r", "gs://my-bucket/path", "/workspace/custom"},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "ws-20573",
+					MountPath: "/workspace/custom",
+					ReadOnly:  true,
+				}},
+			}, {
+				Name:       "build",
+				Image:      "golang",
+				Workspaces: []v1.WorkspaceUsage{{Name: "custom"}},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "ws-20573",
+					MountPath: "/workspace/custom",
+					ReadOnly:  true,
+				}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "ws-20573",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:     "custom",
+				ReadOnly: true,
+			}},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			vols := workspace.CreateVolumes(tc.workspaces)
@@ -1572,6 +1733,29 @@ func TestReplaceWorkspaceBindingsVars(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Replace Ephemeral",
+			replacements: map[string]string{
+				"params.size":          "1Gi",
+				"params.storage-class": "fast",
+			},
+			workspaceBindings: []v1.WorkspaceBinding{
+				{
+					Ephemeral: &v1.EphemeralWorkspaceSource{
+						Size:             "$(params.size)",
+						StorageClassName: "$(params.storage-class)",
+					},
+				},
+			},
+			expected: []v1.WorkspaceBinding{
+				{
+					Ephemeral: &v1.EphemeralWorkspaceSource{
+						Size:             "1Gi",
+						StorageClassName: "fast",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -84,3 +84,17 @@ func ValidateOnlyOnePVCIsUsed(wb []v1.WorkspaceBinding) error {
 	}
 	return nil
 }
+
+// ValidateEphemeralBindings checks that the sizes of the ephemeral WorkspaceBindings in wb, once
+// their parameters have been substituted, are valid quantities.
+func ValidateEphemeralBindings(wb []v1.WorkspaceBinding) error {
+	for _, w := range wb {
+		if w.Ephemeral == nil {
+			continue
+		}
+		if _, err := ephemeralVolumeSource(*w.Ephemeral); err != nil {
+			return pipelineErrors.WrapUserError(fmt.Errorf("ephemeral workspace binding %q is invalid: %w", w.Name, err))
+		}
+	}
+	return nil
+}
@@ -245,3 +245,50 @@ func TestValidateOnlyOnePVCIsUsed_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEphemeralBindings(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		bindings []v1.WorkspaceBinding
+		wantErr  string
+	}{{
+		name: "no ephemeral bindings",
+		bindings: []v1.WorkspaceBinding{{
+			Name:     "foo",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+	}, {
+		name: "valid size",
+		bindings: []v1.WorkspaceBinding{{
+			Name:      "foo",
+			Ephemeral: &v1.EphemeralWorkspaceSource{Size: "1Gi"},
+		}},
+	}, {
+		name: "unresolved size",
+		bindings: []v1.WorkspaceBinding{{
+			Name:      "foo",
+			Ephemeral: &v1.EphemeralWorkspaceSource{Size: "$(params.size)"},
+		}},
+		wantErr: `ephemeral workspace binding "foo" is invalid: invalid size "$(params.size)": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+	}, {
+		name: "zero size",
+		bindings: []v1.WorkspaceBinding{{
+			Name:      "foo",
+			Ephemeral: &v1.EphemeralWorkspaceSource{Size: "0"},
+		}},
+		wantErr: `ephemeral workspace binding "foo" is invalid: invalid size "0": must be a positive quantity`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := workspace.ValidateEphemeralBindings(tc.bindings)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("expected %v received %v", tc.wantErr, err)
+			}
+		})
+	}
+}
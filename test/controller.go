@@ -37,6 +37,8 @@ import (
 	fakepipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1/pipelinerun/fake"
 	faketaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1/task/fake"
 	faketaskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1/taskrun/fake"
+	fakeapprovalinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/approval/fake"
+	fakepipelinescheduleinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/pipelineschedule/fake"
 	fakeverificationpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/verificationpolicy/fake"
 	fakecustomruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/customrun/fake"
 	fakestepactioninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/stepaction/fake"
@@ -83,6 +85,8 @@ type Data struct {
 	ResolutionRequests      []*resolutionv1alpha1.ResolutionRequest
 	ExpectedCloudEventCount int
 	VerificationPolicies    []*v1alpha1.VerificationPolicy
+	PipelineSchedules       []*v1alpha1.PipelineSchedule
+	Approvals               []*v1alpha1.Approval
 	Secrets                 []*corev1.Secret
 }
 
@@ -109,6 +113,8 @@ type Informers struct {
 	LimitRange         coreinformers.LimitRangeInformer
 	ResolutionRequest  resolutioninformersv1alpha1.ResolutionRequestInformer
 	VerificationPolicy informersv1alpha1.VerificationPolicyInformer
+	PipelineSchedule   informersv1alpha1.PipelineScheduleInformer
+	Approval           informersv1alpha1.ApprovalInformer
 	Secret             coreinformers.SecretInformer
 }
 
@@ -195,6 +201,8 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 		LimitRange:         fakelimitrangeinformer.Get(ctx),
 		ResolutionRequest:  fakeresolutionrequestinformer.Get(ctx),
 		VerificationPolicy: fakeverificationpolicyinformer.Get(ctx),
+		PipelineSchedule:   fakepipelinescheduleinformer.Get(ctx),
+		Approval:           fakeapprovalinformer.Get(ctx),
 		Secret:             fakesecretinformer.Get(ctx),
 	}
 
@@ -286,6 +294,22 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 		}
 	}
 
+	c.Pipeline.PrependReactor("*", "pipelineschedules", AddToInformer(t, i.PipelineSchedule.Informer().GetIndexer()))
+	for _, ps := range d.PipelineSchedules {
+		ps := ps.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().PipelineSchedules(ps.Namespace).Create(ctx, ps, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c.Pipeline.PrependReactor("*", "approvals", AddToInformer(t, i.Approval.Informer().GetIndexer()))
+	for _, a := range d.Approvals {
+		a := a.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().Approvals(a.Namespace).Create(ctx, a, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
 	c.Kube.PrependReactor("*", "secrets", AddToInformer(t, i.Secret.Informer().GetIndexer()))
 	for _, s := range d.Secrets {
 		s := s.DeepCopy() // Avoid assumptions that the informer's copy is modified.